@@ -28,6 +28,12 @@ type Config struct {
 	WarmupMs                  int     `yaml:"warmupMs" json:"warmupMs"`                                     // 策略预热时间（毫秒）
 	MaxTradesPerCycle         int     `yaml:"maxTradesPerCycle" json:"maxTradesPerCycle"`                   // 每周期最大交易次数
 
+	// ===== CCI 过滤（见 velocity_engine.go） =====
+	UseCCI    bool    `yaml:"useCCI" json:"useCCI"`       // 是否启用 CCI 过滤：开启后仅在 CCI 穿越阈值且方向与速度符号一致时才触发
+	CCIWindow int     `yaml:"cciWindow" json:"cciWindow"` // CCI 滑动窗口大小
+	LongCCI   float64 `yaml:"longCCI" json:"longCCI"`     // 做多侧 CCI 阈值（正值，如 150）
+	ShortCCI  float64 `yaml:"shortCCI" json:"shortCCI"`   // 做空侧 CCI 阈值（负值，如 -150）
+
 	// ===== 安全参数 =====
 	HedgeOffsetCents      int `yaml:"hedgeOffsetCents" json:"hedgeOffsetCents"`           // 对冲价格偏移（分）
 	MinEntryPriceCents    int `yaml:"minEntryPriceCents" json:"minEntryPriceCents"`       // 最小入场价格（分）
@@ -50,6 +56,31 @@ type Config struct {
 	MarketQualityMaxSpreadCents  int  `yaml:"marketQualityMaxSpreadCents" json:"marketQualityMaxSpreadCents"`   // 市场质量最大价差（分）
 	MarketQualityMaxBookAgeMs    int  `yaml:"marketQualityMaxBookAgeMs" json:"marketQualityMaxBookAgeMs"`       // 订单簿最大年龄（毫秒）
 
+	// ===== ATR 动态止盈止损（见 risk_controller.go） =====
+	UseATR            bool    `yaml:"useATR" json:"useATR"`                       // 是否启用 ATR 动态止盈止损：开启后按合成K线的 ATR 替代静态 cents 阈值
+	ATRWindow         int     `yaml:"atrWindow" json:"atrWindow"`                 // ATR 滑动窗口大小
+	ATRProfitMultiple float64 `yaml:"atrProfitMultiple" json:"atrProfitMultiple"` // 止盈 = entry + ATRProfitMultiple*ATR
+	ATRLossMultiple   float64 `yaml:"atrLossMultiple" json:"atrLossMultiple"`     // 止损 = entry - ATRLossMultiple*ATR
+	ATRBarMs          int     `yaml:"atrBarMs" json:"atrBarMs"`                   // 合成K线的时间窗口（毫秒）
+
+	// ===== 分层马丁格尔加仓（见 position_manager.go） =====
+	StageHalfAmount   []float64 `yaml:"stageHalfAmount" json:"stageHalfAmount"`     // 每级加仓名义金额（USDC），如 [40,60,120,360,1080]
+	StageTriggerCents []int     `yaml:"stageTriggerCents" json:"stageTriggerCents"` // 每级加仓触发的逆势幅度（分），长度必须与 StageHalfAmount 一致
+	MaxStages         int       `yaml:"maxStages" json:"maxStages"`                 // 最多允许加仓的级数（硬上限）
+	MaxStageNotional  float64   `yaml:"maxStageNotional" json:"maxStageNotional"`   // 单个 TokenType 累计加仓名义金额上限（USDC，硬上限）
+
+	// ===== NR-N 窄幅整理确认（见 nrn.go） =====
+	NrCount    int  `yaml:"nrCount" json:"nrCount"`       // N：当前合成K线必须是最近 N 根中波幅最窄的才算 NR-N 信号
+	StrictMode bool `yaml:"strictMode" json:"strictMode"` // 严格模式：额外要求K线收盘价落在与目标方向一致的半区（>50c 为 UP 半区，<50c 为 DOWN 半区）
+
+	// ===== 时段过滤 + 亏损暂停（见 pause_gate.go） =====
+	EnablePause          bool    `yaml:"enablePause" json:"enablePause"`                   // 是否启用时段过滤 + 亏损暂停
+	TradeStartHour       int     `yaml:"tradeStartHour" json:"tradeStartHour"`             // 允许交易的起始小时（0-23，所在时区见 Timezone）
+	TradeEndHour         int     `yaml:"tradeEndHour" json:"tradeEndHour"`                 // 允许交易的结束小时（0-23，不含；支持 end < start 的跨零点窗口）
+	PauseTradeLoss       float64 `yaml:"pauseTradeLoss" json:"pauseTradeLoss"`             // 亏损暂停阈值（USDC，负数；RealizedPnL <= 此值时暂停）
+	ResetPauseOnNewCycle bool    `yaml:"resetPauseOnNewCycle" json:"resetPauseOnNewCycle"` // 新周期开始时是否自动清除亏损暂停
+	Timezone             string  `yaml:"timezone" json:"timezone"`                         // 时段过滤使用的时区（如 "Asia/Shanghai"，留空使用本地时区）
+
 	// ===== 退出策略 =====
 	TakeProfitCents       int                   `yaml:"takeProfitCents" json:"takeProfitCents"`             // 止盈价格（分）
 	StopLossCents         int                   `yaml:"stopLossCents" json:"stopLossCents"`                 // 止损价格（分）
@@ -102,6 +133,85 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("maxTradesPerCycle不能为负数，当前值: %d", c.MaxTradesPerCycle)
 	}
 
+	// CCI 过滤验证
+	if c.UseCCI {
+		if c.CCIWindow <= 0 {
+			return fmt.Errorf("useCCI开启时cciWindow必须大于0，当前值: %d", c.CCIWindow)
+		}
+		if c.LongCCI <= 0 {
+			return fmt.Errorf("useCCI开启时longCCI必须大于0，当前值: %.2f", c.LongCCI)
+		}
+		if c.ShortCCI >= 0 {
+			return fmt.Errorf("useCCI开启时shortCCI必须小于0，当前值: %.2f", c.ShortCCI)
+		}
+	}
+
+	// ATR 动态止盈止损验证
+	if c.UseATR {
+		if c.ATRWindow <= 0 {
+			return fmt.Errorf("useATR开启时atrWindow必须大于0，当前值: %d", c.ATRWindow)
+		}
+		if c.ATRProfitMultiple <= 0 {
+			return fmt.Errorf("useATR开启时atrProfitMultiple必须大于0，当前值: %.6f", c.ATRProfitMultiple)
+		}
+		if c.ATRLossMultiple <= 0 {
+			return fmt.Errorf("useATR开启时atrLossMultiple必须大于0，当前值: %.6f", c.ATRLossMultiple)
+		}
+		if c.ATRBarMs <= 0 {
+			return fmt.Errorf("useATR开启时atrBarMs必须大于0，当前值: %d", c.ATRBarMs)
+		}
+	}
+
+	// 分层马丁格尔加仓验证
+	if len(c.StageHalfAmount) > 0 {
+		if len(c.StageTriggerCents) != len(c.StageHalfAmount) {
+			return fmt.Errorf("stageTriggerCents长度必须与stageHalfAmount一致，当前值: len(stageHalfAmount)=%d, len(stageTriggerCents)=%d",
+				len(c.StageHalfAmount), len(c.StageTriggerCents))
+		}
+		if c.MaxStages <= 0 {
+			return fmt.Errorf("配置了stageHalfAmount时maxStages必须大于0，当前值: %d", c.MaxStages)
+		}
+		if c.MaxStageNotional <= 0 {
+			return fmt.Errorf("配置了stageHalfAmount时maxStageNotional必须大于0，当前值: %.6f", c.MaxStageNotional)
+		}
+		for i, amount := range c.StageHalfAmount {
+			if amount <= 0 {
+				return fmt.Errorf("stageHalfAmount[%d]必须大于0，当前值: %.6f", i, amount)
+			}
+		}
+		for i, cents := range c.StageTriggerCents {
+			if cents <= 0 {
+				return fmt.Errorf("stageTriggerCents[%d]必须大于0，当前值: %d", i, cents)
+			}
+		}
+	}
+
+	// NR-N 窄幅整理确认验证
+	if c.NrCount < 0 {
+		return fmt.Errorf("nrCount不能为负数，当前值: %d", c.NrCount)
+	}
+	if c.NrCount == 1 {
+		return fmt.Errorf("nrCount必须为0（关闭）或>=2，当前值: %d", c.NrCount)
+	}
+
+	// 时段过滤 + 亏损暂停验证
+	if c.EnablePause {
+		if c.TradeStartHour < 0 || c.TradeStartHour > 23 {
+			return fmt.Errorf("enablePause开启时tradeStartHour必须在[0,23]范围内，当前值: %d", c.TradeStartHour)
+		}
+		if c.TradeEndHour < 0 || c.TradeEndHour > 23 {
+			return fmt.Errorf("enablePause开启时tradeEndHour必须在[0,23]范围内，当前值: %d", c.TradeEndHour)
+		}
+		if c.PauseTradeLoss > 0 {
+			return fmt.Errorf("enablePause开启时pauseTradeLoss必须<=0，当前值: %.6f", c.PauseTradeLoss)
+		}
+		if c.Timezone != "" {
+			if _, err := time.LoadLocation(c.Timezone); err != nil {
+				return fmt.Errorf("timezone无效: %s (%w)", c.Timezone, err)
+			}
+		}
+	}
+
 	// 安全参数验证
 	if c.HedgeOffsetCents < 0 {
 		return fmt.Errorf("hedgeOffsetCents不能为负数，当前值: %d", c.HedgeOffsetCents)
@@ -156,6 +266,27 @@ func (c *Config) ApplyDefaults() {
 	if c.MinVelocityCentsPerSec == 0 {
 		c.MinVelocityCentsPerSec = DefaultMinVelocity
 	}
+	if c.CCIWindow == 0 {
+		c.CCIWindow = DefaultCCIWindow
+	}
+	if c.LongCCI == 0 {
+		c.LongCCI = DefaultLongCCI
+	}
+	if c.ShortCCI == 0 {
+		c.ShortCCI = DefaultShortCCI
+	}
+	if c.ATRWindow == 0 {
+		c.ATRWindow = DefaultATRWindow
+	}
+	if c.ATRProfitMultiple == 0 {
+		c.ATRProfitMultiple = DefaultATRProfitMultiple
+	}
+	if c.ATRLossMultiple == 0 {
+		c.ATRLossMultiple = DefaultATRLossMultiple
+	}
+	if c.ATRBarMs == 0 {
+		c.ATRBarMs = DefaultATRBarMs
+	}
 	if c.HedgeOffsetCents == 0 {
 		c.HedgeOffsetCents = DefaultHedgeOffsetCents
 	}
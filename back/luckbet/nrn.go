@@ -0,0 +1,51 @@
+package luckbet
+
+import "time"
+
+// nrBar 是 NR-N 判断用的单根合成K线：按 ATRBarMs 聚合 tick 级价格，记录
+// high/low/close，range = high - low。
+type nrBar struct {
+	start time.Time
+	high  float64
+	low   float64
+	close float64
+	have  bool
+
+	lastClose float64 // 最近一根已收盘K线的收盘价（StrictMode 半区判断用）
+}
+
+// floatRing 是一个固定容量的 float64 环形缓冲区，用于保存最近 N-1 根已收盘
+// K 线的 range（indicators.ring 未导出，这里单独实现一份轻量版本）。
+type floatRing struct {
+	buf   []float64
+	head  int
+	count int
+}
+
+func newFloatRing(capacity int) *floatRing {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &floatRing{buf: make([]float64, capacity)}
+}
+
+func (r *floatRing) Push(v float64) {
+	r.buf[r.head] = v
+	r.head = (r.head + 1) % len(r.buf)
+	if r.count < len(r.buf) {
+		r.count++
+	}
+}
+
+func (r *floatRing) Len() int { return r.count }
+
+func (r *floatRing) Full() bool { return r.count == len(r.buf) }
+
+func (r *floatRing) At(i int) float64 {
+	start := r.head - r.count
+	if start < 0 {
+		start += len(r.buf)
+	}
+	idx := (start + i) % len(r.buf)
+	return r.buf[idx]
+}
@@ -0,0 +1,108 @@
+package luckbet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/betbot/gobet/internal/domain"
+)
+
+func nrSampleAt(tokenType domain.TokenType, priceCents int, ts time.Time) PriceSample {
+	return PriceSample{
+		Timestamp:  ts,
+		PriceCents: priceCents,
+		Price:      domain.PriceFromDecimal(float64(priceCents) / 100.0),
+		TokenType:  tokenType,
+	}
+}
+
+// TestVelocityEngineNrNSignalsOnNarrowestBar 测试连续收窄的K线最终触发 NR-N 信号
+func TestVelocityEngineNrNSignalsOnNarrowestBar(t *testing.T) {
+	cfg := &Config{WindowSeconds: 30, MinMoveCents: 1, MinVelocityCentsPerSec: 0.1, NrCount: 3, ATRBarMs: 1000}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	// 第一根K线：60~40（range=20）
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 60, base))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 40, base.Add(500*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 55, base.Add(1100*time.Millisecond))) // 收盘第一根，开第二根
+
+	// 第二根K线：55~45（range=10）
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 45, base.Add(1600*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 52, base.Add(2200*time.Millisecond))) // 收盘第二根，开第三根
+
+	if engine.IsNrSignal(domain.TokenTypeUp) {
+		t.Error("只有2根历史K线（不足 NrCount-1=2 的要求已满足，但这是判断第三根时才需要），此处不应该提前触发")
+	}
+
+	// 第三根K线：52~50（range=2，严格小于前两根的 20 和 10）
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 50, base.Add(2700*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 51, base.Add(3300*time.Millisecond))) // 收盘第三根
+
+	if !engine.IsNrSignal(domain.TokenTypeUp) {
+		t.Error("第三根K线波幅应该严格小于此前两根，应该触发 NR-N 信号")
+	}
+}
+
+// TestVelocityEngineNrNNoSignalWhenNotNarrowest 测试波幅没有持续收窄时不触发
+func TestVelocityEngineNrNNoSignalWhenNotNarrowest(t *testing.T) {
+	cfg := &Config{WindowSeconds: 30, MinMoveCents: 1, MinVelocityCentsPerSec: 0.1, NrCount: 3, ATRBarMs: 1000}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 50, base))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 52, base.Add(1100*time.Millisecond)))
+
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 52, base.Add(1600*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 40, base.Add(2200*time.Millisecond))) // range 比第一根更宽
+
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 40, base.Add(2700*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 30, base.Add(3300*time.Millisecond))) // range=10，仍比第一根宽
+
+	if engine.IsNrSignal(domain.TokenTypeUp) {
+		t.Error("波幅不是最近几根中最窄时不应该触发 NR-N 信号")
+	}
+}
+
+// TestVelocityEngineNrNStrictModeRequiresHalf 测试 StrictMode 下要求收盘价落在对应半区
+func TestVelocityEngineNrNStrictModeRequiresHalf(t *testing.T) {
+	cfg := &Config{WindowSeconds: 30, MinMoveCents: 1, MinVelocityCentsPerSec: 0.1, NrCount: 2, StrictMode: true, ATRBarMs: 1000}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	engine.AddSample(nrSampleAt(domain.TokenTypeDown, 60, base))
+	engine.AddSample(nrSampleAt(domain.TokenTypeDown, 40, base.Add(1100*time.Millisecond))) // range=20，收盘第一根
+
+	engine.AddSample(nrSampleAt(domain.TokenTypeDown, 50, base.Add(1600*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeDown, 49, base.Add(2200*time.Millisecond))) // 第二根从40开盘，range=10，严格更窄，收盘价49<50
+
+	if !engine.IsNrSignal(domain.TokenTypeDown) {
+		t.Error("DOWN token 收盘价 49 < 50，StrictMode 下应该允许")
+	}
+
+	// 再来一组：range 变宽（49~60），且收盘价落在 UP 半区，不应该触发
+	engine.AddSample(nrSampleAt(domain.TokenTypeDown, 49, base.Add(2700*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeDown, 60, base.Add(3300*time.Millisecond))) // range 变宽，收盘价60>50
+
+	if engine.IsNrSignal(domain.TokenTypeDown) {
+		t.Error("range 没有继续收窄，不应该触发 NR-N 信号")
+	}
+}
+
+// TestVelocityEngineNrNResetClearsState 测试 Reset 清空 NR-N 状态
+func TestVelocityEngineNrNResetClearsState(t *testing.T) {
+	cfg := &Config{WindowSeconds: 30, MinMoveCents: 1, MinVelocityCentsPerSec: 0.1, NrCount: 2, ATRBarMs: 1000}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 60, base))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 40, base.Add(1100*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 50, base.Add(1600*time.Millisecond)))
+	engine.AddSample(nrSampleAt(domain.TokenTypeUp, 49, base.Add(2200*time.Millisecond)))
+
+	engine.Reset()
+
+	if engine.IsNrSignal(domain.TokenTypeUp) {
+		t.Error("Reset 之后不应该有残留的 NR-N 信号")
+	}
+}
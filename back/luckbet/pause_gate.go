@@ -0,0 +1,62 @@
+package luckbet
+
+import "time"
+
+// PauseGate 实现 EnablePause 时的时段过滤 + 亏损暂停判断。时段过滤是无状态的
+// （每次按传入的 ts 重新判断），亏损暂停的状态由调用方保存在 tradingState 里
+// （见 Strategy.OnPriceChanged / Strategy.ResumeTrading）。
+type PauseGate struct {
+	enabled      bool
+	startHour    int
+	endHour      int
+	pauseLoss    float64
+	resetOnCycle bool
+	loc          *time.Location
+}
+
+// NewPauseGate 按 cfg 创建一个 PauseGate；Timezone 无法解析时回退到本地时区
+// （Validate 已经保证配置里的 Timezone 能被 LoadLocation 解析，这里兜底）。
+func NewPauseGate(cfg *Config) *PauseGate {
+	loc := time.Local
+	if cfg.Timezone != "" {
+		if l, err := time.LoadLocation(cfg.Timezone); err == nil {
+			loc = l
+		}
+	}
+	return &PauseGate{
+		enabled:      cfg.EnablePause,
+		startHour:    cfg.TradeStartHour,
+		endHour:      cfg.TradeEndHour,
+		pauseLoss:    cfg.PauseTradeLoss,
+		resetOnCycle: cfg.ResetPauseOnNewCycle,
+		loc:          loc,
+	}
+}
+
+// WithinTradeWindow 判断 ts（转换到配置时区后）的小时是否落在
+// [TradeStartHour, TradeEndHour) 内，支持 end < start 的跨零点窗口
+// （例如 start=22 end=6 表示 22:00 到次日 06:00）。EnablePause=false 或
+// start==end（未配置窗口）时不做限制。
+func (g *PauseGate) WithinTradeWindow(ts time.Time) bool {
+	if !g.enabled || g.startHour == g.endHour {
+		return true
+	}
+	hour := ts.In(g.loc).Hour()
+	if g.startHour < g.endHour {
+		return hour >= g.startHour && hour < g.endHour
+	}
+	return hour >= g.startHour || hour < g.endHour
+}
+
+// LossPaused 判断 realizedPnL 是否已经触及 PauseTradeLoss 阈值。
+func (g *PauseGate) LossPaused(realizedPnL float64) bool {
+	if !g.enabled || g.pauseLoss == 0 {
+		return false
+	}
+	return realizedPnL <= g.pauseLoss
+}
+
+// ResetOnNewCycle 返回新周期开始时是否应该自动清除亏损暂停状态。
+func (g *PauseGate) ResetOnNewCycle() bool {
+	return g.resetOnCycle
+}
@@ -0,0 +1,91 @@
+package luckbet
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPauseGateWithinTradeWindowNormal 测试 start < end 的普通时段窗口
+func TestPauseGateWithinTradeWindowNormal(t *testing.T) {
+	cfg := &Config{EnablePause: true, TradeStartHour: 9, TradeEndHour: 17}
+	gate := NewPauseGate(cfg)
+
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if gate.WithinTradeWindow(day.Add(8 * time.Hour)) {
+		t.Error("08:00 在 [9,17) 窗口之外，不应该允许交易")
+	}
+	if !gate.WithinTradeWindow(day.Add(9 * time.Hour)) {
+		t.Error("09:00 是窗口起点，应该允许交易")
+	}
+	if !gate.WithinTradeWindow(day.Add(16 * time.Hour)) {
+		t.Error("16:00 在窗口内，应该允许交易")
+	}
+	if gate.WithinTradeWindow(day.Add(17 * time.Hour)) {
+		t.Error("17:00 是窗口终点（不含），不应该允许交易")
+	}
+}
+
+// TestPauseGateWithinTradeWindowWrapAround 测试 end < start 的跨零点窗口
+func TestPauseGateWithinTradeWindowWrapAround(t *testing.T) {
+	cfg := &Config{EnablePause: true, TradeStartHour: 22, TradeEndHour: 6}
+	gate := NewPauseGate(cfg)
+
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !gate.WithinTradeWindow(day.Add(23 * time.Hour)) {
+		t.Error("23:00 落在跨零点窗口 [22,6) 内，应该允许交易")
+	}
+	if !gate.WithinTradeWindow(day.Add(2 * time.Hour)) {
+		t.Error("02:00 落在跨零点窗口 [22,6) 内，应该允许交易")
+	}
+	if gate.WithinTradeWindow(day.Add(10 * time.Hour)) {
+		t.Error("10:00 不在跨零点窗口 [22,6) 内，不应该允许交易")
+	}
+	if !gate.WithinTradeWindow(day.Add(22 * time.Hour)) {
+		t.Error("22:00 是窗口起点，应该允许交易")
+	}
+	if gate.WithinTradeWindow(day.Add(6 * time.Hour)) {
+		t.Error("06:00 是窗口终点（不含），不应该允许交易")
+	}
+}
+
+// TestPauseGateDisabledAlwaysWithinWindow 测试 EnablePause=false 时不限制
+func TestPauseGateDisabledAlwaysWithinWindow(t *testing.T) {
+	cfg := &Config{TradeStartHour: 9, TradeEndHour: 17}
+	gate := NewPauseGate(cfg)
+
+	day := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	if !gate.WithinTradeWindow(day) {
+		t.Error("EnablePause=false 时任何时间都应该允许交易")
+	}
+}
+
+// TestPauseGateLossPaused 测试亏损暂停阈值判断
+func TestPauseGateLossPaused(t *testing.T) {
+	cfg := &Config{EnablePause: true, PauseTradeLoss: -50}
+	gate := NewPauseGate(cfg)
+
+	if gate.LossPaused(-10) {
+		t.Error("亏损未达到阈值时不应该暂停")
+	}
+	if !gate.LossPaused(-50) {
+		t.Error("亏损等于阈值时应该暂停")
+	}
+	if !gate.LossPaused(-100) {
+		t.Error("亏损超过阈值时应该暂停")
+	}
+}
+
+// TestPauseGateLossPausedDisabled 测试未配置阈值或未启用时不触发暂停
+func TestPauseGateLossPausedDisabled(t *testing.T) {
+	cfg := &Config{EnablePause: true}
+	gate := NewPauseGate(cfg)
+	if gate.LossPaused(-1000) {
+		t.Error("pauseTradeLoss 未配置（为0）时不应该触发暂停")
+	}
+
+	cfg2 := &Config{PauseTradeLoss: -50}
+	gate2 := NewPauseGate(cfg2)
+	if gate2.LossPaused(-1000) {
+		t.Error("EnablePause=false 时不应该触发暂停")
+	}
+}
@@ -0,0 +1,164 @@
+package luckbet
+
+import (
+	"sync"
+
+	"github.com/betbot/gobet/internal/domain"
+)
+
+// martingalePosition 记录某个 TokenType 当前的分层加仓状态。
+type martingalePosition struct {
+	avgEntryCents float64 // 累计加权平均入场价（分），按份数/合约数加权
+	totalNotional float64 // 累计名义金额（USDC）
+	totalShares   float64 // 累计份数/合约数，用于按份数重新计算加权平均入场价
+	stage         int     // 已经触发的加仓级数（0 表示只有初始入场，尚未加仓）
+	lastFillCents int     // 最近一次成交价（分），用于计算下一级触发所需的逆势幅度
+}
+
+// PositionManager 实现分层马丁格尔加仓（Martingale scale-in）：当某个已成交
+// 入场方向持续逆势运行达到 StageTriggerCents[stage] 时，按 StageHalfAmount[stage]
+// 追加一笔入场并重新计算平均入场价；MaxStages/MaxStageNotional 是硬上限，
+// StageHalfAmount 为空时整个加仓逻辑不生效。
+type PositionManager struct {
+	mu sync.Mutex
+
+	stageHalfAmount   []float64
+	stageTriggerCents []int
+	maxStages         int
+	maxStageNotional  float64
+
+	positions map[domain.TokenType]*martingalePosition
+
+	events chan UIUpdate
+}
+
+// NewPositionManager 按 cfg 创建一个 PositionManager。
+func NewPositionManager(cfg *Config) *PositionManager {
+	return &PositionManager{
+		stageHalfAmount:   cfg.StageHalfAmount,
+		stageTriggerCents: cfg.StageTriggerCents,
+		maxStages:         cfg.MaxStages,
+		maxStageNotional:  cfg.MaxStageNotional,
+		positions:         make(map[domain.TokenType]*martingalePosition),
+		events:            make(chan UIUpdate, 32),
+	}
+}
+
+// Events 返回只读事件通道，供 TerminalUI 渲染加仓阶梯（见 types.go 的 UIUpdate）。
+func (pm *PositionManager) Events() <-chan UIUpdate {
+	return pm.events
+}
+
+// OnEntryFilled 记录一笔新的入场成交，作为该 TokenType 的初始仓位（stage=0）。
+func (pm *PositionManager) OnEntryFilled(tokenType domain.TokenType, priceCents int, notional float64) {
+	if len(pm.stageHalfAmount) == 0 {
+		return
+	}
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.positions[tokenType] = &martingalePosition{
+		avgEntryCents: float64(priceCents),
+		totalNotional: notional,
+		totalShares:   sharesFor(notional, priceCents),
+		lastFillCents: priceCents,
+	}
+}
+
+// sharesFor 把一笔按名义金额（USDC）计的成交换算成份数/合约数，用于份数加权的
+// 平均入场价计算；priceCents<=0 时视为 0 份，避免除零。
+func sharesFor(notional float64, priceCents int) float64 {
+	if priceCents <= 0 {
+		return 0
+	}
+	return notional / (float64(priceCents) / 100.0)
+}
+
+// CheckScaleIn 判断 tokenType 当前持仓是否应该在 currentCents 触发下一级加仓。
+// paused 对应 tradingState.Paused（亏损暂停）：luckbet 目前没有 grid 那样独立的
+// 冻结区间概念，亏损暂停是这里唯一需要接入的风控开关，为 true 时直接拒绝加仓。
+// 返回 ok=false 时不应该提交任何加仓单。
+func (pm *PositionManager) CheckScaleIn(tokenType domain.TokenType, currentCents int, paused bool) (notional float64, ok bool) {
+	if paused || len(pm.stageHalfAmount) == 0 {
+		return 0, false
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pos := pm.positions[tokenType]
+	if pos == nil {
+		return 0, false
+	}
+	if pos.stage >= pm.maxStages || pos.stage >= len(pm.stageHalfAmount) || pos.stage >= len(pm.stageTriggerCents) {
+		return 0, false
+	}
+
+	// 逆势幅度：入场方向是做多该 token，价格走低即为不利。
+	adverse := pos.lastFillCents - currentCents
+	if adverse < pm.stageTriggerCents[pos.stage] {
+		return 0, false
+	}
+
+	addNotional := pm.stageHalfAmount[pos.stage]
+	if pos.totalNotional+addNotional > pm.maxStageNotional {
+		return 0, false
+	}
+
+	return addNotional, true
+}
+
+// RecordScaleIn 在 OrderExecutor 成功提交加仓单成交后调用：推进 stage、按
+// 份数（而不是名义金额）加权重新计算平均入场价，并发出一条 UIUpdateTypePosition
+// 事件——名义金额相同但成交价不同时，份数是不一样的，直接拿名义金额当权重会
+// 算出偏向更早那笔入场价的均价。
+func (pm *PositionManager) RecordScaleIn(tokenType domain.TokenType, priceCents int, notional float64) {
+	pm.mu.Lock()
+	pos := pm.positions[tokenType]
+	if pos == nil {
+		pm.mu.Unlock()
+		return
+	}
+	addShares := sharesFor(notional, priceCents)
+	totalCost := pos.avgEntryCents*pos.totalShares + float64(priceCents)*addShares
+	pos.totalNotional += notional
+	pos.totalShares += addShares
+	if pos.totalShares > 0 {
+		pos.avgEntryCents = totalCost / pos.totalShares
+	}
+	pos.stage++
+	pos.lastFillCents = priceCents
+	stage, avg, total := pos.stage, pos.avgEntryCents, pos.totalNotional
+	pm.mu.Unlock()
+
+	pm.emit(UIUpdate{
+		Type: UIUpdateTypePosition,
+		Data: map[string]interface{}{
+			"tokenType":     tokenType,
+			"stage":         stage,
+			"avgEntryCents": avg,
+			"totalNotional": total,
+		},
+	})
+}
+
+func (pm *PositionManager) emit(update UIUpdate) {
+	select {
+	case pm.events <- update:
+	default:
+		// 事件通道已满：丢弃本次事件，不阻塞交易主流程
+	}
+}
+
+// ResetPosition 清空 tokenType 的加仓阶梯状态（完整止盈退出时调用）。
+func (pm *PositionManager) ResetPosition(tokenType domain.TokenType) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	delete(pm.positions, tokenType)
+}
+
+// ResetCycle 清空所有 TokenType 的加仓阶梯状态（周期切换时调用）。
+func (pm *PositionManager) ResetCycle() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.positions = make(map[domain.TokenType]*martingalePosition)
+}
@@ -0,0 +1,115 @@
+package luckbet
+
+import (
+	"testing"
+
+	"github.com/betbot/gobet/internal/domain"
+)
+
+func stageCfg() *Config {
+	return &Config{
+		StageHalfAmount:   []float64{40, 60, 120},
+		StageTriggerCents: []int{5, 10, 20},
+		MaxStages:         3,
+		MaxStageNotional:  1000,
+	}
+}
+
+// TestPositionManagerNoScaleInWithoutEntry 测试未登记入场仓位时不应该加仓
+func TestPositionManagerNoScaleInWithoutEntry(t *testing.T) {
+	pm := NewPositionManager(stageCfg())
+
+	if _, ok := pm.CheckScaleIn(domain.TokenTypeUp, 40, false); ok {
+		t.Error("没有初始入场仓位时不应该触发加仓")
+	}
+}
+
+// TestPositionManagerScaleInTriggersOnAdverseMove 测试逆势幅度达到阈值后触发加仓
+func TestPositionManagerScaleInTriggersOnAdverseMove(t *testing.T) {
+	pm := NewPositionManager(stageCfg())
+	pm.OnEntryFilled(domain.TokenTypeUp, 50, 40)
+
+	if _, ok := pm.CheckScaleIn(domain.TokenTypeUp, 47, false); ok {
+		t.Error("逆势幅度3分未达到第一级的5分阈值，不应该触发")
+	}
+
+	notional, ok := pm.CheckScaleIn(domain.TokenTypeUp, 45, false)
+	if !ok {
+		t.Fatal("逆势幅度5分达到第一级阈值，应该触发加仓")
+	}
+	if notional != 40 {
+		t.Errorf("第一级加仓金额应为40，实际为%.2f", notional)
+	}
+}
+
+// TestPositionManagerPausedBlocksScaleIn 测试亏损暂停时拒绝加仓
+func TestPositionManagerPausedBlocksScaleIn(t *testing.T) {
+	pm := NewPositionManager(stageCfg())
+	pm.OnEntryFilled(domain.TokenTypeUp, 50, 40)
+
+	if _, ok := pm.CheckScaleIn(domain.TokenTypeUp, 40, true); ok {
+		t.Error("亏损暂停时不应该允许加仓")
+	}
+}
+
+// TestPositionManagerRecordScaleInAdvancesStageAndAverage 测试加仓后推进级数并重算均价
+func TestPositionManagerRecordScaleInAdvancesStageAndAverage(t *testing.T) {
+	pm := NewPositionManager(stageCfg())
+	pm.OnEntryFilled(domain.TokenTypeUp, 50, 40)
+	pm.RecordScaleIn(domain.TokenTypeUp, 40, 40)
+
+	pos := pm.positions[domain.TokenTypeUp]
+	if pos == nil {
+		t.Fatal("加仓后仓位不应为空")
+	}
+	if pos.stage != 1 {
+		t.Errorf("加仓后stage应为1，实际为%d", pos.stage)
+	}
+	// 第一笔：40 USDC @ 50分 = 80份；第二笔：40 USDC @ 40分 = 100份，
+	// 按份数加权平均 = (80*50 + 100*40) / 180。
+	wantAvg := (80.0*50 + 100.0*40) / 180.0
+	if pos.avgEntryCents != wantAvg {
+		t.Errorf("加权平均入场价计算错误，期望%.4f，实际%.4f", wantAvg, pos.avgEntryCents)
+	}
+	if pos.totalNotional != 80 {
+		t.Errorf("累计名义金额应为80，实际为%.2f", pos.totalNotional)
+	}
+}
+
+// TestPositionManagerMaxStageNotionalBlocksScaleIn 测试超过名义金额上限时拒绝加仓
+func TestPositionManagerMaxStageNotionalBlocksScaleIn(t *testing.T) {
+	cfg := stageCfg()
+	cfg.MaxStageNotional = 50
+	pm := NewPositionManager(cfg)
+	pm.OnEntryFilled(domain.TokenTypeUp, 50, 40)
+
+	if _, ok := pm.CheckScaleIn(domain.TokenTypeUp, 45, false); ok {
+		t.Error("加仓后累计名义金额将超过上限，不应该触发")
+	}
+}
+
+// TestPositionManagerResetPositionClearsState 测试 ResetPosition 清空单个 TokenType 状态
+func TestPositionManagerResetPositionClearsState(t *testing.T) {
+	pm := NewPositionManager(stageCfg())
+	pm.OnEntryFilled(domain.TokenTypeUp, 50, 40)
+	pm.ResetPosition(domain.TokenTypeUp)
+
+	if _, ok := pm.CheckScaleIn(domain.TokenTypeUp, 40, false); ok {
+		t.Error("ResetPosition 之后不应该再有残留仓位触发加仓")
+	}
+}
+
+// TestPositionManagerResetCycleClearsAllState 测试 ResetCycle 清空所有 TokenType 状态
+func TestPositionManagerResetCycleClearsAllState(t *testing.T) {
+	pm := NewPositionManager(stageCfg())
+	pm.OnEntryFilled(domain.TokenTypeUp, 50, 40)
+	pm.OnEntryFilled(domain.TokenTypeDown, 50, 40)
+	pm.ResetCycle()
+
+	if _, ok := pm.CheckScaleIn(domain.TokenTypeUp, 40, false); ok {
+		t.Error("ResetCycle 之后UP不应该再有残留仓位")
+	}
+	if _, ok := pm.CheckScaleIn(domain.TokenTypeDown, 40, false); ok {
+		t.Error("ResetCycle 之后DOWN不应该再有残留仓位")
+	}
+}
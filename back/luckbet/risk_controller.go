@@ -0,0 +1,130 @@
+package luckbet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/internal/strategies/common/indicators"
+)
+
+// RiskController 风险控制器：维护 ATR 动态止盈止损状态（UseATR=false 时退化为
+// 静态 cents 阈值）。与 VelocityEngine 一样，ATR 基于 tick 级价格流合成
+// ATRBarMs 长度的 K 线，只在 K 线收盘时推进一次 indicators.ATR。
+type RiskController struct {
+	mu sync.Mutex
+
+	useATR            bool
+	atrWindow         int
+	atrProfitMultiple float64
+	atrLossMultiple   float64
+	atrBarMs          int64
+	atr               *indicators.ATR
+
+	barStart time.Time
+	barHigh  float64
+	barLow   float64
+	haveBar  bool
+
+	atrValue float64
+	atrReady bool
+
+	takeProfitCents int
+	stopLossCents   int
+}
+
+// NewRiskController 按 cfg 创建一个 RiskController。
+func NewRiskController(cfg *Config) *RiskController {
+	return &RiskController{
+		useATR:            cfg.UseATR,
+		atrWindow:         cfg.ATRWindow,
+		atrProfitMultiple: cfg.ATRProfitMultiple,
+		atrLossMultiple:   cfg.ATRLossMultiple,
+		atrBarMs:          int64(cfg.ATRBarMs),
+		atr:               indicators.NewATR(cfg.ATRWindow),
+		takeProfitCents:   cfg.TakeProfitCents,
+		stopLossCents:     cfg.StopLossCents,
+	}
+}
+
+// AddPriceSample 喂入一次价格采样（分），按 ATRBarMs 聚合成合成 K 线；只有在
+// 一根 K 线收盘时才会推进 ATR。UseATR=false 时直接忽略。
+func (rc *RiskController) AddPriceSample(priceCents int, ts time.Time) {
+	if !rc.useATR || priceCents <= 0 {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	price := float64(priceCents)
+	if !rc.haveBar {
+		rc.startBarLocked(price, ts)
+		return
+	}
+	if price > rc.barHigh {
+		rc.barHigh = price
+	}
+	if price < rc.barLow {
+		rc.barLow = price
+	}
+
+	if ts.Sub(rc.barStart) < time.Duration(rc.atrBarMs)*time.Millisecond {
+		return
+	}
+
+	// K 线收盘：把本根 K 线的 low/high 喂给 indicators.ATR（TR 计算见其注释）。
+	if value, ok := rc.atr.Update(rc.barLow, rc.barHigh); ok {
+		rc.atrValue = value
+		rc.atrReady = true
+	}
+	rc.startBarLocked(price, ts)
+}
+
+func (rc *RiskController) startBarLocked(price float64, ts time.Time) {
+	rc.barStart = ts
+	rc.barHigh = price
+	rc.barLow = price
+	rc.haveBar = true
+}
+
+// TakeProfitPx 返回止盈价格（分）：entry + ATRProfitMultiple*ATR；ATR 未就绪
+// 或 UseATR=false 时回退到静态 TakeProfitCents。
+func (rc *RiskController) TakeProfitPx(entryCents int) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.useATR || !rc.atrReady {
+		return entryCents + rc.takeProfitCents
+	}
+	return entryCents + int(rc.atrProfitMultiple*rc.atrValue+0.5)
+}
+
+// StopLossPx 返回止损价格（分）：entry - ATRLossMultiple*ATR；ATR 未就绪
+// 或 UseATR=false 时回退到静态 StopLossCents。
+func (rc *RiskController) StopLossPx(entryCents int) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.useATR || !rc.atrReady {
+		return entryCents - rc.stopLossCents
+	}
+	return entryCents - int(rc.atrLossMultiple*rc.atrValue+0.5)
+}
+
+// ATR 返回最近一次收盘 K 线算出的 ATR 值（未就绪时返回 0, false）。
+func (rc *RiskController) ATR() (float64, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.atrValue, rc.atrReady
+}
+
+// ResetCycle 清空 ATR 合成K线状态（周期切换时调用）。
+func (rc *RiskController) ResetCycle() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.atr = indicators.NewATR(rc.atrWindow)
+	rc.barStart = time.Time{}
+	rc.barHigh = 0
+	rc.barLow = 0
+	rc.haveBar = false
+	rc.atrValue = 0
+	rc.atrReady = false
+}
@@ -0,0 +1,98 @@
+package luckbet
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRiskControllerStaticFallback 测试 UseATR=false 时使用静态 cents 阈值
+func TestRiskControllerStaticFallback(t *testing.T) {
+	cfg := &Config{TakeProfitCents: 10, StopLossCents: 20}
+	rc := NewRiskController(cfg)
+
+	if tp := rc.TakeProfitPx(50); tp != 60 {
+		t.Errorf("TakeProfitPx 应该为 60，实际为 %d", tp)
+	}
+	if sl := rc.StopLossPx(50); sl != 30 {
+		t.Errorf("StopLossPx 应该为 30，实际为 %d", sl)
+	}
+}
+
+// TestRiskControllerATRWarmup 测试 ATR 未就绪前仍回退到静态阈值
+func TestRiskControllerATRWarmup(t *testing.T) {
+	cfg := &Config{
+		UseATR:            true,
+		ATRWindow:         3,
+		ATRProfitMultiple: 2,
+		ATRLossMultiple:   1,
+		ATRBarMs:          1000,
+		TakeProfitCents:   10,
+		StopLossCents:     20,
+	}
+	rc := NewRiskController(cfg)
+
+	base := time.Now()
+	rc.AddPriceSample(50, base)
+
+	if tp := rc.TakeProfitPx(50); tp != 60 {
+		t.Errorf("ATR 未就绪时 TakeProfitPx 应该回退到静态阈值 60，实际为 %d", tp)
+	}
+	if _, ok := rc.ATR(); ok {
+		t.Error("只喂入一根 K 线时 ATR 不应该就绪")
+	}
+}
+
+// TestRiskControllerATRDrivesTargets 测试喂满窗口后 ATR 驱动止盈止损
+func TestRiskControllerATRDrivesTargets(t *testing.T) {
+	cfg := &Config{
+		UseATR:            true,
+		ATRWindow:         2,
+		ATRProfitMultiple: 2,
+		ATRLossMultiple:   1,
+		ATRBarMs:          1000,
+		TakeProfitCents:   10,
+		StopLossCents:     20,
+	}
+	rc := NewRiskController(cfg)
+
+	base := time.Now()
+	bars := []int{50, 55, 48, 60}
+	for i, p := range bars {
+		rc.AddPriceSample(p, base.Add(time.Duration(i)*time.Second))
+	}
+	// 再喂一个样本触发最后一根 K 线收盘
+	rc.AddPriceSample(60, base.Add(time.Duration(len(bars))*time.Second))
+
+	atr, ok := rc.ATR()
+	if !ok {
+		t.Fatal("喂满窗口后 ATR 应该就绪")
+	}
+	if atr <= 0 {
+		t.Errorf("ATR 应该 > 0，实际为 %.4f", atr)
+	}
+
+	entry := 50
+	if tp := rc.TakeProfitPx(entry); tp <= entry {
+		t.Errorf("ATR 就绪时 TakeProfitPx 应该高于入场价，实际为 %d", tp)
+	}
+	if sl := rc.StopLossPx(entry); sl >= entry {
+		t.Errorf("ATR 就绪时 StopLossPx 应该低于入场价，实际为 %d", sl)
+	}
+}
+
+// TestRiskControllerResetCycle 测试 ResetCycle 清空 ATR 状态
+func TestRiskControllerResetCycle(t *testing.T) {
+	cfg := &Config{UseATR: true, ATRWindow: 2, ATRProfitMultiple: 1, ATRLossMultiple: 1, ATRBarMs: 1000}
+	rc := NewRiskController(cfg)
+
+	base := time.Now()
+	for i, p := range []int{50, 55, 60} {
+		rc.AddPriceSample(p, base.Add(time.Duration(i)*time.Second))
+	}
+
+	rc.ResetCycle()
+
+	if _, ok := rc.ATR(); ok {
+		t.Error("ResetCycle 之后 ATR 状态应该被清空")
+	}
+}
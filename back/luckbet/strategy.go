@@ -2,6 +2,7 @@ package luckbet
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -35,6 +36,7 @@ type Strategy struct {
 	positionManager  *PositionManager
 	terminalUI       *TerminalUI
 	configManager    *ConfigManager
+	pauseGate        *PauseGate
 
 	// 交易状态
 	tradingState *TradingState
@@ -64,7 +66,27 @@ func (s *Strategy) Initialize() error {
 	if s.performanceMetrics == nil {
 		s.performanceMetrics = &PerformanceMetrics{}
 	}
-	
+
+	// 初始化速度引擎（CCI 过滤见 velocity_engine.go）
+	if s.velocityEngine == nil {
+		s.velocityEngine = NewVelocityEngine(&s.Config)
+	}
+
+	// 初始化风险控制器（ATR 动态止盈止损见 risk_controller.go）
+	if s.riskController == nil {
+		s.riskController = NewRiskController(&s.Config)
+	}
+
+	// 初始化时段过滤 + 亏损暂停（见 pause_gate.go）
+	if s.pauseGate == nil {
+		s.pauseGate = NewPauseGate(&s.Config)
+	}
+
+	// 初始化分层马丁格尔加仓管理器（见 position_manager.go）
+	if s.positionManager == nil {
+		s.positionManager = NewPositionManager(&s.Config)
+	}
+
 	// 注册订单更新回调
 	if s.TradingService != nil {
 		s.orderUpdateOnce.Do(func() {
@@ -130,14 +152,22 @@ func (s *Strategy) OnCycle(_ context.Context, _ *domain.Market, newMarket *domai
 	s.tradingState.CurrentCycle = newMarket.Slug
 	s.tradingState.CycleStartTime = time.Unix(newMarket.Timestamp, 0)
 	
-	// 重置组件状态（将在后续任务中实现）
-	// if s.velocityEngine != nil {
-	//     s.velocityEngine.Reset()
-	// }
-	// if s.riskController != nil {
-	//     s.riskController.ResetCycle()
-	// }
-	
+	// 重置组件状态
+	if s.velocityEngine != nil {
+		s.velocityEngine.Reset()
+	}
+	if s.riskController != nil {
+		s.riskController.ResetCycle()
+	}
+	if s.positionManager != nil {
+		s.positionManager.ResetCycle()
+	}
+	// 亏损暂停是否随新周期自动解除，由 ResetPauseOnNewCycle 配置决定
+	if s.pauseGate != nil && s.pauseGate.ResetOnNewCycle() {
+		s.tradingState.Paused = false
+		s.tradingState.PauseReason = ""
+	}
+
 	log.Infof("✅ [%s] 周期切换完成: %s", ID, newMarket.Slug)
 }
 
@@ -179,6 +209,13 @@ func (s *Strategy) OnOrderUpdate(ctx context.Context, order *domain.Order) error
 	// 记录成功交易
 	if order.Status == domain.OrderStatusFilled {
 		s.performanceMetrics.SuccessfulTrades++
+
+		// 入场订单成交：登记为该 TokenType 的初始仓位，进入马丁格尔加仓阶梯
+		if order.IsEntryOrder && order.FilledPrice != nil && s.positionManager != nil {
+			priceCents := order.FilledPrice.ToCents()
+			notional := order.FilledPrice.ToDecimal() * order.ExecutedSize()
+			s.positionManager.OnEntryFilled(order.TokenType, priceCents, notional)
+		}
 	}
 
 	return nil
@@ -198,6 +235,30 @@ func (s *Strategy) OnPriceChanged(ctx context.Context, e *events.PriceChangedEve
 		return nil
 	}
 
+	// 时段过滤 + 亏损暂停：在任何触发逻辑之前短路退出
+	if s.pauseGate != nil {
+		now := time.Now()
+		s.mu.Lock()
+		if !s.tradingState.Paused && s.pauseGate.LossPaused(s.performanceMetrics.RealizedPnL) {
+			s.tradingState.Paused = true
+			s.tradingState.PauseReason = fmt.Sprintf("亏损达到暂停阈值: realizedPnL=%.4f <= pauseTradeLoss=%.4f",
+				s.performanceMetrics.RealizedPnL, s.PauseTradeLoss)
+			log.Warnf("⏸️ [%s] %s", ID, s.tradingState.PauseReason)
+		}
+		paused := s.tradingState.Paused
+		withinWindow := s.pauseGate.WithinTradeWindow(now)
+		s.mu.Unlock()
+
+		if paused {
+			log.Debugf("⏸️ [%s] 交易已暂停，跳过价格事件: market=%s", ID, e.Market.Slug)
+			return nil
+		}
+		if !withinWindow {
+			log.Debugf("🕐 [%s] 不在允许交易时段内，跳过价格事件: market=%s hour=%d", ID, e.Market.Slug, now.Hour())
+			return nil
+		}
+	}
+
 	// 记录首次接收到价格数据的时间
 	s.mu.Lock()
 	if s.tradingState.FirstSeenAt.IsZero() {
@@ -206,20 +267,68 @@ func (s *Strategy) OnPriceChanged(ctx context.Context, e *events.PriceChangedEve
 	}
 	s.mu.Unlock()
 
-	// 核心交易逻辑将在后续任务中实现
-	// 1. 添加价格样本到速度引擎
-	// 2. 计算速度指标
-	// 3. 检查触发条件
-	// 4. 执行风险检查
+	// 1. 添加价格样本到速度引擎，计算速度指标（CCI 过滤见 velocity_engine.go）
+	sample := PriceSample{
+		Timestamp:  e.Timestamp,
+		PriceCents: int(e.NewPrice.ToDecimal()*100 + 0.5),
+		Price:      e.NewPrice,
+		TokenType:  e.TokenType,
+	}
+	metrics := s.velocityEngine.AddSample(sample)
+
+	// 同步喂入风险控制器，驱动 ATR 合成K线（见 risk_controller.go）
+	s.riskController.AddPriceSample(sample.PriceCents, sample.Timestamp)
+
+	s.mu.Lock()
+	if cci, ok := s.velocityEngine.LatestCCI(e.TokenType); ok {
+		if e.TokenType == domain.TokenTypeUp {
+			s.performanceMetrics.LatestCCIUp = cci
+		} else {
+			s.performanceMetrics.LatestCCIDown = cci
+		}
+	}
+	s.tradingState.NrSignal = s.velocityEngine.IsNrSignal(e.TokenType)
+	s.tradingState.NrSignalToken = e.TokenType
+	s.mu.Unlock()
+
+	// 2/3. 检查触发条件（含 CCI 方向过滤 + NR-N 窄幅整理确认，见 nrn.go）
+	// 4. 执行风险检查（止盈止损价格见 riskController.TakeProfitPx/StopLossPx）
 	// 5. 执行配对交易
 	// 6. 更新UI显示
+	// 将在后续任务中实现（OrderExecutor 仍是占位符）
+	if s.velocityEngine.Signal(metrics) {
+		log.Infof("⚡ [%s] 速度触发信号: market=%s tokenType=%s velocity=%.4f分/秒 delta=%d分",
+			ID, e.Market.Slug, e.TokenType, metrics.Velocity, metrics.Delta)
+	}
 
-	log.Debugf("📈 [%s] 价格变化: market=%s tokenType=%s newPrice=%.4f", 
+	// 分层马丁格尔加仓检查（见 position_manager.go）：仅判断是否应该加仓，
+	// 实际下单仍由 OrderExecutor 负责（占位符，将在后续任务中实现）
+	if s.positionManager != nil {
+		s.mu.RLock()
+		paused := s.tradingState.Paused
+		s.mu.RUnlock()
+		if notional, ok := s.positionManager.CheckScaleIn(e.TokenType, sample.PriceCents, paused); ok {
+			log.Infof("📐 [%s] 触发分层加仓: market=%s tokenType=%s priceCents=%d notional=%.2f",
+				ID, e.Market.Slug, e.TokenType, sample.PriceCents, notional)
+		}
+	}
+
+	log.Debugf("📈 [%s] 价格变化: market=%s tokenType=%s newPrice=%.4f",
 		ID, e.Market.Slug, e.TokenType, e.NewPrice.ToDecimal())
 
 	return nil
 }
 
+// ResumeTrading 手动清除亏损暂停状态，供终端UI在确认风险已解除后调用；
+// 时段过滤不受此方法影响（仍按 TradeStartHour/TradeEndHour 判断）。
+func (s *Strategy) ResumeTrading() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tradingState.Paused = false
+	s.tradingState.PauseReason = ""
+	log.Infof("▶️ [%s] 手动恢复交易", ID)
+}
+
 // GetTradingState 获取交易状态（线程安全）
 func (s *Strategy) GetTradingState() *TradingState {
 	s.mu.RLock()
@@ -235,27 +344,15 @@ func (s *Strategy) GetPerformanceMetrics() *PerformanceMetrics {
 }
 
 // 占位符结构体定义（将在后续任务中实现）
-
-// VelocityEngine 速度引擎占位符
-type VelocityEngine struct {
-	// 将在任务2中实现
-}
+// VelocityEngine 已在 velocity_engine.go 中实现。
+// RiskController 已在 risk_controller.go 中实现。
+// PositionManager 已在 position_manager.go 中实现。
 
 // OrderExecutor 订单执行器占位符
 type OrderExecutor struct {
 	// 将在任务3中实现
 }
 
-// RiskController 风险控制器占位符
-type RiskController struct {
-	// 将在任务4中实现
-}
-
-// PositionManager 头寸管理器占位符
-type PositionManager struct {
-	// 将在任务5中实现
-}
-
 // TerminalUI 终端UI占位符
 type TerminalUI struct {
 	// 将在任务6中实现
@@ -65,6 +65,17 @@ type TradingState struct {
 	BiasReady  bool             // 外部偏向数据是否就绪
 	BiasToken  domain.TokenType // 偏向的代币方向
 	BiasReason string           // 偏向原因说明
+
+	// 暂停状态（见 pause_gate.go）。只有亏损暂停会设置这两个字段并一直保持，
+	// 直到 ResetPauseOnNewCycle 触发的周期重置或手动调用 Strategy.ResumeTrading；
+	// 时段过滤本身是无状态的，每次 OnPriceChanged 都会重新判断，不写入这里。
+	Paused      bool   // 是否因亏损触发暂停
+	PauseReason string // 暂停原因说明
+
+	// NR-N 窄幅整理确认（见 nrn.go）。每次 OnPriceChanged 都从 VelocityEngine
+	// 同步最近一次判断结果，不跨周期累积。
+	NrSignal      bool             // 最近一次价格事件对应的 token 是否处于 NR-N 信号
+	NrSignalToken domain.TokenType // NrSignal 对应的 token
 }
 
 // NewTradingState 创建新的交易状态
@@ -220,6 +231,7 @@ type PerformanceMetrics struct {
 
 	// 盈亏统计
 	TotalPnL        float64 // 总盈亏
+	RealizedPnL     float64 // 已实现盈亏（用于 PauseTradeLoss 判断，见 pause_gate.go）
 	WinningTrades   int     // 盈利交易数
 	LosingTrades    int     // 亏损交易数
 	AverageWin      float64 // 平均盈利
@@ -234,6 +246,10 @@ type PerformanceMetrics struct {
 	// 执行指标
 	AverageExecutionTime time.Duration // 平均执行时间
 	OrderFillRate        float64       // 订单成交率
+
+	// 指标诊断（见 velocity_engine.go，UseCCI 未开启或窗口未填满时为 0）
+	LatestCCIUp   float64 // UP token 最新 CCI 值
+	LatestCCIDown float64 // DOWN token 最新 CCI 值
 }
 
 // SlippageMetrics 滑点统计
@@ -269,6 +285,13 @@ const (
 	DefaultOrderSize            = 10.0   // 默认订单大小
 	DefaultHedgeOffsetCents     = 2      // 默认对冲偏移（分）
 	DefaultMaxTradesPerCycle    = 10     // 默认每周期最大交易次数
+	DefaultCCIWindow            = 20     // 默认CCI滑动窗口大小
+	DefaultLongCCI              = 150.0  // 默认做多侧CCI阈值
+	DefaultShortCCI             = -150.0 // 默认做空侧CCI阈值
+	DefaultATRWindow            = 14     // 默认ATR滑动窗口大小
+	DefaultATRProfitMultiple    = 1.5    // 默认止盈ATR倍数
+	DefaultATRLossMultiple      = 1.0    // 默认止损ATR倍数
+	DefaultATRBarMs             = 60000  // 默认ATR合成K线窗口（毫秒）
 	DefaultTakeProfitCents      = 10     // 默认止盈（分）
 	DefaultStopLossCents        = 20     // 默认止损（分）
 	DefaultMaxHoldSeconds       = 600    // 默认最大持有时间（秒）
@@ -0,0 +1,286 @@
+package luckbet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/strategies/common/indicators"
+)
+
+// VelocityEngine 按 TokenType 维护价格样本滑动窗口，计算价格变化速度
+// （VelocityMetrics），并在 UseCCI 开启时叠加一个 CCI 振荡器过滤噪声突破：
+// 只有当 CCI 穿越 LongCCI/ShortCCI 阈值的方向与速度符号一致时才认为是有效的
+// 趋势延续信号，而不是均值回归中的一次假突破。
+type VelocityEngine struct {
+	mu sync.Mutex
+
+	windowSeconds int
+	minVelocity   float64
+	minMoveCents  int
+
+	useCCI    bool
+	cciWindow int
+	longCCI   float64
+	shortCCI  float64
+
+	nrCount    int
+	strictMode bool
+	nrBarMs    int64
+
+	samples map[domain.TokenType][]PriceSample
+	cci     map[domain.TokenType]*indicators.CCI
+	lastCCI map[domain.TokenType]float64
+	cciOk   map[domain.TokenType]bool
+
+	nrBars   map[domain.TokenType]*nrBar
+	nrRanges map[domain.TokenType]*floatRing
+	nrSignal map[domain.TokenType]bool
+}
+
+// NewVelocityEngine 按 cfg 创建一个 VelocityEngine。
+func NewVelocityEngine(cfg *Config) *VelocityEngine {
+	return &VelocityEngine{
+		windowSeconds: cfg.WindowSeconds,
+		minVelocity:   cfg.MinVelocityCentsPerSec,
+		minMoveCents:  cfg.MinMoveCents,
+		useCCI:        cfg.UseCCI,
+		cciWindow:     cfg.CCIWindow,
+		longCCI:       cfg.LongCCI,
+		shortCCI:      cfg.ShortCCI,
+		nrCount:       cfg.NrCount,
+		strictMode:    cfg.StrictMode,
+		nrBarMs:       int64(cfg.ATRBarMs),
+		samples:       make(map[domain.TokenType][]PriceSample),
+		cci:           make(map[domain.TokenType]*indicators.CCI),
+		lastCCI:       make(map[domain.TokenType]float64),
+		cciOk:         make(map[domain.TokenType]bool),
+		nrBars:        make(map[domain.TokenType]*nrBar),
+		nrRanges:      make(map[domain.TokenType]*floatRing),
+		nrSignal:      make(map[domain.TokenType]bool),
+	}
+}
+
+// AddSample 喂入一个新的价格样本，更新该 TokenType 的滑动窗口与（可选）CCI，
+// 返回基于窗口内最旧/最新样本计算出的速度指标。
+func (e *VelocityEngine) AddSample(sample PriceSample) VelocityMetrics {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	list := append(e.samples[sample.TokenType], sample)
+	cutoff := sample.Timestamp.Add(-time.Duration(e.windowSeconds) * time.Second)
+	pruned := list[:0]
+	for _, s := range list {
+		if !s.Timestamp.Before(cutoff) {
+			pruned = append(pruned, s)
+		}
+	}
+	e.samples[sample.TokenType] = pruned
+
+	if e.useCCI {
+		cci, ok := e.cci[sample.TokenType]
+		if !ok {
+			cci = indicators.NewCCI(e.cciWindow)
+			e.cci[sample.TokenType] = cci
+		}
+		value, ok := cci.Update(sample.Price.ToDecimal())
+		e.lastCCI[sample.TokenType] = value
+		e.cciOk[sample.TokenType] = ok
+	}
+
+	if e.nrCount >= 2 {
+		e.updateNrNLocked(sample)
+	}
+
+	return e.computeLocked(sample.TokenType)
+}
+
+// updateNrNLocked 把 sample 聚合进该 TokenType 的 NR-N 合成K线（长度复用
+// ATRBarMs），在每根K线收盘时判断其 range 是否严格小于缓冲区内此前 NrCount-1
+// 根K线的 range，并把结果写入 nrSignal。调用方必须持有 e.mu。
+func (e *VelocityEngine) updateNrNLocked(sample PriceSample) {
+	tokenType := sample.TokenType
+	price := float64(sample.PriceCents)
+	if price <= 0 {
+		return
+	}
+
+	bar := e.nrBars[tokenType]
+	if bar == nil {
+		bar = &nrBar{}
+		e.nrBars[tokenType] = bar
+	}
+	if !bar.have {
+		bar.start = sample.Timestamp
+		bar.high = price
+		bar.low = price
+		bar.close = price
+		bar.have = true
+		return
+	}
+	if price > bar.high {
+		bar.high = price
+	}
+	if price < bar.low {
+		bar.low = price
+	}
+	bar.close = price
+
+	if sample.Timestamp.Sub(bar.start) < time.Duration(e.nrBarMs)*time.Millisecond {
+		return
+	}
+
+	ring := e.nrRanges[tokenType]
+	if ring == nil {
+		ring = newFloatRing(e.nrCount - 1)
+		e.nrRanges[tokenType] = ring
+	}
+
+	rangeVal := bar.high - bar.low
+	signal := ring.Full()
+	if signal {
+		for i := 0; i < ring.Len(); i++ {
+			if rangeVal >= ring.At(i) {
+				signal = false
+				break
+			}
+		}
+	}
+	e.nrSignal[tokenType] = signal
+	ring.Push(rangeVal)
+	bar.lastClose = price
+
+	bar.start = sample.Timestamp
+	bar.high = price
+	bar.low = price
+	bar.close = price
+}
+
+// computeLocked 用窗口内最旧/最新样本计算速度，与 property_test.go 中
+// calculateVelocityFromSamples 的简化实现保持同一套公式：delta/duration。
+func (e *VelocityEngine) computeLocked(tokenType domain.TokenType) VelocityMetrics {
+	samples := e.samples[tokenType]
+	if len(samples) < 2 {
+		return VelocityMetrics{TokenType: tokenType, IsValid: false, SampleCount: len(samples)}
+	}
+
+	first := samples[0]
+	last := samples[len(samples)-1]
+	duration := last.Timestamp.Sub(first.Timestamp).Seconds()
+	if duration <= 0 {
+		return VelocityMetrics{TokenType: tokenType, IsValid: false, SampleCount: len(samples)}
+	}
+
+	delta := last.PriceCents - first.PriceCents
+	return VelocityMetrics{
+		TokenType:   tokenType,
+		Delta:       delta,
+		Duration:    duration,
+		Velocity:    float64(delta) / duration,
+		IsValid:     true,
+		SampleCount: len(samples),
+		StartPrice:  first.Price,
+		EndPrice:    last.Price,
+		Timestamp:   last.Timestamp,
+	}
+}
+
+// LatestCCI 返回 tokenType 最近一次的 CCI 值；窗口未填满或 UseCCI 关闭时
+// ok=false。
+func (e *VelocityEngine) LatestCCI(tokenType domain.TokenType) (value float64, ok bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lastCCI[tokenType], e.cciOk[tokenType]
+}
+
+// Signal 判断 metrics 是否应该触发入场：速度幅度与价格变化必须先达到
+// MinVelocityCentsPerSec/MinMoveCents 的基础阈值；UseCCI 开启时还要求该
+// TokenType 的 CCI 已就绪，且速度为正时 CCI 已越过 LongCCI、速度为负时 CCI
+// 已越过 ShortCCI（方向必须与速度符号一致）。
+func (e *VelocityEngine) Signal(metrics VelocityMetrics) bool {
+	if !metrics.IsValid {
+		return false
+	}
+	if absInt(metrics.Delta) < e.minMoveCents {
+		return false
+	}
+	if absFloat(metrics.Velocity) < e.minVelocity {
+		return false
+	}
+	if !e.useCCI {
+		return true
+	}
+
+	e.mu.Lock()
+	cciValue, ok := e.lastCCI[metrics.TokenType], e.cciOk[metrics.TokenType]
+	e.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	switch {
+	case metrics.Velocity > 0:
+		return cciValue >= e.longCCI
+	case metrics.Velocity < 0:
+		return cciValue <= e.shortCCI
+	default:
+		return false
+	}
+}
+
+// IsNrSignal 判断 tokenType 最近一次收盘的合成K线是否处于 NR-N 信号（即该
+// K线的 range 严格小于此前 NrCount-1 根K线）。StrictMode 开启时还要求该K线
+// 收盘价落在与 tokenType 一致的半区（UP 要求 >50c，DOWN 要求 <50c）——速度
+// 阈值本身仍由调用方另行通过 Signal(metrics) 检查。
+func (e *VelocityEngine) IsNrSignal(tokenType domain.TokenType) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.nrCount < 2 || !e.nrSignal[tokenType] {
+		return false
+	}
+	if !e.strictMode {
+		return true
+	}
+
+	bar := e.nrBars[tokenType]
+	if bar == nil || !bar.have {
+		return false
+	}
+	switch tokenType {
+	case domain.TokenTypeUp:
+		return bar.lastClose > 50
+	case domain.TokenTypeDown:
+		return bar.lastClose < 50
+	default:
+		return false
+	}
+}
+
+// Reset 清空所有 TokenType 的样本窗口、CCI 状态与 NR-N 状态（周期切换时调用，
+// 见 Strategy.OnCycle）。
+func (e *VelocityEngine) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.samples = make(map[domain.TokenType][]PriceSample)
+	e.cci = make(map[domain.TokenType]*indicators.CCI)
+	e.lastCCI = make(map[domain.TokenType]float64)
+	e.cciOk = make(map[domain.TokenType]bool)
+	e.nrBars = make(map[domain.TokenType]*nrBar)
+	e.nrRanges = make(map[domain.TokenType]*floatRing)
+	e.nrSignal = make(map[domain.TokenType]bool)
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func absFloat(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
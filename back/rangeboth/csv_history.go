@@ -0,0 +1,157 @@
+package rangeboth
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/betbot/gobet/pkg/backtest"
+	"github.com/betbot/gobet/pkg/marketspec"
+)
+
+// CSVHistoryProvider 从两个 CSV 文件加载历史数据，实现 backtest.HistoryProvider：
+//   - ticksPath: slug,timestamp_unix,up_price,down_price[,volume]
+//   - resolutionsPath: slug,up_won,close_price[,volume]
+//
+// 两个文件都带表头（首行会被跳过）。slug 不在 resolutionsPath 里代表该周期
+// 在这份历史数据里还没收盘。
+type CSVHistoryProvider struct {
+	ticks       []backtest.Tick
+	resolutions map[string]backtest.Resolution
+}
+
+// LoadCSVHistory 读取 ticksPath/resolutionsPath 并构造 CSVHistoryProvider。
+func LoadCSVHistory(ticksPath, resolutionsPath string) (*CSVHistoryProvider, error) {
+	ticks, err := readTicksCSV(ticksPath)
+	if err != nil {
+		return nil, fmt.Errorf("读取 ticks CSV 失败: %w", err)
+	}
+	resolutions, err := readResolutionsCSV(resolutionsPath)
+	if err != nil {
+		return nil, fmt.Errorf("读取 resolutions CSV 失败: %w", err)
+	}
+	return &CSVHistoryProvider{ticks: ticks, resolutions: resolutions}, nil
+}
+
+func readTicksCSV(path string) ([]backtest.Tick, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	if _, err := r.Read(); err != nil { // 跳过表头
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ticks []backtest.Tick
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) < 4 {
+			continue
+		}
+		ts, err := strconv.ParseInt(rec[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("非法 timestamp_unix %q: %w", rec[1], err)
+		}
+		up, err := strconv.ParseFloat(rec[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("非法 up_price %q: %w", rec[2], err)
+		}
+		down, err := strconv.ParseFloat(rec[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("非法 down_price %q: %w", rec[3], err)
+		}
+		var volume float64
+		if len(rec) > 4 {
+			volume, _ = strconv.ParseFloat(rec[4], 64)
+		}
+		ticks = append(ticks, backtest.Tick{
+			Slug:      rec[0],
+			Timestamp: time.Unix(ts, 0),
+			UpPrice:   up,
+			DownPrice: down,
+			Volume:    volume,
+		})
+	}
+	return ticks, nil
+}
+
+func readResolutionsCSV(path string) (map[string]backtest.Resolution, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	if _, err := r.Read(); err != nil { // 跳过表头
+		if err == io.EOF {
+			return map[string]backtest.Resolution{}, nil
+		}
+		return nil, err
+	}
+
+	out := make(map[string]backtest.Resolution)
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) < 3 {
+			continue
+		}
+		up, err := strconv.ParseBool(rec[1])
+		if err != nil {
+			return nil, fmt.Errorf("非法 up_won %q: %w", rec[1], err)
+		}
+		closePrice, err := strconv.ParseFloat(rec[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("非法 close_price %q: %w", rec[2], err)
+		}
+		var volume float64
+		if len(rec) > 3 {
+			volume, _ = strconv.ParseFloat(rec[3], 64)
+		}
+		out[rec[0]] = backtest.Resolution{Slug: rec[0], Up: up, ClosePrice: closePrice, Volume: volume}
+	}
+	return out, nil
+}
+
+// Ticks 实现 backtest.HistoryProvider：按 slug 过滤出对应周期的 tick。
+func (p *CSVHistoryProvider) Ticks(_ context.Context, _ marketspec.MarketSpec, slug string, _ int64) ([]backtest.Tick, error) {
+	var out []backtest.Tick
+	for _, t := range p.ticks {
+		if t.Slug == slug {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// Resolution 实现 backtest.HistoryProvider：slug 不在 resolutions CSV 里时
+// 视为该周期在这份历史数据里还没收盘。
+func (p *CSVHistoryProvider) Resolution(_ context.Context, _ marketspec.MarketSpec, slug string, _ int64) (*backtest.Resolution, error) {
+	res, ok := p.resolutions[slug]
+	if !ok {
+		return nil, nil
+	}
+	return &res, nil
+}
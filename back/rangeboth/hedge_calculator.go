@@ -4,6 +4,12 @@ import (
 	"math"
 )
 
+// maxEffectivePrice 是 (1-price) 分母允许的价格上限：price 一旦达到或超过这个
+// 值（例如经手续费/滑点加成后的有效价格 >= 1），继续买入这一腿已经无法提升
+// 收益（每股付出 >= 每股赔付 1），分母会变成 <=0，必须在除法前拦截，否则会
+// 算出负数/巨大的"需要补单数量"。
+const maxEffectivePrice = 0.999999
+
 // HedgeState 当前持仓状态
 type HedgeState struct {
 	UpShares   float64 // UP持仓数量
@@ -63,14 +69,16 @@ func CalculateHedgeNeeds(state HedgeState, upPrice, downPrice, targetMinProfit,
 	if profitIfUpWin < targetMinProfit {
 		// UP不足，需要补UP
 		profitGap := targetMinProfit - profitIfUpWin
-		upNeeded = profitGap / (1.0 - upPrice)
+		if upPrice < maxEffectivePrice {
+			upNeeded = profitGap / (1.0 - upPrice)
+		}
 		// 考虑补UP后对DOWN收益的影响
 		// 补UP后总成本增加：ΔC = upNeeded * upPrice
 		// DOWN收益变为：Q_down - (C_total + ΔC)
 		// 如果DOWN收益仍然不足，需要同时补DOWN
 		newTotalCost := totalCost + upNeeded*upPrice
 		newProfitIfDownWin := state.DownShares*1.0 - newTotalCost
-		if newProfitIfDownWin < targetMinProfit {
+		if newProfitIfDownWin < targetMinProfit && downPrice < maxEffectivePrice {
 			// DOWN也需要补
 			downGap := targetMinProfit - newProfitIfDownWin
 			downNeeded = downGap / (1.0 - downPrice)
@@ -78,11 +86,13 @@ func CalculateHedgeNeeds(state HedgeState, upPrice, downPrice, targetMinProfit,
 	} else if profitIfDownWin < targetMinProfit {
 		// DOWN不足，需要补DOWN
 		profitGap := targetMinProfit - profitIfDownWin
-		downNeeded = profitGap / (1.0 - downPrice)
+		if downPrice < maxEffectivePrice {
+			downNeeded = profitGap / (1.0 - downPrice)
+		}
 		// 考虑补DOWN后对UP收益的影响
 		newTotalCost := totalCost + downNeeded*downPrice
 		newProfitIfUpWin := state.UpShares*1.0 - newTotalCost
-		if newProfitIfUpWin < targetMinProfit {
+		if newProfitIfUpWin < targetMinProfit && upPrice < maxEffectivePrice {
 			// UP也需要补
 			upGap := targetMinProfit - newProfitIfUpWin
 			upNeeded = upGap / (1.0 - upPrice)
@@ -0,0 +1,198 @@
+package rangeboth
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/betbot/gobet/clob/types"
+)
+
+// HedgeConstraints 描述补单时除了价格/持仓以外还要满足的下单约束。
+type HedgeConstraints struct {
+	UpTokenID   string // UP token 的 CLOB 资产 ID
+	DownTokenID string // DOWN token 的 CLOB 资产 ID
+
+	TickSize     types.TickSize // 价格精度，提交价会被向上取整到这个网格
+	MinOrderSize float64        // 单腿最小下单量（shares），低于这个量就不下这条腿
+
+	FeeRateBps  int // 双边手续费率（基点），计入补单成本
+	SlippageBps int // 预期滑点（基点），抬高实际成交价的估计
+
+	// NegRisk 透传给最终下单（不影响这里的补单量计算，由提交订单的地方决定
+	// 走 CTFExchange 还是 NegRiskCTFExchange 合约）。
+	NegRisk *bool
+}
+
+// HedgePlan 是 HedgeSolver 的输出：两条腿各自需要提交的补单（可能为空）。
+type HedgePlan struct {
+	UpOrders   []types.UserOrder
+	DownOrders []types.UserOrder
+}
+
+func tickSizeFloat(t types.TickSize) float64 {
+	v, err := strconv.ParseFloat(string(t), 64)
+	if err != nil || v <= 0 {
+		return 0.01
+	}
+	return v
+}
+
+// roundUpToTick 把买入价向上取整到 tick 网格：宁可多付一点，也不要因为价格
+// 没对齐网格导致交易所拒单，从而完全对不上冲。
+func roundUpToTick(price, tick float64) float64 {
+	if tick <= 0 {
+		return price
+	}
+	return math.Ceil(price/tick) * tick
+}
+
+// solveHedgeNeeds 用解析解（而不是 CalculateHedgeNeeds 那种"先补一条腿、再看另一
+// 条腿够不够"的两轮贪心）直接求两条腿都达到 targetMinProfit 所需的最小补单量。
+//
+// 记当前 profitIfUpWin0 = UpShares-totalCost = A，profitIfDownWin0 = DownShares-
+// totalCost = B（用 CalculateHedgeState 的定义）。补 ΔUp/ΔDown 之后：
+//
+//	profitIfUpWin'   = A + ΔUp*(1-pu) - ΔDown*pd
+//	profitIfDownWin' = B + ΔDown*(1-pd) - ΔUp*pu
+//
+// 贪心解的问题在于：它先把 ΔUp 解到让第一个式子恰好等于 targetMinProfit，再据此
+// 算 ΔDown；但 ΔDown 那一项里的 "-ΔUp*pu" 在第二步又会把第一条腿刚好顶到目标值
+// 的 profitIfUpWin' 拉低，贪心不会回头重新调整 ΔUp 去抵消这个交叉影响。
+//
+// 当两条腿都需要补时，真正的最优解是让两个约束同时取等号（LP 最优解落在可行域
+// 某个顶点上，两腿都不足时这个顶点就是两条约束线的交点），即解 2x2 线性方程组：
+//
+//	(1-pu)*ΔUp -      pd*ΔDown = GapUp    , GapUp   = targetMinProfit - A
+//	    -pu*ΔUp + (1-pd)*ΔDown = GapDown  , GapDown = targetMinProfit - B
+//
+// 行列式 D = (1-pu)(1-pd) - pu*pd = 1-pu-pd：在 YES+NO 两腿价格之和接近1（扣除
+// 手续费/滑点前）时 D 接近0；一旦两腿的有效价格之和达到或超过1（D<=0），无论怎么
+// 补都无法同时让两腿盈利，只能退化为单腿贪心（和 CalculateHedgeNeeds 一致），交给
+// 调用方承担剩余的单边风险。
+func solveHedgeNeeds(state HedgeState, pu, pd, targetMinProfit, maxOrderSize float64) (upNeeded, downNeeded float64) {
+	totalCost := state.UpCost + state.DownCost
+	if totalCost <= 0 {
+		return 0, 0
+	}
+
+	a := state.UpShares - totalCost
+	b := state.DownShares - totalCost
+	if a >= targetMinProfit && b >= targetMinProfit {
+		return 0, 0
+	}
+
+	needUp := a < targetMinProfit
+	needDown := b < targetMinProfit
+	gapUp := targetMinProfit - a
+	gapDown := targetMinProfit - b
+
+	switch {
+	case needUp && !needDown && pu < maxEffectivePrice:
+		// 只有 UP 不足：单变量解，ΔDown=0。
+		upNeeded = gapUp / (1.0 - pu)
+		// 补完 UP 后交叉项可能把 DOWN 也拖到目标线以下，这时退化为联立求解。
+		if b-upNeeded*pu < targetMinProfit {
+			upNeeded, downNeeded = solveJoint(pu, pd, gapUp, gapDown)
+		}
+	case needDown && !needUp && pd < maxEffectivePrice:
+		// 只有 DOWN 不足：单变量解，ΔUp=0。
+		downNeeded = gapDown / (1.0 - pd)
+		if a-downNeeded*pd < targetMinProfit {
+			upNeeded, downNeeded = solveJoint(pu, pd, gapUp, gapDown)
+		}
+	case needUp || needDown:
+		upNeeded, downNeeded = solveJoint(pu, pd, gapUp, gapDown)
+	}
+
+	if upNeeded > maxOrderSize {
+		upNeeded = maxOrderSize
+	}
+	if downNeeded > maxOrderSize {
+		downNeeded = maxOrderSize
+	}
+	if upNeeded < 0 {
+		upNeeded = 0
+	}
+	if downNeeded < 0 {
+		downNeeded = 0
+	}
+	return upNeeded, downNeeded
+}
+
+// solveJoint 解两条腿约束同时取等号的 2x2 线性方程组；D<=0（两腿有效价格之和
+// 达到或超过1，没有可盈利的补单组合）或某一侧解出负数（最优顶点落在坐标轴上，
+// 也就是其实只需要补另一条腿）时，退化为对应的单变量解。
+func solveJoint(pu, pd, gapUp, gapDown float64) (upNeeded, downNeeded float64) {
+	d := 1.0 - pu - pd
+	if d <= 1e-9 {
+		// 两腿价格之和已经接近或超过1：补单不可能让两条腿同时达标，只能各自
+		// 尽量单独逼近目标，优先保住还有盈利空间的那一条腿。
+		if pu < maxEffectivePrice && gapUp > 0 {
+			upNeeded = gapUp / (1.0 - pu)
+		}
+		if pd < maxEffectivePrice && gapDown > 0 {
+			downNeeded = gapDown / (1.0 - pd)
+		}
+		return upNeeded, downNeeded
+	}
+
+	upNeeded = (gapUp*(1.0-pd) + pd*gapDown) / d
+	downNeeded = ((1.0-pu)*gapDown + pu*gapUp) / d
+
+	if upNeeded < 0 && pd < maxEffectivePrice {
+		// 交点落在 ΔUp<0 的一侧：最优顶点其实在 ΔUp=0 这条轴上，只需要补 DOWN。
+		return 0, gapDown / (1.0 - pd)
+	}
+	if downNeeded < 0 && pu < maxEffectivePrice {
+		return gapUp / (1.0 - pu), 0
+	}
+	return upNeeded, downNeeded
+}
+
+// NewHedgeSolver 用 solveHedgeNeeds 的解析两变量解，叠加手续费、滑点、tick size
+// 和最小下单量约束，输出可以直接提交的补单计划。
+//
+// 手续费和滑点都会让实际补仓成本高于盘口价，所以先把 upPrice/downPrice 按
+// 1+(feeRateBps+slippageBps)/10000 抬高后再喂给 solveHedgeNeeds，
+// 这样算出来的补单量本身就已经把这部分损耗算进去了；提交价则在盘口价基础上
+// 只加滑点 buffer（手续费不影响挂单价格本身），再向上取整到 tick size。单量
+// 低于 MinOrderSize 的腿直接丢弃——留一点残余风险，好过提交一个会被拒绝的单。
+func NewHedgeSolver(state HedgeState, upPrice, downPrice, targetMinProfit, maxOrderSize float64, c HedgeConstraints) HedgePlan {
+	costMultiplier := 1.0 + float64(c.FeeRateBps+c.SlippageBps)/10000.0
+	effUpPrice := upPrice * costMultiplier
+	effDownPrice := downPrice * costMultiplier
+
+	upNeeded, downNeeded := solveHedgeNeeds(state, effUpPrice, effDownPrice, targetMinProfit, maxOrderSize)
+
+	tick := tickSizeFloat(c.TickSize)
+	slippageMultiplier := 1.0 + float64(c.SlippageBps)/10000.0
+	submitUpPrice := roundUpToTick(upPrice*slippageMultiplier, tick)
+	submitDownPrice := roundUpToTick(downPrice*slippageMultiplier, tick)
+
+	var feeRateBps *int
+	if c.FeeRateBps != 0 {
+		fr := c.FeeRateBps
+		feeRateBps = &fr
+	}
+
+	var plan HedgePlan
+	if upNeeded > 0 && upNeeded >= c.MinOrderSize {
+		plan.UpOrders = append(plan.UpOrders, types.UserOrder{
+			TokenID:    c.UpTokenID,
+			Price:      submitUpPrice,
+			Size:       upNeeded,
+			Side:       types.SideBuy,
+			FeeRateBps: feeRateBps,
+		})
+	}
+	if downNeeded > 0 && downNeeded >= c.MinOrderSize {
+		plan.DownOrders = append(plan.DownOrders, types.UserOrder{
+			TokenID:    c.DownTokenID,
+			Price:      submitDownPrice,
+			Size:       downNeeded,
+			Side:       types.SideBuy,
+			FeeRateBps: feeRateBps,
+		})
+	}
+	return plan
+}
@@ -0,0 +1,161 @@
+package rangeboth
+
+import (
+	"math"
+	"testing"
+
+	"github.com/betbot/gobet/clob/types"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// TestSolveHedgeNeedsAlreadySufficientIsNoOp 验证两腿收益都已达标时不补单。
+func TestSolveHedgeNeedsAlreadySufficientIsNoOp(t *testing.T) {
+	state := HedgeState{UpShares: 100, DownShares: 100, UpCost: 40, DownCost: 40}
+	upNeeded, downNeeded := solveHedgeNeeds(state, 0.5, 0.5, 10, 1000)
+	if upNeeded != 0 || downNeeded != 0 {
+		t.Errorf("两腿都已达标，不应补单，实际 up=%.4f down=%.4f", upNeeded, downNeeded)
+	}
+}
+
+// TestSolveHedgeNeedsSingleLegDeficient 验证只有一条腿不足时退化为单变量解，
+// 且不会误补另一条本来就达标的腿。
+func TestSolveHedgeNeedsSingleLegDeficient(t *testing.T) {
+	// UpShares=100,DownShares=60,cost=40：profitIfUpWin=60(达标)，
+	// profitIfDownWin=20(不足，目标30)。
+	state := HedgeState{UpShares: 100, DownShares: 60, UpCost: 20, DownCost: 20}
+	upNeeded, downNeeded := solveHedgeNeeds(state, 0.5, 0.5, 30, 1000)
+
+	wantDown := (30.0 - 20.0) / (1.0 - 0.5)
+	if !approxEqual(downNeeded, wantDown) {
+		t.Errorf("downNeeded应为%.4f，实际为%.4f", wantDown, downNeeded)
+	}
+	if upNeeded != 0 {
+		t.Errorf("UP腿本来就达标，不应补单，实际为%.4f", upNeeded)
+	}
+
+	// 补完DOWN之后验证两腿收益确实都达到目标。
+	totalCost := state.UpCost + state.DownCost + downNeeded*0.5
+	profitIfUpWin := state.UpShares - totalCost
+	profitIfDownWin := state.DownShares + downNeeded - totalCost
+	if profitIfUpWin < 30-1e-6 || profitIfDownWin < 30-1e-6 {
+		t.Errorf("补单后两腿收益应都达到30，实际 up=%.4f down=%.4f", profitIfUpWin, profitIfDownWin)
+	}
+}
+
+// TestSolveHedgeNeedsJointSolveBeatsGreedy 是本次修复的核心验证：两条腿同时
+// 不足时，联立求解得到的结果应该让两条腿的补单后收益同时达到目标（而不是像
+// 旧的贪心算法那样，第二步补单会把第一步刚好顶到目标线的那条腿重新拉低）。
+func TestSolveHedgeNeedsJointSolveBeatsGreedy(t *testing.T) {
+	// 两腿都不足：profitIfUpWin = 50-40 = 10, profitIfDownWin = 50-40 = 10，
+	// 目标是30。
+	state := HedgeState{UpShares: 50, DownShares: 50, UpCost: 20, DownCost: 20}
+	pu, pd := 0.4, 0.4
+	target := 30.0
+
+	upNeeded, downNeeded := solveHedgeNeeds(state, pu, pd, target, 1000)
+	if upNeeded <= 0 || downNeeded <= 0 {
+		t.Fatalf("两腿都不足，应该两条腿都补单，实际 up=%.4f down=%.4f", upNeeded, downNeeded)
+	}
+
+	totalCost := state.UpCost + state.DownCost + upNeeded*pu + downNeeded*pd
+	profitIfUpWin := state.UpShares + upNeeded - totalCost
+	profitIfDownWin := state.DownShares + downNeeded - totalCost
+
+	if !approxEqual(profitIfUpWin, target) {
+		t.Errorf("联立解应让profitIfUpWin恰好等于目标%.4f，实际为%.4f", target, profitIfUpWin)
+	}
+	if !approxEqual(profitIfDownWin, target) {
+		t.Errorf("联立解应让profitIfDownWin恰好等于目标%.4f，实际为%.4f", target, profitIfDownWin)
+	}
+
+	// 旧的贪心算法（CalculateHedgeNeeds）在这种两腿都不足的场景下，第二步补单
+	// 会把第一步刚顶到目标线的那条腿重新拉低于目标——证明新解析解确实不同于
+	// 且优于旧贪心。
+	greedyUp, greedyDown := CalculateHedgeNeeds(state, pu, pd, target, 1000)
+	greedyTotalCost := state.UpCost + state.DownCost + greedyUp*pu + greedyDown*pd
+	greedyProfitIfUpWin := state.UpShares + greedyUp - greedyTotalCost
+	if greedyProfitIfUpWin >= target-1e-6 {
+		t.Fatalf("前提假设有误：本场景下旧贪心算法应该也会让UP腿补单后收益低于目标，实际为%.4f（说明该测试场景没有覆盖到两步贪心互相拖累的bug）", greedyProfitIfUpWin)
+	}
+}
+
+// TestSolveHedgeNeedsDegenerateWhenPricesSumToOne 验证两腿有效价格之和达到1
+// （D<=0）时不会算出负数或发散的补单量，而是退化为尽量单独逼近目标。
+func TestSolveHedgeNeedsDegenerateWhenPricesSumToOne(t *testing.T) {
+	state := HedgeState{UpShares: 50, DownShares: 50, UpCost: 20, DownCost: 20}
+	upNeeded, downNeeded := solveHedgeNeeds(state, 0.5, 0.51, 30, 1000)
+	if upNeeded < 0 || downNeeded < 0 {
+		t.Errorf("退化场景下补单量不应为负，实际 up=%.4f down=%.4f", upNeeded, downNeeded)
+	}
+	if math.IsInf(upNeeded, 0) || math.IsInf(downNeeded, 0) || math.IsNaN(upNeeded) || math.IsNaN(downNeeded) {
+		t.Errorf("退化场景下补单量不应发散，实际 up=%.4f down=%.4f", upNeeded, downNeeded)
+	}
+}
+
+// TestSolveHedgeNeedsClampsToMaxOrderSize 验证补单量会被裁到maxOrderSize。
+func TestSolveHedgeNeedsClampsToMaxOrderSize(t *testing.T) {
+	state := HedgeState{UpShares: 50, DownShares: 50, UpCost: 20, DownCost: 20}
+	upNeeded, downNeeded := solveHedgeNeeds(state, 0.5, 0.5, 30, 5)
+	if upNeeded > 5 || downNeeded > 5 {
+		t.Errorf("补单量应被裁到5，实际 up=%.4f down=%.4f", upNeeded, downNeeded)
+	}
+}
+
+// TestNewHedgeSolverAppliesFeeSlippageAndTickRounding 验证 NewHedgeSolver
+// 在解析解之上仍然正确叠加手续费/滑点成本以及 tick size 取整、最小下单量过滤。
+func TestNewHedgeSolverAppliesFeeSlippageAndTickRounding(t *testing.T) {
+	state := HedgeState{UpShares: 50, DownShares: 60, UpCost: 20, DownCost: 20}
+	c := HedgeConstraints{
+		UpTokenID:    "up-token",
+		DownTokenID:  "down-token",
+		TickSize:     types.TickSize001,
+		MinOrderSize: 1,
+		FeeRateBps:   100,
+		SlippageBps:  50,
+	}
+
+	plan := NewHedgeSolver(state, 0.50, 0.40, 30, 1000, c)
+
+	if len(plan.DownOrders) != 1 {
+		t.Fatalf("DOWN腿收益不足，应该补单，实际订单数为%d", len(plan.DownOrders))
+	}
+	order := plan.DownOrders[0]
+	if order.Side != types.SideBuy {
+		t.Errorf("补单方向应为买入，实际为%s", order.Side)
+	}
+	if order.TokenID != c.DownTokenID {
+		t.Errorf("TokenID应为%s，实际为%s", c.DownTokenID, order.TokenID)
+	}
+
+	tick := tickSizeFloat(c.TickSize)
+	remainder := math.Mod(order.Price, tick)
+	if remainder > 1e-9 && tick-remainder > 1e-9 {
+		t.Errorf("提交价%.6f未对齐tick size %.6f", order.Price, tick)
+	}
+	wantMinSubmitPrice := 0.40 * (1.0 + float64(c.SlippageBps)/10000.0)
+	if order.Price < wantMinSubmitPrice-1e-9 {
+		t.Errorf("提交价应至少包含滑点buffer，期望>=%.6f，实际为%.6f", wantMinSubmitPrice, order.Price)
+	}
+}
+
+// TestNewHedgeSolverDropsBelowMinOrderSize 验证补单量低于MinOrderSize时直接
+// 丢弃，而不是提交一个大概率被拒绝的小单。
+func TestNewHedgeSolverDropsBelowMinOrderSize(t *testing.T) {
+	// UP腿早已远超目标，DOWN腿只差一点点（补单量算下来只有2份）。
+	state := HedgeState{UpShares: 100, DownShares: 69, UpCost: 20, DownCost: 20}
+	c := HedgeConstraints{
+		UpTokenID:    "up-token",
+		DownTokenID:  "down-token",
+		TickSize:     types.TickSize01,
+		MinOrderSize: 50,
+	}
+
+	plan := NewHedgeSolver(state, 0.5, 0.5, 30, 1000, c)
+
+	if len(plan.UpOrders) != 0 || len(plan.DownOrders) != 0 {
+		t.Errorf("补单量低于MinOrderSize应全部丢弃，实际 up=%d down=%d", len(plan.UpOrders), len(plan.DownOrders))
+	}
+}
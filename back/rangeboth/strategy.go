@@ -693,8 +693,12 @@ func (s *Strategy) handleRebalancePhase(ctx context.Context, market *domain.Mark
 	upPrice := yesBid.ToDecimal()
 	downPrice := noBid.ToDecimal()
 
-	// 计算需要补的数量
-	upNeeded, downNeeded := CalculateHedgeNeeds(hedgeState, upPrice, downPrice, s.RebalanceMinProfit, s.RebalanceMaxOrderSize)
+	// 计算需要补的数量：走 NewHedgeSolver，把手续费/滑点/tick size/最小下单量都
+	// 算进补单量里（而不是用原始 CalculateHedgeNeeds，那样补单后的实际成交价会
+	// 比计算时用的盘口价更差，导致补了单仍然没真正达到目标收益）。
+	plan := NewHedgeSolver(hedgeState, upPrice, downPrice, s.RebalanceMinProfit, s.RebalanceMaxOrderSize, s.hedgeConstraints(market))
+	upNeeded := sumOrderSizes(plan.UpOrders)
+	downNeeded := sumOrderSizes(plan.DownOrders)
 
 	if upNeeded <= 0 && downNeeded <= 0 {
 		log.Debugf("✅ [%s] 计算后无需补单", ID)
@@ -789,6 +793,37 @@ func (s *Strategy) cancelPendingOrders(ctx context.Context, marketSlug string) e
 	return nil
 }
 
+// hedgeConstraints 把当前市场的 tick/最小下单量精度和配置的手续费/滑点率
+// 打包成 NewHedgeSolver 需要的约束。
+func (s *Strategy) hedgeConstraints(market *domain.Market) HedgeConstraints {
+	c := HedgeConstraints{
+		MinOrderSize: s.minOrderSize,
+		FeeRateBps:   s.RebalanceFeeRateBps,
+		SlippageBps:  s.RebalanceSlippageBps,
+	}
+	if market != nil {
+		c.UpTokenID = market.YesAssetID
+		c.DownTokenID = market.NoAssetID
+	}
+	if s.currentPrecision != nil {
+		if parsed, err := ParseTickSize(s.currentPrecision.TickSize); err == nil {
+			c.TickSize = parsed
+		}
+		negRisk := s.currentPrecision.NegRisk
+		c.NegRisk = &negRisk
+	}
+	return c
+}
+
+// sumOrderSizes 累加 HedgePlan 里一条腿的所有补单数量。
+func sumOrderSizes(orders []types.UserOrder) float64 {
+	var total float64
+	for _, o := range orders {
+		total += o.Size
+	}
+	return total
+}
+
 // placeRebalanceOrders 根据计算结果智能补单
 func (s *Strategy) placeRebalanceOrders(ctx context.Context, market *domain.Market, upNeeded, downNeeded float64) error {
 	if s.TradingService == nil || market == nil {
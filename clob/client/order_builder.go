@@ -45,6 +45,49 @@ var RoundingConfig = map[types.TickSize]RoundConfig{
 	},
 }
 
+// ErrBelowMinOrderSize 表示订单的名义金额（size * price）低于市场要求的最小
+// 下单量。调用方可以用 errors.As 取出 Notional/MinOrderSize，参考
+// grid.Config 的 AutoAdjustSize/MaxSizeAdjustRatio 自动放大 size 后重试。
+type ErrBelowMinOrderSize struct {
+	Notional     float64
+	MinOrderSize float64
+}
+
+func (e *ErrBelowMinOrderSize) Error() string {
+	return fmt.Sprintf("订单金额 %.6f 低于最小下单量 %.6f", e.Notional, e.MinOrderSize)
+}
+
+// roundConfigFromPrecision 根据 MarketPrecisionInfo 推导 RoundConfig，取代
+// RoundingConfig 硬编码表，从而支持表中未收录的 tick size：
+//   - Price 位数 = -log10(tickSize)
+//   - Size 位数 = MinOrderSize 的小数位数
+//   - Amount 位数 = Price + 2
+func roundConfigFromPrecision(precision *types.MarketPrecisionInfo) (RoundConfig, error) {
+	// 直接解析 tick size 字符串算小数位数，不经过 types.ParseTickSize —— 那个函数
+	// 只认识 RoundingConfig 表里的四个字面量，会让本该支持任意 tick size 的这条
+	// 路径重新被同一张硬编码表卡住（见 chunk178-7）。
+	tickSizeFloat, err := strconv.ParseFloat(precision.TickSize, 64)
+	if err != nil {
+		return RoundConfig{}, fmt.Errorf("tick size 不是合法数字: %s", precision.TickSize)
+	}
+	if tickSizeFloat <= 0 {
+		return RoundConfig{}, fmt.Errorf("tick size 必须大于0: %s", precision.TickSize)
+	}
+	priceDecimals := int(math.Round(-math.Log10(tickSizeFloat)))
+
+	minOrderSize, err := strconv.ParseFloat(precision.MinOrderSize, 64)
+	if err != nil {
+		return RoundConfig{}, fmt.Errorf("min order size 不是合法数字: %s", precision.MinOrderSize)
+	}
+	sizeDecimals := decimalPlaces(minOrderSize)
+
+	return RoundConfig{
+		Price:  priceDecimals,
+		Size:   sizeDecimals,
+		Amount: priceDecimals + 2,
+	}, nil
+}
+
 // OrderBuilder 订单构建器
 type OrderBuilder struct {
 	client        *Client
@@ -69,10 +112,31 @@ func (ob *OrderBuilder) BuildOrder(ctx context.Context, userOrder *types.UserOrd
 		return nil, fmt.Errorf("获取合约配置失败: %w", err)
 	}
 
-	// 获取舍入配置
-	roundConfig, ok := RoundingConfig[options.TickSize]
-	if !ok {
-		return nil, fmt.Errorf("不支持的 tick size: %s", options.TickSize)
+	// 获取舍入配置：优先使用 options.Precision 动态推导，
+	// 这样可以支持 RoundingConfig 硬编码表之外的 tick size（见 chunk178-7）
+	var roundConfig RoundConfig
+	var ok bool
+	if options.Precision != nil {
+		roundConfig, err = roundConfigFromPrecision(options.Precision)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		roundConfig, ok = RoundingConfig[options.TickSize]
+		if !ok {
+			return nil, fmt.Errorf("不支持的 tick size: %s", options.TickSize)
+		}
+	}
+
+	// 校验最小下单金额（仅在提供了市场精度时可知 MinOrderSize）
+	if options.Precision != nil {
+		minOrderSize, parseErr := strconv.ParseFloat(options.Precision.MinOrderSize, 64)
+		if parseErr == nil && minOrderSize > 0 {
+			notional := userOrder.Size * userOrder.Price
+			if notional < minOrderSize {
+				return nil, &ErrBelowMinOrderSize{Notional: notional, MinOrderSize: minOrderSize}
+			}
+		}
 	}
 
 	// 获取签名者地址
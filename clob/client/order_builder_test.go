@@ -0,0 +1,67 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/betbot/gobet/clob/types"
+)
+
+func TestRoundConfigFromPrecisionDerivesDecimals(t *testing.T) {
+	precision := &types.MarketPrecisionInfo{TickSize: "0.001", MinOrderSize: "5"}
+	cfg, err := roundConfigFromPrecision(precision)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Price != 3 {
+		t.Errorf("Price decimals应为3，实际为%d", cfg.Price)
+	}
+	if cfg.Size != 0 {
+		t.Errorf("Size decimals应为0（MinOrderSize=5无小数），实际为%d", cfg.Size)
+	}
+	if cfg.Amount != 5 {
+		t.Errorf("Amount decimals应为Price+2=5，实际为%d", cfg.Amount)
+	}
+}
+
+func TestRoundConfigFromPrecisionFractionalMinOrderSize(t *testing.T) {
+	precision := &types.MarketPrecisionInfo{TickSize: "0.01", MinOrderSize: "0.1"}
+	cfg, err := roundConfigFromPrecision(precision)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Price != 2 {
+		t.Errorf("Price decimals应为2，实际为%d", cfg.Price)
+	}
+	if cfg.Size != 1 {
+		t.Errorf("Size decimals应为1（MinOrderSize=0.1），实际为%d", cfg.Size)
+	}
+}
+
+func TestRoundConfigFromPrecisionSupportsArbitraryTickSize(t *testing.T) {
+	// "0.5" 不在 RoundingConfig 硬编码表里，但应该和表里收录的 tick size 一样
+	// 能正常推导出舍入配置（见 chunk178-7：新增 CLOB 市场不应该被这张表卡住）。
+	precision := &types.MarketPrecisionInfo{TickSize: "0.5", MinOrderSize: "1"}
+	cfg, err := roundConfigFromPrecision(precision)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Price != 0 {
+		t.Errorf("Price decimals应为0（-log10(0.5)≈0.3，四舍五入为0），实际为%d", cfg.Price)
+	}
+}
+
+func TestRoundConfigFromPrecisionRejectsInvalidTickSize(t *testing.T) {
+	for _, tickSize := range []string{"not-a-number", "0", "-0.01"} {
+		precision := &types.MarketPrecisionInfo{TickSize: tickSize, MinOrderSize: "1"}
+		if _, err := roundConfigFromPrecision(precision); err == nil {
+			t.Errorf("tick size %q 应该返回错误", tickSize)
+		}
+	}
+}
+
+func TestErrBelowMinOrderSizeMessage(t *testing.T) {
+	err := &ErrBelowMinOrderSize{Notional: 0.5, MinOrderSize: 1.0}
+	if err.Error() == "" {
+		t.Error("错误信息不应为空")
+	}
+}
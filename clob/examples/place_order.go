@@ -14,6 +14,7 @@ import (
 	"github.com/betbot/gobet/clob/client"
 	"github.com/betbot/gobet/clob/signing"
 	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/pkg/notifier/lark"
 )
 
 // 示例：下单
@@ -31,6 +32,8 @@ import (
 //   export API_PASSPHRASE="your_api_passphrase"
 //   export CHAIN_ID=137
 //   export CLOB_API_URL="https://clob.polymarket.com"
+//   export LARK_WEBHOOK_URL="https://open.feishu.cn/open-apis/bot/v2/hook/xxx"  # 可选，下单结果推送到飞书
+//   export LARK_WEBHOOK_SECRET="xxx"  # 可选，飞书机器人签名密钥
 //   go run place_order.go
 
 func main() {
@@ -258,5 +261,28 @@ func main() {
 		fmt.Printf("  export ORDER_ID=%s\n", orderResp.OrderID)
 		fmt.Println("  go run get_open_orders.go")
 	}
+
+	notifyOrderResult(tokenID, side, orderResp)
+}
+
+// notifyOrderResult 如果设置了 LARK_WEBHOOK_URL，就把这次下单结果推送到飞书。
+// 不设置时什么都不做，不影响示例的默认行为。
+func notifyOrderResult(tokenID string, side types.Side, orderResp *types.OrderResponse) {
+	webhookURL := os.Getenv("LARK_WEBHOOK_URL")
+	if webhookURL == "" {
+		return
+	}
+
+	n := lark.New(lark.Config{
+		WebhookURL: webhookURL,
+		Secret:     os.Getenv("LARK_WEBHOOK_SECRET"),
+	})
+	defer n.Stop()
+
+	n.Notify(lark.Event{
+		EventType: "order",
+		AssetID:   tokenID,
+		Message:   fmt.Sprintf("side=%s order_id=%s success=%v", side, orderResp.OrderID, orderResp.Success),
+	})
 }
 
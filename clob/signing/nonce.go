@@ -0,0 +1,261 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/clob/types"
+)
+
+// defaultHeaderCacheTTL 是 L1/L2 header 缓存的默认有效期：同一个 bucket
+// 内复用已经签好的 header，不重复做 ECDSA/HMAC 运算。
+const defaultHeaderCacheTTL = 2 * time.Second
+
+// NonceStore 持久化 (address, chainID) 最近一次分配出去的 nonce，防止进程
+// 重启后从 0 重新计数导致 nonce 复用。
+type NonceStore interface {
+	// Load 返回 key 对应的已持久化 nonce；key 从未出现过时返回 (0, false)。
+	Load(key string) (int64, bool)
+	// Save 持久化 key 最近一次分配出去的 nonce（下一次分配为该值+1）。
+	Save(key string, next int64) error
+}
+
+// MemoryNonceStore 是进程内的 NonceStore，仅用于测试或不要求跨重启持久化
+// 的场景。
+type MemoryNonceStore struct {
+	mu   sync.Mutex
+	data map[string]int64
+}
+
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{data: make(map[string]int64)}
+}
+
+func (s *MemoryNonceStore) Load(key string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.data[key]
+	return n, ok
+}
+
+func (s *MemoryNonceStore) Save(key string, next int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = next
+	return nil
+}
+
+// FileNonceStore 把每个 key 的 nonce 计数写到磁盘上的一个 JSON 文件，重启
+// 后从文件恢复，避免重用已经签过名的 nonce。
+type FileNonceStore struct {
+	mu   sync.Mutex
+	path string
+	data map[string]int64
+}
+
+func NewFileNonceStore(path string) (*FileNonceStore, error) {
+	s := &FileNonceStore{path: path, data: make(map[string]int64)}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("读取 nonce store 文件失败: %w", err)
+	}
+	if len(raw) == 0 {
+		return s, nil
+	}
+	if err := json.Unmarshal(raw, &s.data); err != nil {
+		return nil, fmt.Errorf("解析 nonce store 文件失败: %w", err)
+	}
+	return s, nil
+}
+
+func (s *FileNonceStore) Load(key string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.data[key]
+	return n, ok
+}
+
+func (s *FileNonceStore) Save(key string, next int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = next
+	raw, err := json.Marshal(s.data)
+	if err != nil {
+		return fmt.Errorf("序列化 nonce store 失败: %w", err)
+	}
+	if err := os.WriteFile(s.path, raw, 0o600); err != nil {
+		return fmt.Errorf("写入 nonce store 文件失败: %w", err)
+	}
+	return nil
+}
+
+// nonceSlot 是某个 (address, chainID) 当前持有的 nonce 及其签好的 header。
+// 同一个 bucket 内再次 Acquire 会直接复用这个 slot，跳过 ECDSA 签名；
+// bucket 过期或 slot 正被占用时才会分配一个新 nonce。
+type nonceSlot struct {
+	nonce    int64
+	bucket   int64
+	header   *types.L1PolyHeader
+	leasedOut bool
+}
+
+// NonceManager 原子分配单调递增的 L1 nonce，并缓存签好的 L1PolyHeader 以避免
+// 在请求热路径上重复做 EIP712/ECDSA 签名。签名在 bucketedTimestamp 对应的
+// 窗口内本来就是有效的，所以同一个 bucket 内复用同一个 nonce+签名是安全的。
+type NonceManager struct {
+	mu    sync.Mutex
+	store NonceStore
+	ttl   time.Duration
+	slots map[string]*nonceSlot
+}
+
+// NewNonceManager 创建一个 NonceManager。store 为 nil 时退化为纯内存计数
+// （进程重启会从 0 重新开始，调用方需要自行判断是否可接受）。ttl<=0 时使用
+// defaultHeaderCacheTTL。
+func NewNonceManager(store NonceStore, ttl time.Duration) *NonceManager {
+	if ttl <= 0 {
+		ttl = defaultHeaderCacheTTL
+	}
+	return &NonceManager{store: store, ttl: ttl, slots: make(map[string]*nonceSlot)}
+}
+
+func nonceKey(address string, chainID types.Chain) string {
+	return fmt.Sprintf("%s:%d", address, chainID)
+}
+
+func (m *NonceManager) bucket(now time.Time) int64 {
+	// 用纳秒而不是 now.Unix()/ttl.Seconds() 计算：后者在 ttl < 1s（完全合理的热路径
+	// 延迟优化取值，比如 500ms）时 int64(ttl.Seconds()) 截断为 0，直接整数除零 panic。
+	return now.UnixNano() / m.ttl.Nanoseconds()
+}
+
+// AcquireL1Headers 返回 privateKey 对应地址在 chainID 上的一组 L1 认证头。
+// 如果当前 bucket 内已经有一个未被占用的 nonce/header，直接复用；否则分配
+// 一个新的单调递增 nonce 并签名。返回的 release 必须在请求结束后调用 ——
+// 请求失败时调用 release 会把这个 nonce 标记为未占用，供下一次 Acquire 在
+// 同一个 bucket 内复用，而不是白白浪费掉。
+func (m *NonceManager) AcquireL1Headers(privateKey *ecdsa.PrivateKey, chainID types.Chain) (*types.L1PolyHeader, func(), error) {
+	address := GetAddressFromPrivateKey(privateKey).Hex()
+	key := nonceKey(address, chainID)
+	now := time.Now()
+	bucket := m.bucket(now)
+
+	m.mu.Lock()
+	slot := m.slots[key]
+	if slot != nil && slot.bucket == bucket && !slot.leasedOut {
+		slot.leasedOut = true
+		m.mu.Unlock()
+		return slot.header, m.releaseFunc(key, slot), nil
+	}
+
+	nonce, err := m.allocateLocked(key)
+	if err != nil {
+		m.mu.Unlock()
+		return nil, func() {}, err
+	}
+	m.mu.Unlock()
+
+	ts := now.Unix()
+	header, err := CreateL1Headers(privateKey, chainID, &nonce, &ts)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("构建 L1 headers 失败: %w", err)
+	}
+
+	newSlot := &nonceSlot{nonce: nonce, bucket: bucket, header: header, leasedOut: true}
+	m.mu.Lock()
+	m.slots[key] = newSlot
+	m.mu.Unlock()
+
+	return header, m.releaseFunc(key, newSlot), nil
+}
+
+// allocateLocked 必须在持有 m.mu 的情况下调用，分配并持久化下一个 nonce。
+func (m *NonceManager) allocateLocked(key string) (int64, error) {
+	var next int64
+	if m.store != nil {
+		if last, ok := m.store.Load(key); ok {
+			next = last + 1
+		}
+	} else if slot, ok := m.slots[key]; ok {
+		next = slot.nonce + 1
+	}
+	if m.store != nil {
+		if err := m.store.Save(key, next); err != nil {
+			return 0, fmt.Errorf("持久化 nonce 失败: %w", err)
+		}
+	}
+	return next, nil
+}
+
+func (m *NonceManager) releaseFunc(key string, slot *nonceSlot) func() {
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if current, ok := m.slots[key]; ok && current == slot {
+			current.leasedOut = false
+		}
+	}
+}
+
+// cachedL2Header 是 L2HeaderSigner 里按 (method, path, bucket) 缓存的签名。
+type cachedL2Header struct {
+	header *types.L2PolyHeader
+	bucket int64
+}
+
+// L2HeaderSigner 为常见的 (method, path) 组合预计算/缓存 HMAC 签名，避免
+// 高频调用（比如轮询同一个 GetOrder 端点）在热路径上反复算 HMAC。只有
+// l2HeaderArgs.Body 为 nil 时才会命中缓存 —— 带 body 的请求（比如下单）
+// 每次内容都不同，缓存没有意义，直接透传给 CreateL2Headers。
+type L2HeaderSigner struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	cache map[string]cachedL2Header
+}
+
+// NewL2HeaderSigner 创建一个 L2HeaderSigner。ttl<=0 时使用 defaultHeaderCacheTTL。
+func NewL2HeaderSigner(ttl time.Duration) *L2HeaderSigner {
+	if ttl <= 0 {
+		ttl = defaultHeaderCacheTTL
+	}
+	return &L2HeaderSigner{ttl: ttl, cache: make(map[string]cachedL2Header)}
+}
+
+// Sign 返回 l2HeaderArgs 对应的 L2 认证头，命中缓存时跳过 HMAC 运算。
+func (s *L2HeaderSigner) Sign(privateKey *ecdsa.PrivateKey, creds *types.ApiKeyCreds, l2HeaderArgs *types.L2HeaderArgs) (*types.L2PolyHeader, error) {
+	if l2HeaderArgs.Body != nil {
+		return CreateL2Headers(privateKey, creds, l2HeaderArgs, nil)
+	}
+
+	now := time.Now()
+	// 同 NonceManager.bucket：用纳秒计算，避免 ttl < 1s 时 int64(ttl.Seconds())
+	// 截断为 0 导致整数除零。
+	bucket := now.UnixNano() / s.ttl.Nanoseconds()
+	key := fmt.Sprintf("%s:%s:%s", creds.Key, l2HeaderArgs.Method, l2HeaderArgs.RequestPath)
+
+	s.mu.Lock()
+	if cached, ok := s.cache[key]; ok && cached.bucket == bucket {
+		s.mu.Unlock()
+		return cached.header, nil
+	}
+	s.mu.Unlock()
+
+	ts := now.Unix()
+	header, err := CreateL2Headers(privateKey, creds, l2HeaderArgs, &ts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedL2Header{header: header, bucket: bucket}
+	s.mu.Unlock()
+
+	return header, nil
+}
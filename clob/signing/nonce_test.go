@@ -0,0 +1,98 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/betbot/gobet/clob/types"
+)
+
+func testPrivateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成测试私钥失败: %v", err)
+	}
+	return key
+}
+
+// TestNonceManagerSubSecondTTLDoesNotPanic 复现 chunk174-4 的 bug：ttl<1s（比如
+// 500ms 这种合理的热路径延迟优化取值）不应该让 bucket() 整数除零 panic。
+func TestNonceManagerSubSecondTTLDoesNotPanic(t *testing.T) {
+	m := NewNonceManager(NewMemoryNonceStore(), 500*time.Millisecond)
+	key := testPrivateKey(t)
+
+	header, release, err := m.AcquireL1Headers(key, types.ChainPolygon)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if header == nil {
+		t.Fatal("header 不应为 nil")
+	}
+	release()
+}
+
+// TestNonceManagerReusesSlotWithinBucket 验证同一个 bucket 内复用同一个 nonce，
+// release 之后可以被下一次 Acquire 复用而不是白白分配新 nonce。
+func TestNonceManagerReusesSlotWithinBucket(t *testing.T) {
+	m := NewNonceManager(NewMemoryNonceStore(), time.Hour)
+	key := testPrivateKey(t)
+
+	header1, release1, err := m.AcquireL1Headers(key, types.ChainPolygon)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	release1()
+
+	header2, release2, err := m.AcquireL1Headers(key, types.ChainPolygon)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	defer release2()
+
+	if header1.PolyNonce != header2.PolyNonce {
+		t.Errorf("同一个 bucket 内 release 后应复用同一个 nonce，got %s / %s", header1.PolyNonce, header2.PolyNonce)
+	}
+}
+
+// TestL2HeaderSignerSubSecondTTLDoesNotPanic 复现 chunk174-4 的 bug 在
+// L2HeaderSigner.Sign 侧的等价场景。
+func TestL2HeaderSignerSubSecondTTLDoesNotPanic(t *testing.T) {
+	s := NewL2HeaderSigner(200 * time.Millisecond)
+	key := testPrivateKey(t)
+	creds := &types.ApiKeyCreds{Key: "k", Secret: "c2VjcmV0", Passphrase: "p"}
+	args := &types.L2HeaderArgs{Method: "GET", RequestPath: "/orders"}
+
+	header, err := s.Sign(key, creds, args)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if header == nil {
+		t.Fatal("header 不应为 nil")
+	}
+}
+
+// TestL2HeaderSignerCachesWithinBucket 验证命中缓存时返回同一个 header（不重复
+// 计算 HMAC）。
+func TestL2HeaderSignerCachesWithinBucket(t *testing.T) {
+	s := NewL2HeaderSigner(time.Hour)
+	key := testPrivateKey(t)
+	creds := &types.ApiKeyCreds{Key: "k", Secret: "c2VjcmV0", Passphrase: "p"}
+	args := &types.L2HeaderArgs{Method: "GET", RequestPath: "/orders"}
+
+	header1, err := s.Sign(key, creds, args)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	header2, err := s.Sign(key, creds, args)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if header1 != header2 {
+		t.Errorf("同一个 bucket 内应命中缓存返回同一个 *L2PolyHeader，got %p / %p", header1, header2)
+	}
+}
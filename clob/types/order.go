@@ -144,6 +144,11 @@ type OpenOrderParams struct {
 type CreateOrderOptions struct {
 	TickSize TickSize
 	NegRisk  *bool
+
+	// Precision 可选的市场精度信息。设置后 OrderBuilder.BuildOrder 会优先用它
+	// 派生 RoundConfig（而不是查 RoundingConfig 硬编码表），从而支持
+	// RoundingConfig 中未收录的 tick size，并据此校验最小下单金额。
+	Precision *MarketPrecisionInfo
 }
 
 // OrderScoringParams 订单评分参数
@@ -0,0 +1,28 @@
+package types
+
+import "fmt"
+
+// MarketPrecisionInfo 市场精度信息（从配置文件或 CLOB 市场接口加载）。
+// 迁移自 internal/strategies/velocityhedgehold，作为 OrderBuilder 与各策略
+// 共享的精度来源，避免每个策略各自维护一份 tick size / 最小下单量。
+type MarketPrecisionInfo struct {
+	TickSize     string // 价格精度（如 "0.01", "0.001"）
+	MinOrderSize string // 最小订单大小（如 "0.1", "5"）
+	NegRisk      bool   // 是否为负风险市场
+}
+
+// ParseTickSize 解析 tick size 字符串为 TickSize 类型
+func ParseTickSize(tickSizeStr string) (TickSize, error) {
+	switch tickSizeStr {
+	case "0.1":
+		return TickSize01, nil
+	case "0.01":
+		return TickSize001, nil
+	case "0.001":
+		return TickSize0001, nil
+	case "0.0001":
+		return TickSize00001, nil
+	default:
+		return "", fmt.Errorf("不支持的 tick size: %s", tickSizeStr)
+	}
+}
@@ -0,0 +1,182 @@
+// Command backtest 离线重放历史 UP/DOWN 价格数据，驱动 back/rangeboth 的
+// 对冲策略（BacktestStrategy，经 NewHedgeSolver 求补单），输出一份 JSON 摘要和一份
+// 逐周期的权益曲线 CSV。
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/betbot/gobet/back/rangeboth"
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/pkg/backtest"
+	"github.com/betbot/gobet/pkg/marketspec"
+)
+
+// runConfig 是本命令自己的 yaml 配置，和主程序的 config.yaml 无关——只描述
+// 一次离线回测需要的市场规格、时间窗口、历史数据文件和对冲参数。
+type runConfig struct {
+	Symbol    string `yaml:"symbol"`
+	Timeframe string `yaml:"timeframe"`
+	Kind      string `yaml:"kind"`
+
+	FromUnix int64 `yaml:"fromUnix"`
+	ToUnix   int64 `yaml:"toUnix"`
+
+	TicksCSV       string `yaml:"ticksCsv"`
+	ResolutionsCSV string `yaml:"resolutionsCsv"`
+
+	StartingCash float64 `yaml:"startingCash"`
+
+	TargetMinProfit float64 `yaml:"targetMinProfit"`
+	MaxOrderSize    float64 `yaml:"maxOrderSize"`
+
+	TickSize     string  `yaml:"tickSize"`
+	MinOrderSize float64 `yaml:"minOrderSize"`
+	FeeRateBps   int     `yaml:"feeRateBps"`
+	SlippageBps  int     `yaml:"slippageBps"`
+
+	SummaryJSON string `yaml:"summaryJson"`
+	EquityCSV   string `yaml:"equityCsv"`
+}
+
+func main() {
+	cfgPath := flag.String("config", "backtest.yaml", "回测配置 yaml 路径")
+	flag.Parse()
+
+	cfg, err := loadConfig(*cfgPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "加载配置失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := run(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "回测运行失败: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func loadConfig(path string) (runConfig, error) {
+	var cfg runConfig
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func run(cfg runConfig) error {
+	spec, err := marketspec.New(cfg.Symbol, cfg.Timeframe, cfg.Kind)
+	if err != nil {
+		return fmt.Errorf("构造 MarketSpec 失败: %w", err)
+	}
+
+	history, err := rangeboth.LoadCSVHistory(cfg.TicksCSV, cfg.ResolutionsCSV)
+	if err != nil {
+		return err
+	}
+
+	tickSize, err := types.ParseTickSize(cfg.TickSize)
+	if err != nil {
+		tickSize = types.TickSize001
+	}
+
+	exchange := backtest.NewPaperExchange(cfg.StartingCash, cfg.SlippageBps)
+	strategy := rangeboth.NewBacktestStrategy(nil, cfg.TargetMinProfit, cfg.MaxOrderSize, rangeboth.HedgeConstraints{
+		TickSize:     tickSize,
+		MinOrderSize: cfg.MinOrderSize,
+		FeeRateBps:   cfg.FeeRateBps,
+		SlippageBps:  cfg.SlippageBps,
+	})
+
+	harness, err := backtest.NewHarness(backtest.Config{
+		Spec:     spec,
+		From:     time.Unix(cfg.FromUnix, 0),
+		To:       time.Unix(cfg.ToUnix, 0),
+		History:  history,
+		Strategy: strategy,
+		Exchange: exchange,
+	})
+	if err != nil {
+		return fmt.Errorf("构造 Harness 失败: %w", err)
+	}
+	strategy.Harness = harness
+
+	if err := harness.Run(context.Background()); err != nil {
+		return err
+	}
+
+	if err := writeSummaryJSON(cfg.SummaryJSON, cfg.StartingCash, exchange); err != nil {
+		return fmt.Errorf("写 summary JSON 失败: %w", err)
+	}
+	if err := writeEquityCSV(cfg.EquityCSV, exchange); err != nil {
+		return fmt.Errorf("写 equity CSV 失败: %w", err)
+	}
+
+	fmt.Printf("回测完成：%d 笔成交，写出 %s / %s\n", len(exchange.Fills()), cfg.SummaryJSON, cfg.EquityCSV)
+	return nil
+}
+
+// summary 是 JSON 摘要的结构，只包含最基本的回测结果，更细的逐周期数据在
+// equity CSV 里。
+type summary struct {
+	StartingCash float64 `json:"startingCash"`
+	FillCount    int     `json:"fillCount"`
+	FinalEquity  float64 `json:"finalEquity"`
+}
+
+func writeSummaryJSON(path string, startingCash float64, exchange *backtest.PaperExchange) error {
+	curve := exchange.EquityCurve()
+	var finalEquity float64
+	if len(curve) > 0 {
+		finalEquity = curve[len(curve)-1].Equity
+	}
+	s := summary{
+		StartingCash: startingCash,
+		FillCount:    len(exchange.Fills()),
+		FinalEquity:  finalEquity,
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func writeEquityCSV(path string, exchange *backtest.PaperExchange) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := w.Write([]string{"period_start_unix", "cash", "positions_value", "equity"}); err != nil {
+		return err
+	}
+	for _, p := range exchange.EquityCurve() {
+		row := []string{
+			strconv.FormatInt(p.PeriodStartUnix, 10),
+			strconv.FormatFloat(p.Cash, 'f', -1, 64),
+			strconv.FormatFloat(p.PositionsValue, 'f', -1, 64),
+			strconv.FormatFloat(p.Equity, 'f', -1, 64),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
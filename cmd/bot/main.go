@@ -16,6 +16,7 @@ import (
 	"github.com/betbot/gobet/clob/signing"
 	"github.com/betbot/gobet/clob/types"
 	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/fixgateway"
 	"github.com/betbot/gobet/internal/infrastructure/websocket"
 	"github.com/betbot/gobet/internal/metrics"
 	"github.com/betbot/gobet/internal/services"
@@ -364,6 +365,39 @@ func main() {
 	// 设置限价单最小 share 数量（仅限价单 GTC 时应用）
 	tradingService.SetMinShareSize(cfg.MinShareSize)
 
+	// 权益止损/回撤巡检：跨所有策略生效，因为所有策略的下单都经过同一个
+	// TradingService.PlaceOrder，而断路器熔断会直接挡在那里。
+	tradingService.SetStopLossFraction(cfg.StopLossFraction)
+	if cfg.StopLossFraction > 0 {
+		riskCheckInterval := time.Duration(cfg.RiskCheckIntervalSeconds) * time.Second
+		go func() {
+			ticker := time.NewTicker(riskCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-rootCtx.Done():
+					return
+				case <-ticker.C:
+					tradingService.CheckEquityStopLoss()
+				}
+			}
+		}()
+		logrus.Infof("✅ 权益止损巡检已启动: 回撤阈值=%.2f%%, 间隔=%ds", cfg.StopLossFraction*100, cfg.RiskCheckIntervalSeconds)
+	}
+
+	// 可选：FIX 4.4 执行回报网关（默认关闭，通过环境变量启用）。
+	// 配置了且会话健康时，REST 轮询间隔会自动拉长（见 startOrderStatusSyncImpl）。
+	if fixSettingsPath := os.Getenv("GOBET_FIX_SETTINGS"); fixSettingsPath != "" {
+		fixGateway := fixgateway.NewGateway(tradingService)
+		if err := fixGateway.Start(fixSettingsPath); err != nil {
+			logrus.Warnf("⚠️ FIX 网关启动失败，继续使用 REST/WebSocket: %v", err)
+		} else {
+			tradingService.SetFixGateway(fixGateway)
+			defer fixGateway.Stop()
+			logrus.Infof("✅ FIX 执行回报网关已启动: settings=%s", fixSettingsPath)
+		}
+	}
+
 	// 创建 Environment
 	environ := bbgo.NewEnvironment()
 	environ.SetMarketDataService(marketDataService)
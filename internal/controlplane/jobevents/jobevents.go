@@ -0,0 +1,90 @@
+// Package jobevents 是一个进程内的 job 运行事件发布/订阅总线：
+// startXxxBatch 在各阶段（排队/运行中/进度/成功/失败）发布事件，
+// SSE handler（见 server 包的 handleJobRunsStream）订阅后转发给前端。
+package jobevents
+
+import (
+	"sync"
+	"time"
+)
+
+// Phase 描述一条事件所处的 job run 生命周期阶段。
+type Phase string
+
+const (
+	PhaseQueued    Phase = "queued"
+	PhaseRunning   Phase = "running"
+	PhaseProgress  Phase = "progress"
+	PhaseSucceeded Phase = "succeeded"
+	PhaseFailed    Phase = "failed"
+)
+
+// Event 是总线上流转的单条事件。
+type Event struct {
+	JobName   string         `json:"job_name"`
+	RunID     int64          `json:"run_id"`
+	Phase     Phase          `json:"phase"`
+	Trigger   string         `json:"trigger,omitempty"`
+	Progress  map[string]any `json:"progress,omitempty"`
+	Message   string         `json:"message,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+type subscriber struct {
+	ch   chan Event
+	jobs map[string]bool // 空 map 表示订阅所有 job
+}
+
+// Bus 是一个简单的进程内发布/订阅总线，支持按 job_name 过滤。
+type Bus struct {
+	mu   sync.Mutex
+	subs map[int]*subscriber
+	next int
+}
+
+// NewBus 创建一个空总线。
+func NewBus() *Bus {
+	return &Bus{subs: make(map[int]*subscriber)}
+}
+
+// Subscribe 注册一个订阅者，jobs 为空表示订阅所有 job 的事件。
+// 返回的 cancel 必须在订阅者断开时调用，以释放其 channel。
+func (b *Bus) Subscribe(jobs []string, bufSize int) (ch <-chan Event, cancel func()) {
+	if bufSize <= 0 {
+		bufSize = 64
+	}
+	jobSet := make(map[string]bool, len(jobs))
+	for _, j := range jobs {
+		jobSet[j] = true
+	}
+	sub := &subscriber{ch: make(chan Event, bufSize), jobs: jobSet}
+
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	cancel = func() {
+		b.mu.Lock()
+		delete(b.subs, id)
+		b.mu.Unlock()
+	}
+	return sub.ch, cancel
+}
+
+// Publish 把事件广播给所有匹配的订阅者。订阅者 channel 满了就丢弃该事件，
+// 避免慢消费者拖慢 job 执行。
+func (b *Bus) Publish(ev Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range b.subs {
+		if len(sub.jobs) > 0 && !sub.jobs[ev.JobName] {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+		}
+	}
+}
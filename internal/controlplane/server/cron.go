@@ -0,0 +1,203 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule 是解析后的 cron 表达式：标准 5 段（分 时 日 月 周），
+// 也允许在最前面附加一段"秒"凑成 6 段（秒 分 时 日 月 周）。
+// 每一段支持 *、*/N、N、N-M 以及用逗号拼接的组合，如 "1-5,10,*/15"。
+type cronSchedule struct {
+	hasSeconds bool
+	seconds    map[int]bool
+	minutes    map[int]bool
+	hours      map[int]bool
+	days       map[int]bool
+	months     map[int]bool
+	weekdays   map[int]bool
+
+	// dom/dow 字段原文是否为 "*"：用于标准 cron 的日/周 OR 语义判断（见 dayMatches）。
+	domWildcard bool
+	dowWildcard bool
+}
+
+// parseCronExpr 解析标准 5 段 cron 表达式（分 时 日 月 周），或额外带秒的 6 段。
+func parseCronExpr(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(strings.TrimSpace(expr))
+
+	var secField, minField, hourField, domField, monField, dowField string
+	switch len(fields) {
+	case 5:
+		minField, hourField, domField, monField, dowField = fields[0], fields[1], fields[2], fields[3], fields[4]
+	case 6:
+		secField, minField, hourField, domField, monField, dowField = fields[0], fields[1], fields[2], fields[3], fields[4], fields[5]
+	default:
+		return nil, fmt.Errorf("cron 表达式必须是 5 段（分 时 日 月 周）或 6 段（秒 分 时 日 月 周），实际为 %d 段: %q", len(fields), expr)
+	}
+
+	cs := &cronSchedule{
+		domWildcard: domField == "*",
+		dowWildcard: dowField == "*",
+	}
+
+	var err error
+	if secField != "" {
+		cs.hasSeconds = true
+		if cs.seconds, err = parseCronField(secField, 0, 59); err != nil {
+			return nil, fmt.Errorf("解析秒字段失败: %w", err)
+		}
+	} else {
+		cs.seconds = map[int]bool{0: true}
+	}
+	if cs.minutes, err = parseCronField(minField, 0, 59); err != nil {
+		return nil, fmt.Errorf("解析分字段失败: %w", err)
+	}
+	if cs.hours, err = parseCronField(hourField, 0, 23); err != nil {
+		return nil, fmt.Errorf("解析时字段失败: %w", err)
+	}
+	if cs.days, err = parseCronField(domField, 1, 31); err != nil {
+		return nil, fmt.Errorf("解析日字段失败: %w", err)
+	}
+	if cs.months, err = parseCronField(monField, 1, 12); err != nil {
+		return nil, fmt.Errorf("解析月字段失败: %w", err)
+	}
+	// 星期按 0-6（0=周日），与 time.Weekday 一致
+	if cs.weekdays, err = parseCronField(dowField, 0, 6); err != nil {
+		return nil, fmt.Errorf("解析星期字段失败: %w", err)
+	}
+	return cs, nil
+}
+
+// parseCronField 解析单个 cron 字段，返回该字段允许的取值集合。
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	out := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		step := 1
+		rangePart := part
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			rangePart = part[:idx]
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("非法步长: %q", part)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = min, max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			if len(bounds) != 2 {
+				return nil, fmt.Errorf("非法区间: %q", part)
+			}
+			a, errA := strconv.Atoi(bounds[0])
+			b, errB := strconv.Atoi(bounds[1])
+			if errA != nil || errB != nil {
+				return nil, fmt.Errorf("非法区间: %q", part)
+			}
+			lo, hi = a, b
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, fmt.Errorf("非法数值: %q", part)
+			}
+			lo, hi = n, n
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("字段取值超出范围 [%d,%d]: %q", min, max, part)
+		}
+		for v := lo; v <= hi; v += step {
+			out[v] = true
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("字段为空: %q", field)
+	}
+	return out, nil
+}
+
+// dayMatches 实现标准 cron 对日/周字段的 OR 语义：两个字段都被限定（非 "*"）时，
+// 命中其一即可；只有一个被限定时，以被限定的那个为准；都是 "*" 时恒为真。
+func (cs *cronSchedule) dayMatches(t time.Time) bool {
+	dayOK := cs.days[t.Day()]
+	dowOK := cs.weekdays[int(t.Weekday())]
+	switch {
+	case !cs.domWildcard && !cs.dowWildcard:
+		return dayOK || dowOK
+	case !cs.domWildcard:
+		return dayOK
+	case !cs.dowWildcard:
+		return dowOK
+	default:
+		return true
+	}
+}
+
+// matches 判断 t（已截断到秒）是否命中该 cron 表达式。
+func (cs *cronSchedule) matches(t time.Time) bool {
+	if cs.hasSeconds && !cs.seconds[t.Second()] {
+		return false
+	}
+	if !cs.minutes[t.Minute()] {
+		return false
+	}
+	if !cs.hours[t.Hour()] {
+		return false
+	}
+	if !cs.months[int(t.Month())] {
+		return false
+	}
+	return cs.dayMatches(t)
+}
+
+// next 从 after（不含）开始向前扫描，返回下一次命中时间。
+// 最多向前扫描 2 年，避免非法表达式（例如 31 号配 2 月）导致死循环。
+func (cs *cronSchedule) next(after time.Time) (time.Time, bool) {
+	step := time.Minute
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	if cs.hasSeconds {
+		step = time.Second
+		t = after.Truncate(time.Second).Add(time.Second)
+	}
+	limit := after.AddDate(2, 0, 0)
+	for t.Before(limit) {
+		if cs.matches(t) {
+			return t, true
+		}
+		t = t.Add(step)
+	}
+	return time.Time{}, false
+}
+
+// previewCronFireTimes 返回从 after 开始的接下来 n 次触发时间，供 UI 预览用。
+func previewCronFireTimes(expr string, after time.Time, n int) ([]time.Time, error) {
+	cs, err := parseCronExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		n = 5
+	}
+	out := make([]time.Time, 0, n)
+	cursor := after
+	for i := 0; i < n; i++ {
+		next, ok := cs.next(cursor)
+		if !ok {
+			break
+		}
+		out = append(out, next)
+		cursor = next
+	}
+	return out, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"time"
+
+	"github.com/betbot/gobet/internal/controlplane/jobevents"
 )
 
 func (s *Server) startEquitySnapshotBatch(trigger string) (int64, error) {
@@ -11,30 +13,44 @@ func (s *Server) startEquitySnapshotBatch(trigger string) (int64, error) {
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "equity_snapshot", "batch", nil, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "equity_snapshot", "batch", nil, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
+	s.publishJobEvent("equity_snapshot", runID, jobevents.PhaseQueued, trigger, nil, "")
+	jobCtx, jobCancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	s.registerRun(runID, jobCancel)
 	go func() {
-		jobCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-		defer cancel()
+		defer jobCancel()
+		defer s.unregisterRun(runID)
 		s.doEquitySnapshotBatch(jobCtx, runID, trigger)
 	}()
 	return runID, nil
 }
 
 func (s *Server) doEquitySnapshotBatch(ctx context.Context, runID int64, trigger string) {
+	startedAt := time.Now()
+	s.publishJobEvent("equity_snapshot", runID, jobevents.PhaseRunning, trigger, nil, "")
 	accounts, err := s.listAccounts(ctx)
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("equity_snapshot", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("equity_snapshot", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 	okCount := 0
 	errCount := 0
+	firstError := ""
 	for _, a := range accounts {
+		if ctx.Err() != nil {
+			break
+		}
 		if err := s.createEquitySnapshotForAccount(ctx, a.ID); err != nil {
 			errCount++
+			if firstError == "" {
+				firstError = err.Error()
+			}
 			continue
 		}
 		okCount++
@@ -42,6 +58,12 @@ func (s *Server) doEquitySnapshotBatch(ctx context.Context, runID int64, trigger
 	meta2, _ := json.Marshal(map[string]any{"trigger": trigger, "accounts": len(accounts), "ok": okCount, "err": errCount})
 	metaStr2 := string(meta2)
 	_ = s.finishJobRun(ctx, runID, errCount == 0, nilIfEmpty(errCount, "some accounts failed"), &metaStr2)
+	if errCount == 0 {
+		s.publishJobEvent("equity_snapshot", runID, jobevents.PhaseSucceeded, trigger, nil, "")
+	} else {
+		s.publishJobEvent("equity_snapshot", runID, jobevents.PhaseFailed, trigger, nil, "some accounts failed")
+	}
+	s.notifyJobOutcome("equity_snapshot", runID, trigger, startedAt, okCount, errCount, firstError)
 }
 
 func (s *Server) createEquitySnapshotForAccount(ctx context.Context, accountID string) error {
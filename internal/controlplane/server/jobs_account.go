@@ -17,7 +17,7 @@ func (s *Server) startBalanceSyncAccount(accountID string, trigger string) (int6
 	defer cancel()
 	metaJSON, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(metaJSON)
-	runID, err := s.insertJobRunStart(ctx, "balance_sync", "account", &accountID, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "balance_sync", "account", &accountID, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
@@ -53,7 +53,7 @@ func (s *Server) startRedeemAccount(accountID string, trigger string) (int64, er
 	defer cancel()
 	metaJSON, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(metaJSON)
-	runID, err := s.insertJobRunStart(ctx, "redeem", "account", &accountID, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "redeem", "account", &accountID, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
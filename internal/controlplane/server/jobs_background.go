@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/betbot/gobet/internal/controlplane/jobevents"
 	sdkapi "github.com/betbot/gobet/pkg/sdk/api"
 	sdkredeem "github.com/betbot/gobet/pkg/sdk/redeem"
 	sdktypes "github.com/betbot/gobet/pkg/sdk/types"
@@ -23,7 +24,7 @@ func (s *Server) startBackground() {
 	positionsInterval := parseDurationEnv("GOBET_POSITIONS_SYNC_INTERVAL", 60*time.Second)
 	openOrdersInterval := parseDurationEnv("GOBET_OPEN_ORDERS_SYNC_INTERVAL", 60*time.Second)
 
-	s.bgWG.Add(5)
+	s.bgWG.Add(6)
 	go func() {
 		defer s.bgWG.Done()
 		s.balanceSyncLoop(ctx, balanceInterval)
@@ -44,6 +45,10 @@ func (s *Server) startBackground() {
 		defer s.bgWG.Done()
 		s.openOrdersSyncLoop(ctx, openOrdersInterval)
 	}()
+	go func() {
+		defer s.bgWG.Done()
+		s.cronSchedulerLoop(ctx)
+	}()
 }
 
 func (s *Server) balanceSyncLoop(ctx context.Context, interval time.Duration) {
@@ -184,24 +189,32 @@ func (s *Server) startBalanceSyncBatch(trigger string) (int64, error) {
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "balance_sync", "batch", nil, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "balance_sync", "batch", nil, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
+	s.publishJobEvent("balance_sync", runID, jobevents.PhaseQueued, trigger, nil, "")
 
+	jobCtx, jobCancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	s.registerRun(runID, jobCancel)
 	go func() {
-		jobCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-		defer cancel()
+		defer jobCancel()
+		defer s.unregisterRun(runID)
 		s.doBalanceSyncBatch(jobCtx, runID, trigger)
 	}()
 	return runID, nil
 }
 
 func (s *Server) doBalanceSyncBatch(ctx context.Context, runID int64, trigger string) {
+	startedAt := time.Now()
+	s.publishJobEvent("balance_sync", runID, jobevents.PhaseRunning, trigger, nil, "")
+
 	accounts, err := s.listAccounts(ctx)
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("balance_sync", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("balance_sync", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 
@@ -217,12 +230,10 @@ func (s *Server) doBalanceSyncBatch(ctx context.Context, runID int64, trigger st
 	outCh := make(chan res, len(accounts))
 
 	for _, a := range accounts {
-		a := a
-		select {
-		case <-ctx.Done():
+		if ctx.Err() != nil {
 			break
-		default:
 		}
+		a := a
 		sem <- struct{}{}
 		go func() {
 			defer func() { <-sem }()
@@ -245,20 +256,33 @@ func (s *Server) doBalanceSyncBatch(ctx context.Context, runID int64, trigger st
 
 	close(outCh)
 	var (
-		okCount  int
-		errCount int
+		okCount    int
+		errCount   int
+		firstError string
 	)
 	for r := range outCh {
 		if r.ok {
 			okCount++
 		} else {
 			errCount++
+			if firstError == "" {
+				firstError = r.err
+			}
 		}
+		s.publishJobEvent("balance_sync", runID, jobevents.PhaseProgress, trigger, map[string]any{
+			"done": okCount + errCount, "total": len(accounts), "ok": okCount, "err": errCount,
+		}, "")
 	}
 
 	meta2, _ := json.Marshal(map[string]any{"trigger": trigger, "accounts": len(accounts), "ok": okCount, "err": errCount})
 	metaStr2 := string(meta2)
 	_ = s.finishJobRun(ctx, runID, errCount == 0, nilIfEmpty(errCount, "some accounts failed"), &metaStr2)
+	if errCount == 0 {
+		s.publishJobEvent("balance_sync", runID, jobevents.PhaseSucceeded, trigger, nil, "")
+	} else {
+		s.publishJobEvent("balance_sync", runID, jobevents.PhaseFailed, trigger, nil, "some accounts failed")
+	}
+	s.notifyJobOutcome("balance_sync", runID, trigger, startedAt, okCount, errCount, firstError)
 }
 
 func nilIfEmpty(errCount int, msg string) *string {
@@ -291,29 +315,38 @@ func (s *Server) startRedeemBatch(trigger string) (int64, error) {
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "redeem", "batch", nil, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "redeem", "batch", nil, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
+	s.publishJobEvent("redeem", runID, jobevents.PhaseQueued, trigger, nil, "")
+	jobCtx, jobCancel := context.WithTimeout(context.Background(), 20*time.Minute)
+	s.registerRun(runID, jobCancel)
 	go func() {
-		jobCtx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
-		defer cancel()
+		defer jobCancel()
+		defer s.unregisterRun(runID)
 		s.doRedeemBatch(jobCtx, runID, trigger)
 	}()
 	return runID, nil
 }
 
 func (s *Server) doRedeemBatch(ctx context.Context, runID int64, trigger string) {
+	startedAt := time.Now()
+	s.publishJobEvent("redeem", runID, jobevents.PhaseRunning, trigger, nil, "")
 	bc, err := loadBuilderCredsFromEnv()
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("redeem", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("redeem", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 	masterKey, err := loadMasterKey()
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("redeem", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("redeem", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 
@@ -326,6 +359,8 @@ func (s *Server) doRedeemBatch(ctx context.Context, runID int64, trigger string)
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("redeem", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("redeem", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 
@@ -341,12 +376,10 @@ func (s *Server) doRedeemBatch(ctx context.Context, runID int64, trigger string)
 	outCh := make(chan rr, len(accounts))
 
 	for _, a := range accounts {
-		a := a
-		select {
-		case <-ctx.Done():
+		if ctx.Err() != nil {
 			break
-		default:
 		}
+		a := a
 		sem <- struct{}{}
 		go func() {
 			defer func() { <-sem }()
@@ -399,6 +432,7 @@ func (s *Server) doRedeemBatch(ctx context.Context, runID int64, trigger string)
 		errCount     int
 		totalRedeems int
 		totalUSDC    float64
+		firstError   string
 	)
 	for r := range outCh {
 		if r.ok {
@@ -407,6 +441,9 @@ func (s *Server) doRedeemBatch(ctx context.Context, runID int64, trigger string)
 			totalUSDC += r.usdc
 		} else {
 			errCount++
+			if firstError == "" {
+				firstError = r.err
+			}
 		}
 	}
 
@@ -420,4 +457,10 @@ func (s *Server) doRedeemBatch(ctx context.Context, runID int64, trigger string)
 	})
 	metaStr2 := string(meta2)
 	_ = s.finishJobRun(ctx, runID, errCount == 0, nilIfEmpty(errCount, "some accounts failed"), &metaStr2)
+	if errCount == 0 {
+		s.publishJobEvent("redeem", runID, jobevents.PhaseSucceeded, trigger, nil, "")
+	} else {
+		s.publishJobEvent("redeem", runID, jobevents.PhaseFailed, trigger, nil, "some accounts failed")
+	}
+	s.notifyJobOutcome("redeem", runID, trigger, startedAt, okCount, errCount, firstError)
 }
@@ -28,95 +28,102 @@ func (s *Server) handleJobRunsList(w http.ResponseWriter, r *http.Request) {
 }
 
 type jobTriggerRequest struct {
-	Trigger string `json:"trigger,omitempty"`
+	Trigger        string `json:"trigger,omitempty"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
-func (s *Server) handleJobBalanceSyncNow(w http.ResponseWriter, r *http.Request) {
+// handleJobTriggerNow 是所有 "/api/jobs/xxx_now" 端点共用的处理逻辑：
+//  1. 如果带了 idempotency_key 并且在 jobTriggerKeyWindow 内见过，直接把原来那次的
+//     run_id 返回（HTTP 200），不会再开一个新的 batch；
+//  2. 否则检查这个 job 是不是已经有一次还在跑（single-flight），除非带了
+//     ?force=true，有的话返回 HTTP 409 和那次活跃的 run_id；
+//  3. 都没有命中就真正调用 starter 开一个新 run，并在带了 idempotency_key 时记录下来。
+//
+// 第 2/3 步之间用 jobScheduleLocksMu/jobScheduleRunning（和 jobs_scheduler.go 的
+// cron 调度器共用同一把按 job_name 的进程内锁）把"查运行状态"和"真正启动"钉在
+// 一起：否则两个并发的手动触发（或一次手动 + 一次 cron）会各自查到"没在跑"，
+// 然后都调用 starter，跑出两个重叠的 batch。
+func (s *Server) handleJobTriggerNow(w http.ResponseWriter, r *http.Request, jobName string, starter func(trigger string) (int64, error)) {
 	var req jobTriggerRequest
 	_ = json.NewDecoder(r.Body).Decode(&req)
 	trigger := strings.TrimSpace(req.Trigger)
 	if trigger == "" {
 		trigger = "manual"
 	}
-	runID, err := s.startBalanceSyncBatch(trigger)
-	if err != nil {
-		writeError(w, 500, fmt.Sprintf("start job failed: %v", err))
-		return
+	idempotencyKey := strings.TrimSpace(req.IdempotencyKey)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if idempotencyKey != "" {
+		runID, found, err := s.findJobTriggerKey(ctx, jobName, idempotencyKey)
+		if err != nil {
+			writeError(w, 500, fmt.Sprintf("db lookup idempotency key: %v", err))
+			return
+		}
+		if found {
+			writeJSON(w, 200, map[string]any{"ok": true, "run_id": runID, "deduped": true})
+			return
+		}
 	}
-	writeJSON(w, 202, map[string]any{"ok": true, "run_id": runID})
-}
 
-func (s *Server) handleJobRedeemNow(w http.ResponseWriter, r *http.Request) {
-	var req jobTriggerRequest
-	_ = json.NewDecoder(r.Body).Decode(&req)
-	trigger := strings.TrimSpace(req.Trigger)
-	if trigger == "" {
-		trigger = "manual"
+	force := strings.TrimSpace(r.URL.Query().Get("force")) == "true"
+	if !force {
+		if !s.tryLockJobSchedule(jobName) {
+			// 另一次触发（手动或 cron）正处在"查运行状态→启动"的临界区内，
+			// 直接当作运行中处理，不再重复查 DB。
+			running, _ := s.getRunningJobRun(ctx, jobName)
+			var runID int64
+			if running != nil {
+				runID = running.ID
+			}
+			writeError(w, 409, fmt.Sprintf("job %q already running or starting as run_id=%d", jobName, runID))
+			return
+		}
+		defer s.unlockJobSchedule(jobName)
+
+		running, err := s.getRunningJobRun(ctx, jobName)
+		if err != nil {
+			writeError(w, 500, fmt.Sprintf("db check running job run: %v", err))
+			return
+		}
+		if running != nil {
+			writeError(w, 409, fmt.Sprintf("job %q already running as run_id=%d", jobName, running.ID))
+			return
+		}
 	}
-	runID, err := s.startRedeemBatch(trigger)
+
+	runID, err := starter(trigger)
 	if err != nil {
 		writeError(w, 500, fmt.Sprintf("start job failed: %v", err))
 		return
 	}
+	if idempotencyKey != "" {
+		_ = s.recordJobTriggerKey(ctx, jobName, idempotencyKey, runID)
+	}
 	writeJSON(w, 202, map[string]any{"ok": true, "run_id": runID})
 }
 
+func (s *Server) handleJobBalanceSyncNow(w http.ResponseWriter, r *http.Request) {
+	s.handleJobTriggerNow(w, r, "balance_sync", s.startBalanceSyncBatch)
+}
+
+func (s *Server) handleJobRedeemNow(w http.ResponseWriter, r *http.Request) {
+	s.handleJobTriggerNow(w, r, "redeem", s.startRedeemBatch)
+}
+
 func (s *Server) handleJobTradesSyncNow(w http.ResponseWriter, r *http.Request) {
-	var req jobTriggerRequest
-	_ = json.NewDecoder(r.Body).Decode(&req)
-	trigger := strings.TrimSpace(req.Trigger)
-	if trigger == "" {
-		trigger = "manual"
-	}
-	runID, err := s.startTradesSyncBatch(trigger)
-	if err != nil {
-		writeError(w, 500, fmt.Sprintf("start job failed: %v", err))
-		return
-	}
-	writeJSON(w, 202, map[string]any{"ok": true, "run_id": runID})
+	s.handleJobTriggerNow(w, r, "trades_sync", s.startTradesSyncBatch)
 }
 
 func (s *Server) handleJobPositionsSyncNow(w http.ResponseWriter, r *http.Request) {
-	var req jobTriggerRequest
-	_ = json.NewDecoder(r.Body).Decode(&req)
-	trigger := strings.TrimSpace(req.Trigger)
-	if trigger == "" {
-		trigger = "manual"
-	}
-	runID, err := s.startPositionsSyncBatch(trigger)
-	if err != nil {
-		writeError(w, 500, fmt.Sprintf("start job failed: %v", err))
-		return
-	}
-	writeJSON(w, 202, map[string]any{"ok": true, "run_id": runID})
+	s.handleJobTriggerNow(w, r, "positions_sync", s.startPositionsSyncBatch)
 }
 
 func (s *Server) handleJobOpenOrdersSyncNow(w http.ResponseWriter, r *http.Request) {
-	var req jobTriggerRequest
-	_ = json.NewDecoder(r.Body).Decode(&req)
-	trigger := strings.TrimSpace(req.Trigger)
-	if trigger == "" {
-		trigger = "manual"
-	}
-	runID, err := s.startOpenOrdersSyncBatch(trigger)
-	if err != nil {
-		writeError(w, 500, fmt.Sprintf("start job failed: %v", err))
-		return
-	}
-	writeJSON(w, 202, map[string]any{"ok": true, "run_id": runID})
+	s.handleJobTriggerNow(w, r, "open_orders_sync", s.startOpenOrdersSyncBatch)
 }
 
 func (s *Server) handleJobEquitySnapshotNow(w http.ResponseWriter, r *http.Request) {
-	var req jobTriggerRequest
-	_ = json.NewDecoder(r.Body).Decode(&req)
-	trigger := strings.TrimSpace(req.Trigger)
-	if trigger == "" {
-		trigger = "manual"
-	}
-	runID, err := s.startEquitySnapshotBatch(trigger)
-	if err != nil {
-		writeError(w, 500, fmt.Sprintf("start job failed: %v", err))
-		return
-	}
-	writeJSON(w, 202, map[string]any{"ok": true, "run_id": runID})
+	s.handleJobTriggerNow(w, r, "equity_snapshot", s.startEquitySnapshotBatch)
 }
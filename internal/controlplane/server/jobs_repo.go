@@ -5,20 +5,46 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
-func (s *Server) insertJobRunStart(ctx context.Context, jobName string, scope string, accountID *string, metaJSON *string) (int64, error) {
+// insertJobRunStart 创建一条 job_runs 记录。当 trigger 形如 "retry:<run_id>" 时
+// （见 jobs_run_cancel.go 的 handleJobRunRetry），自动把 parent_run_id 解析出来，
+// 这样就不用给每个 startXxxBatch 的签名单独加参数。
+func (s *Server) insertJobRunStart(ctx context.Context, jobName string, scope string, accountID *string, metaJSON *string, trigger string) (int64, error) {
+	var parentRunID *int64
+	if strings.HasPrefix(trigger, "retry:") {
+		if n, err := strconv.ParseInt(strings.TrimPrefix(trigger, "retry:"), 10, 64); err == nil {
+			parentRunID = &n
+		}
+	}
 	res, err := s.db.ExecContext(ctx, `
-INSERT INTO job_runs (job_name, scope, account_id, started_at, meta_json)
-VALUES (?,?,?,?,?)
-`, jobName, scope, accountID, time.Now().Format(time.RFC3339Nano), metaJSON)
+INSERT INTO job_runs (job_name, scope, account_id, started_at, meta_json, parent_run_id)
+VALUES (?,?,?,?,?,?)
+`, jobName, scope, accountID, time.Now().Format(time.RFC3339Nano), metaJSON, parentRunID)
 	if err != nil {
 		return 0, err
 	}
 	return res.LastInsertId()
 }
 
+// markJobRunCanceled 把一条还在运行（finished_at 为空）的 job run 标记为已取消；
+// 若该 run 已经结束，返回 sql.ErrNoRows。真正中断 worker 还需要调用 Server.cancelRun
+// 触发其 context.CancelFunc，见 jobs_run_cancel.go。
+func (s *Server) markJobRunCanceled(ctx context.Context, runID int64, canceledBy string) error {
+	res, err := s.db.ExecContext(ctx, `
+UPDATE job_runs
+SET status='canceled', canceled_at=?, canceled_by=?
+WHERE id=? AND finished_at IS NULL
+`, time.Now().Format(time.RFC3339Nano), canceledBy, runID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
 func (s *Server) finishJobRun(ctx context.Context, runID int64, ok bool, errMsg *string, metaJSON *string) error {
 	_, err := s.db.ExecContext(ctx, `
 UPDATE job_runs
@@ -28,12 +54,74 @@ WHERE id=?
 	return err
 }
 
+const jobRunColumns = `id, job_name, scope, account_id, started_at, finished_at, ok, error, meta_json, status, canceled_at, canceled_by, parent_run_id`
+
+func scanJobRun(row rowScanner) (*JobRun, error) {
+	var (
+		j           JobRun
+		accountID   sql.NullString
+		startedAt   string
+		finishedAt  sql.NullString
+		okVal       sql.NullInt64
+		errStr      sql.NullString
+		meta        sql.NullString
+		status      sql.NullString
+		canceledAt  sql.NullString
+		canceledBy  sql.NullString
+		parentRunID sql.NullInt64
+	)
+	if err := row.Scan(&j.ID, &j.JobName, &j.Scope, &accountID, &startedAt, &finishedAt, &okVal, &errStr, &meta,
+		&status, &canceledAt, &canceledBy, &parentRunID); err != nil {
+		return nil, err
+	}
+	if accountID.Valid {
+		v := accountID.String
+		j.AccountID = &v
+	}
+	j.StartedAt, _ = time.Parse(time.RFC3339Nano, startedAt)
+	if finishedAt.Valid {
+		if t, err := time.Parse(time.RFC3339Nano, finishedAt.String); err == nil {
+			j.FinishedAt = &t
+		}
+	}
+	if okVal.Valid {
+		v := okVal.Int64 != 0
+		j.OK = &v
+	}
+	if errStr.Valid {
+		v := errStr.String
+		j.Error = &v
+	}
+	if meta.Valid {
+		v := meta.String
+		j.MetaJSON = &v
+	}
+	if status.Valid {
+		v := status.String
+		j.Status = &v
+	}
+	if canceledAt.Valid {
+		if t, err := time.Parse(time.RFC3339Nano, canceledAt.String); err == nil {
+			j.CanceledAt = &t
+		}
+	}
+	if canceledBy.Valid {
+		v := canceledBy.String
+		j.CanceledBy = &v
+	}
+	if parentRunID.Valid {
+		v := parentRunID.Int64
+		j.ParentRunID = &v
+	}
+	return &j, nil
+}
+
 func (s *Server) listJobRuns(ctx context.Context, limit int) ([]JobRun, error) {
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
 	rows, err := s.db.QueryContext(ctx, `
-SELECT id, job_name, scope, account_id, started_at, finished_at, ok, error, meta_json
+SELECT `+jobRunColumns+`
 FROM job_runs
 ORDER BY started_at DESC
 LIMIT ?
@@ -45,41 +133,11 @@ LIMIT ?
 
 	var out []JobRun
 	for rows.Next() {
-		var (
-			j          JobRun
-			accountID  sql.NullString
-			startedAt  string
-			finishedAt sql.NullString
-			okVal      sql.NullInt64
-			errStr     sql.NullString
-			meta       sql.NullString
-		)
-		if err := rows.Scan(&j.ID, &j.JobName, &j.Scope, &accountID, &startedAt, &finishedAt, &okVal, &errStr, &meta); err != nil {
+		j, err := scanJobRun(rows)
+		if err != nil {
 			return nil, err
 		}
-		if accountID.Valid {
-			v := accountID.String
-			j.AccountID = &v
-		}
-		j.StartedAt, _ = time.Parse(time.RFC3339Nano, startedAt)
-		if finishedAt.Valid {
-			if t, err := time.Parse(time.RFC3339Nano, finishedAt.String); err == nil {
-				j.FinishedAt = &t
-			}
-		}
-		if okVal.Valid {
-			v := okVal.Int64 != 0
-			j.OK = &v
-		}
-		if errStr.Valid {
-			v := errStr.String
-			j.Error = &v
-		}
-		if meta.Valid {
-			v := meta.String
-			j.MetaJSON = &v
-		}
-		out = append(out, j)
+		out = append(out, *j)
 	}
 	return out, rows.Err()
 }
@@ -93,46 +151,72 @@ func boolToInt(v bool) int {
 
 func (s *Server) getJobRun(ctx context.Context, runID int64) (*JobRun, error) {
 	row := s.db.QueryRowContext(ctx, `
-SELECT id, job_name, scope, account_id, started_at, finished_at, ok, error, meta_json
+SELECT `+jobRunColumns+`
 FROM job_runs
 WHERE id=?
 `, runID)
-	var (
-		j          JobRun
-		accountID  sql.NullString
-		startedAt  string
-		finishedAt sql.NullString
-		okVal      sql.NullInt64
-		errStr     sql.NullString
-		meta       sql.NullString
-	)
-	if err := row.Scan(&j.ID, &j.JobName, &j.Scope, &accountID, &startedAt, &finishedAt, &okVal, &errStr, &meta); err != nil {
+	j, err := scanJobRun(row)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("scan job run: %w", err)
 	}
-	if accountID.Valid {
-		v := accountID.String
-		j.AccountID = &v
-	}
-	j.StartedAt, _ = time.Parse(time.RFC3339Nano, startedAt)
-	if finishedAt.Valid {
-		if t, err := time.Parse(time.RFC3339Nano, finishedAt.String); err == nil {
-			j.FinishedAt = &t
+	return j, nil
+}
+
+// getRunningJobRun 返回 jobName 当前这条仍在跑（finished_at 为空）的 job run，
+// 供 /api/jobs/xxx_now 的单飞（single-flight）检查使用，见 handleJobTriggerNow。
+func (s *Server) getRunningJobRun(ctx context.Context, jobName string) (*JobRun, error) {
+	row := s.db.QueryRowContext(ctx, `
+SELECT `+jobRunColumns+`
+FROM job_runs
+WHERE job_name=? AND finished_at IS NULL
+ORDER BY started_at DESC
+LIMIT 1
+`, jobName)
+	j, err := scanJobRun(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("scan job run: %w", err)
 	}
-	if okVal.Valid {
-		v := okVal.Int64 != 0
-		j.OK = &v
+	return j, nil
+}
+
+// jobTriggerKeyWindow 是幂等 key 的有效期：同一 (job_name, key) 在这个时间窗口内
+// 重复触发会直接返回第一次的 run_id，而不是再开一个新的 batch。
+const jobTriggerKeyWindow = 10 * time.Minute
+
+// findJobTriggerKey 查找一个尚未过期的幂等 key 对应的 run_id；key 为空或未命中时
+// found 为 false。
+func (s *Server) findJobTriggerKey(ctx context.Context, jobName string, key string) (runID int64, found bool, err error) {
+	if key == "" {
+		return 0, false, nil
 	}
-	if errStr.Valid {
-		v := errStr.String
-		j.Error = &v
+	cutoff := time.Now().Add(-jobTriggerKeyWindow).Format(time.RFC3339Nano)
+	err = s.db.QueryRowContext(ctx, `
+SELECT run_id FROM job_trigger_keys WHERE job_name=? AND key=? AND created_at>=?
+`, jobName, key, cutoff).Scan(&runID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, err
 	}
-	if meta.Valid {
-		v := meta.String
-		j.MetaJSON = &v
+	return runID, true, nil
+}
+
+// recordJobTriggerKey 记录（或刷新）一个幂等 key 对应的 run_id。
+func (s *Server) recordJobTriggerKey(ctx context.Context, jobName string, key string, runID int64) error {
+	if key == "" {
+		return nil
 	}
-	return &j, nil
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO job_trigger_keys (job_name, key, run_id, created_at)
+VALUES (?,?,?,?)
+ON CONFLICT(job_name, key) DO UPDATE SET run_id=excluded.run_id, created_at=excluded.created_at
+`, jobName, key, runID, time.Now().Format(time.RFC3339Nano))
+	return err
 }
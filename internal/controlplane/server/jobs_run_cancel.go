@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// registerRun 记录一个正在运行的 job run 的 context.CancelFunc，供 cancelRun 中断；
+// 进程重启后 runRegistry 为空，旧 run 将无法再被 cancel（只能等它自己超时）。
+func (s *Server) registerRun(runID int64, cancel context.CancelFunc) {
+	s.runRegistryMu.Lock()
+	s.runRegistry[runID] = cancel
+	s.runRegistryMu.Unlock()
+}
+
+func (s *Server) unregisterRun(runID int64) {
+	s.runRegistryMu.Lock()
+	delete(s.runRegistry, runID)
+	s.runRegistryMu.Unlock()
+}
+
+// cancelRun 触发 runID 对应的 context.CancelFunc；返回 false 表示该 run 当前不在
+// registry 里（已经结束，或者服务重启丢失了内存态）。
+func (s *Server) cancelRun(runID int64) bool {
+	s.runRegistryMu.Lock()
+	cancel, ok := s.runRegistry[runID]
+	s.runRegistryMu.Unlock()
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+type jobRunCancelRequest struct {
+	CanceledBy string `json:"canceled_by,omitempty"`
+}
+
+// handleJobRunCancel 请求中断一个还在运行的 job run：先在 DB 里标记
+// status='canceled'，再（如果进程还记得这个 run）触发其 context 取消，
+// 让 worker 在下一次 ctx.Err() 检查点提前退出。
+func (s *Server) handleJobRunCancel(w http.ResponseWriter, r *http.Request) {
+	runID, err := strconv.ParseInt(chiURLParam(r, "runID"), 10, 64)
+	if err != nil {
+		writeError(w, 400, "invalid run id")
+		return
+	}
+
+	var req jobRunCancelRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	canceledBy := strings.TrimSpace(req.CanceledBy)
+	if canceledBy == "" {
+		canceledBy = "api"
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if err := s.markJobRunCanceled(ctx, runID, canceledBy); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, 409, "job run already finished or not found")
+			return
+		}
+		writeError(w, 500, fmt.Sprintf("db mark job run canceled: %v", err))
+		return
+	}
+	s.cancelRun(runID)
+	writeJSON(w, 200, map[string]any{"ok": true})
+}
+
+// handleJobRunRetry 用同样的 job_name 重新发起一次 batch，trigger 编码了
+// 原始 run_id（"retry:<run_id>"），insertJobRunStart 会据此回填 parent_run_id，
+// 从而让 UI 能把一串 retry 串成一条 lineage。
+func (s *Server) handleJobRunRetry(w http.ResponseWriter, r *http.Request) {
+	runID, err := strconv.ParseInt(chiURLParam(r, "runID"), 10, 64)
+	if err != nil {
+		writeError(w, 400, "invalid run id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	run, err := s.getJobRun(ctx, runID)
+	cancel()
+	if err != nil {
+		writeError(w, 500, fmt.Sprintf("db get job run: %v", err))
+		return
+	}
+	if run == nil {
+		writeError(w, 404, "job run not found")
+		return
+	}
+
+	starter, ok := s.jobStartFuncs()[run.JobName]
+	if !ok {
+		writeError(w, 400, fmt.Sprintf("job %q is not retriable (no start func)", run.JobName))
+		return
+	}
+
+	newRunID, err := starter(fmt.Sprintf("retry:%d", runID))
+	if err != nil {
+		writeError(w, 500, fmt.Sprintf("start retry failed: %v", err))
+		return
+	}
+	writeJSON(w, 202, map[string]any{"ok": true, "run_id": newRunID, "parent_run_id": runID})
+}
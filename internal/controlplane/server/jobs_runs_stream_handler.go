@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/betbot/gobet/internal/controlplane/jobevents"
+)
+
+// publishJobEvent 是对 jobEvents 总线的一层薄封装：startXxxBatch/doXxxBatch
+// 在各阶段调用它上报状态，由 handleJobRunsStream 订阅转发给前端。
+func (s *Server) publishJobEvent(jobName string, runID int64, phase jobevents.Phase, trigger string, progress map[string]any, message string) {
+	if s.jobEvents == nil {
+		return
+	}
+	s.jobEvents.Publish(jobevents.Event{
+		JobName:   jobName,
+		RunID:     runID,
+		Phase:     phase,
+		Trigger:   trigger,
+		Progress:  progress,
+		Message:   message,
+		Timestamp: time.Now(),
+	})
+}
+
+// handleJobRunsStream 是 job_runs 状态变化的 SSE 端点：连接建立时先推一份
+// listJobRuns 的快照（event: snapshot），随后持续推送 jobEvents 总线上的
+// 实时事件（event: job）。支持 ?job=xxx&job=yyy 重复参数按 job_name 过滤。
+func (s *Server) handleJobRunsStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, 500, "streaming not supported")
+		return
+	}
+
+	jobs := r.URL.Query()["job"]
+
+	snapshotLimit := 50
+	if v := strings.TrimSpace(r.URL.Query().Get("limit")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= 200 {
+			snapshotLimit = n
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	runs, err := s.listJobRuns(ctx, snapshotLimit)
+	cancel()
+	if err != nil {
+		writeError(w, 500, fmt.Sprintf("db list job runs: %v", err))
+		return
+	}
+	if len(jobs) > 0 {
+		jobSet := make(map[string]bool, len(jobs))
+		for _, j := range jobs {
+			jobSet[j] = true
+		}
+		filtered := runs[:0]
+		for _, run := range runs {
+			if jobSet[run.JobName] {
+				filtered = append(filtered, run)
+			}
+		}
+		runs = filtered
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache, no-transform")
+	w.Header().Set("Connection", "keep-alive")
+
+	writeEvent(w, flusher, "snapshot", runs)
+
+	ch, unsubscribe := s.jobEvents.Subscribe(jobs, 128)
+	defer unsubscribe()
+
+	keepAlive := time.NewTicker(15 * time.Second)
+	defer keepAlive.Stop()
+
+	notify := r.Context().Done()
+	for {
+		select {
+		case <-notify:
+			return
+		case <-keepAlive.C:
+			fmt.Fprintf(w, ": keepalive\n\n")
+			flusher.Flush()
+		case ev := <-ch:
+			writeEvent(w, flusher, "job", ev)
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, event string, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	flusher.Flush()
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// jobStartFuncs 把 job_name 映射到对应的 startXxxBatch 方法，供 cron 调度器
+// 统一驱动——和 /api/jobs/xxx_now 手动触发走的是同一套 startXxxBatch。
+func (s *Server) jobStartFuncs() map[string]func(trigger string) (int64, error) {
+	return map[string]func(trigger string) (int64, error){
+		"balance_sync":     s.startBalanceSyncBatch,
+		"redeem":           s.startRedeemBatch,
+		"trades_sync":      s.startTradesSyncBatch,
+		"positions_sync":   s.startPositionsSyncBatch,
+		"open_orders_sync": s.startOpenOrdersSyncBatch,
+		"equity_snapshot":  s.startEquitySnapshotBatch,
+	}
+}
+
+// cronSchedulerLoop 是唯一的 cron 调度 ticker，从 startBackground 启动；
+// 每秒评估一次 job_schedules，命中的 job 以 trigger="cron" 触发。
+func (s *Server) cronSchedulerLoop(ctx context.Context) {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			s.evaluateJobSchedulesOnce(ctx, now)
+		}
+	}
+}
+
+func (s *Server) evaluateJobSchedulesOnce(ctx context.Context, now time.Time) {
+	schedules, err := s.listJobSchedules(ctx)
+	if err != nil {
+		return
+	}
+	starters := s.jobStartFuncs()
+
+	for _, sch := range schedules {
+		sch := sch
+		if !sch.Enabled {
+			continue
+		}
+		starter, ok := starters[sch.JobName]
+		if !ok {
+			continue
+		}
+
+		cs, err := parseCronExpr(sch.CronExpr)
+		if err != nil {
+			continue
+		}
+
+		// 首次评估（next_run_at 还没算过）：只计算并持久化下一次触发时间，本次 tick 不触发。
+		if sch.NextRunAt == nil {
+			if next, ok := cs.next(now.Add(-time.Second)); ok {
+				_ = s.updateJobScheduleNextRunAt(ctx, sch.JobName, next)
+			}
+			continue
+		}
+		if now.Before(*sch.NextRunAt) {
+			continue
+		}
+
+		// 上一轮还在跑：本次 tick 跳过。next_run_at 不推进，下一个 tick 会再次评估，
+		// 上一轮结束后的第一个 tick 即补跑这一次。
+		if !s.tryLockJobSchedule(sch.JobName) {
+			continue
+		}
+		running, err := s.isJobRunning(ctx, sch.JobName)
+		if err != nil || running {
+			s.unlockJobSchedule(sch.JobName)
+			continue
+		}
+
+		jitterMs := sch.JitterMs
+		go func(jobName string, start func(string) (int64, error), jitterMs int) {
+			defer s.unlockJobSchedule(jobName)
+			if jitterMs > 0 {
+				time.Sleep(time.Duration(rand.Intn(jitterMs)) * time.Millisecond)
+			}
+			_, _ = start("cron")
+			_ = s.updateJobScheduleLastRunAt(context.Background(), jobName, time.Now())
+		}(sch.JobName, starter, jitterMs)
+
+		if next, ok := cs.next(now); ok {
+			_ = s.updateJobScheduleNextRunAt(ctx, sch.JobName, next)
+		}
+	}
+}
+
+// tryLockJobSchedule 和 unlockJobSchedule 实现按 job_name 的互斥：
+// cron ticker 与并发触发之间共享同一把锁，避免同一个 job 被重复调起。
+func (s *Server) tryLockJobSchedule(jobName string) bool {
+	s.jobScheduleLocksMu.Lock()
+	defer s.jobScheduleLocksMu.Unlock()
+	if s.jobScheduleRunning == nil {
+		s.jobScheduleRunning = make(map[string]bool)
+	}
+	if s.jobScheduleRunning[jobName] {
+		return false
+	}
+	s.jobScheduleRunning[jobName] = true
+	return true
+}
+
+func (s *Server) unlockJobSchedule(jobName string) {
+	s.jobScheduleLocksMu.Lock()
+	defer s.jobScheduleLocksMu.Unlock()
+	delete(s.jobScheduleRunning, jobName)
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (s *Server) handleJobSchedulesList(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	schedules, err := s.listJobSchedules(ctx)
+	if err != nil {
+		writeError(w, 500, fmt.Sprintf("db list job schedules: %v", err))
+		return
+	}
+	writeJSON(w, 200, schedules)
+}
+
+type jobScheduleRequest struct {
+	JobName       string  `json:"job_name"`
+	CronExpr      string  `json:"cron_expr"`
+	Enabled       *bool   `json:"enabled,omitempty"`
+	JitterMs      int     `json:"jitter_ms"`
+	MaxConcurrent int     `json:"max_concurrent"`
+	TimeoutS      int     `json:"timeout_s"`
+	ChannelIDs    []int64 `json:"notifier_channel_ids,omitempty"`
+}
+
+func (s *Server) handleJobSchedulesCreate(w http.ResponseWriter, r *http.Request) {
+	var req jobScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, 400, "invalid json body")
+		return
+	}
+	req.JobName = strings.TrimSpace(req.JobName)
+	req.CronExpr = strings.TrimSpace(req.CronExpr)
+	if req.JobName == "" {
+		writeError(w, 400, "job_name is required")
+		return
+	}
+	if _, ok := s.jobStartFuncs()[req.JobName]; !ok {
+		writeError(w, 400, fmt.Sprintf("unknown job_name: %s", req.JobName))
+		return
+	}
+	if _, err := parseCronExpr(req.CronExpr); err != nil {
+		writeError(w, 400, fmt.Sprintf("invalid cron_expr: %v", err))
+		return
+	}
+	if req.MaxConcurrent <= 0 {
+		req.MaxConcurrent = 1
+	}
+	if req.TimeoutS <= 0 {
+		req.TimeoutS = 600
+	}
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	js := &JobSchedule{
+		JobName:       req.JobName,
+		CronExpr:      req.CronExpr,
+		Enabled:       enabled,
+		JitterMs:      req.JitterMs,
+		MaxConcurrent: req.MaxConcurrent,
+		TimeoutS:      req.TimeoutS,
+		ChannelIDs:    req.ChannelIDs,
+	}
+	if err := s.createJobSchedule(ctx, js); err != nil {
+		writeError(w, 500, fmt.Sprintf("db create job schedule: %v", err))
+		return
+	}
+	writeJSON(w, 201, map[string]any{"ok": true})
+}
+
+func (s *Server) handleJobScheduleUpdate(w http.ResponseWriter, r *http.Request) {
+	jobName := strings.TrimSpace(chiURLParam(r, "jobName"))
+	if jobName == "" {
+		writeError(w, 400, "job_name is required")
+		return
+	}
+
+	var req jobScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, 400, "invalid json body")
+		return
+	}
+	req.CronExpr = strings.TrimSpace(req.CronExpr)
+	if _, err := parseCronExpr(req.CronExpr); err != nil {
+		writeError(w, 400, fmt.Sprintf("invalid cron_expr: %v", err))
+		return
+	}
+	if req.MaxConcurrent <= 0 {
+		req.MaxConcurrent = 1
+	}
+	if req.TimeoutS <= 0 {
+		req.TimeoutS = 600
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if err := s.updateJobSchedule(ctx, jobName, req.CronExpr, req.JitterMs, req.MaxConcurrent, req.TimeoutS, req.ChannelIDs); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, 404, "job schedule not found")
+			return
+		}
+		writeError(w, 500, fmt.Sprintf("db update job schedule: %v", err))
+		return
+	}
+	writeJSON(w, 200, map[string]any{"ok": true})
+}
+
+func (s *Server) handleJobScheduleDelete(w http.ResponseWriter, r *http.Request) {
+	jobName := strings.TrimSpace(chiURLParam(r, "jobName"))
+	if jobName == "" {
+		writeError(w, 400, "job_name is required")
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if err := s.deleteJobSchedule(ctx, jobName); err != nil {
+		writeError(w, 500, fmt.Sprintf("db delete job schedule: %v", err))
+		return
+	}
+	writeJSON(w, 200, map[string]any{"ok": true})
+}
+
+func (s *Server) handleJobScheduleEnable(w http.ResponseWriter, r *http.Request) {
+	s.setJobScheduleEnabledHandler(w, r, true)
+}
+
+func (s *Server) handleJobScheduleDisable(w http.ResponseWriter, r *http.Request) {
+	s.setJobScheduleEnabledHandler(w, r, false)
+}
+
+func (s *Server) setJobScheduleEnabledHandler(w http.ResponseWriter, r *http.Request, enabled bool) {
+	jobName := strings.TrimSpace(chiURLParam(r, "jobName"))
+	if jobName == "" {
+		writeError(w, 400, "job_name is required")
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if err := s.setJobScheduleEnabled(ctx, jobName, enabled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, 404, "job schedule not found")
+			return
+		}
+		writeError(w, 500, fmt.Sprintf("db update job schedule: %v", err))
+		return
+	}
+	writeJSON(w, 200, map[string]any{"ok": true})
+}
+
+type jobSchedulePreviewRequest struct {
+	CronExpr string `json:"cron_expr"`
+	N        int    `json:"n,omitempty"`
+}
+
+// handleJobSchedulePreview 是 dry-run 预览端点：不落库，只返回从当前时间起
+// 接下来 N 次触发时间，供 UI 在保存前校验 cron 表达式。
+func (s *Server) handleJobSchedulePreview(w http.ResponseWriter, r *http.Request) {
+	var req jobSchedulePreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, 400, "invalid json body")
+		return
+	}
+	req.CronExpr = strings.TrimSpace(req.CronExpr)
+	n := req.N
+	if v := strings.TrimSpace(r.URL.Query().Get("n")); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			n = parsed
+		}
+	}
+	if n <= 0 || n > 50 {
+		n = 5
+	}
+
+	times, err := previewCronFireTimes(req.CronExpr, time.Now(), n)
+	if err != nil {
+		writeError(w, 400, fmt.Sprintf("invalid cron_expr: %v", err))
+		return
+	}
+	writeJSON(w, 200, map[string]any{"fire_times": times})
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (s *Server) listJobSchedules(ctx context.Context) ([]JobSchedule, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT job_name, cron_expr, enabled, jitter_ms, max_concurrent, timeout_s, notifier_channel_ids, next_run_at, last_run_at, created_at, updated_at
+FROM job_schedules
+ORDER BY job_name ASC
+`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []JobSchedule
+	for rows.Next() {
+		js, err := scanJobSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *js)
+	}
+	return out, rows.Err()
+}
+
+func (s *Server) getJobSchedule(ctx context.Context, jobName string) (*JobSchedule, error) {
+	row := s.db.QueryRowContext(ctx, `
+SELECT job_name, cron_expr, enabled, jitter_ms, max_concurrent, timeout_s, notifier_channel_ids, next_run_at, last_run_at, created_at, updated_at
+FROM job_schedules
+WHERE job_name=?
+`, jobName)
+	js, err := scanJobSchedule(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("scan job schedule: %w", err)
+	}
+	return js, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJobSchedule(row rowScanner) (*JobSchedule, error) {
+	var (
+		js         JobSchedule
+		enabledInt int
+		channelIDs sql.NullString
+		nextRunAt  sql.NullString
+		lastRunAt  sql.NullString
+		createdAt  string
+		updatedAt  string
+	)
+	if err := row.Scan(&js.JobName, &js.CronExpr, &enabledInt, &js.JitterMs, &js.MaxConcurrent, &js.TimeoutS,
+		&channelIDs, &nextRunAt, &lastRunAt, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	js.Enabled = enabledInt != 0
+	if channelIDs.Valid && channelIDs.String != "" {
+		_ = json.Unmarshal([]byte(channelIDs.String), &js.ChannelIDs)
+	}
+	if nextRunAt.Valid {
+		if t, err := time.Parse(time.RFC3339Nano, nextRunAt.String); err == nil {
+			js.NextRunAt = &t
+		}
+	}
+	if lastRunAt.Valid {
+		if t, err := time.Parse(time.RFC3339Nano, lastRunAt.String); err == nil {
+			js.LastRunAt = &t
+		}
+	}
+	js.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	js.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedAt)
+	return &js, nil
+}
+
+func (s *Server) createJobSchedule(ctx context.Context, js *JobSchedule) error {
+	channelIDsJSON, _ := json.Marshal(js.ChannelIDs)
+	now := time.Now().Format(time.RFC3339Nano)
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO job_schedules (job_name, cron_expr, enabled, jitter_ms, max_concurrent, timeout_s, notifier_channel_ids, created_at, updated_at)
+VALUES (?,?,?,?,?,?,?,?,?)
+`, js.JobName, js.CronExpr, boolToInt(js.Enabled), js.JitterMs, js.MaxConcurrent, js.TimeoutS, string(channelIDsJSON), now, now)
+	return err
+}
+
+func (s *Server) updateJobSchedule(ctx context.Context, jobName string, cronExpr string, jitterMs int, maxConcurrent int, timeoutS int, channelIDs []int64) error {
+	channelIDsJSON, _ := json.Marshal(channelIDs)
+	res, err := s.db.ExecContext(ctx, `
+UPDATE job_schedules
+SET cron_expr=?, jitter_ms=?, max_concurrent=?, timeout_s=?, notifier_channel_ids=?, updated_at=?, next_run_at=NULL
+WHERE job_name=?
+`, cronExpr, jitterMs, maxConcurrent, timeoutS, string(channelIDsJSON), time.Now().Format(time.RFC3339Nano), jobName)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+func (s *Server) deleteJobSchedule(ctx context.Context, jobName string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM job_schedules WHERE job_name=?`, jobName)
+	return err
+}
+
+func (s *Server) setJobScheduleEnabled(ctx context.Context, jobName string, enabled bool) error {
+	res, err := s.db.ExecContext(ctx, `
+UPDATE job_schedules
+SET enabled=?, updated_at=?, next_run_at=NULL
+WHERE job_name=?
+`, boolToInt(enabled), time.Now().Format(time.RFC3339Nano), jobName)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+func (s *Server) updateJobScheduleNextRunAt(ctx context.Context, jobName string, nextRunAt time.Time) error {
+	_, err := s.db.ExecContext(ctx, `
+UPDATE job_schedules SET next_run_at=?, updated_at=? WHERE job_name=?
+`, nextRunAt.Format(time.RFC3339Nano), time.Now().Format(time.RFC3339Nano), jobName)
+	return err
+}
+
+func (s *Server) updateJobScheduleLastRunAt(ctx context.Context, jobName string, lastRunAt time.Time) error {
+	_, err := s.db.ExecContext(ctx, `
+UPDATE job_schedules SET last_run_at=?, updated_at=? WHERE job_name=?
+`, lastRunAt.Format(time.RFC3339Nano), time.Now().Format(time.RFC3339Nano), jobName)
+	return err
+}
+
+// isJobRunning 判断 jobName 是否还有一条 job_runs 记录处于运行中
+// （finished_at 为空即视为 "running"；status 列只在取消时写成 'canceled'，这里不看它）。
+func (s *Server) isJobRunning(ctx context.Context, jobName string) (bool, error) {
+	var exists int
+	err := s.db.QueryRowContext(ctx, `
+SELECT 1 FROM job_runs WHERE job_name=? AND finished_at IS NULL LIMIT 1
+`, jobName).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func checkRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package server
+
+import "time"
+
+// JobSchedule 描述一个 job 的 cron 调度策略，持久化在 job_schedules 表中，
+// 由 cronSchedulerLoop 周期性评估并驱动对应的 startXxxBatch（见 jobs_scheduler.go）。
+type JobSchedule struct {
+	JobName       string     `json:"job_name"`
+	CronExpr      string     `json:"cron_expr"`
+	Enabled       bool       `json:"enabled"`
+	JitterMs      int        `json:"jitter_ms"`
+	MaxConcurrent int        `json:"max_concurrent"`
+	TimeoutS      int        `json:"timeout_s"`
+	ChannelIDs    []int64    `json:"notifier_channel_ids,omitempty"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
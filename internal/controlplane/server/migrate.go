@@ -82,6 +82,34 @@ CREATE TABLE IF NOT EXISTS job_runs (
 );`,
 		`CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs(started_at DESC);`,
 		`
+CREATE TABLE IF NOT EXISTS job_schedules (
+  job_name TEXT PRIMARY KEY,
+  cron_expr TEXT NOT NULL,
+  enabled INTEGER NOT NULL DEFAULT 1,
+  jitter_ms INTEGER NOT NULL DEFAULT 0,
+  max_concurrent INTEGER NOT NULL DEFAULT 1,
+  timeout_s INTEGER NOT NULL DEFAULT 600,
+  notifier_channel_ids TEXT, -- JSON array of notifier_channels.id
+  next_run_at TEXT,
+  last_run_at TEXT,
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL
+);`,
+		`
+CREATE TABLE IF NOT EXISTS notifier_channels (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL UNIQUE,
+  type TEXT NOT NULL, -- "lark" | "slack" | "telegram" | "webhook"
+  url TEXT NOT NULL,
+  secret TEXT,
+  min_severity TEXT NOT NULL DEFAULT 'info',
+  job_filter TEXT, -- JSON array of job_name；为空表示接收所有 job
+  rate_limit_per_min INTEGER NOT NULL DEFAULT 0, -- 0 表示不限流
+  enabled INTEGER NOT NULL DEFAULT 1,
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL
+);`,
+		`
 CREATE TABLE IF NOT EXISTS sync_state (
   account_id TEXT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
   key TEXT NOT NULL,
@@ -149,6 +177,14 @@ CREATE TABLE IF NOT EXISTS clob_trades (
 );`,
 		`CREATE INDEX IF NOT EXISTS idx_clob_trades_account_time ON clob_trades(account_id, match_time_ts DESC);`,
 		`
+CREATE TABLE IF NOT EXISTS job_trigger_keys (
+  job_name TEXT NOT NULL,
+  key TEXT NOT NULL,
+  run_id INTEGER NOT NULL,
+  created_at TEXT NOT NULL,
+  PRIMARY KEY (job_name, key)
+);`,
+		`
 CREATE TABLE IF NOT EXISTS account_equity_snapshots (
   account_id TEXT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
   cash_usdc REAL NOT NULL,
@@ -208,6 +244,27 @@ CREATE TABLE IF NOT EXISTS account_equity_snapshots (
 		}
 	}
 
+	// 兼容：旧库没有 job_runs status/canceled_at/canceled_by/parent_run_id 时补齐
+	for _, col := range []struct {
+		name string
+		ddl  string
+	}{
+		{"status", `ALTER TABLE job_runs ADD COLUMN status TEXT;`},
+		{"canceled_at", `ALTER TABLE job_runs ADD COLUMN canceled_at TEXT;`},
+		{"canceled_by", `ALTER TABLE job_runs ADD COLUMN canceled_by TEXT;`},
+		{"parent_run_id", `ALTER TABLE job_runs ADD COLUMN parent_run_id INTEGER;`},
+	} {
+		ok, err := hasColumn(ctx, s.db, "job_runs", col.name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if _, err := s.db.ExecContext(ctx, col.ddl); err != nil {
+				return fmt.Errorf("alter job_runs add %s: %w", col.name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/betbot/gobet/internal/notifier"
+)
+
+func (s *Server) handleNotifierChannelsList(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	channels, err := s.listNotifierChannels(ctx)
+	if err != nil {
+		writeError(w, 500, fmt.Sprintf("db list notifier channels: %v", err))
+		return
+	}
+	writeJSON(w, 200, channels)
+}
+
+type notifierChannelRequest struct {
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	URL             string   `json:"url"`
+	Secret          string   `json:"secret,omitempty"`
+	MinSeverity     string   `json:"min_severity,omitempty"`
+	JobFilter       []string `json:"job_filter,omitempty"`
+	RateLimitPerMin int      `json:"rate_limit_per_min"`
+	Enabled         *bool    `json:"enabled,omitempty"`
+}
+
+func (req *notifierChannelRequest) toChannel() (*NotifierChannel, error) {
+	name := strings.TrimSpace(req.Name)
+	typ := strings.TrimSpace(req.Type)
+	url := strings.TrimSpace(req.URL)
+	if name == "" || typ == "" || url == "" {
+		return nil, fmt.Errorf("name/type/url 均为必填")
+	}
+	if _, err := notifier.New(notifier.ChannelConfig{Type: notifier.ChannelType(typ), URL: url, Secret: req.Secret}); err != nil {
+		return nil, err
+	}
+	minSeverity := strings.TrimSpace(req.MinSeverity)
+	if minSeverity == "" {
+		minSeverity = string(notifier.SeverityInfo)
+	}
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	return &NotifierChannel{
+		Name:            name,
+		Type:            typ,
+		URL:             url,
+		Secret:          req.Secret,
+		MinSeverity:     minSeverity,
+		JobFilter:       req.JobFilter,
+		RateLimitPerMin: req.RateLimitPerMin,
+		Enabled:         enabled,
+	}, nil
+}
+
+func (s *Server) handleNotifierChannelsCreate(w http.ResponseWriter, r *http.Request) {
+	var req notifierChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, 400, "invalid json body")
+		return
+	}
+	ch, err := req.toChannel()
+	if err != nil {
+		writeError(w, 400, err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	id, err := s.createNotifierChannel(ctx, ch)
+	if err != nil {
+		writeError(w, 500, fmt.Sprintf("db create notifier channel: %v", err))
+		return
+	}
+	writeJSON(w, 201, map[string]any{"ok": true, "id": id})
+}
+
+func (s *Server) handleNotifierChannelUpdate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chiURLParam(r, "channelID"), 10, 64)
+	if err != nil {
+		writeError(w, 400, "invalid channel id")
+		return
+	}
+	var req notifierChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, 400, "invalid json body")
+		return
+	}
+	ch, err := req.toChannel()
+	if err != nil {
+		writeError(w, 400, err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if err := s.updateNotifierChannel(ctx, id, ch); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, 404, "notifier channel not found")
+			return
+		}
+		writeError(w, 500, fmt.Sprintf("db update notifier channel: %v", err))
+		return
+	}
+	writeJSON(w, 200, map[string]any{"ok": true})
+}
+
+func (s *Server) handleNotifierChannelDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chiURLParam(r, "channelID"), 10, 64)
+	if err != nil {
+		writeError(w, 400, "invalid channel id")
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if err := s.deleteNotifierChannel(ctx, id); err != nil {
+		writeError(w, 500, fmt.Sprintf("db delete notifier channel: %v", err))
+		return
+	}
+	writeJSON(w, 200, map[string]any{"ok": true})
+}
+
+// handleNotifierChannelTest 发送一条 canned 测试消息，供 UI 验证渠道配置是否可用。
+func (s *Server) handleNotifierChannelTest(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chiURLParam(r, "channelID"), 10, 64)
+	if err != nil {
+		writeError(w, 400, "invalid channel id")
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	ch, err := s.getNotifierChannel(ctx, id)
+	cancel()
+	if err != nil {
+		writeError(w, 500, fmt.Sprintf("db get notifier channel: %v", err))
+		return
+	}
+	if ch == nil {
+		writeError(w, 404, "notifier channel not found")
+		return
+	}
+
+	n, err := notifier.New(notifier.ChannelConfig{Type: notifier.ChannelType(ch.Type), URL: ch.URL, Secret: ch.Secret})
+	if err != nil {
+		writeError(w, 400, err.Error())
+		return
+	}
+	sendCtx, sendCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer sendCancel()
+	if err := n.Notify(sendCtx, notifier.NotifyEvent{
+		JobName:   "test",
+		Trigger:   "test",
+		Severity:  notifier.SeverityInfo,
+		Message:   "this is a test notification from gobet",
+		Timestamp: time.Now(),
+	}); err != nil {
+		writeError(w, 502, fmt.Sprintf("send test notification failed: %v", err))
+		return
+	}
+	writeJSON(w, 200, map[string]any{"ok": true})
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (s *Server) listNotifierChannels(ctx context.Context) ([]NotifierChannel, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id, name, type, url, secret, min_severity, job_filter, rate_limit_per_min, enabled, created_at, updated_at
+FROM notifier_channels
+ORDER BY id ASC
+`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []NotifierChannel
+	for rows.Next() {
+		ch, err := scanNotifierChannel(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *ch)
+	}
+	return out, rows.Err()
+}
+
+func (s *Server) getNotifierChannel(ctx context.Context, id int64) (*NotifierChannel, error) {
+	row := s.db.QueryRowContext(ctx, `
+SELECT id, name, type, url, secret, min_severity, job_filter, rate_limit_per_min, enabled, created_at, updated_at
+FROM notifier_channels WHERE id=?
+`, id)
+	ch, err := scanNotifierChannel(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("scan notifier channel: %w", err)
+	}
+	return ch, nil
+}
+
+func scanNotifierChannel(row rowScanner) (*NotifierChannel, error) {
+	var (
+		ch         NotifierChannel
+		secret     sql.NullString
+		jobFilter  sql.NullString
+		enabledInt int
+		createdAt  string
+		updatedAt  string
+	)
+	if err := row.Scan(&ch.ID, &ch.Name, &ch.Type, &ch.URL, &secret, &ch.MinSeverity, &jobFilter, &ch.RateLimitPerMin, &enabledInt, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if secret.Valid {
+		ch.Secret = secret.String
+	}
+	if jobFilter.Valid && jobFilter.String != "" {
+		_ = json.Unmarshal([]byte(jobFilter.String), &ch.JobFilter)
+	}
+	ch.Enabled = enabledInt != 0
+	ch.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	ch.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedAt)
+	return &ch, nil
+}
+
+func (s *Server) createNotifierChannel(ctx context.Context, ch *NotifierChannel) (int64, error) {
+	jobFilterJSON, _ := json.Marshal(ch.JobFilter)
+	now := time.Now().Format(time.RFC3339Nano)
+	res, err := s.db.ExecContext(ctx, `
+INSERT INTO notifier_channels (name, type, url, secret, min_severity, job_filter, rate_limit_per_min, enabled, created_at, updated_at)
+VALUES (?,?,?,?,?,?,?,?,?,?)
+`, ch.Name, ch.Type, ch.URL, ch.Secret, ch.MinSeverity, string(jobFilterJSON), ch.RateLimitPerMin, boolToInt(ch.Enabled), now, now)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (s *Server) updateNotifierChannel(ctx context.Context, id int64, ch *NotifierChannel) error {
+	jobFilterJSON, _ := json.Marshal(ch.JobFilter)
+	res, err := s.db.ExecContext(ctx, `
+UPDATE notifier_channels
+SET name=?, type=?, url=?, secret=?, min_severity=?, job_filter=?, rate_limit_per_min=?, enabled=?, updated_at=?
+WHERE id=?
+`, ch.Name, ch.Type, ch.URL, ch.Secret, ch.MinSeverity, string(jobFilterJSON), ch.RateLimitPerMin, boolToInt(ch.Enabled), time.Now().Format(time.RFC3339Nano), id)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+func (s *Server) deleteNotifierChannel(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM notifier_channels WHERE id=?`, id)
+	return err
+}
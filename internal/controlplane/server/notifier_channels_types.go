@@ -0,0 +1,32 @@
+package server
+
+import "time"
+
+// NotifierChannel 描述一个出站通知渠道配置，持久化在 notifier_channels 表中，
+// 由 notifyJobOutcome（见 notifier_dispatch.go）按 job_filter/min_severity 匹配后投递。
+type NotifierChannel struct {
+	ID              int64     `json:"id"`
+	Name            string    `json:"name"`
+	Type            string    `json:"type"`
+	URL             string    `json:"url"`
+	Secret          string    `json:"secret,omitempty"`
+	MinSeverity     string    `json:"min_severity"`
+	JobFilter       []string  `json:"job_filter,omitempty"`
+	RateLimitPerMin int       `json:"rate_limit_per_min"`
+	Enabled         bool      `json:"enabled"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Matches 判断该渠道是否应该接收 jobName 的事件；job_filter 为空表示接收所有 job。
+func (c *NotifierChannel) Matches(jobName string) bool {
+	if len(c.JobFilter) == 0 {
+		return true
+	}
+	for _, j := range c.JobFilter {
+		if j == jobName {
+			return true
+		}
+	}
+	return false
+}
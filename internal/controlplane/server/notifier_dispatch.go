@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/betbot/gobet/internal/notifier"
+)
+
+// notifyJobOutcome 在一个 job run 结束时调用：按渠道的 job_filter/min_severity
+// 选出匹配的通知渠道（若该 job 的 schedule 显式指定了 notifier_channel_ids，
+// 则在此基础上再收窄到那个列表），经各自的限流窗口后异步发送，发送失败不影响
+// job 本身的结果。
+
+func (s *Server) notifyJobOutcome(jobName string, runID int64, trigger string, startedAt time.Time, okCount, errCount int, firstErr string) {
+	if s.notifierRateLimiter == nil {
+		return
+	}
+	severity := notifier.SeverityInfo
+	if errCount > 0 {
+		severity = notifier.SeverityError
+	}
+	event := notifier.NotifyEvent{
+		JobName:    jobName,
+		RunID:      runID,
+		Trigger:    trigger,
+		Severity:   severity,
+		Duration:   time.Since(startedAt),
+		OKCount:    okCount,
+		ErrCount:   errCount,
+		FirstError: firstErr,
+		Timestamp:  time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	channels, err := s.listNotifierChannels(ctx)
+	var scheduleChannelIDs map[int64]bool
+	if err == nil {
+		if sched, serr := s.getJobSchedule(ctx, jobName); serr == nil && sched != nil && len(sched.ChannelIDs) > 0 {
+			scheduleChannelIDs = make(map[int64]bool, len(sched.ChannelIDs))
+			for _, id := range sched.ChannelIDs {
+				scheduleChannelIDs[id] = true
+			}
+		}
+	}
+	cancel()
+	if err != nil {
+		return
+	}
+
+	for _, ch := range channels {
+		ch := ch
+		if !ch.Enabled || !ch.Matches(jobName) || !event.Severity.Meets(notifier.Severity(ch.MinSeverity)) {
+			continue
+		}
+		// job schedule 若显式指定了 notifier_channel_ids，则只投递给该列表中的渠道
+		if scheduleChannelIDs != nil && !scheduleChannelIDs[ch.ID] {
+			continue
+		}
+		if !s.notifierRateLimiter.Allow(strconv.FormatInt(ch.ID, 10), ch.RateLimitPerMin) {
+			continue
+		}
+		n, err := notifier.New(notifier.ChannelConfig{Type: notifier.ChannelType(ch.Type), URL: ch.URL, Secret: ch.Secret})
+		if err != nil {
+			continue
+		}
+		go func() {
+			sendCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = n.Notify(sendCtx, event)
+		}()
+	}
+}
@@ -9,9 +9,13 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "modernc.org/sqlite"
+
+	"github.com/betbot/gobet/internal/controlplane/jobevents"
+	"github.com/betbot/gobet/internal/notifier"
 )
 
 type Config struct {
@@ -27,6 +31,20 @@ type Server struct {
 
 	bgCancel func()
 	bgWG     sync.WaitGroup
+
+	// cron 调度器的按 job_name 互斥，见 jobs_scheduler.go
+	jobScheduleLocksMu sync.Mutex
+	jobScheduleRunning map[string]bool
+
+	// job run 状态变化的进程内事件总线，见 jobs_runs_stream_handler.go
+	jobEvents *jobevents.Bus
+
+	// 出站通知渠道（Lark/Slack/Telegram/webhook）的按渠道限流器，见 notifier_dispatch.go
+	notifierRateLimiter *notifier.RateLimiter
+
+	// 运行中 job run 的 context.CancelFunc 登记表，供 cancelRun 中断，见 jobs_run_cancel.go
+	runRegistryMu sync.Mutex
+	runRegistry   map[int64]context.CancelFunc
 }
 
 func New(cfg Config) (*Server, error) {
@@ -54,7 +72,13 @@ func New(cfg Config) (*Server, error) {
 	db.SetMaxOpenConns(1) // SQLite：单连接更稳定
 	db.SetMaxIdleConns(1)
 
-	s := &Server{cfg: cfg, db: db}
+	s := &Server{
+		cfg:                 cfg,
+		db:                  db,
+		jobEvents:           jobevents.NewBus(),
+		notifierRateLimiter: notifier.NewRateLimiter(time.Minute),
+		runRegistry:         make(map[int64]context.CancelFunc),
+	}
 	if err := s.migrate(); err != nil {
 		_ = db.Close()
 		return nil, err
@@ -105,6 +129,10 @@ func (s *Server) Router() http.Handler {
 
 	jobs := api.Group("/jobs")
 	jobs.GET("/runs", s.wrap(s.handleJobRunsList))
+	jobs.GET("/runs/stream", s.wrap(s.handleJobRunsStream))
+	jobRunID := jobs.Group("/runs/:runID")
+	jobRunID.POST("/cancel", s.wrap(s.handleJobRunCancel))
+	jobRunID.POST("/retry", s.wrap(s.handleJobRunRetry))
 	jobs.POST("/balance_sync", s.wrap(s.handleJobBalanceSyncNow))
 	jobs.POST("/redeem", s.wrap(s.handleJobRedeemNow))
 	jobs.POST("/trades_sync", s.wrap(s.handleJobTradesSyncNow))
@@ -112,6 +140,24 @@ func (s *Server) Router() http.Handler {
 	jobs.POST("/open_orders_sync", s.wrap(s.handleJobOpenOrdersSyncNow))
 	jobs.POST("/equity_snapshot", s.wrap(s.handleJobEquitySnapshotNow))
 
+	jobSchedules := jobs.Group("/schedules")
+	jobSchedules.GET("/", s.wrap(s.handleJobSchedulesList))
+	jobSchedules.POST("/", s.wrap(s.handleJobSchedulesCreate))
+	jobSchedules.POST("/preview", s.wrap(s.handleJobSchedulePreview))
+	jobScheduleName := jobSchedules.Group("/:jobName")
+	jobScheduleName.PUT("/", s.wrap(s.handleJobScheduleUpdate))
+	jobScheduleName.DELETE("/", s.wrap(s.handleJobScheduleDelete))
+	jobScheduleName.POST("/enable", s.wrap(s.handleJobScheduleEnable))
+	jobScheduleName.POST("/disable", s.wrap(s.handleJobScheduleDisable))
+
+	notifiers := api.Group("/notifiers")
+	notifiers.GET("/", s.wrap(s.handleNotifierChannelsList))
+	notifiers.POST("/", s.wrap(s.handleNotifierChannelsCreate))
+	notifierChannelID := notifiers.Group("/:channelID")
+	notifierChannelID.PUT("/", s.wrap(s.handleNotifierChannelUpdate))
+	notifierChannelID.DELETE("/", s.wrap(s.handleNotifierChannelDelete))
+	notifierChannelID.POST("/test", s.wrap(s.handleNotifierChannelTest))
+
 	bots := api.Group("/bots")
 	bots.GET("/", s.wrap(s.handleBotsList))
 	bots.POST("/", s.wrap(s.handleBotsCreate))
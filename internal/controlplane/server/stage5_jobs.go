@@ -6,6 +6,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/betbot/gobet/internal/controlplane/jobevents"
 )
 
 func (s *Server) startTradesSyncBatch(trigger string) (int64, error) {
@@ -13,48 +15,70 @@ func (s *Server) startTradesSyncBatch(trigger string) (int64, error) {
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "trades_sync", "batch", nil, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "trades_sync", "batch", nil, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
+	s.publishJobEvent("trades_sync", runID, jobevents.PhaseQueued, trigger, nil, "")
+	jobCtx, jobCancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	s.registerRun(runID, jobCancel)
 	go func() {
-		jobCtx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-		defer cancel()
+		defer jobCancel()
+		defer s.unregisterRun(runID)
 		s.doTradesSyncBatch(jobCtx, runID, trigger)
 	}()
 	return runID, nil
 }
 
 func (s *Server) doTradesSyncBatch(ctx context.Context, runID int64, trigger string) {
+	startedAt := time.Now()
+	s.publishJobEvent("trades_sync", runID, jobevents.PhaseRunning, trigger, nil, "")
 	mnemonic, err := s.loadMnemonic()
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("trades_sync", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("trades_sync", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 	accounts, err := s.listAccounts(ctx)
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("trades_sync", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("trades_sync", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 	okCount := 0
 	errCount := 0
 	insertedTotal := 0
+	firstError := ""
 	for _, a := range accounts {
+		if ctx.Err() != nil {
+			break
+		}
 		path, err := derivationPathFromAccountID(a.ID)
 		if err != nil {
 			errCount++
+			if firstError == "" {
+				firstError = err.Error()
+			}
 			continue
 		}
 		derived, err := deriveWalletFromMnemonic(mnemonic, path)
 		if err != nil {
 			errCount++
+			if firstError == "" {
+				firstError = err.Error()
+			}
 			continue
 		}
 		n, _, err := s.syncAccountTrades(ctx, a, derived.PrivateKeyHex)
 		if err != nil {
 			errCount++
+			if firstError == "" {
+				firstError = err.Error()
+			}
 			continue
 		}
 		insertedTotal += n
@@ -63,6 +87,12 @@ func (s *Server) doTradesSyncBatch(ctx context.Context, runID int64, trigger str
 	meta2, _ := json.Marshal(map[string]any{"trigger": trigger, "accounts": len(accounts), "ok": okCount, "err": errCount, "inserted": insertedTotal})
 	metaStr2 := string(meta2)
 	_ = s.finishJobRun(ctx, runID, errCount == 0, nilIfEmpty(errCount, "some accounts failed"), &metaStr2)
+	if errCount == 0 {
+		s.publishJobEvent("trades_sync", runID, jobevents.PhaseSucceeded, trigger, nil, "")
+	} else {
+		s.publishJobEvent("trades_sync", runID, jobevents.PhaseFailed, trigger, nil, "some accounts failed")
+	}
+	s.notifyJobOutcome("trades_sync", runID, trigger, startedAt, okCount, errCount, firstError)
 }
 
 func (s *Server) startPositionsSyncBatch(trigger string) (int64, error) {
@@ -70,30 +100,44 @@ func (s *Server) startPositionsSyncBatch(trigger string) (int64, error) {
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "positions_sync", "batch", nil, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "positions_sync", "batch", nil, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
+	s.publishJobEvent("positions_sync", runID, jobevents.PhaseQueued, trigger, nil, "")
+	jobCtx, jobCancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	s.registerRun(runID, jobCancel)
 	go func() {
-		jobCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-		defer cancel()
+		defer jobCancel()
+		defer s.unregisterRun(runID)
 		s.doPositionsSyncBatch(jobCtx, runID, trigger)
 	}()
 	return runID, nil
 }
 
 func (s *Server) doPositionsSyncBatch(ctx context.Context, runID int64, trigger string) {
+	startedAt := time.Now()
+	s.publishJobEvent("positions_sync", runID, jobevents.PhaseRunning, trigger, nil, "")
 	accounts, err := s.listAccounts(ctx)
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("positions_sync", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("positions_sync", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 	okCount := 0
 	errCount := 0
+	firstError := ""
 	for _, a := range accounts {
+		if ctx.Err() != nil {
+			break
+		}
 		if err := s.syncAccountPositions(ctx, a); err != nil {
 			errCount++
+			if firstError == "" {
+				firstError = err.Error()
+			}
 			continue
 		}
 		okCount++
@@ -101,6 +145,12 @@ func (s *Server) doPositionsSyncBatch(ctx context.Context, runID int64, trigger
 	meta2, _ := json.Marshal(map[string]any{"trigger": trigger, "accounts": len(accounts), "ok": okCount, "err": errCount})
 	metaStr2 := string(meta2)
 	_ = s.finishJobRun(ctx, runID, errCount == 0, nilIfEmpty(errCount, "some accounts failed"), &metaStr2)
+	if errCount == 0 {
+		s.publishJobEvent("positions_sync", runID, jobevents.PhaseSucceeded, trigger, nil, "")
+	} else {
+		s.publishJobEvent("positions_sync", runID, jobevents.PhaseFailed, trigger, nil, "some accounts failed")
+	}
+	s.notifyJobOutcome("positions_sync", runID, trigger, startedAt, okCount, errCount, firstError)
 }
 
 func (s *Server) startOpenOrdersSyncBatch(trigger string) (int64, error) {
@@ -108,23 +158,30 @@ func (s *Server) startOpenOrdersSyncBatch(trigger string) (int64, error) {
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "open_orders_sync", "batch", nil, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "open_orders_sync", "batch", nil, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
+	s.publishJobEvent("open_orders_sync", runID, jobevents.PhaseQueued, trigger, nil, "")
+	jobCtx, jobCancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	s.registerRun(runID, jobCancel)
 	go func() {
-		jobCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-		defer cancel()
+		defer jobCancel()
+		defer s.unregisterRun(runID)
 		s.doOpenOrdersSyncBatch(jobCtx, runID, trigger)
 	}()
 	return runID, nil
 }
 
 func (s *Server) doOpenOrdersSyncBatch(ctx context.Context, runID int64, trigger string) {
+	startedAt := time.Now()
+	s.publishJobEvent("open_orders_sync", runID, jobevents.PhaseRunning, trigger, nil, "")
 	mnemonic, err := s.loadMnemonic()
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("open_orders_sync", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("open_orders_sync", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 	host := strings.TrimSpace(os.Getenv("CLOB_API_URL"))
@@ -133,23 +190,38 @@ func (s *Server) doOpenOrdersSyncBatch(ctx context.Context, runID int64, trigger
 	if err != nil {
 		msg := err.Error()
 		_ = s.finishJobRun(ctx, runID, false, &msg, nil)
+		s.publishJobEvent("open_orders_sync", runID, jobevents.PhaseFailed, trigger, nil, msg)
+		s.notifyJobOutcome("open_orders_sync", runID, trigger, startedAt, 0, 1, msg)
 		return
 	}
 	okCount := 0
 	errCount := 0
+	firstError := ""
 	for _, a := range accounts {
+		if ctx.Err() != nil {
+			break
+		}
 		path, err := derivationPathFromAccountID(a.ID)
 		if err != nil {
 			errCount++
+			if firstError == "" {
+				firstError = err.Error()
+			}
 			continue
 		}
 		derived, err := deriveWalletFromMnemonic(mnemonic, path)
 		if err != nil {
 			errCount++
+			if firstError == "" {
+				firstError = err.Error()
+			}
 			continue
 		}
 		if err := s.syncAccountOpenOrders(ctx, a, derived.PrivateKeyHex); err != nil {
 			errCount++
+			if firstError == "" {
+				firstError = err.Error()
+			}
 			continue
 		}
 		okCount++
@@ -157,4 +229,10 @@ func (s *Server) doOpenOrdersSyncBatch(ctx context.Context, runID int64, trigger
 	meta2, _ := json.Marshal(map[string]any{"trigger": trigger, "accounts": len(accounts), "ok": okCount, "err": errCount})
 	metaStr2 := string(meta2)
 	_ = s.finishJobRun(ctx, runID, errCount == 0, nilIfEmpty(errCount, "some accounts failed"), &metaStr2)
+	if errCount == 0 {
+		s.publishJobEvent("open_orders_sync", runID, jobevents.PhaseSucceeded, trigger, nil, "")
+	} else {
+		s.publishJobEvent("open_orders_sync", runID, jobevents.PhaseFailed, trigger, nil, "some accounts failed")
+	}
+	s.notifyJobOutcome("open_orders_sync", runID, trigger, startedAt, okCount, errCount, firstError)
 }
@@ -11,7 +11,7 @@ func (s *Server) startTradesSyncAccount(accountID string, trigger string) (int64
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "trades_sync", "account", &accountID, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "trades_sync", "account", &accountID, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
@@ -64,7 +64,7 @@ func (s *Server) startPositionsSyncAccount(accountID string, trigger string) (in
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "positions_sync", "account", &accountID, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "positions_sync", "account", &accountID, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
@@ -98,7 +98,7 @@ func (s *Server) startOpenOrdersSyncAccount(accountID string, trigger string) (i
 	defer cancel()
 	meta, _ := json.Marshal(map[string]any{"trigger": trigger})
 	metaStr := string(meta)
-	runID, err := s.insertJobRunStart(ctx, "open_orders_sync", "account", &accountID, &metaStr)
+	runID, err := s.insertJobRunStart(ctx, "open_orders_sync", "account", &accountID, &metaStr, trigger)
 	if err != nil {
 		return 0, err
 	}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"time"
+
+	"github.com/betbot/gobet/pkg/fixedpoint"
 )
 
 // Position 仓位领域模型
@@ -139,23 +141,27 @@ const (
 )
 
 // ArbitragePositionState 套利策略双向持仓状态
+//
+// QUp/QDown/CUp/CDown 用 fixedpoint.Value（1e8 精度）而非 float64 承载，因为
+// 单个周期内会被高频累加（s.rounds 可达数百次）：float64 累加在长周期下会
+// 逐步漂移，定点数用整数运算避免这个问题。
 type ArbitragePositionState struct {
-	QUp          float64   // UP 持仓数量
-	QDown        float64   // DOWN 持仓数量
-	CUp          float64   // UP 总成本（USDC）
-	CDown        float64   // DOWN 总成本（USDC）
-	Market       *Market   // 市场信息
-	CycleStartTime int64   // 周期开始时间（Unix时间戳）
+	QUp            fixedpoint.Value // UP 持仓数量
+	QDown          fixedpoint.Value // DOWN 持仓数量
+	CUp            fixedpoint.Value // UP 总成本（USDC）
+	CDown          fixedpoint.Value // DOWN 总成本（USDC）
+	Market         *Market          // 市场信息
+	CycleStartTime int64            // 周期开始时间（Unix时间戳）
 }
 
 // ProfitIfUpWin 计算若UP获胜的即时利润（USDC）
-func (s *ArbitragePositionState) ProfitIfUpWin() float64 {
-	return s.QUp*1.0 - s.CUp - s.CDown
+func (s *ArbitragePositionState) ProfitIfUpWin() fixedpoint.Value {
+	return s.QUp.Sub(s.CUp).Sub(s.CDown)
 }
 
 // ProfitIfDownWin 计算若DOWN获胜的即时利润（USDC）
-func (s *ArbitragePositionState) ProfitIfDownWin() float64 {
-	return s.QDown*1.0 - s.CUp - s.CDown
+func (s *ArbitragePositionState) ProfitIfDownWin() fixedpoint.Value {
+	return s.QDown.Sub(s.CUp).Sub(s.CDown)
 }
 
 // GetElapsedTimeAt 获取距离周期开始的已过时间（秒），以传入的 nowUnix 为准。
@@ -0,0 +1,310 @@
+// Package fixgateway 把交易所的 FIX 4.4 ExecutionReport（35=8）推送翻译成
+// 和 OrderSyncService 从 REST 轮询产生的同一套 UpdateOrderCommand / ProcessTradeCommand
+// 语义，作为 CLOB WebSocket/REST 轮询之外、延迟更低的推送式成交来源（参见 doc 10 的
+// QuickFIX/Go 集成方案）。本包只负责"接收并翻译"，ClOrdID 的分配/下单路径不在这里。
+package fixgateway
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/sirupsen/logrus"
+
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/internal/domain"
+)
+
+var log = logrus.WithField("component", "fixgateway")
+
+// fixHealthTimeout：距离上一次收到任何 FIX 消息（业务或心跳）超过这个时间，
+// 就认为会话不健康，调用方应该退回 REST 轮询。
+const fixHealthTimeout = 30 * time.Second
+
+// OrderCommandSink 是 fixgateway 向上游投递订单/成交更新的出口。fixgateway 不直接依赖
+// internal/services，避免反向依赖；TradingService 在组装时把自己包装成这个接口传进来。
+type OrderCommandSink interface {
+	// SubmitOrderUpdate 对应 OrderSyncService 从 REST 轮询产生的 UpdateOrderCommand。
+	SubmitOrderUpdate(order *domain.Order)
+	// SubmitTrade 对应 OrderSyncService 从 REST 轮询产生的 ProcessTradeCommand。
+	SubmitTrade(trade *domain.Trade)
+}
+
+// Gateway 是一个 QuickFIX initiator 会话的封装，实现 quickfix.Application。
+type Gateway struct {
+	sink OrderCommandSink
+
+	initiator *quickfix.Initiator
+
+	mu           sync.RWMutex
+	sessionID    quickfix.SessionID
+	loggedOn     bool
+	lastActivity time.Time
+
+	// idMu 保护 ClOrdID <-> 本地 OrderID 的映射。
+	idMu         sync.RWMutex
+	clOrdToLocal map[string]string
+	localToClOrd map[string]string
+}
+
+// NewGateway 创建一个还未连接的 Gateway。
+func NewGateway(sink OrderCommandSink) *Gateway {
+	return &Gateway{
+		sink:         sink,
+		clOrdToLocal: make(map[string]string),
+		localToClOrd: make(map[string]string),
+	}
+}
+
+// RegisterClOrdID 登记一次下单时使用的 ClOrdID 和本地 OrderID 的对应关系，
+// 供后续收到的 ExecutionReport 反查本地订单。
+func (g *Gateway) RegisterClOrdID(clOrdID, localOrderID string) {
+	g.idMu.Lock()
+	defer g.idMu.Unlock()
+	g.clOrdToLocal[clOrdID] = localOrderID
+	g.localToClOrd[localOrderID] = clOrdID
+}
+
+// localOrderID 反查本地 OrderID。没有登记过映射时，约定 ClOrdID 本身就是本地 OrderID
+// （即下单时直接把本地 OrderID 当作 ClOrdID 送出去，这是最常见的情况）。
+func (g *Gateway) localOrderID(clOrdID string) string {
+	g.idMu.RLock()
+	defer g.idMu.RUnlock()
+	if local, ok := g.clOrdToLocal[clOrdID]; ok {
+		return local
+	}
+	return clOrdID
+}
+
+// Start 加载 QuickFIX 配置文件（cfg 格式，SessionSettings）并启动 initiator。
+func (g *Gateway) Start(settingsPath string) error {
+	f, err := os.Open(settingsPath)
+	if err != nil {
+		return fmt.Errorf("打开 FIX 配置文件失败: %w", err)
+	}
+	defer f.Close()
+
+	settings, err := quickfix.ParseSettings(f)
+	if err != nil {
+		return fmt.Errorf("解析 FIX 配置失败: %w", err)
+	}
+
+	logFactory := quickfix.NewScreenLogFactory()
+	storeFactory := quickfix.NewMemoryStoreFactory()
+
+	initiator, err := quickfix.NewInitiator(g, storeFactory, settings, logFactory)
+	if err != nil {
+		return fmt.Errorf("创建 FIX initiator 失败: %w", err)
+	}
+
+	if err := initiator.Start(); err != nil {
+		return fmt.Errorf("启动 FIX initiator 失败: %w", err)
+	}
+
+	g.initiator = initiator
+	log.Infof("✅ [FIX] initiator 已启动: settings=%s", settingsPath)
+	return nil
+}
+
+// Stop 停止 FIX 会话。
+func (g *Gateway) Stop() {
+	if g.initiator != nil {
+		g.initiator.Stop()
+	}
+}
+
+// IsHealthy 报告 FIX 会话是否已登录且最近收到过消息。
+// startOrderStatusSyncImpl 用它来决定是否放宽 REST 轮询间隔（不会跳过轮询）。
+func (g *Gateway) IsHealthy() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if !g.loggedOn {
+		return false
+	}
+	return time.Since(g.lastActivity) < fixHealthTimeout
+}
+
+// --- quickfix.Application 接口实现 ---
+
+// OnCreate 实现 quickfix.Application。
+func (g *Gateway) OnCreate(sessionID quickfix.SessionID) {
+	g.mu.Lock()
+	g.sessionID = sessionID
+	g.mu.Unlock()
+}
+
+// OnLogon 实现 quickfix.Application。
+func (g *Gateway) OnLogon(sessionID quickfix.SessionID) {
+	g.mu.Lock()
+	g.loggedOn = true
+	g.lastActivity = time.Now()
+	g.mu.Unlock()
+	log.Infof("✅ [FIX] 会话已登录: %s", sessionID)
+}
+
+// OnLogout 实现 quickfix.Application。
+func (g *Gateway) OnLogout(sessionID quickfix.SessionID) {
+	g.mu.Lock()
+	g.loggedOn = false
+	g.mu.Unlock()
+	log.Warnf("⚠️ [FIX] 会话已登出: %s", sessionID)
+}
+
+// ToAdmin 实现 quickfix.Application（不需要改写发往对方的 admin 消息）。
+func (g *Gateway) ToAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) {}
+
+// ToApp 实现 quickfix.Application（本网关只接收 ExecutionReport，不主动发业务消息）。
+func (g *Gateway) ToApp(msg *quickfix.Message, sessionID quickfix.SessionID) error {
+	return nil
+}
+
+// FromAdmin 实现 quickfix.Application，只用来刷新心跳时间。
+func (g *Gateway) FromAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	g.mu.Lock()
+	g.lastActivity = time.Now()
+	g.mu.Unlock()
+	return nil
+}
+
+// FromApp 实现 quickfix.Application，目前只处理 ExecutionReport（35=8）。
+func (g *Gateway) FromApp(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	g.mu.Lock()
+	g.lastActivity = time.Now()
+	g.mu.Unlock()
+
+	var msgType field.MsgTypeField
+	if err := msg.Header.Get(&msgType); err != nil {
+		return err
+	}
+	if msgType.String() != string(enum.MsgType_EXECUTION_REPORT) {
+		return nil
+	}
+
+	if err := g.handleExecutionReport(msg); err != nil {
+		log.Warnf("⚠️ [FIX] 处理 ExecutionReport 失败: %v", err)
+	}
+	return nil
+}
+
+// handleExecutionReport 把 ExecutionReport 映射为 domain.Order / domain.Trade，
+// 和 OrderSyncService 从 REST 轮询得到的结果走同一套 UpdateOrderCommand / ProcessTradeCommand。
+//
+// 字段映射：
+//   - ClOrdID(11)                 -> 本地 OrderID（经 clOrdToLocal 反查）
+//   - OrdStatus(39) 0/1/2/4/8     -> domain.OrderStatus
+//   - CumQty(14)                  -> FilledSize
+//   - LeavesQty(151)              -> 不读取
+func (g *Gateway) handleExecutionReport(msg *quickfix.Message) error {
+	var clOrdIDField field.ClOrdIDField
+	if err := msg.Body.Get(&clOrdIDField); err != nil {
+		return fmt.Errorf("缺少 ClOrdID: %w", err)
+	}
+	localOrderID := g.localOrderID(clOrdIDField.String())
+
+	var ordStatusField field.OrdStatusField
+	if err := msg.Body.Get(&ordStatusField); err != nil {
+		return fmt.Errorf("缺少 OrdStatus: %w", err)
+	}
+
+	var symbolField field.SymbolField
+	_ = msg.Body.Get(&symbolField)
+
+	var sideField field.SideField
+	_ = msg.Body.Get(&sideField)
+
+	var cumQtyField field.CumQtyField
+	_ = msg.Body.Get(&cumQtyField)
+	cumQty := decimalFieldToFloat(&cumQtyField)
+
+	var avgPxField field.AvgPxField
+	_ = msg.Body.Get(&avgPxField)
+	avgPx := decimalFieldToFloat(&avgPxField)
+
+	order := &domain.Order{
+		OrderID:    localOrderID,
+		AssetID:    symbolField.String(),
+		Side:       fixSideToSide(enum.Side(sideField.String())),
+		Price:      domain.PriceFromDecimal(avgPx),
+		FilledSize: cumQty,
+		Status:     fixOrdStatusToOrderStatus(enum.OrdStatus(ordStatusField.String())),
+	}
+
+	now := time.Now()
+	switch order.Status {
+	case domain.OrderStatusFilled, domain.OrderStatusPartial:
+		order.FilledAt = &now
+	case domain.OrderStatusCanceled:
+		order.CanceledAt = &now
+	}
+
+	g.sink.SubmitOrderUpdate(order)
+
+	var execTypeField field.ExecTypeField
+	if err := msg.Body.Get(&execTypeField); err == nil && enum.ExecType(execTypeField.String()) == enum.ExecType_TRADE {
+		var lastQtyField field.LastQtyField
+		_ = msg.Body.Get(&lastQtyField)
+		lastQty := decimalFieldToFloat(&lastQtyField)
+
+		if lastQty > 0 {
+			var execIDField field.ExecIDField
+			_ = msg.Body.Get(&execIDField)
+
+			var lastPxField field.LastPxField
+			_ = msg.Body.Get(&lastPxField)
+			lastPx := decimalFieldToFloat(&lastPxField)
+
+			trade := &domain.Trade{
+				ID:      fmt.Sprintf("fix:%s", execIDField.String()),
+				OrderID: localOrderID,
+				AssetID: order.AssetID,
+				Side:    order.Side,
+				Price:   domain.PriceFromDecimal(lastPx),
+				Size:    lastQty,
+				Time:    now,
+			}
+			g.sink.SubmitTrade(trade)
+		}
+	}
+
+	return nil
+}
+
+// decimalFieldToFloat 把一个已经 Get 过的 FIX Decimal 字段转成 float64，
+// 解析失败时返回 0（ExecutionReport 里这类字段偶发缺失不应该让整条消息处理失败）。
+func decimalFieldToFloat(f fmt.Stringer) float64 {
+	v, err := strconv.ParseFloat(f.String(), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// fixOrdStatusToOrderStatus 把 FIX OrdStatus(39) 映射到 domain.OrderStatus。
+func fixOrdStatusToOrderStatus(ordStatus enum.OrdStatus) domain.OrderStatus {
+	switch ordStatus {
+	case enum.OrdStatus_NEW:
+		return domain.OrderStatusOpen
+	case enum.OrdStatus_PARTIALLY_FILLED:
+		return domain.OrderStatusPartial
+	case enum.OrdStatus_FILLED:
+		return domain.OrderStatusFilled
+	case enum.OrdStatus_CANCELED:
+		return domain.OrderStatusCanceled
+	case enum.OrdStatus_REJECTED:
+		return domain.OrderStatusFailed
+	default:
+		return domain.OrderStatusPending
+	}
+}
+
+func fixSideToSide(side enum.Side) types.Side {
+	if side == enum.Side_SELL {
+		return types.SideSell
+	}
+	return types.SideBuy
+}
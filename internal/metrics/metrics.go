@@ -8,6 +8,11 @@ var (
 	SnapshotSaves   = expvar.NewInt("snapshot_saves")
 	SnapshotLoads   = expvar.NewInt("snapshot_loads")
 
+	// 仓位对账（Data API positions 与 OrderEngine 本地仓位的周期性核对）
+	PositionReconcileRuns        = expvar.NewInt("position_reconcile_runs")
+	PositionReconcileDrift       = expvar.NewInt("position_reconcile_drift")
+	PositionReconcileCorrections = expvar.NewInt("position_reconcile_corrections")
+
 	// 交易执行（TradingService / OrderEngine 入口）
 	PlaceOrderRuns                = expvar.NewInt("place_order_runs")
 	PlaceOrderErrors              = expvar.NewInt("place_order_errors")
@@ -18,5 +23,21 @@ var (
 	PlaceOrderLatencyTotalMs      = expvar.NewInt("place_order_latency_total_ms")
 	PlaceOrderLatencySamples      = expvar.NewInt("place_order_latency_samples")
 	PlaceOrderBlockedInvalidInput = expvar.NewInt("place_order_blocked_invalid_input")
-)
 
+	// REST 对账断路器（GetOpenOrders 轮询的自适应退避 + 熔断，见 RestEndpointBreaker）
+	RestBreakerState         = expvar.NewInt("rest_breaker_state") // 0=closed 1=open 2=half_open
+	RestBreakerOpens         = expvar.NewInt("rest_breaker_opens")
+	RestBreakerProbes        = expvar.NewInt("rest_breaker_probes")
+	RestBreakerLatencyEWMAMs = expvar.NewFloat("rest_breaker_latency_ewma_ms")
+	RestBreakerErrorRateEWMA = expvar.NewFloat("rest_breaker_error_rate_ewma")
+
+	// 跨交易所对冲（unifiedarb.Strategy 的 HedgeTradingService 路径，见 hedge.go）
+	HedgeOrdersSubmitted    = expvar.NewInt("hedge_orders_submitted")
+	HedgeOrdersRateLimited  = expvar.NewInt("hedge_orders_rate_limited")
+	HedgeOrdersErrors       = expvar.NewInt("hedge_orders_errors")
+	HedgeSlippageCentsTotal = expvar.NewInt("hedge_slippage_cents_total")
+	HedgeSlippageCentsLast  = expvar.NewInt("hedge_slippage_cents_last")
+	HedgeLatencyTotalMs     = expvar.NewInt("hedge_latency_total_ms")
+	HedgeLatencyLastMs      = expvar.NewInt("hedge_latency_last_ms")
+	HedgeLatencySamples     = expvar.NewInt("hedge_latency_samples")
+)
@@ -7,9 +7,26 @@ import (
 	"net"
 	"net/http"
 	"net/http/pprof"
+	"sync"
 	"time"
 )
 
+// adminHandlers 是按 pattern 注册的 admin HTTP handler（手动触发对账/维护类操作）。
+// 调用方（比如 services.OrderSyncService）在启动时通过 RegisterAdminHandler 挂载，
+// newMux 在启动时一并注册到 metrics/debug 这同一个 mux 上，不需要单独起一个端口。
+var (
+	adminMu       sync.Mutex
+	adminHandlers = map[string]http.HandlerFunc{}
+)
+
+// RegisterAdminHandler 注册一个 admin HTTP handler，在 Start/StartAsync 启动的
+// mux 上一并挂载。重复调用同一个 pattern 会覆盖旧的 handler，方便热重载场景。
+func RegisterAdminHandler(pattern string, handler http.HandlerFunc) {
+	adminMu.Lock()
+	defer adminMu.Unlock()
+	adminHandlers[pattern] = handler
+}
+
 func newMux() *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.Handle("/debug/vars", expvar.Handler())
@@ -20,6 +37,13 @@ func newMux() *http.ServeMux {
 	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
 	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	adminMu.Lock()
+	for pattern, handler := range adminHandlers {
+		mux.HandleFunc(pattern, handler)
+	}
+	adminMu.Unlock()
+
 	return mux
 }
 
@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// larkNotifier 推送到飞书/Lark 自定义机器人 webhook。
+// 签名规则（飞书官方文档）：把 "<timestamp>\n<secret>" 当作 HmacSHA256 的 key，
+// 对空字节串计算 HMAC，再对结果做 base64 编码，作为请求体里的 sign 字段。
+type larkNotifier struct {
+	url    string
+	secret string
+}
+
+func (n *larkNotifier) Notify(ctx context.Context, event NotifyEvent) error {
+	ts := time.Now().Unix()
+	payload := map[string]any{
+		"msg_type": "text",
+		"content":  map[string]any{"text": formatEventText(event)},
+	}
+	if n.secret != "" {
+		payload["timestamp"] = strconv.FormatInt(ts, 10)
+		payload["sign"] = larkSign(ts, n.secret)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.url, body)
+}
+
+func larkSign(timestamp int64, secret string) string {
+	key := fmt.Sprintf("%d\n%s", timestamp, secret)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(nil)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,94 @@
+// Package notifier 提供可插拔的出站通知渠道（飞书/Slack/Telegram/通用 webhook），
+// 供 controlplane/server 在 job run 完成时上报结构化事件。
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Severity 是事件的严重程度，渠道按 min_severity 过滤低于该级别的事件。
+type Severity string
+
+const (
+	SeverityInfo  Severity = "info"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+)
+
+var severityRank = map[Severity]int{SeverityInfo: 0, SeverityWarn: 1, SeverityError: 2}
+
+// Meets 判断 s 是否达到或超过 min 的严重程度；未知的 min 视为 info。
+func (s Severity) Meets(min Severity) bool {
+	return severityRank[s] >= severityRank[min]
+}
+
+// NotifyEvent 是一次 job run 结束（或 test 端点）时要推送的结构化事件。
+type NotifyEvent struct {
+	JobName    string
+	RunID      int64
+	Trigger    string
+	Severity   Severity
+	Duration   time.Duration
+	OKCount    int
+	ErrCount   int
+	FirstError string
+	Message    string
+	Timestamp  time.Time
+}
+
+// Notifier 是一个可插拔的出站通知渠道。
+type Notifier interface {
+	Notify(ctx context.Context, event NotifyEvent) error
+}
+
+// ChannelType 标识 Notifier 的具体实现。
+type ChannelType string
+
+const (
+	ChannelLark     ChannelType = "lark"
+	ChannelSlack    ChannelType = "slack"
+	ChannelTelegram ChannelType = "telegram"
+	ChannelWebhook  ChannelType = "webhook"
+)
+
+// ChannelConfig 是构造一个 Notifier 所需的最小配置，对应 notifier_channels 表的一行。
+// Telegram 的 chat_id 复用 Secret 字段承载（bot token 放在 URL 里）。
+type ChannelConfig struct {
+	Type   ChannelType
+	URL    string
+	Secret string
+}
+
+// New 按 cfg.Type 构造对应的 Notifier 实现。
+func New(cfg ChannelConfig) (Notifier, error) {
+	switch cfg.Type {
+	case ChannelLark:
+		return &larkNotifier{url: cfg.URL, secret: cfg.Secret}, nil
+	case ChannelSlack:
+		return &slackNotifier{url: cfg.URL}, nil
+	case ChannelTelegram:
+		return &telegramNotifier{botURL: cfg.URL, chatID: cfg.Secret}, nil
+	case ChannelWebhook:
+		return &webhookNotifier{url: cfg.URL, secret: cfg.Secret}, nil
+	default:
+		return nil, fmt.Errorf("不支持的通知渠道类型: %q", cfg.Type)
+	}
+}
+
+func formatEventText(event NotifyEvent) string {
+	status := "succeeded"
+	if event.ErrCount > 0 {
+		status = "failed"
+	}
+	text := fmt.Sprintf("[%s] job=%s run_id=%d trigger=%s duration=%s ok=%d err=%d",
+		status, event.JobName, event.RunID, event.Trigger, event.Duration.Round(time.Second), event.OKCount, event.ErrCount)
+	if event.FirstError != "" {
+		text += fmt.Sprintf(" first_error=%q", event.FirstError)
+	}
+	if event.Message != "" {
+		text += " msg=" + event.Message
+	}
+	return text
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter 是一个按 key 独立计数的滑动窗口限流器：每个 key 在 window 内
+// 最多允许 limit 次 Allow 返回 true。用于给每个通知渠道单独限流。
+type RateLimiter struct {
+	mu     sync.Mutex
+	window time.Duration
+	hits   map[string][]time.Time
+}
+
+// NewRateLimiter 创建一个滑动窗口为 window 的限流器。
+func NewRateLimiter(window time.Duration) *RateLimiter {
+	return &RateLimiter{window: window, hits: make(map[string][]time.Time)}
+}
+
+// Allow 判断 key 在当前窗口内是否还允许再发一次；limit<=0 表示不限流。
+// 允许时会记录本次时间，计入后续窗口判断。
+func (r *RateLimiter) Allow(key string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+	now := time.Now()
+	cutoff := now.Add(-r.window)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.hits[key][:0]
+	for _, t := range r.hits[key] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) >= limit {
+		r.hits[key] = kept
+		return false
+	}
+	r.hits[key] = append(kept, now)
+	return true
+}
@@ -0,0 +1,19 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// slackNotifier 推送到 Slack Incoming Webhook。
+type slackNotifier struct {
+	url string
+}
+
+func (n *slackNotifier) Notify(ctx context.Context, event NotifyEvent) error {
+	body, err := json.Marshal(map[string]any{"text": formatEventText(event)})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.url, body)
+}
@@ -0,0 +1,25 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// telegramNotifier 调用 Telegram Bot API 的 sendMessage。
+// botURL 是完整的 https://api.telegram.org/bot<token>/sendMessage 地址，
+// chatID 是目标会话 ID。
+type telegramNotifier struct {
+	botURL string
+	chatID string
+}
+
+func (n *telegramNotifier) Notify(ctx context.Context, event NotifyEvent) error {
+	body, err := json.Marshal(map[string]any{
+		"chat_id": n.chatID,
+		"text":    formatEventText(event),
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.botURL, body)
+}
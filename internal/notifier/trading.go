@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TradingNotifier 把策略层面的事件（订单成交/对冲达成/风控熔断/一般错误）
+// 翻译成 NotifyEvent，复用已有的 Notifier 渠道实现（飞书/Slack/Telegram/
+// webhook），策略代码不需要自己拼 NotifyEvent 的字段。
+type TradingNotifier struct {
+	Notifier Notifier
+	// JobName 挂在 NotifyEvent.JobName 上，通常用策略 ID（如 "rangeboth"）。
+	JobName string
+}
+
+// NewTradingNotifier 包装一个已有的 Notifier，jobName 通常传策略 ID。
+func NewTradingNotifier(n Notifier, jobName string) *TradingNotifier {
+	return &TradingNotifier{Notifier: n, JobName: jobName}
+}
+
+func (t *TradingNotifier) notify(ctx context.Context, severity Severity, msg string) error {
+	if t == nil || t.Notifier == nil {
+		return nil
+	}
+	return t.Notifier.Notify(ctx, NotifyEvent{
+		JobName:   t.JobName,
+		Severity:  severity,
+		Message:   msg,
+		Timestamp: time.Now(),
+	})
+}
+
+// NotifyOrderFilled 上报一笔订单成交（或在没有独立成交确认机制的策略里，
+// 代表订单已被交易所接受提交）。
+func (t *TradingNotifier) NotifyOrderFilled(ctx context.Context, marketSlug, assetID string, side string, price, size float64) error {
+	return t.notify(ctx, SeverityInfo, fmt.Sprintf(
+		"order filled: market=%s asset=%s side=%s price=%.4f size=%.4f", marketSlug, assetID, side, price, size))
+}
+
+// NotifyHedgeAchieved 上报一次对冲/双边建仓达成（例如双边挂单都成功提交，
+// 或补单后 min(Profit_up, Profit_down) 达到目标值）。
+func (t *TradingNotifier) NotifyHedgeAchieved(ctx context.Context, marketSlug string, minProfit float64) error {
+	return t.notify(ctx, SeverityInfo, fmt.Sprintf(
+		"hedge achieved: market=%s minProfit=%.4f", marketSlug, minProfit))
+}
+
+// NotifyRiskBreach 上报一次风控熔断（止损/权益回撤超限等）。
+func (t *TradingNotifier) NotifyRiskBreach(ctx context.Context, reason string, drawdown float64) error {
+	return t.notify(ctx, SeverityError, fmt.Sprintf(
+		"risk breach: reason=%s drawdown=%.4f", reason, drawdown))
+}
+
+// NotifyError 上报一个一般性的策略内部错误，where 标识发生的位置（如策略 ID
+// 或调用阶段），方便在通知渠道里区分来源。
+func (t *TradingNotifier) NotifyError(ctx context.Context, where string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return t.notify(ctx, SeverityError, fmt.Sprintf("error: context=%s err=%v", where, err))
+}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// webhookNotifier 是通用 JSON POST webhook：整个 NotifyEvent 序列化为 body。
+// 配置了 secret 时额外带上 X-Signature: hex(HMAC-SHA256(body, secret))，供接收方校验来源。
+type webhookNotifier struct {
+	url    string
+	secret string
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, event NotifyEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var headers map[string]string
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(body)
+		headers = map[string]string{"X-Signature": hex.EncodeToString(mac.Sum(nil))}
+	}
+	return postJSONWithHeaders(ctx, n.url, body, headers)
+}
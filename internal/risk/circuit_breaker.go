@@ -2,6 +2,7 @@ package risk
 
 import (
 	"fmt"
+	"math"
 	"sync/atomic"
 	"time"
 
@@ -25,6 +26,10 @@ type CircuitBreakerConfig struct {
 	// CooldownSeconds 熔断后的冷却时间（秒）。冷却时间后自动尝试恢复。
 	// 0 表示不自动恢复，需要手动调用 Resume()。
 	CooldownSeconds int64
+
+	// StopLossFraction 账户权益相对历史最高点的最大回撤比例（0.2 表示回撤超过
+	// 20% 就熔断）。<=0 表示不启用，由上层定期调用 UpdateEquity() 驱动。
+	StopLossFraction float64
 }
 
 // CircuitBreaker 高频快路径使用原子变量，低频配置更新使用原子值。
@@ -46,6 +51,16 @@ type CircuitBreaker struct {
 	// 配置（用 atomic.Value 也可以；这里用原子字段，保持简单）
 	maxConsecutiveErrors atomic.Int64
 	dailyLossLimitCents  atomic.Int64
+
+	// 权益回撤止损：equityHighWaterCents 是见过的历史最高账户权益（分），
+	// stopLossFractionBits 是 StopLossFraction 的 math.Float64bits 编码。
+	equityHighWaterCents atomic.Int64
+	stopLossFractionBits atomic.Uint64
+
+	// haltHandler 在熔断触发的瞬间被调用一次，用于撤销全部挂单等副作用；
+	// 由上层（通常是构造 TradingService 时）通过 SetHaltHandler 注册，
+	// 不注册则不产生额外副作用。
+	haltHandler atomic.Value // func(reason string)
 }
 
 func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
@@ -63,6 +78,75 @@ func (cb *CircuitBreaker) SetConfig(cfg CircuitBreakerConfig) {
 	if cfg.CooldownSeconds > 0 {
 		cb.cooldownSeconds.Store(cfg.CooldownSeconds)
 	}
+	cb.stopLossFractionBits.Store(math.Float64bits(cfg.StopLossFraction))
+}
+
+// SetStopLossFraction 只更新权益回撤止损比例，不影响 MaxConsecutiveErrors/
+// DailyLossLimitCents/CooldownSeconds 等其它已经生效的配置（调用 SetConfig
+// 会把整份配置覆盖掉，容易误伤其它字段）。
+func (cb *CircuitBreaker) SetStopLossFraction(fraction float64) {
+	if cb == nil {
+		return
+	}
+	cb.stopLossFractionBits.Store(math.Float64bits(fraction))
+}
+
+// SetHaltHandler 注册熔断触发时要执行的副作用（如撤销全部挂单），h 为 nil
+// 时取消注册。只保留最近一次注册的 handler，和 SetConfig 一样是低频调用。
+func (cb *CircuitBreaker) SetHaltHandler(h func(reason string)) {
+	if cb == nil {
+		return
+	}
+	cb.haltHandler.Store(h)
+}
+
+// triggerHalt 把断路器状态置为熔断（若尚未熔断），记录熔断时间并调用已注册
+// 的 haltHandler。reason 只用于日志和 handler，不影响熔断逻辑本身。
+func (cb *CircuitBreaker) triggerHalt(reason string) {
+	if !cb.halted.CompareAndSwap(false, true) {
+		return
+	}
+	cb.lastHaltedAt.Store(time.Now().Unix())
+	log.Warnf("🚨 Circuit Breaker 打开: %s", reason)
+	if v := cb.haltHandler.Load(); v != nil {
+		if h, ok := v.(func(string)); ok && h != nil {
+			h(reason)
+		}
+	}
+}
+
+// UpdateEquity 由上层（通常是定期巡检任务）喂入账户当前总权益（USDC）。
+// 维护历史最高权益，一旦相对最高点的回撤比例达到 StopLossFraction 就立即熔断。
+// StopLossFraction <= 0 时该检查关闭。
+func (cb *CircuitBreaker) UpdateEquity(equity float64) {
+	if cb == nil {
+		return
+	}
+	frac := math.Float64frombits(cb.stopLossFractionBits.Load())
+	if frac <= 0 {
+		return
+	}
+
+	cents := int64(math.Round(equity * 100))
+	for {
+		hw := cb.equityHighWaterCents.Load()
+		if cents <= hw {
+			break
+		}
+		if cb.equityHighWaterCents.CompareAndSwap(hw, cents) {
+			break
+		}
+	}
+
+	hw := cb.equityHighWaterCents.Load()
+	if hw <= 0 {
+		return
+	}
+	drawdown := float64(hw-cents) / float64(hw)
+	if drawdown >= frac {
+		cb.triggerHalt(fmt.Sprintf("权益回撤 %.2f%% 达到止损线 %.2f%% (高点=%.2f, 当前=%.2f)",
+			drawdown*100, frac*100, float64(hw)/100, equity))
+	}
 }
 
 // Halt 手动熔断（如人工介入或检测到严重异常）。
@@ -129,10 +213,7 @@ func (cb *CircuitBreaker) AllowTrading() error {
 		errors := cb.consecutiveErrors.Load()
 		if errors >= maxErr {
 			// 达到错误阈值，触发熔断
-			if cb.halted.CompareAndSwap(false, true) {
-				cb.lastHaltedAt.Store(time.Now().Unix())
-				log.Warnf("🚨 Circuit Breaker 打开：连续错误达到阈值 (errors=%d/%d)", errors, maxErr)
-			}
+			cb.triggerHalt(fmt.Sprintf("连续错误达到阈值 (errors=%d/%d)", errors, maxErr))
 			return ErrCircuitBreakerOpen
 		}
 	}
@@ -144,10 +225,7 @@ func (cb *CircuitBreaker) AllowTrading() error {
 		pnl := cb.dailyPnlCents.Load()
 		if pnl <= -limit {
 			// 达到亏损阈值，触发熔断
-			if cb.halted.CompareAndSwap(false, true) {
-				cb.lastHaltedAt.Store(time.Now().Unix())
-				log.Warnf("🚨 Circuit Breaker 打开：当日亏损达到阈值 (pnl=%dc, limit=%dc)", pnl, limit)
-			}
+			cb.triggerHalt(fmt.Sprintf("当日亏损达到阈值 (pnl=%dc, limit=%dc)", pnl, limit))
 			return ErrCircuitBreakerOpen
 		}
 	}
@@ -226,6 +226,16 @@ type OrderEngine struct {
 
 	// 周期代号（generation）：每次周期切换递增，用于丢弃旧周期的异步回流命令
 	generation int64
+
+	// 事务性消息 outbox（可选）：UpdateOrderCommand/ProcessTradeCommand 提交时落一条待确认事件，
+	// 详见 order_outbox.go。nil 时完全不影响 OrderEngine 的行为。
+	outbox *OrderOutbox
+}
+
+// SetOutbox 挂载 OrderOutbox。必须在 Run 之前调用（或至少在并发 SubmitCommand 之前），
+// 因为 outbox 字段本身没有加锁保护。
+func (e *OrderEngine) SetOutbox(outbox *OrderOutbox) {
+	e.outbox = outbox
 }
 
 // NewOrderEngine 创建新的订单引擎
@@ -249,6 +259,10 @@ func NewOrderEngine(ioExecutor *ioExecutor, minOrderSize float64, dryRun bool) *
 
 // SubmitCommand 提交命令到引擎（线程安全）
 func (e *OrderEngine) SubmitCommand(cmd OrderCommand) {
+	// outbox 写入在调用方 goroutine 里同步完成（而不是在 engine 的单 goroutine 里），
+	// 这样才能在"写事件"和"命令真正被引擎处理"之间建模出两阶段消息的半消息窗口。
+	e.outbox.RecordCommand(cmd)
+
 	select {
 	case e.cmdChan <- cmd:
 		// 命令已提交
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/internal/domain"
+)
+
+// 撮合代价权重：α 作用于数量差（单位：数量），β 作用于下单时间差（单位：秒）。
+// 价格差本身以「分」为单位，量级和这两个系数配合下给出的是一个可比较的综合代价。
+const (
+	orderMatchSizeWeight = 0.5
+	orderMatchAgeWeight  = 0.01
+
+	// orderMatchMaxCost 是撮合代价阈值：超过这个值的指派会被拒绝，
+	// 对应的本地订单退回"未匹配"，交由调用方走原来的失败/已成交判定。
+	orderMatchMaxCost = 5.0
+
+	// orderMatchInfeasibleCost 用于 assetID/side 不匹配或价格不在允许区间的候选，
+	// 必须显著大于 orderMatchMaxCost，让匈牙利算法宁可把该行/列分配给哑行/哑列也不会选中它。
+	orderMatchInfeasibleCost = 1e9
+
+	// orderMatchDummyCost 是方阵补齐时哑行/哑列的代价，需要严格小于 orderMatchInfeasibleCost，
+	// 否则不可行的候选对可能反而被算法当作"代价更低"的选择。
+	orderMatchDummyCost = 1e6
+)
+
+// orderMatchAssignment 是匈牙利算法为某个本地订单找到的指派结果。
+type orderMatchAssignment struct {
+	APIOrder      types.OpenOrder
+	APIPriceCents int
+	Cost          float64
+}
+
+// matchOrdersHungarian 在候选的本地订单与交易所开放订单之间做一次全局最优的一对一指派，
+// 取代逐单贪心（谁先遍历到价格最接近的订单就占用谁）的旧逻辑，
+// 避免两个价格相近的本地订单抢到同一个交易所订单 ID。
+//
+// 返回值以本地订单的 OrderID 为 key；代价超过 orderMatchMaxCost 或没有可行候选的本地订单
+// 不会出现在返回值中，调用方应将其视为未匹配。
+func matchOrdersHungarian(localOrders []*domain.Order, apiOrders []types.OpenOrder) map[string]orderMatchAssignment {
+	result := make(map[string]orderMatchAssignment)
+	m := len(localOrders)
+	n := len(apiOrders)
+	if m == 0 || n == 0 {
+		return result
+	}
+
+	size := m
+	if n > size {
+		size = n
+	}
+
+	cost := make([][]float64, size)
+	for i := range cost {
+		cost[i] = make([]float64, size)
+		for j := range cost[i] {
+			cost[i][j] = orderMatchDummyCost
+		}
+	}
+
+	for i, local := range localOrders {
+		for j, api := range apiOrders {
+			cost[i][j] = orderMatchCost(local, api)
+		}
+	}
+
+	rowToCol := hungarianAssign(cost)
+
+	for i, local := range localOrders {
+		j := rowToCol[i]
+		if j < 0 || j >= n {
+			continue
+		}
+		c := cost[i][j]
+		if c > orderMatchMaxCost {
+			continue
+		}
+		api := apiOrders[j]
+		apiPriceCents := 0
+		if apiPrice, err := strconv.ParseFloat(api.Price, 64); err == nil {
+			apiPriceCents = int(math.Round(apiPrice * 100))
+		}
+		result[local.OrderID] = orderMatchAssignment{
+			APIOrder:      api,
+			APIPriceCents: apiPriceCents,
+			Cost:          c,
+		}
+	}
+
+	return result
+}
+
+// orderMatchCost 计算一对（本地订单，交易所开放订单）的撮合代价。
+// assetID/side 不一致，或价格不在本地订单所属档位（入场 60-90 分 / 对冲 1-40 分）内时，
+// 返回 orderMatchInfeasibleCost 以排除该候选。
+func orderMatchCost(local *domain.Order, api types.OpenOrder) float64 {
+	if api.AssetID != local.AssetID || api.Side != string(local.Side) {
+		return orderMatchInfeasibleCost
+	}
+
+	apiPrice, err := strconv.ParseFloat(api.Price, 64)
+	if err != nil {
+		return orderMatchInfeasibleCost
+	}
+	apiPriceCents := int(math.Round(apiPrice * 100))
+
+	if local.IsEntryOrder {
+		if apiPriceCents < 60 || apiPriceCents > 90 {
+			return orderMatchInfeasibleCost
+		}
+	} else {
+		if apiPriceCents < 1 || apiPriceCents > 40 {
+			return orderMatchInfeasibleCost
+		}
+	}
+
+	priceDiff := math.Abs(float64(apiPriceCents - local.Price.Cents))
+
+	apiOriginalSize, _ := strconv.ParseFloat(api.OriginalSize, 64)
+	apiSizeMatched, _ := strconv.ParseFloat(api.SizeMatched, 64)
+	apiRemainingSize := apiOriginalSize - apiSizeMatched
+	localRemainingSize := local.Size - local.FilledSize
+	sizeDiff := math.Abs(localRemainingSize - apiRemainingSize)
+
+	ageDiff := math.Abs(time.Unix(api.CreatedAt, 0).Sub(local.CreatedAt).Seconds())
+
+	return priceDiff + orderMatchSizeWeight*sizeDiff + orderMatchAgeWeight*ageDiff
+}
+
+// hungarianAssign 对 n x n 的代价矩阵求解最小费用的一对一指派（Kuhn-Munkres 算法，O(n^3)）。
+// 返回 rowToCol，rowToCol[i] 是分配给第 i 行的列索引（从 0 开始）。
+func hungarianAssign(cost [][]float64) []int {
+	n := len(cost)
+	const inf = math.MaxFloat64 / 2
+
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	p := make([]int, n+1) // p[j]：当前分配给第 j 列的行（1-indexed），0 表示未分配
+	way := make([]int, n+1)
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minV := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := 0; j <= n; j++ {
+			minV[j] = inf
+		}
+
+		for {
+			used[j0] = true
+			i0 := p[j0]
+			delta := inf
+			j1 := -1
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				cur := cost[i0-1][j-1] - u[i0] - v[j]
+				if cur < minV[j] {
+					minV[j] = cur
+					way[j] = j0
+				}
+				if minV[j] < delta {
+					delta = minV[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minV[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	rowToCol := make([]int, n)
+	for i := range rowToCol {
+		rowToCol[i] = -1
+	}
+	for j := 1; j <= n; j++ {
+		if p[j] != 0 {
+			rowToCol[p[j]-1] = j - 1
+		}
+	}
+	return rowToCol
+}
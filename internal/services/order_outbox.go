@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var outboxLog = logrus.WithField("component", "order_outbox")
+
+// OutboxEventType 区分落盘事件对应的命令类型。
+type OutboxEventType string
+
+const (
+	OutboxEventUpdateOrder  OutboxEventType = "update_order"
+	OutboxEventProcessTrade OutboxEventType = "process_trade"
+)
+
+// OutboxEvent 是 OrderOutbox 中的一条"半消息"：UpdateOrderCommand/ProcessTradeCommand
+// 提交给 OrderEngine 的同时落盘一条对应事件。确认/回滚由 OrderSyncService 的对账阶段驱动
+// （见 trading_sync.go 的 syncAllOrderStatusImpl），发布给下游消费者由 StartPublisher 驱动。
+type OutboxEvent struct {
+	ID          string          `json:"id"`
+	Type        OutboxEventType `json:"type"`
+	OrderID     string          `json:"order_id"`
+	AssetID     string          `json:"asset_id"`
+	Side        string          `json:"side"`
+	Status      string          `json:"status"`
+	Size        float64         `json:"size"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Confirmed   bool            `json:"confirmed"`
+	ConfirmedAt *time.Time      `json:"confirmed_at,omitempty"`
+	RolledBack  bool            `json:"rolled_back"`
+}
+
+// OutboxStore 持久化 outbox 事件。bolt/sqlite/pg 等实现都满足这个接口；
+// 默认实现 JSONFileOutboxStore 复用仓库里"先写临时文件再 rename"的落盘方式（见 pkg/persistence）。
+type OutboxStore interface {
+	Append(event *OutboxEvent) error
+	Pending() ([]*OutboxEvent, error)
+	MarkConfirmed(id string) error
+	MarkRolledBack(id string) error
+}
+
+// OutboxBroker 把 outbox 事件投递给下游（NATS JetStream / RocketMQ / Kafka 等），供风控/PnL/
+// dashboard 消费。Publish 返回错误时上层按 at-least-once 语义重试，不会从 store 里删除事件。
+type OutboxBroker interface {
+	Publish(ctx context.Context, event *OutboxEvent) error
+}
+
+// LogBroker 是默认 broker：只记录日志，不依赖任何具体消息中间件。生产环境应替换为
+// NATS JetStream / RocketMQ / Kafka 的实现——它们只需实现同一个 OutboxBroker 接口即可热插拔。
+type LogBroker struct{}
+
+// Publish 实现 OutboxBroker。
+func (LogBroker) Publish(ctx context.Context, event *OutboxEvent) error {
+	outboxLog.Debugf("📤 [outbox] 投递事件（log broker 占位实现）: id=%s, type=%s, orderID=%s, status=%s",
+		event.ID, event.Type, event.OrderID, event.Status)
+	return nil
+}
+
+// JSONFileOutboxStore 是基于单个 JSON 文件的 OutboxStore 实现（内存索引 + 整体 rewrite），
+// 适合单进程部署；多副本/高吞吐场景应换成 bolt/sqlite/pg 实现（替换时只需满足 OutboxStore 接口）。
+type JSONFileOutboxStore struct {
+	mu     sync.Mutex
+	path   string
+	events map[string]*OutboxEvent
+	order  []string // 插入顺序，保证 Pending() 的返回顺序稳定
+}
+
+// NewJSONFileOutboxStore 创建基于文件的 outbox store，启动时从磁盘恢复未完成事件。
+func NewJSONFileOutboxStore(path string) (*JSONFileOutboxStore, error) {
+	s := &JSONFileOutboxStore{
+		path:   path,
+		events: make(map[string]*OutboxEvent),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *JSONFileOutboxStore) load() error {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if len(b) == 0 {
+		return nil
+	}
+	var events []*OutboxEvent
+	if err := json.Unmarshal(b, &events); err != nil {
+		return err
+	}
+	for _, e := range events {
+		if e == nil {
+			continue
+		}
+		s.events[e.ID] = e
+		s.order = append(s.order, e.ID)
+	}
+	return nil
+}
+
+// persistLocked 把当前内存状态整体落盘。调用方必须持有 s.mu。
+func (s *JSONFileOutboxStore) persistLocked() error {
+	events := make([]*OutboxEvent, 0, len(s.order))
+	for _, id := range s.order {
+		if e, ok := s.events[id]; ok {
+			events = append(events, e)
+		}
+	}
+	b, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Append 实现 OutboxStore。
+func (s *JSONFileOutboxStore) Append(event *OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.events[event.ID]; !exists {
+		s.order = append(s.order, event.ID)
+	}
+	s.events[event.ID] = event
+	return s.persistLocked()
+}
+
+// Pending 实现 OutboxStore。
+func (s *JSONFileOutboxStore) Pending() ([]*OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*OutboxEvent, 0)
+	for _, id := range s.order {
+		e := s.events[id]
+		if e != nil && !e.Confirmed && !e.RolledBack {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// MarkConfirmed 实现 OutboxStore。
+func (s *JSONFileOutboxStore) MarkConfirmed(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.events[id]
+	if !ok || e.Confirmed {
+		return nil
+	}
+	now := time.Now()
+	e.Confirmed = true
+	e.ConfirmedAt = &now
+	return s.persistLocked()
+}
+
+// MarkRolledBack 实现 OutboxStore。
+func (s *JSONFileOutboxStore) MarkRolledBack(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.events[id]
+	if !ok || e.RolledBack {
+		return nil
+	}
+	e.RolledBack = true
+	return s.persistLocked()
+}
+
+// OrderOutbox 是事务性 outbox：UpdateOrderCommand/ProcessTradeCommand 提交给 OrderEngine 的
+// 同时记录一条待确认事件（"半消息"），由 StartPublisher 以 at-least-once 语义投递给下游 broker，
+// 再由 OrderSyncService 的对账阶段确认/回滚本地状态（两阶段事务性消息的落地版本）。
+type OrderOutbox struct {
+	store  OutboxStore
+	broker OutboxBroker
+
+	mu        sync.Mutex
+	byOrderID map[string][]string // orderID -> outbox 事件 ID（按时间顺序），供对账阶段反查
+}
+
+// NewOrderOutbox 创建 OrderOutbox。store 为 nil 时整个 outbox 退化为空操作（不阻塞交易主流程）；
+// broker 为 nil 时使用只打日志的 LogBroker。
+func NewOrderOutbox(store OutboxStore, broker OutboxBroker) *OrderOutbox {
+	if broker == nil {
+		broker = LogBroker{}
+	}
+	return &OrderOutbox{
+		store:     store,
+		broker:    broker,
+		byOrderID: make(map[string][]string),
+	}
+}
+
+// RecordCommand 为 UpdateOrderCommand/ProcessTradeCommand 写一条 pending outbox 事件；
+// 其他命令类型会被忽略。必须是 best-effort：outbox 写入失败不能影响 OrderEngine 的正常处理，
+// 只记录一条告警日志。
+func (o *OrderOutbox) RecordCommand(cmd OrderCommand) {
+	if o == nil || o.store == nil || cmd == nil {
+		return
+	}
+
+	var event *OutboxEvent
+	switch c := cmd.(type) {
+	case *UpdateOrderCommand:
+		if c.Order == nil {
+			return
+		}
+		event = &OutboxEvent{
+			ID:        fmt.Sprintf("outbox_%s_%s", OutboxEventUpdateOrder, c.id),
+			Type:      OutboxEventUpdateOrder,
+			OrderID:   c.Order.OrderID,
+			AssetID:   c.Order.AssetID,
+			Side:      string(c.Order.Side),
+			Status:    string(c.Order.Status),
+			Size:      c.Order.Size,
+			CreatedAt: time.Now(),
+		}
+	case *ProcessTradeCommand:
+		if c.Trade == nil {
+			return
+		}
+		event = &OutboxEvent{
+			ID:        fmt.Sprintf("outbox_%s_%s", OutboxEventProcessTrade, c.id),
+			Type:      OutboxEventProcessTrade,
+			OrderID:   c.Trade.OrderID,
+			AssetID:   c.Trade.AssetID,
+			Side:      string(c.Trade.Side),
+			Status:    "trade",
+			Size:      c.Trade.Size,
+			CreatedAt: time.Now(),
+		}
+	default:
+		return
+	}
+
+	if err := o.store.Append(event); err != nil {
+		outboxLog.Warnf("⚠️ [outbox] 写入事件失败: id=%s, error=%v", event.ID, err)
+		return
+	}
+
+	if event.OrderID != "" {
+		o.mu.Lock()
+		o.byOrderID[event.OrderID] = append(o.byOrderID[event.OrderID], event.ID)
+		o.mu.Unlock()
+	}
+}
+
+// StartPublisher 启动 publisher goroutine：周期性把未终结（未确认/未回滚）的事件投递给 broker。
+// at-least-once：Publish 失败时本轮跳过，下一轮继续重试，不会从 store 里删除事件。
+func (o *OrderOutbox) StartPublisher(ctx context.Context, interval time.Duration) {
+	if o == nil || o.store == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.publishPending(ctx)
+		}
+	}
+}
+
+func (o *OrderOutbox) publishPending(ctx context.Context) {
+	events, err := o.store.Pending()
+	if err != nil {
+		outboxLog.Warnf("⚠️ [outbox] 读取待发布事件失败: %v", err)
+		return
+	}
+	for _, e := range events {
+		if err := o.broker.Publish(ctx, e); err != nil {
+			outboxLog.Warnf("⚠️ [outbox] 投递事件失败（将在下一轮重试）: id=%s, error=%v", e.ID, err)
+		}
+	}
+}
+
+// UnconfirmedOlderThan 返回指定 orderID 最新一条仍处于 pending（未确认/未回滚）状态、且距创建
+// 超过 minAge 的 outbox 事件；没有这样的事件则返回 nil。用于 syncAllOrderStatusImpl 的对账阶段
+// 判断是否该把本地订单回滚为 OrderStatusFailed（替代原先"不在开放列表就直接判失败"的启发式）。
+func (o *OrderOutbox) UnconfirmedOlderThan(orderID string, minAge time.Duration) *OutboxEvent {
+	if o == nil || o.store == nil || orderID == "" {
+		return nil
+	}
+	o.mu.Lock()
+	ids := append([]string(nil), o.byOrderID[orderID]...)
+	o.mu.Unlock()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	pending, err := o.store.Pending()
+	if err != nil {
+		return nil
+	}
+	pendingByID := make(map[string]*OutboxEvent, len(pending))
+	for _, e := range pending {
+		pendingByID[e.ID] = e
+	}
+
+	// 取这个 orderID 最新一条仍是 pending 的事件：如果它还没到超时时间，就不用再往旧事件找。
+	for i := len(ids) - 1; i >= 0; i-- {
+		e, ok := pendingByID[ids[i]]
+		if !ok {
+			continue
+		}
+		if time.Since(e.CreatedAt) >= minAge {
+			return e
+		}
+		return nil
+	}
+	return nil
+}
+
+// Confirm 把某个 orderID 名下所有未确认的 outbox 事件标记为已确认（交易所侧已证实该订单状态）。
+func (o *OrderOutbox) Confirm(orderID string) {
+	if o == nil || o.store == nil || orderID == "" {
+		return
+	}
+	o.mu.Lock()
+	ids := append([]string(nil), o.byOrderID[orderID]...)
+	o.mu.Unlock()
+	for _, id := range ids {
+		if err := o.store.MarkConfirmed(id); err != nil {
+			outboxLog.Warnf("⚠️ [outbox] 标记确认失败: id=%s, error=%v", id, err)
+		}
+	}
+}
+
+// RollBack 把一条 outbox 事件标记为已回滚（对应的本地订单已被置为 OrderStatusFailed）。
+func (o *OrderOutbox) RollBack(event *OutboxEvent) {
+	if o == nil || o.store == nil || event == nil {
+		return
+	}
+	if err := o.store.MarkRolledBack(event.ID); err != nil {
+		outboxLog.Warnf("⚠️ [outbox] 标记回滚失败: id=%s, error=%v", event.ID, err)
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/internal/metrics"
+)
+
+// restBreakerState 是 RestEndpointBreaker 的三态状态机。
+type restBreakerState int
+
+const (
+	restBreakerClosed restBreakerState = iota
+	restBreakerOpen
+	restBreakerHalfOpen
+)
+
+const (
+	// restBreakerFailureThreshold：滑动窗口内累计这么多次失败就跳闸。
+	restBreakerFailureThreshold = 5
+	// restBreakerFailureWindow：统计连续失败次数的滑动窗口。
+	restBreakerFailureWindow = 30 * time.Second
+	// restBreakerCooldown：跳闸后多久放一个探测请求进来尝试半开。
+	restBreakerCooldown = 10 * time.Second
+	// restBreakerEWMAAlpha：延迟/错误率 EWMA 的平滑系数。
+	restBreakerEWMAAlpha = 0.2
+)
+
+// RestEndpointBreaker 是围绕单个 REST 端点（这里是 clobClient.GetOpenOrders）的
+// 自适应退避 + 熔断控制器：按连续失败次数指数拉长轮询间隔（由调用方按上限裁剪），
+// 并在短时间内失败过多时直接跳闸、只放一个探测请求进来验证端点是否恢复。
+// 并发调用者通过 Allow/RecordSuccess/RecordFailure 三个方法驱动状态机。
+type RestEndpointBreaker struct {
+	mu sync.Mutex
+
+	state             restBreakerState
+	consecutiveErrors int
+	failureTimes      []time.Time
+	openedAt          time.Time
+	probeInFlight     bool
+
+	latencyEWMAMs  float64
+	errorRateEWMA  float64
+	hasLatencyData bool
+}
+
+// NewRestEndpointBreaker 创建一个初始处于 closed 状态的断路器。
+func NewRestEndpointBreaker() *RestEndpointBreaker {
+	return &RestEndpointBreaker{state: restBreakerClosed}
+}
+
+// Allow 报告调用方是否可以发起一次真正的 REST 调用。
+// closed 状态下总是放行；open 状态下在冷却期内拒绝，冷却期过后放一个探测请求进入
+// half-open；half-open 状态下只允许一个探测请求在途，避免探测请求本身扎堆。
+func (b *RestEndpointBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case restBreakerClosed:
+		return true
+	case restBreakerOpen:
+		if time.Since(b.openedAt) < restBreakerCooldown {
+			return false
+		}
+		b.state = restBreakerHalfOpen
+		b.probeInFlight = true
+		metrics.RestBreakerState.Set(int64(restBreakerHalfOpen))
+		metrics.RestBreakerProbes.Add(1)
+		return true
+	case restBreakerHalfOpen:
+		if b.probeInFlight {
+			return false
+		}
+		b.probeInFlight = true
+		metrics.RestBreakerProbes.Add(1)
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess 记录一次成功的调用及其延迟，更新 EWMA 并在 half-open 探测成功时闭合断路器。
+func (b *RestEndpointBreaker) RecordSuccess(latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.recordLatencyLocked(latency)
+	b.recordOutcomeLocked(false)
+
+	b.consecutiveErrors = 0
+	b.failureTimes = nil
+
+	if b.state != restBreakerClosed {
+		b.state = restBreakerClosed
+		b.probeInFlight = false
+		metrics.RestBreakerState.Set(int64(restBreakerClosed))
+	}
+}
+
+// RecordFailure 记录一次失败的调用：累加连续失败计数，清理窗口外的旧失败，
+// 超过阈值则跳闸；half-open 探测失败则直接打回 open 重新冷却。
+func (b *RestEndpointBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.recordOutcomeLocked(true)
+	b.consecutiveErrors++
+
+	now := time.Now()
+	b.failureTimes = append(b.failureTimes, now)
+	cutoff := now.Add(-restBreakerFailureWindow)
+	kept := b.failureTimes[:0]
+	for _, t := range b.failureTimes {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	b.failureTimes = kept
+
+	switch b.state {
+	case restBreakerHalfOpen:
+		b.openLocked(now)
+	case restBreakerClosed:
+		if len(b.failureTimes) >= restBreakerFailureThreshold {
+			b.openLocked(now)
+		}
+	}
+}
+
+func (b *RestEndpointBreaker) openLocked(now time.Time) {
+	b.state = restBreakerOpen
+	b.openedAt = now
+	b.probeInFlight = false
+	metrics.RestBreakerState.Set(int64(restBreakerOpen))
+	metrics.RestBreakerOpens.Add(1)
+}
+
+func (b *RestEndpointBreaker) recordLatencyLocked(latency time.Duration) {
+	ms := float64(latency.Milliseconds())
+	if !b.hasLatencyData {
+		b.latencyEWMAMs = ms
+		b.hasLatencyData = true
+	} else {
+		b.latencyEWMAMs = restBreakerEWMAAlpha*ms + (1-restBreakerEWMAAlpha)*b.latencyEWMAMs
+	}
+	metrics.RestBreakerLatencyEWMAMs.Set(b.latencyEWMAMs)
+}
+
+func (b *RestEndpointBreaker) recordOutcomeLocked(failed bool) {
+	outcome := 0.0
+	if failed {
+		outcome = 1.0
+	}
+	b.errorRateEWMA = restBreakerEWMAAlpha*outcome + (1-restBreakerEWMAAlpha)*b.errorRateEWMA
+	metrics.RestBreakerErrorRateEWMA.Set(b.errorRateEWMA)
+}
+
+// IsOpen 报告断路器当前是否处于 open（跳闸）状态。half-open 不算 open：
+// 探测请求本身仍然被视为“可以尝试”的正常路径。
+func (b *RestEndpointBreaker) IsOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == restBreakerOpen
+}
+
+// BackoffMultiplier 根据连续失败次数返回一个指数增长的轮询间隔放大倍数，
+// 由调用方（startOrderStatusSyncImpl）按 orderStatusSyncIntervalWithoutOrders * 10 裁剪上限。
+func (b *RestEndpointBreaker) BackoffMultiplier() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.consecutiveErrors <= 0 {
+		return 1
+	}
+	multiplier := math.Pow(2, float64(b.consecutiveErrors))
+	if multiplier < 1 {
+		return 1
+	}
+	return multiplier
+}
+
+// ForceReset 强制把断路器重置为 closed 状态，供 admin 接口在确认端点恢复后手动复位。
+func (b *RestEndpointBreaker) ForceReset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = restBreakerClosed
+	b.consecutiveErrors = 0
+	b.failureTimes = nil
+	b.probeInFlight = false
+	metrics.RestBreakerState.Set(int64(restBreakerClosed))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/betbot/gobet/clob/types"
 	"github.com/betbot/gobet/internal/domain"
 	"github.com/betbot/gobet/internal/execution"
+	"github.com/betbot/gobet/internal/fixgateway"
 	"github.com/betbot/gobet/internal/marketstate"
 	"github.com/betbot/gobet/internal/ports"
 	"github.com/betbot/gobet/internal/risk"
@@ -63,6 +64,23 @@ type TradingService struct {
 	orderStatusSyncIntervalWithOrders    int
 	orderStatusSyncIntervalWithoutOrders int
 
+	// 仓位对账配置（Data API positions 与 OrderEngine 本地仓位）
+	positionReconcileIntervalWithOrders    int
+	positionReconcileIntervalWithoutOrders int
+	positionReconcileThreshold             float64 // 单个 asset 的 size 偏差超过该阈值（shares）才触发补偿
+
+	// 订单事件 outbox（事务性消息：UpdateOrderCommand/ProcessTradeCommand 提交时落一条待确认事件）
+	outbox                      *OrderOutbox
+	outboxConfirmTimeoutSeconds int // 超过该时长仍未被交易所确认的 outbox 事件，对账阶段按失败回滚
+
+	// GetOpenOrders 轮询端点的自适应退避 + 熔断（见 rest_breaker.go），避免上游 REST 故障
+	// 被对账循环放大成热轮询风暴
+	restBreaker *RestEndpointBreaker
+
+	// 可选的 FIX 4.4 执行回报网关（见 internal/fixgateway）：未配置时为 nil，
+	// startOrderStatusSyncImpl 仍然只走 REST 轮询。
+	fixGateway *fixgateway.Gateway
+
 	// 执行层保护（防重复/风控）
 	inFlightDeduper *execution.InFlightDeduper
 	circuitBreaker  *risk.CircuitBreaker
@@ -102,21 +120,38 @@ func NewTradingService(clobClient *client.Client, dryRun bool) *TradingService {
 	// 创建 OrderEngine
 	orderEngine := NewOrderEngine(ioExecutor, minOrderSize, dryRun)
 
+	// 订单事件 outbox：默认落盘到 data/outbox/orders.json，broker 默认只打日志（占位，
+	// 生产环境替换为 NATS JetStream / RocketMQ / Kafka 的 OutboxBroker 实现）。
+	var outboxStore OutboxStore
+	if store, err := NewJSONFileOutboxStore("data/outbox/orders.json"); err != nil {
+		log.Warnf("⚠️ [outbox] 初始化失败，订单事件将不会落盘: %v", err)
+	} else {
+		outboxStore = store
+	}
+	orderOutbox := NewOrderOutbox(outboxStore, nil)
+	orderEngine.SetOutbox(orderOutbox)
+
 	service := &TradingService{
-		orderEngine:                          orderEngine,
-		ioExecutor:                           ioExecutor,
-		clobClient:                           clobClient,
-		funderAddress:                        "",
-		signatureType:                        types.SignatureTypeBrowser,
-		dryRun:                               dryRun,
-		minOrderSize:                         minOrderSize,
-		minShareSize:                         5.0, // 默认 5.0 shares（Polymarket 限价单要求）
-		ctx:                                  ctx,
-		cancel:                               cancel,
-		orderStatusCache:                     cache.NewOrderStatusCache(),
-		orderStatusSyncIntervalWithOrders:    3,  // 默认3秒
-		orderStatusSyncIntervalWithoutOrders: 30, // 默认30秒
-		inFlightDeduper:                      execution.NewInFlightDeduper(2*time.Second, 64),
+		orderEngine:                            orderEngine,
+		ioExecutor:                             ioExecutor,
+		clobClient:                             clobClient,
+		funderAddress:                          "",
+		signatureType:                          types.SignatureTypeBrowser,
+		dryRun:                                 dryRun,
+		minOrderSize:                           minOrderSize,
+		minShareSize:                           5.0, // 默认 5.0 shares（Polymarket 限价单要求）
+		ctx:                                    ctx,
+		cancel:                                 cancel,
+		orderStatusCache:                       cache.NewOrderStatusCache(),
+		orderStatusSyncIntervalWithOrders:      3,   // 默认3秒
+		orderStatusSyncIntervalWithoutOrders:   30,  // 默认30秒
+		positionReconcileIntervalWithOrders:    15,  // 默认15秒
+		positionReconcileIntervalWithoutOrders: 120, // 默认120秒
+		positionReconcileThreshold:             0.5, // 默认0.5 shares
+		outbox:                                 orderOutbox,
+		outboxConfirmTimeoutSeconds:            20, // 默认20秒（与原有的"不在开放列表"告警阈值一致）
+		restBreaker:                            NewRestEndpointBreaker(),
+		inFlightDeduper:                        execution.NewInFlightDeduper(2*time.Second, 64),
 		circuitBreaker: risk.NewCircuitBreaker(risk.CircuitBreakerConfig{
 			// 默认只启用“连续错误熔断”，避免误伤；当日亏损上限可后续接入完整 PnL 统计后再启用。
 			MaxConsecutiveErrors: 10,
@@ -136,6 +171,12 @@ func NewTradingService(clobClient *client.Client, dryRun bool) *TradingService {
 	service.snapshots = &SnapshotService{s: service}
 	service.syncer = &OrderSyncService{s: service}
 
+	// 风控熔断（权益回撤止损/连续错误/当日亏损）：一旦触发，撤销本账户下所有策略的全部挂单（不区分周期）。
+	service.circuitBreaker.SetHaltHandler(func(reason string) {
+		log.Warnf("🚨 风控熔断，正在撤销全部挂单: %s", reason)
+		service.CancelOrdersNotInMarket(service.ctx, "")
+	})
+
 	if dryRun {
 		log.Warnf("📝 纸交易模式已启用：不会进行真实交易，订单信息仅记录在日志中")
 	}
@@ -283,6 +324,21 @@ func (s *TradingService) SetOrderStatusSyncConfig(withOrdersSeconds, withoutOrde
 	log.Infof("订单状态同步配置已更新: 有活跃订单时=%d秒, 无活跃订单时=%d秒", s.orderStatusSyncIntervalWithOrders, s.orderStatusSyncIntervalWithoutOrders)
 }
 
+// SetPositionReconcileConfig 设置仓位对账配置（无锁版本）
+func (s *TradingService) SetPositionReconcileConfig(withOrdersSeconds, withoutOrdersSeconds int, thresholdShares float64) {
+	if withOrdersSeconds > 0 {
+		s.positionReconcileIntervalWithOrders = withOrdersSeconds
+	}
+	if withoutOrdersSeconds > 0 {
+		s.positionReconcileIntervalWithoutOrders = withoutOrdersSeconds
+	}
+	if thresholdShares > 0 {
+		s.positionReconcileThreshold = thresholdShares
+	}
+	log.Infof("仓位对账配置已更新: 有活跃订单时=%d秒, 无活跃订单时=%d秒, 阈值=%.4f shares",
+		s.positionReconcileIntervalWithOrders, s.positionReconcileIntervalWithoutOrders, s.positionReconcileThreshold)
+}
+
 // OnOrderUpdate 注册订单更新回调（通过 OrderEngine）
 func (s *TradingService) OnOrderUpdate(handler ports.OrderUpdateHandler) {
 	s.orderEngine.OnOrderUpdate(handler)
@@ -358,6 +414,14 @@ func (s *TradingService) Start(ctx context.Context) error {
 	// 启动定期订单状态同步（如果需要）
 	go s.startOrderStatusSync(s.ctx)
 
+	// 启动定期仓位对账（Data API positions vs. OrderEngine 本地仓位）
+	go s.startPositionReconcile(s.ctx)
+	s.registerPositionReconcileAdminHandler()
+	s.registerRestBreakerAdminHandler()
+
+	// 启动订单事件 outbox 的 publisher（at-least-once 投递给下游 broker）
+	go s.outbox.StartPublisher(s.ctx, 2*time.Second)
+
 	return nil
 }
 
@@ -411,6 +475,45 @@ func (s *TradingService) SetMinShareSize(minShareSize float64) {
 	log.Infof("✅ 已设置限价单最小 share 数量: %.2f（仅限价单 GTC 时应用）", minShareSize)
 }
 
+// SetStopLossFraction 设置账户权益相对历史最高点的最大回撤比例，<=0 表示关闭
+// 该检查。实际的回撤判断由 CheckEquityStopLoss 定期喂入权益驱动。
+//
+// 只更新止损比例本身，不经过 SetConfig 重建整份断路器配置——否则会用这里的
+// 硬编码值覆盖掉 MaxConsecutiveErrors/DailyLossLimitCents 等可能已经被其它地方
+// 设置过的字段。
+func (s *TradingService) SetStopLossFraction(fraction float64) {
+	if s.circuitBreaker == nil {
+		return
+	}
+	s.circuitBreaker.SetStopLossFraction(fraction)
+	if fraction > 0 {
+		log.Infof("✅ 已设置权益止损线: 回撤超过 %.2f%% 将熔断并撤销全部挂单", fraction*100)
+	}
+}
+
+// CheckEquityStopLoss 查询当前账户权益并喂给断路器，供上层（通常是一个定期
+// 巡检的 ticker）周期性调用。查询失败时静默跳过，不影响下一次巡检。
+//
+// 权益 = 现金余额 + 持仓市值。持仓市值用成本价（AvgPrice）近似——本层没有可用
+// 的实时标记价，用现金余额本身会导致开仓花掉的现金被误判为回撤。
+func (s *TradingService) CheckEquityStopLoss() {
+	if s.circuitBreaker == nil {
+		return
+	}
+	balance, ok := s.GetBalanceUSDC()
+	if !ok {
+		return
+	}
+	equity := balance
+	for _, pos := range s.GetOpenPositions() {
+		if pos == nil || pos.Size <= 0 {
+			continue
+		}
+		equity += pos.Size * pos.AvgPrice
+	}
+	s.circuitBreaker.UpdateEquity(equity)
+}
+
 // WaitOrderResult 等待订单处理结果（已废弃，现在通过 OrderEngine 处理）
 // 保留此方法用于向后兼容，但不再使用
 func (s *TradingService) WaitOrderResult(ctx context.Context, orderID string, timeout time.Duration) (*OrderResult, error) {
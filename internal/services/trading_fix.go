@@ -0,0 +1,50 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/fixgateway"
+)
+
+// SetFixGateway 注入一个已经构建好的 FIX 4.4 执行回报网关（可选）。
+// TradingService 把自己暴露成 fixgateway.OrderCommandSink，让网关收到的
+// ExecutionReport 和 OrderSyncService 从 REST 轮询产生的走同一条 OrderEngine 提交路径。
+func (s *TradingService) SetFixGateway(g *fixgateway.Gateway) {
+	s.fixGateway = g
+}
+
+// HasHealthyFixGateway 报告是否配置了 FIX 网关且会话健康（已登录、最近收到过消息）。
+// startOrderStatusSyncImpl 用它来决定是否可以放宽 REST 轮询间隔。
+func (s *TradingService) HasHealthyFixGateway() bool {
+	return s.fixGateway != nil && s.fixGateway.IsHealthy()
+}
+
+// SubmitOrderUpdate 实现 fixgateway.OrderCommandSink：把 ExecutionReport 翻译出的
+// domain.Order 按 UpdateOrderCommand 提交给 OrderEngine，和 WebSocket/REST 来源一致。
+func (s *TradingService) SubmitOrderUpdate(order *domain.Order) {
+	if order == nil {
+		return
+	}
+	updateCmd := &UpdateOrderCommand{
+		id:    fmt.Sprintf("fix_update_%s_%d", order.OrderID, time.Now().UnixNano()),
+		Gen:   s.currentEngineGeneration(),
+		Order: order,
+	}
+	s.orderEngine.SubmitCommand(updateCmd)
+}
+
+// SubmitTrade 实现 fixgateway.OrderCommandSink：把 ExecutionReport 翻译出的
+// domain.Trade 按 ProcessTradeCommand 提交给 OrderEngine。
+func (s *TradingService) SubmitTrade(trade *domain.Trade) {
+	if trade == nil {
+		return
+	}
+	cmd := &ProcessTradeCommand{
+		id:    fmt.Sprintf("fix_trade_%d", time.Now().UnixNano()),
+		Gen:   s.currentEngineGeneration(),
+		Trade: trade,
+	}
+	s.orderEngine.SubmitCommand(cmd)
+}
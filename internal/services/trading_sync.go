@@ -9,10 +9,15 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/betbot/gobet/clob/types"
 	"github.com/betbot/gobet/internal/domain"
 	"github.com/betbot/gobet/internal/metrics"
 )
 
+// fixHealthyPollBackoffMultiplier：FIX 执行回报网关健康时，REST 轮询间隔的放大倍数
+// （只拉长而不是完全关闭，FIX 会话短暂异常时 REST 仍然是兜底安全网）。
+const fixHealthyPollBackoffMultiplier = 5
+
 // startOrderStatusSync 定期同步订单状态（通过 API 查询）
 // 如果 WebSocket 失败，会自动缩短同步间隔
 func (os *OrderSyncService) startOrderStatusSyncImpl(ctx context.Context) {
@@ -57,6 +62,25 @@ func (os *OrderSyncService) startOrderStatusSyncImpl(ctx context.Context) {
 				syncInterval = currentSyncIntervalWithoutOrders
 			}
 
+			// FIX 执行回报网关登录且健康时，成交/状态更新已经是推送式的，
+			// REST 轮询只需要作为兜底安全网，大幅拉长间隔（而不是完全关闭，
+			// 避免 FIX 会话中间短暂异常时丢单）。
+			if s.HasHealthyFixGateway() {
+				syncInterval *= fixHealthyPollBackoffMultiplier
+			}
+
+			// GetOpenOrders 连续失败时指数拉长轮询间隔，避免对一个正在故障的上游
+			// 端点发起热轮询；上限钳制在"无活跃订单"间隔的10倍，不让断路器把
+			// 对账完全饿死。
+			if s.restBreaker != nil {
+				if backoffInterval := time.Duration(float64(syncInterval) * s.restBreaker.BackoffMultiplier()); backoffInterval > syncInterval {
+					syncInterval = backoffInterval
+				}
+				if maxInterval := currentSyncIntervalWithoutOrders * 10; syncInterval > maxInterval {
+					syncInterval = maxInterval
+				}
+			}
+
 			// 检查是否到了同步时间
 			if time.Since(lastSyncTime) >= syncInterval {
 				os.syncAllOrderStatusImpl(ctx)
@@ -105,13 +129,31 @@ func (os *OrderSyncService) syncAllOrderStatusImpl(ctx context.Context) {
 
 	log.Debugf("🔄 [订单状态同步] 开始同步 %d 个活跃订单的状态", len(orderIDs))
 
+	// GetOpenOrders 的自适应熔断：跳闸时不再发起 REST 调用，改为拉取持仓接口作为
+	// 降级信号（见 fetchUserPositionsFromAPIImpl），活跃订单的本地状态原样保留，
+	// 等待下一次探测请求或 WebSocket 推送把它们带回来。
+	if s.restBreaker != nil && !s.restBreaker.Allow() {
+		log.Warnf("🔄 [订单状态同步] REST 断路器已跳闸，跳过 GetOpenOrders，改用持仓接口作为降级信号")
+		if err := os.fetchUserPositionsFromAPIImpl(ctx); err != nil {
+			log.Warnf("🔄 [订单状态同步] 降级信号（持仓接口）拉取也失败: %v", err)
+		}
+		return
+	}
+
 	// 获取所有开放订单
+	restCallStart := time.Now()
 	openOrdersResp, err := s.clobClient.GetOpenOrders(ctx, nil)
 	if err != nil {
+		if s.restBreaker != nil {
+			s.restBreaker.RecordFailure()
+		}
 		log.Warnf("🔄 [订单状态同步] 获取开放订单失败: %v", err)
 		metrics.ReconcileErrors.Add(1)
 		return
 	}
+	if s.restBreaker != nil {
+		s.restBreaker.RecordSuccess(time.Since(restCallStart))
+	}
 
 	log.Debugf("🔄 [订单状态同步] API 返回 %d 个开放订单", len(openOrdersResp))
 
@@ -149,6 +191,13 @@ func (os *OrderSyncService) syncAllOrderStatusImpl(ctx context.Context) {
 	updatedOrderIDs := make(map[string]string) // oldID -> newID
 	_ = updatedOrderIDs                        // 保留：用于未来输出/诊断
 
+	// 已经被 ID/属性精确匹配占用的交易所订单 ID，后面跑匈牙利算法时要排除，
+	// 避免一个交易所订单被精确匹配和模糊匹配各"认领"一次。
+	consumedAPIOrderIDs := make(map[string]bool)
+	// 走到业务规则档位（入场 60-90 分 / 对冲 1-40 分）但没有精确匹配上的本地订单，
+	// 统一收集起来，循环结束后一次性跑匈牙利算法做全局最优指派（见 order_matching.go）。
+	var pendingMatch []*domain.Order
+
 	for _, orderID := range orderIDs {
 		order, exists := localOrdersMap[orderID]
 		if !exists {
@@ -173,6 +222,8 @@ func (os *OrderSyncService) syncAllOrderStatusImpl(ctx context.Context) {
 				Order: order,
 			}
 			s.orderEngine.SubmitCommand(updateCmd)
+			s.outbox.Confirm(orderID)
+			consumedAPIOrderIDs[orderID] = true
 			continue
 		}
 
@@ -195,6 +246,8 @@ func (os *OrderSyncService) syncAllOrderStatusImpl(ctx context.Context) {
 				log.Warnf("⚠️ [状态一致性] 订单状态可能不一致: orderID=%s, WebSocket状态=%s, API状态=open",
 					orderID, order.Status)
 			}
+			s.outbox.Confirm(orderID)
+			consumedAPIOrderIDs[orderID] = true
 			continue
 		}
 
@@ -230,95 +283,70 @@ func (os *OrderSyncService) syncAllOrderStatusImpl(ctx context.Context) {
 			// 更新缓存
 			s.orderStatusCache.Delete(orderID)
 			s.orderStatusCache.Set(matchedOrderID, true)
+			s.outbox.Confirm(matchedOrderID)
+			consumedAPIOrderIDs[matchedOrderID] = true
 
 			log.Debugf("🔄 [订单状态同步] 订单 ID 已更新: %s -> %s", orderID, matchedOrderID)
 			continue
 		}
 
-		// 风险5修复：改进订单ID匹配算法（业务规则匹配）
-		matched := false
-		var bestMatch *struct {
-			orderID string
-			price   int
-			score   float64 // 匹配分数：价格差异越小，分数越高
-		}
-
+		// 风险5修复：原来这里逐单贪心按业务规则价格区间匹配（60-90 / 1-40 分，1/(1+priceDiff) 打分），
+		// 两个价格相近的本地订单可能抢到同一个交易所订单 ID。现在只把符合档位的候选收集起来，
+		// 等这一轮所有本地订单都扫描完，统一跑一次匈牙利算法做全局最优的一对一指派（见下方、
+		// 以及 order_matching.go 的 matchOrdersHungarian）。
+		qualifiesForBucketMatch := false
 		if order.IsEntryOrder {
 			// 入场订单：价格应该在 60-90 之间
-			if order.Price.Cents >= 60 && order.Price.Cents <= 90 {
-				for _, apiOrder := range openOrdersResp {
-					apiPrice, err := strconv.ParseFloat(apiOrder.Price, 64)
-					if err != nil {
-						continue
-					}
-					apiPriceCents := int(apiPrice * 100)
-
-					if apiOrder.AssetID == order.AssetID &&
-						apiOrder.Side == string(order.Side) &&
-						apiPriceCents >= 60 && apiPriceCents <= 90 {
-						priceDiff := math.Abs(float64(apiPriceCents - order.Price.Cents))
-						if priceDiff <= 2 {
-							score := 1.0 / (1.0 + priceDiff)
-							if bestMatch == nil || score > bestMatch.score {
-								bestMatch = &struct {
-									orderID string
-									price   int
-									score   float64
-								}{
-									orderID: apiOrder.ID,
-									price:   apiPriceCents,
-									score:   score,
-								}
-							}
-						}
-					}
-				}
-			}
+			qualifiesForBucketMatch = order.Price.Cents >= 60 && order.Price.Cents <= 90
 		} else {
 			// 对冲订单：价格应该在 1-40 之间
-			if order.Price.Cents >= 1 && order.Price.Cents <= 40 {
-				for _, apiOrder := range openOrdersResp {
-					apiPrice, err := strconv.ParseFloat(apiOrder.Price, 64)
-					if err != nil {
-						continue
-					}
-					apiPriceCents := int(apiPrice * 100)
-
-					if apiOrder.AssetID == order.AssetID &&
-						apiOrder.Side == string(order.Side) &&
-						apiPriceCents >= 1 && apiPriceCents <= 40 {
-						priceDiff := math.Abs(float64(apiPriceCents - order.Price.Cents))
-						if priceDiff <= 2 {
-							score := 1.0 / (1.0 + priceDiff)
-							if bestMatch == nil || score > bestMatch.score {
-								bestMatch = &struct {
-									orderID string
-									price   int
-									score   float64
-								}{
-									orderID: apiOrder.ID,
-									price:   apiPriceCents,
-									score:   score,
-								}
-							}
-						}
-					}
-				}
+			qualifiesForBucketMatch = order.Price.Cents >= 1 && order.Price.Cents <= 40
+		}
+
+		if qualifiesForBucketMatch {
+			pendingMatch = append(pendingMatch, order)
+			continue
+		}
+
+		if os.finalizeUnmatchedOrderImpl(ctx, order, orderID) {
+			filledCount++
+		}
+	}
+
+	// 对收集到的候选本地订单，与尚未被 ID/属性精确匹配占用的交易所开放订单
+	// 做一次全局最优指派，取代逐单贪心，消除"两个本地订单抢同一个交易所订单 ID"的问题。
+	if len(pendingMatch) > 0 {
+		candidates := make([]types.OpenOrder, 0, len(openOrdersResp))
+		for _, apiOrder := range openOrdersResp {
+			if !consumedAPIOrderIDs[apiOrder.ID] {
+				candidates = append(candidates, apiOrder)
 			}
 		}
 
-		if bestMatch != nil {
-			matchedOrderID := bestMatch.orderID
-			matchedPriceCents := bestMatch.price
+		assignments := matchOrdersHungarian(pendingMatch, candidates)
+		for _, order := range pendingMatch {
+			orderID := order.OrderID
 			orderType := "入场订单"
 			if !order.IsEntryOrder {
 				orderType = "对冲订单"
 			}
-			log.Infof("🔄 [订单状态同步] 通过业务规则匹配找到%s: 本地ID=%s, 服务器ID=%s, assetID=%s, side=%s, 本地价格=%dc, 服务器价格=%dc, 匹配分数=%.2f",
-				orderType, orderID, matchedOrderID, order.AssetID, order.Side, order.Price.Cents, matchedPriceCents, bestMatch.score)
+
+			assignment, ok := assignments[orderID]
+			if !ok {
+				log.Warnf("⚠️ [订单匹配失败] 匈牙利算法找不到代价可接受的%s指派: orderID=%s, assetID=%s, side=%s, price=%dc, 可能订单已成交或取消",
+					orderType, orderID, order.AssetID, order.Side, order.Price.Cents)
+				if os.finalizeUnmatchedOrderImpl(ctx, order, orderID) {
+					filledCount++
+				}
+				continue
+			}
+
+			matchedOrderID := assignment.APIOrder.ID
+			log.Infof("🔄 [订单状态同步] 通过匈牙利算法全局指派找到%s: 本地ID=%s, 服务器ID=%s, assetID=%s, side=%s, 本地价格=%dc, 服务器价格=%dc, 代价=%.2f",
+				orderType, orderID, matchedOrderID, order.AssetID, order.Side, order.Price.Cents, assignment.APIPriceCents, assignment.Cost)
 
 			order.OrderID = matchedOrderID
-			order.Price = domain.Price{Cents: matchedPriceCents}
+			order.Price = domain.Price{Cents: assignment.APIPriceCents}
 			updatedOrderIDs[orderID] = matchedOrderID
 
 			updateCmd := &UpdateOrderCommand{
@@ -330,75 +358,84 @@ func (os *OrderSyncService) syncAllOrderStatusImpl(ctx context.Context) {
 
 			s.orderStatusCache.Delete(orderID)
 			s.orderStatusCache.Set(matchedOrderID, true)
+			s.outbox.Confirm(matchedOrderID)
 
-			log.Debugf("🔄 [订单状态同步] %s ID 已更新: %s -> %s", orderType, orderID, matchedOrderID)
-			matched = true
-		} else if order.IsEntryOrder || (!order.IsEntryOrder && order.Price.Cents >= 1 && order.Price.Cents <= 40) {
-			orderType := "入场订单"
-			if !order.IsEntryOrder {
-				orderType = "对冲订单"
-			}
-			log.Warnf("⚠️ [订单匹配失败] 无法通过业务规则匹配%s: orderID=%s, assetID=%s, side=%s, price=%dc, 可能订单已成交或取消",
-				orderType, orderID, order.AssetID, order.Side, order.Price.Cents)
+			log.Debugf("🔄 [订单状态同步] %s ID 已更新（匈牙利算法指派）: %s -> %s", orderType, orderID, matchedOrderID)
 		}
+	}
 
-		if matched {
-			continue
-		}
+	if filledCount > 0 {
+		log.Debugf("🔄 [订单状态同步] 完成：发现 %d 个订单已成交", filledCount)
+	} else {
+		log.Debugf("🔄 [订单状态同步] 完成：所有 %d 个订单仍在开放订单列表中", len(orderIDs))
+	}
+}
 
-		// 本地订单不在交易所 open 列表：视为成交/取消/失败（做一层安全判定）
-		if order.Status == domain.OrderStatusFailed {
-			log.Debugf("🔄 [订单状态同步] 订单已标记为失败，跳过同步: orderID=%s", orderID)
-			continue
-		}
+// finalizeUnmatchedOrderImpl 处理一个既没有通过 ID/属性精确匹配、也没有通过匈牙利算法
+// 全局指派匹配上交易所开放订单的本地订单：本地订单不在交易所 open 列表，视为成交/取消/失败
+// （做一层安全判定），返回 true 表示该订单被判定为已成交。
+func (os *OrderSyncService) finalizeUnmatchedOrderImpl(ctx context.Context, order *domain.Order, orderID string) bool {
+	s := os.s
 
-		hasServerOrderID := order.OrderID != "" &&
-			order.OrderID != orderID &&
-			!isLocalGeneratedOrderID(order.OrderID)
+	if order.Status == domain.OrderStatusFailed {
+		log.Debugf("🔄 [订单状态同步] 订单已标记为失败，跳过同步: orderID=%s", orderID)
+		return false
+	}
 
-		if order.Status == domain.OrderStatusPending && !hasServerOrderID {
-			log.Warnf("⚠️ [订单状态同步] 订单可能提交失败: orderID=%s, 本地ID=%s, WebSocket状态=%s, API状态=不在开放列表中（可能是提交失败，而非已成交）",
-				orderID, order.OrderID, order.Status)
+	hasServerOrderID := order.OrderID != "" &&
+		order.OrderID != orderID &&
+		!isLocalGeneratedOrderID(order.OrderID)
 
-			order.Status = domain.OrderStatusFailed
-			s.orderEngine.SubmitCommand(&UpdateOrderCommand{
-				id:    fmt.Sprintf("sync_failed_%s", orderID),
-				Gen:   s.currentEngineGeneration(),
-				Order: order,
-			})
-			s.orderStatusCache.Set(orderID, false)
-			continue
+	if order.Status == domain.OrderStatusPending && !hasServerOrderID {
+		// 反查阶段：不再"不在开放列表就直接判失败"，而是看 outbox 里这个订单最新一条
+		// 事件是否已经超过确认窗口仍未被交易所证实（见 order_outbox.go）。
+		timeout := time.Duration(s.outboxConfirmTimeoutSeconds) * time.Second
+		if timeout <= 0 {
+			timeout = 20 * time.Second
 		}
-
-		if order.Status == domain.OrderStatusFilled {
-			log.Debugf("🔄 [订单状态同步] 订单已通过WebSocket更新为已成交，API确认不在开放列表中，状态一致: orderID=%s", orderID)
-			continue
-		} else if order.Status == domain.OrderStatusOpen || order.Status == domain.OrderStatusPending {
-			log.Warnf("⚠️ [状态一致性] WebSocket和API状态不一致: orderID=%s, WebSocket状态=%s, API状态=已成交/已取消",
-				orderID, order.Status)
+		pendingEvent := s.outbox.UnconfirmedOlderThan(orderID, timeout)
+		if pendingEvent == nil {
+			log.Debugf("🔄 [订单状态同步] 订单提交待确认（未超过 outbox 确认窗口，暂不回滚）: orderID=%s", orderID)
+			return false
 		}
 
-		log.Infof("🔄 [订单状态同步] 订单已成交: orderID=%s, side=%s, price=%.4f, size=%.2f",
-			orderID, order.Side, order.Price.ToDecimal(), order.Size)
-
-		order.Status = domain.OrderStatusFilled
-		now := time.Now()
-		order.FilledAt = &now
+		log.Warnf("⚠️ [订单状态同步] 订单超过%v仍未被交易所确认，按 outbox 反查回滚: orderID=%s, 本地ID=%s, WebSocket状态=%s, API状态=不在开放列表中",
+			timeout, orderID, order.OrderID, order.Status)
 
+		order.Status = domain.OrderStatusFailed
 		s.orderEngine.SubmitCommand(&UpdateOrderCommand{
-			id:    fmt.Sprintf("sync_filled_%s", orderID),
+			id:    fmt.Sprintf("sync_failed_%s", orderID),
 			Gen:   s.currentEngineGeneration(),
 			Order: order,
 		})
-		filledCount++
+		s.outbox.RollBack(pendingEvent)
 		s.orderStatusCache.Set(orderID, false)
+		return false
 	}
 
-	if filledCount > 0 {
-		log.Debugf("🔄 [订单状态同步] 完成：发现 %d 个订单已成交", filledCount)
-	} else {
-		log.Debugf("🔄 [订单状态同步] 完成：所有 %d 个订单仍在开放订单列表中", len(orderIDs))
+	if order.Status == domain.OrderStatusFilled {
+		log.Debugf("🔄 [订单状态同步] 订单已通过WebSocket更新为已成交，API确认不在开放列表中，状态一致: orderID=%s", orderID)
+		return false
+	} else if order.Status == domain.OrderStatusOpen || order.Status == domain.OrderStatusPending {
+		log.Warnf("⚠️ [状态一致性] WebSocket和API状态不一致: orderID=%s, WebSocket状态=%s, API状态=已成交/已取消",
+			orderID, order.Status)
 	}
+
+	log.Infof("🔄 [订单状态同步] 订单已成交: orderID=%s, side=%s, price=%.4f, size=%.2f",
+		orderID, order.Side, order.Price.ToDecimal(), order.Size)
+
+	order.Status = domain.OrderStatusFilled
+	now := time.Now()
+	order.FilledAt = &now
+
+	s.orderEngine.SubmitCommand(&UpdateOrderCommand{
+		id:    fmt.Sprintf("sync_filled_%s", orderID),
+		Gen:   s.currentEngineGeneration(),
+		Order: order,
+	})
+	s.outbox.Confirm(orderID)
+	s.orderStatusCache.Set(orderID, false)
+	return true
 }
 
 func (os *OrderSyncService) syncOrderStatusImpl(ctx context.Context, orderID string) error {
@@ -420,6 +457,9 @@ func (os *OrderSyncService) syncOrderStatusImpl(ctx context.Context, orderID str
 		return nil
 	}
 
+	// GetOrder 成功返回即说明交易所侧已经证实了这个订单，对应的 outbox 事件可以确认
+	s.outbox.Confirm(orderID)
+
 	originalSize, _ := strconv.ParseFloat(order.OriginalSize, 64)
 	sizeMatched, _ := strconv.ParseFloat(order.SizeMatched, 64)
 
@@ -522,50 +562,306 @@ func (os *OrderSyncService) startOrderConfirmationTimeoutCheckImpl(ctx context.C
 	}
 }
 
-// checkOrderConfirmationTimeout 检查订单确认超时（已简化，不再使用锁）
+// restBreakerOpenConfirmTimeoutDivisor：REST 断路器跳闸、GetOpenOrders 反查不可用期间，
+// outbox 确认超时按这个比例收紧，尽快把卡住的 pending 订单暴露出来而不是一直等 REST 恢复。
+const restBreakerOpenConfirmTimeoutDivisor = 2
+
+// checkOrderConfirmationTimeout 检查订单确认超时。正常情况下 syncAllOrderStatusImpl 的
+// GetOpenOrders 反查已经覆盖了这个职责；只有在 REST 断路器跳闸、反查被跳过时，这里才
+// 用一个收紧后的 outbox 确认窗口主动扫描 pending 订单，避免 outbox 里卡住的订单一直
+// 等到断路器恢复才被发现。
 func (os *OrderSyncService) checkOrderConfirmationTimeoutImpl(ctx context.Context) {
-	log.Debugf("订单确认超时检测已简化，现在通过 OrderEngine 管理")
+	s := os.s
+	if s.restBreaker == nil || !s.restBreaker.IsOpen() {
+		return
+	}
+
+	timeout := time.Duration(s.outboxConfirmTimeoutSeconds) * time.Second / restBreakerOpenConfirmTimeoutDivisor
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	for _, order := range s.GetActiveOrders() {
+		if order == nil || order.Status != domain.OrderStatusPending {
+			continue
+		}
+		hasServerOrderID := order.OrderID != "" && !isLocalGeneratedOrderID(order.OrderID)
+		if hasServerOrderID {
+			continue
+		}
+
+		pendingEvent := s.outbox.UnconfirmedOlderThan(order.OrderID, timeout)
+		if pendingEvent == nil {
+			continue
+		}
+
+		log.Warnf("⚠️ [订单确认超时] REST 断路器跳闸期间超过%v仍未确认，按 outbox 反查回滚: orderID=%s", timeout, order.OrderID)
+
+		order.Status = domain.OrderStatusFailed
+		s.orderEngine.SubmitCommand(&UpdateOrderCommand{
+			id:    fmt.Sprintf("sync_failed_%s", order.OrderID),
+			Gen:   s.currentEngineGeneration(),
+			Order: order,
+		})
+		s.outbox.RollBack(pendingEvent)
+		s.orderStatusCache.Set(order.OrderID, false)
+	}
 }
 
-// FetchUserPositionsFromAPI 从Polymarket Data API拉取用户持仓并校正本地状态
-func (os *OrderSyncService) fetchUserPositionsFromAPIImpl(ctx context.Context) error {
+// fetchUserPositionSizesImpl 从 Polymarket Data API 拉取用户持仓，返回按 assetID 聚合的 size。
+func (os *OrderSyncService) fetchUserPositionSizesImpl(ctx context.Context) (map[string]float64, error) {
 	s := os.s
 	if s.funderAddress == "" {
-		return fmt.Errorf("funder地址未设置，无法拉取持仓")
+		return nil, fmt.Errorf("funder地址未设置，无法拉取持仓")
 	}
 
 	apiURL := fmt.Sprintf("https://data-api.polymarket.com/positions?user=%s&sizeThreshold=0.01&limit=500", s.funderAddress)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
-		return fmt.Errorf("创建HTTP请求失败: %w", err)
+		return nil, fmt.Errorf("创建HTTP请求失败: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("HTTP请求失败: %w", err)
+		return nil, fmt.Errorf("HTTP请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API返回错误状态码: %d", resp.StatusCode)
+		return nil, fmt.Errorf("API返回错误状态码: %d", resp.StatusCode)
 	}
 
 	var positions []map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&positions); err != nil {
-		return fmt.Errorf("解析响应失败: %w", err)
+		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
 
-	log.Infof("📊 [仓位同步] 从API拉取到 %d 个持仓", len(positions))
+	log.Debugf("📊 [仓位对账] 从API拉取到 %d 个持仓", len(positions))
+	sizes := make(map[string]float64, len(positions))
 	for _, pos := range positions {
-		if asset, ok := pos["asset"].(string); ok {
-			if size, ok := pos["size"].(string); ok {
-				sizeFloat, _ := strconv.ParseFloat(size, 64)
-				log.Debugf("📊 [仓位同步] 持仓: asset=%s, size=%.4f", asset, sizeFloat)
-			}
+		asset, ok := pos["asset"].(string)
+		if !ok || asset == "" {
+			continue
 		}
+		sizeStr, ok := pos["size"].(string)
+		if !ok {
+			continue
+		}
+		sizeFloat, _ := strconv.ParseFloat(sizeStr, 64)
+		sizes[asset] = sizeFloat
+	}
+	return sizes, nil
+}
+
+// FetchUserPositionsFromAPI 从Polymarket Data API拉取用户持仓并校正本地状态
+func (os *OrderSyncService) fetchUserPositionsFromAPIImpl(ctx context.Context) error {
+	sizes, err := os.fetchUserPositionSizesImpl(ctx)
+	if err != nil {
+		return err
+	}
+	for asset, size := range sizes {
+		log.Debugf("📊 [仓位对账] 持仓: asset=%s, size=%.4f", asset, size)
 	}
 	return nil
 }
+
+// localFilledPosition 是某个 assetID 在本地 OrderEngine 已成交订单上的聚合口径，
+// side/price/tokenType 取自最近一笔贡献了 FilledSize 的订单，用于合成补偿 Trade。
+type localFilledPosition struct {
+	filledSize float64
+	side       types.Side
+	price      domain.Price
+	tokenType  domain.TokenType
+	at         time.Time // 最近一笔贡献订单的 CreatedAt
+}
+
+// aggregateLocalFilledSizesImpl 按 assetID 汇总本地订单的累计成交量（sum of FilledSize）。
+func (os *OrderSyncService) aggregateLocalFilledSizesImpl() map[string]*localFilledPosition {
+	s := os.s
+	agg := make(map[string]*localFilledPosition)
+	for _, order := range s.GetAllOrders() {
+		if order == nil || order.AssetID == "" || order.FilledSize <= 0 {
+			continue
+		}
+		p, ok := agg[order.AssetID]
+		if !ok {
+			p = &localFilledPosition{}
+			agg[order.AssetID] = p
+		}
+		p.filledSize += order.FilledSize
+		if p.at.IsZero() || !order.CreatedAt.Before(p.at) {
+			p.side = order.Side
+			p.price = order.Price
+			p.tokenType = order.TokenType
+			p.at = order.CreatedAt
+		}
+	}
+	return agg
+}
+
+// oppositeSide 返回相反方向，用于把"本地仓位偏多"的偏差合成为反向补偿成交。
+func oppositeSide(side types.Side) types.Side {
+	if side == types.SideBuy {
+		return types.SideSell
+	}
+	return types.SideBuy
+}
+
+// reconcilePositionsImpl 对账 Data API 持仓与 OrderEngine 本地持仓：
+// 按 asset 计算 (API size - 本地累计 FilledSize) 的偏差，超过
+// s.positionReconcileThreshold 时，合成补偿 ProcessTradeCommand（与 syncOrderStatusImpl
+// 的 delta-trade 补偿思路一致），用于弥补 WebSocket trade 丢弃导致的仓位漂移。
+func (os *OrderSyncService) reconcilePositionsImpl(ctx context.Context) error {
+	s := os.s
+	metrics.PositionReconcileRuns.Add(1)
+
+	apiSizes, err := os.fetchUserPositionSizesImpl(ctx)
+	if err != nil {
+		metrics.ReconcileErrors.Add(1)
+		return err
+	}
+
+	localAgg := os.aggregateLocalFilledSizesImpl()
+	threshold := s.positionReconcileThreshold
+
+	driftCount := 0
+	correctionCount := 0
+	for assetID, apiSize := range apiSizes {
+		local := localAgg[assetID]
+		localSize := 0.0
+		if local != nil {
+			localSize = local.filledSize
+		}
+
+		delta := apiSize - localSize
+		if math.Abs(delta) <= threshold {
+			continue
+		}
+
+		driftCount++
+		metrics.PositionReconcileDrift.Add(1)
+		log.Warnf("⚠️ [仓位对账] 发现仓位偏差: assetID=%s, API=%.4f, 本地=%.4f, delta=%.4f",
+			assetID, apiSize, localSize, delta)
+
+		if local == nil {
+			// 本地没有任何该 asset 的成交记录，无法确定 side/price，只记录漂移，不合成补偿
+			continue
+		}
+
+		side := local.side
+		if delta < 0 {
+			// 本地比交易所仓位多：合成一笔反向成交，把本地累计成交量拉回 API 口径
+			side = oppositeSide(local.side)
+		}
+
+		trade := &domain.Trade{
+			ID:        fmt.Sprintf("position_reconcile:%s:%d", assetID, time.Now().UnixNano()),
+			AssetID:   assetID,
+			Side:      side,
+			Price:     local.price,
+			Size:      math.Abs(delta),
+			TokenType: local.tokenType,
+			Time:      time.Now(),
+		}
+		s.orderEngine.SubmitCommand(&ProcessTradeCommand{
+			id:    fmt.Sprintf("position_reconcile_trade_%d", time.Now().UnixNano()),
+			Gen:   s.currentEngineGeneration(),
+			Trade: trade,
+		})
+		correctionCount++
+		metrics.PositionReconcileCorrections.Add(1)
+	}
+
+	if driftCount == 0 {
+		log.Debugf("📊 [仓位对账] 完成：未发现仓位偏差（%d 个资产）", len(apiSizes))
+	} else {
+		log.Infof("📊 [仓位对账] 完成：发现 %d 个资产偏差，合成 %d 笔补偿交易", driftCount, correctionCount)
+	}
+	return nil
+}
+
+// startPositionReconcile 定期对账仓位（Data API positions vs. OrderEngine 本地持仓）
+// 节奏与 startOrderStatusSyncImpl 一致：根据是否有活跃订单选择同步间隔。
+func (os *OrderSyncService) startPositionReconcileImpl(ctx context.Context) {
+	s := os.s
+	withOrdersSeconds := s.positionReconcileIntervalWithOrders
+	withoutOrdersSeconds := s.positionReconcileIntervalWithoutOrders
+
+	log.Infof("📊 [仓位对账] 启动定期仓位对账（有活跃订单时每%d秒，无活跃订单时每%d秒）",
+		withOrdersSeconds, withoutOrdersSeconds)
+
+	if err := os.reconcilePositionsImpl(ctx); err != nil {
+		log.Warnf("📊 [仓位对账] 首次对账失败: %v", err)
+	}
+
+	ticker := time.NewTicker(1 * time.Second) // 每1秒检查一次，逻辑同订单状态同步
+	defer ticker.Stop()
+
+	lastRunTime := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("📊 [仓位对账] 仓位对账已停止")
+			return
+		case <-ticker.C:
+			hasActiveOrders := len(s.GetActiveOrders()) > 0
+
+			currentIntervalWithOrders := time.Duration(s.positionReconcileIntervalWithOrders) * time.Second
+			currentIntervalWithoutOrders := time.Duration(s.positionReconcileIntervalWithoutOrders) * time.Second
+
+			var interval time.Duration
+			if hasActiveOrders {
+				interval = currentIntervalWithOrders
+			} else {
+				interval = currentIntervalWithoutOrders
+			}
+
+			if time.Since(lastRunTime) >= interval {
+				if err := os.reconcilePositionsImpl(ctx); err != nil {
+					log.Warnf("📊 [仓位对账] 对账失败: %v", err)
+				}
+				lastRunTime = time.Now()
+			}
+		}
+	}
+}
+
+// registerPositionReconcileAdminHandler 注册 /admin/position-reconcile，支持手动触发一次仓位对账
+func (os *OrderSyncService) registerPositionReconcileAdminHandlerImpl() {
+	metrics.RegisterAdminHandler("/admin/position-reconcile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+		defer cancel()
+
+		if err := os.reconcilePositionsImpl(ctx); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+}
+
+// registerRestBreakerAdminHandler 注册 /admin/rest-breaker-reset，允许运维在确认上游
+// REST 端点已恢复后手动把断路器强制复位成 closed，不用等冷却期自然过去。
+func (os *OrderSyncService) registerRestBreakerAdminHandlerImpl() {
+	s := os.s
+	metrics.RegisterAdminHandler("/admin/rest-breaker-reset", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if s.restBreaker != nil {
+			s.restBreaker.ForceReset()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+}
@@ -46,3 +46,24 @@ func (s *TradingService) FetchUserPositionsFromAPI(ctx context.Context) error {
 	}
 	return s.syncer.fetchUserPositionsFromAPIImpl(ctx)
 }
+
+func (s *TradingService) startPositionReconcile(ctx context.Context) {
+	if s.syncer == nil {
+		return
+	}
+	s.syncer.startPositionReconcileImpl(ctx)
+}
+
+func (s *TradingService) registerPositionReconcileAdminHandler() {
+	if s.syncer == nil {
+		return
+	}
+	s.syncer.registerPositionReconcileAdminHandlerImpl()
+}
+
+func (s *TradingService) registerRestBreakerAdminHandler() {
+	if s.syncer == nil {
+		return
+	}
+	s.syncer.registerRestBreakerAdminHandlerImpl()
+}
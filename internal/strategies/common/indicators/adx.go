@@ -0,0 +1,93 @@
+package indicators
+
+import "math"
+
+// ADX 是平均趋向指标（Average Directional Index）的简化实现。
+//
+// 标准 ADX 基于 High/Low/Close 计算 +DM/-DM 和真实波幅（TR）；这里的输入同样是
+// 单一价格流，没有独立的 High/Low，因此用相邻两次采样的价格变化近似 +DM/-DM，
+// 用变化幅度的绝对值近似 TR。窗口内使用 Wilder 平滑（与标准 ADX 一致的 RMA），
+// 值域仍落在 [0,100]，数值越大代表趋势越强（而非方向）。
+type ADX struct {
+	window  int
+	prev    float64
+	hasPrev bool
+
+	smoothedPlusDM  float64
+	smoothedMinusDM float64
+	smoothedTR      float64
+	samples         int
+
+	adx    float64
+	hasADX bool
+}
+
+// NewADX 创建一个窗口大小为 window 的 ADX 指标。
+func NewADX(window int) *ADX {
+	if window <= 0 {
+		window = 14
+	}
+	return &ADX{window: window}
+}
+
+// Update 喂入一个新的价格样本，返回当前 ADX 值（样本不足 window+1 个时返回 0, false）。
+func (a *ADX) Update(price float64) (value float64, ok bool) {
+	if !a.hasPrev {
+		a.prev = price
+		a.hasPrev = true
+		return 0, false
+	}
+
+	change := price - a.prev
+	a.prev = price
+
+	plusDM, minusDM := 0.0, 0.0
+	if change > 0 {
+		plusDM = change
+	} else if change < 0 {
+		minusDM = -change
+	}
+	tr := math.Abs(change)
+
+	n := float64(a.window)
+	if a.samples < a.window {
+		// Wilder 平滑的初始化阶段：先用简单累加，凑够 window 个样本后再启用 RMA。
+		a.smoothedPlusDM += plusDM
+		a.smoothedMinusDM += minusDM
+		a.smoothedTR += tr
+		a.samples++
+	} else {
+		a.smoothedPlusDM = a.smoothedPlusDM - a.smoothedPlusDM/n + plusDM
+		a.smoothedMinusDM = a.smoothedMinusDM - a.smoothedMinusDM/n + minusDM
+		a.smoothedTR = a.smoothedTR - a.smoothedTR/n + tr
+	}
+
+	if a.samples < a.window || a.smoothedTR == 0 {
+		return 0, false
+	}
+
+	plusDI := 100 * a.smoothedPlusDM / a.smoothedTR
+	minusDI := 100 * a.smoothedMinusDM / a.smoothedTR
+	diSum := plusDI + minusDI
+	dx := 0.0
+	if diSum > 0 {
+		dx = 100 * math.Abs(plusDI-minusDI) / diSum
+	}
+
+	if !a.hasADX {
+		a.adx = dx
+		a.hasADX = true
+	} else {
+		a.adx = (a.adx*(n-1) + dx) / n
+	}
+	return a.adx, true
+}
+
+// DI 返回最近一次 Update 之后的 +DI/-DI（窗口未填满前返回 0, false），供需要判断
+// 趋势方向（而非只看强度）的调用方使用，例如只在 +DI 与 -DI 的领先方向上放行入场。
+func (a *ADX) DI() (plusDI, minusDI float64, ok bool) {
+	if a.samples < a.window || a.smoothedTR == 0 {
+		return 0, 0, false
+	}
+	return 100 * a.smoothedPlusDM / a.smoothedTR, 100 * a.smoothedMinusDM / a.smoothedTR, true
+}
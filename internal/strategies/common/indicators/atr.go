@@ -0,0 +1,57 @@
+package indicators
+
+import "math"
+
+// ATR 是平均真实波幅（Average True Range）的滑动窗口实现，基于连续的 best-bid/
+// best-ask 快照构造“合成 K 线”：用每次快照的 (bid, ask) 当作这根合成 K 线的
+// low/high，用 mid 当作 close，从而在没有真实 OHLC 的 tick 级价格流上计算 TR。
+type ATR struct {
+	window int
+
+	prevClose float64
+	hasPrev   bool
+
+	smoothedTR float64
+	samples    int
+}
+
+// NewATR 创建一个窗口大小为 window 的 ATR 指标。
+func NewATR(window int) *ATR {
+	if window <= 0 {
+		window = 14
+	}
+	return &ATR{window: window}
+}
+
+// Update 喂入一次 (bestBid, bestAsk) 快照，返回当前 ATR（样本不足 window 个时
+// 返回 0, false）。bid/ask 任一 <= 0 时视为无效快照，直接忽略（不计入 TR）。
+func (a *ATR) Update(bid, ask float64) (value float64, ok bool) {
+	if bid <= 0 || ask <= 0 || ask < bid {
+		return a.current()
+	}
+
+	high, low := ask, bid
+	tr := high - low
+	if a.hasPrev {
+		tr = math.Max(tr, math.Abs(high-a.prevClose))
+		tr = math.Max(tr, math.Abs(low-a.prevClose))
+	}
+	a.prevClose = (high + low) / 2
+	a.hasPrev = true
+
+	n := float64(a.window)
+	if a.samples < a.window {
+		a.smoothedTR += tr
+		a.samples++
+	} else {
+		a.smoothedTR = a.smoothedTR - a.smoothedTR/n + tr
+	}
+	return a.current()
+}
+
+func (a *ATR) current() (float64, bool) {
+	if a.samples < a.window {
+		return 0, false
+	}
+	return a.smoothedTR / float64(a.window), true
+}
@@ -0,0 +1,49 @@
+package indicators
+
+import "time"
+
+// Bar 是按 1 秒宽度聚合出的 OHLC bar。
+type Bar struct {
+	Open, High, Low, Close float64
+	Start                  time.Time
+}
+
+// Range 返回这根 bar 的振幅（high-low），ATR/NR 都基于它。
+func (b Bar) Range() float64 { return b.High - b.Low }
+
+// BarBuilder 把散点价格 tick 聚合成固定 1 秒宽度的 bar：同一秒内的 tick 更新
+// High/Low/Close，一旦 tick 的时间戳跨入下一秒，上一根 bar 就收盘。
+type BarBuilder struct {
+	cur Bar
+	has bool
+}
+
+// NewBarBuilder 创建一个空的 1 秒 bar 聚合器。
+func NewBarBuilder() *BarBuilder { return &BarBuilder{} }
+
+// Update 喂入一个 (price, ts) tick；如果这个 tick 使当前 bar 收盘（ts 落在了
+// 下一秒），返回收盘的 bar 和 true，并用这个 tick 开启下一根 bar。
+func (b *BarBuilder) Update(price float64, ts time.Time) (closed Bar, ok bool) {
+	sec := ts.Truncate(time.Second)
+
+	if !b.has {
+		b.cur = Bar{Open: price, High: price, Low: price, Close: price, Start: sec}
+		b.has = true
+		return Bar{}, false
+	}
+
+	if sec.Equal(b.cur.Start) {
+		if price > b.cur.High {
+			b.cur.High = price
+		}
+		if price < b.cur.Low {
+			b.cur.Low = price
+		}
+		b.cur.Close = price
+		return Bar{}, false
+	}
+
+	closed = b.cur
+	b.cur = Bar{Open: price, High: price, Low: price, Close: price, Start: sec}
+	return closed, true
+}
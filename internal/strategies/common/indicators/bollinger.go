@@ -0,0 +1,54 @@
+package indicators
+
+import "math"
+
+// Bollinger 是滑动窗口布林带指标：中轨为窗口 SMA，上/下轨为中轨 ± bandWidth 倍标准差。
+type Bollinger struct {
+	window    *ring
+	bandWidth float64
+}
+
+// NewBollinger 创建一个窗口大小为 window、带宽倍数为 bandWidth 的布林带指标。
+// bandWidth <= 0 时使用标准值 2.0。
+func NewBollinger(window int, bandWidth float64) *Bollinger {
+	if bandWidth <= 0 {
+		bandWidth = 2.0
+	}
+	return &Bollinger{window: newRing(window), bandWidth: bandWidth}
+}
+
+// BollingerBands 是一次布林带计算的结果。
+type BollingerBands struct {
+	Mid   float64
+	Upper float64
+	Lower float64
+}
+
+// Update 喂入一个新的价格样本，返回当前布林带（窗口未填满前返回零值, false）。
+func (b *Bollinger) Update(price float64) (bands BollingerBands, ok bool) {
+	b.window.Push(price)
+	if !b.window.Full() {
+		return BollingerBands{}, false
+	}
+
+	n := b.window.Len()
+	sma := 0.0
+	for i := 0; i < n; i++ {
+		sma += b.window.At(i)
+	}
+	sma /= float64(n)
+
+	variance := 0.0
+	for i := 0; i < n; i++ {
+		d := b.window.At(i) - sma
+		variance += d * d
+	}
+	variance /= float64(n)
+	stddev := math.Sqrt(variance)
+
+	return BollingerBands{
+		Mid:   sma,
+		Upper: sma + b.bandWidth*stddev,
+		Lower: sma - b.bandWidth*stddev,
+	}, true
+}
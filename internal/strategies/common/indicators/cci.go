@@ -0,0 +1,48 @@
+package indicators
+
+// cciConstant 是 CCI 的标准常数，使得约 70%-80% 的取值落在 [-100, 100] 区间内。
+const cciConstant = 0.015
+
+// CCI 是顺势指标（Commodity Channel Index）的滑动窗口实现。
+//
+// 标准 CCI 基于典型价格 (High+Low+Close)/3；这里的输入是单一价格流（token 的
+// 成交/盘口价），没有独立的 High/Low，因此直接把每次采样的价格当作典型价格，
+// 这是对 tick 级单价序列做 CCI 的常见简化。
+type CCI struct {
+	window *ring
+}
+
+// NewCCI 创建一个窗口大小为 window 的 CCI 指标。
+func NewCCI(window int) *CCI {
+	return &CCI{window: newRing(window)}
+}
+
+// Update 喂入一个新的价格样本，返回当前 CCI 值（窗口未填满前返回 0, false）。
+func (c *CCI) Update(price float64) (value float64, ok bool) {
+	c.window.Push(price)
+	if !c.window.Full() {
+		return 0, false
+	}
+
+	n := c.window.Len()
+	sma := 0.0
+	for i := 0; i < n; i++ {
+		sma += c.window.At(i)
+	}
+	sma /= float64(n)
+
+	meanDeviation := 0.0
+	for i := 0; i < n; i++ {
+		d := c.window.At(i) - sma
+		if d < 0 {
+			d = -d
+		}
+		meanDeviation += d
+	}
+	meanDeviation /= float64(n)
+
+	if meanDeviation == 0 {
+		return 0, true
+	}
+	return (price - sma) / (cciConstant * meanDeviation), true
+}
@@ -0,0 +1,34 @@
+package indicators
+
+// NRDetector 检测 N-bar Narrow Range（窄幅区间）压缩：当最近一根 bar 的
+// range（high-low）是最近 lookback 根里最小的（并列也算），标记为 NR-n。
+// 常见用法是把它和 ATR 配合：NR-n 之后的放量突破比普通波动更可能延续。
+type NRDetector struct {
+	ranges   *ring
+	lookback int
+}
+
+// NewNRDetector 创建一个回看 lookback 根 bar 的 NR 探测器；lookback < 2 时
+// 用 2（至少要能比较“当前 bar”和“前一根 bar”）。
+func NewNRDetector(lookback int) *NRDetector {
+	if lookback < 2 {
+		lookback = 2
+	}
+	return &NRDetector{ranges: newRing(lookback), lookback: lookback}
+}
+
+// Update 喂入一根新 bar 的 range（high-low），返回这根 bar 是否构成 NR-n
+// （窗口未填满 lookback 根时 ok=false）。
+func (d *NRDetector) Update(barRange float64) (isNR bool, ok bool) {
+	d.ranges.Push(barRange)
+	if !d.ranges.Full() {
+		return false, false
+	}
+	n := d.ranges.Len()
+	for i := 0; i < n; i++ {
+		if d.ranges.At(i) < barRange {
+			return false, true
+		}
+	}
+	return true, true
+}
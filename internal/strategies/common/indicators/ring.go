@@ -0,0 +1,46 @@
+package indicators
+
+// ring 是一个固定容量的 float64 环形缓冲区，用于滑动窗口类指标（CCI/Bollinger/NR）
+// 的底层存储：按插入顺序覆盖最旧的样本，避免每次计算都重新分配切片。
+type ring struct {
+	buf   []float64
+	head  int // 下一次写入的位置
+	count int // 已写入的样本数（<= cap）
+}
+
+func newRing(capacity int) *ring {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &ring{buf: make([]float64, capacity)}
+}
+
+func (r *ring) Push(v float64) {
+	r.buf[r.head] = v
+	r.head = (r.head + 1) % len(r.buf)
+	if r.count < len(r.buf) {
+		r.count++
+	}
+}
+
+func (r *ring) Len() int { return r.count }
+
+func (r *ring) Full() bool { return r.count == len(r.buf) }
+
+// At 按从旧到新的顺序返回第 i 个样本（0 表示窗口内最旧的样本）。
+func (r *ring) At(i int) float64 {
+	start := r.head - r.count
+	if start < 0 {
+		start += len(r.buf)
+	}
+	idx := (start + i) % len(r.buf)
+	return r.buf[idx]
+}
+
+// Last 返回最近一次 Push 的值，空缓冲区返回 0, false。
+func (r *ring) Last() (float64, bool) {
+	if r.count == 0 {
+		return 0, false
+	}
+	return r.At(r.count - 1), true
+}
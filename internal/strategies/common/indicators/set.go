@@ -0,0 +1,62 @@
+package indicators
+
+// Config 描述一个 Set 的窗口/参数配置，供策略从自己的 Config 结构体转换而来。
+type Config struct {
+	CCIWindow          int
+	BollingerWindow    int
+	BollingerBandWidth float64
+	ADXWindow          int
+}
+
+// Set 是某个 token 价格流上的一组指标（CCI + Bollinger + ADX），按到达顺序
+// 喂入同一个价格样本即可同时驱动三个指标，避免各自维护独立的滑动窗口。
+type Set struct {
+	cci       *CCI
+	bollinger *Bollinger
+	adx       *ADX
+
+	last Snapshot
+}
+
+// Snapshot 是一次 Update 之后三个指标的快照，用于阶段切换日志等调试输出。
+type Snapshot struct {
+	Price   float64
+	CCI     float64
+	CCIOk   bool
+	Bands   BollingerBands
+	BandsOk bool
+	ADX     float64
+	ADXOk   bool
+}
+
+// NewSet 按 cfg 创建一个指标集合。
+func NewSet(cfg Config) *Set {
+	return &Set{
+		cci:       NewCCI(cfg.CCIWindow),
+		bollinger: NewBollinger(cfg.BollingerWindow, cfg.BollingerBandWidth),
+		adx:       NewADX(cfg.ADXWindow),
+	}
+}
+
+// Update 喂入一个新的价格样本，更新全部三个指标并返回最新快照。
+func (s *Set) Update(price float64) Snapshot {
+	cciVal, cciOk := s.cci.Update(price)
+	bands, bandsOk := s.bollinger.Update(price)
+	adxVal, adxOk := s.adx.Update(price)
+
+	s.last = Snapshot{
+		Price:   price,
+		CCI:     cciVal,
+		CCIOk:   cciOk,
+		Bands:   bands,
+		BandsOk: bandsOk,
+		ADX:     adxVal,
+		ADXOk:   adxOk,
+	}
+	return s.last
+}
+
+// Last 返回最近一次 Update 的快照（未 Update 过时为零值）。
+func (s *Set) Last() Snapshot {
+	return s.last
+}
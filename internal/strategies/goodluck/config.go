@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/betbot/gobet/internal/common"
+	corebrain "github.com/betbot/gobet/internal/strategycore/brain"
 )
 
 const ID = "goodluck"
@@ -498,3 +499,7 @@ func (c *Config) GetMaxSpreadVolatilityPercent() float64 { return c.MaxSpreadVol
 func (c *Config) GetPriceStabilityMaxSpreadFilterCents() int {
 	return c.PriceStabilityMaxSpreadFilterCents
 }
+
+// GetPositionMode 实现 strategycore/brain.ConfigInterface：goodluck 目前只按
+// 净仓位（NetMode）做决策，还不支持双向持仓记账。
+func (c *Config) GetPositionMode() corebrain.PositionMode { return corebrain.NetMode }
@@ -72,6 +72,18 @@ type Config struct {
 	MaxEntriesPerPeriod int `json:"maxEntriesPerPeriod" yaml:"maxEntriesPerPeriod"`
 	// 限制：最多同时挂多少笔“入场单”（不含止盈单）
 	MaxOpenEntryOrders int `json:"maxOpenEntryOrders" yaml:"maxOpenEntryOrders"`
+
+	// ===== ADX 趋势过滤（见 adx_regime.go） =====
+	// 启用后按 UP 币中间价合成的 K 线计算 ADX 趋势强度，分三档约束新增入场：
+	// ADX>=High 视为强趋势，完全冻结新增（可选撤单，见 CancelEntryOrdersOnFreeze）；
+	// Medium<=ADX<High 只允许顺着 +DI/-DI 领先方向的那一侧入场；ADX<Medium 不做限制。
+	// ADXLowSingle 只在 Validate 里校验 Low<Medium<High，不参与分档。
+	EnableADX       bool    `json:"enableADX" yaml:"enableADX"`
+	ADXWindow       int     `json:"adxWindow" yaml:"adxWindow"`
+	ADXBarMs        int     `json:"adxBarMs" yaml:"adxBarMs"` // 合成 K 线的时间窗口（毫秒）
+	ADXHighSingle   float64 `json:"adxHighSingle" yaml:"adxHighSingle"`
+	ADXMediumSingle float64 `json:"adxMediumSingle" yaml:"adxMediumSingle"`
+	ADXLowSingle    float64 `json:"adxLowSingle" yaml:"adxLowSingle"`
 }
 
 func (c *Config) WaitForRoundCompleteEnabled() bool {
@@ -171,6 +183,29 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("freezeLowCents 建议在 [0,50] 范围内")
 	}
 
+	// ADX 趋势过滤校验
+	if c.EnableADX {
+		if c.ADXWindow <= 0 {
+			c.ADXWindow = 14
+		}
+		if c.ADXBarMs <= 0 {
+			c.ADXBarMs = 60000
+		}
+		if c.ADXHighSingle <= 0 {
+			c.ADXHighSingle = 40
+		}
+		if c.ADXMediumSingle <= 0 {
+			c.ADXMediumSingle = 20
+		}
+		if c.ADXLowSingle <= 0 {
+			c.ADXLowSingle = 15
+		}
+		if !(c.ADXLowSingle < c.ADXMediumSingle && c.ADXMediumSingle < c.ADXHighSingle) {
+			return fmt.Errorf("enableADX 时必须满足 adxLowSingle < adxMediumSingle < adxHighSingle，当前值: low=%.2f medium=%.2f high=%.2f",
+				c.ADXLowSingle, c.ADXMediumSingle, c.ADXHighSingle)
+		}
+	}
+
 	return nil
 }
 
@@ -65,6 +65,9 @@ type Strategy struct {
 	tracked map[string]*trackedOrder
 	// 已经使用过的 gridLevel（防止重复"同一层级反复入场"）
 	usedLevel map[domain.TokenType]map[int]bool
+
+	// ADX 趋势过滤（见 adx_regime.go，EnableADX=false 时保持 nil）
+	adxGate *adxGate
 }
 
 type trackedOrderKind string
@@ -124,6 +127,9 @@ func (s *Strategy) Initialize() error {
 	if s.roundStartTime == nil {
 		s.roundStartTime = make(map[int]time.Time)
 	}
+	if s.EnableADX && s.adxGate == nil {
+		s.adxGate = newADXGate(s.Config)
+	}
 	return nil
 }
 
@@ -297,6 +303,20 @@ func (s *Strategy) processPrice(ctx context.Context, e *events.PriceChangedEvent
 		return
 	}
 
+	// ADX 趋势过滤：用 UP 币价格推进合成 K 线，再按当前档位判断该 token 是否允许入场
+	if s.adxGate != nil {
+		if e.TokenType == domain.TokenTypeUp {
+			s.adxGate.Update(float64(e.NewPrice.Cents), now)
+		}
+		if regime, value := s.adxGate.Regime(); !s.adxGate.AllowsEntry(e.TokenType) {
+			log.Infof("🔍 [grid] processPrice: ADX 趋势过滤，跳过 token=%s price=%dc regime=%s adx=%.2f", e.TokenType, e.NewPrice.Cents, regime, value)
+			if s.CancelEntryOrdersOnFreeze && regime == adxRegimeHigh {
+				s.cancelAllEntryOrders(ctx, m.Slug)
+			}
+			return
+		}
+	}
+
 	// 限制并发入场单数量
 	if s.countOpenEntryOrders(m.Slug) >= s.MaxOpenEntryOrders {
 		return
@@ -17,14 +17,28 @@ func (a *MomentumConfigAdapter) AdaptConfig(strategyConfig interface{}, proxyCon
 		ID,
 		func(cfg config.StrategyConfig) *config.MomentumConfig { return cfg.Momentum },
 		func(c *config.MomentumConfig) (*MomentumStrategyConfig, error) {
+			assets := make([]string, 0, len(c.Assets))
+			for _, a := range c.Assets {
+				assets = append(assets, strings.ToUpper(strings.TrimSpace(a)))
+			}
+			overrides := make(map[string]AssetOverride, len(c.Overrides))
+			for sym, ov := range c.Overrides {
+				overrides[strings.ToUpper(strings.TrimSpace(sym))] = AssetOverride{
+					ThresholdBps: ov.ThresholdBps,
+					SizeUSDC:     ov.SizeUSDC,
+					MinEdgeCents: ov.MinEdgeCents,
+				}
+			}
 			out := &MomentumStrategyConfig{
 				Asset:          strings.ToUpper(strings.TrimSpace(c.Asset)),
+				Assets:         assets,
 				SizeUSDC:       c.SizeUSDC,
 				ThresholdBps:   c.ThresholdBps,
 				WindowSecs:     c.WindowSecs,
 				MinEdgeCents:   c.MinEdgeCents,
 				CooldownSecs:   c.CooldownSecs,
 				UsePolygonFeed: c.UsePolygonFeed,
+				Overrides:      overrides,
 			}
 			if err := out.Validate(); err != nil {
 				return nil, err
@@ -7,9 +7,24 @@ import (
 	"github.com/betbot/gobet/internal/strategies/common"
 )
 
+// AssetOverride 为篮子里的单个资产覆盖顶层的交易参数。0 值表示不覆盖，沿用
+// 顶层配置；用来表达“整个篮子一起跑，但流动性差的资产收紧阈值/缩小仓位”。
+type AssetOverride struct {
+	ThresholdBps int     `json:"thresholdBps,omitempty" yaml:"thresholdBps,omitempty"`
+	SizeUSDC     float64 `json:"sizeUSDC,omitempty" yaml:"sizeUSDC,omitempty"`
+	MinEdgeCents int     `json:"minEdgeCents,omitempty" yaml:"minEdgeCents,omitempty"`
+}
+
 // MomentumStrategyConfig 动量策略配置（来自 pkg/config.MomentumConfig 的适配结果）。
 type MomentumStrategyConfig struct {
-	Asset          string  `json:"asset" yaml:"asset"`
+	// Asset 已废弃：单资产时代的字段，仅为兼容旧配置写法保留。新配置请使用
+	// Assets；Validate 会在 Assets 为空时把 Asset 并入 Assets，并在 Assets
+	// 非空时把 Asset 回填成 Assets[0]，供仍按旧字段读取的调用方使用。
+	Asset string `json:"asset,omitempty" yaml:"asset,omitempty"`
+
+	// Assets 这个策略实例监听并可交易的资产篮子（大写 symbol，例如 "BTC"、"ETH"）。
+	Assets []string `json:"assets" yaml:"assets"`
+
 	SizeUSDC       float64 `json:"sizeUSDC" yaml:"sizeUSDC"`
 	ThresholdBps   int     `json:"thresholdBps" yaml:"thresholdBps"`
 	WindowSecs     int     `json:"windowSecs" yaml:"windowSecs"`
@@ -17,6 +32,19 @@ type MomentumStrategyConfig struct {
 	CooldownSecs   int     `json:"cooldownSecs" yaml:"cooldownSecs"`
 	UsePolygonFeed bool    `json:"usePolygonFeed" yaml:"usePolygonFeed"`
 
+	// Overrides 按资产（大写 symbol）覆盖 ThresholdBps/SizeUSDC/MinEdgeCents。
+	// key 必须出现在 Assets 里，否则 Validate 会报错。
+	Overrides map[string]AssetOverride `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+
+	// AtrPeriod 非 0 时启用“波动率自适应阈值”：ThresholdBps 不再是一个固定值，
+	// 改用 NR-n 压缩之后、突破幅度超过 AtrMultiplier * ATR 作为触发条件（见
+	// strategy.go 里的 volTracker）。为 0（默认）时完全走原来的固定 bps 阈值路径。
+	AtrPeriod int `json:"atrPeriod,omitempty" yaml:"atrPeriod,omitempty"`
+	// NrLookback 是 NR-n 压缩探测器回看的 bar 数量，AtrPeriod 启用时必须 >= 2。
+	NrLookback int `json:"nrLookback,omitempty" yaml:"nrLookback,omitempty"`
+	// AtrMultiplier 是触发所需的“突破幅度 / ATR”倍数，默认 2.0。
+	AtrMultiplier float64 `json:"atrMultiplier,omitempty" yaml:"atrMultiplier,omitempty"`
+
 	AutoMerge common.AutoMergeConfig `yaml:"autoMerge" json:"autoMerge"`
 }
 
@@ -30,9 +58,31 @@ func (c *MomentumStrategyConfig) Validate() error {
 		return fmt.Errorf("配置为空")
 	}
 	c.AutoMerge.Normalize()
-	if strings.TrimSpace(c.Asset) == "" {
-		return fmt.Errorf("asset 不能为空")
+
+	// 兼容旧的单资产配置：Assets 为空时用 Asset 兜底。
+	if len(c.Assets) == 0 && strings.TrimSpace(c.Asset) != "" {
+		c.Assets = []string{c.Asset}
+	}
+	if len(c.Assets) == 0 {
+		return fmt.Errorf("assets 不能为空")
 	}
+
+	seen := make(map[string]bool, len(c.Assets))
+	normalized := make([]string, 0, len(c.Assets))
+	for _, a := range c.Assets {
+		sym := strings.ToUpper(strings.TrimSpace(a))
+		if sym == "" {
+			return fmt.Errorf("assets 中存在空白资产 ID")
+		}
+		if seen[sym] {
+			return fmt.Errorf("assets 中存在重复资产: %s", sym)
+		}
+		seen[sym] = true
+		normalized = append(normalized, sym)
+	}
+	c.Assets = normalized
+	c.Asset = c.Assets[0] // 回填旧字段，兼容仍在读取 Asset 的调用方
+
 	if c.SizeUSDC <= 0 {
 		return fmt.Errorf("size_usdc 必须大于 0")
 	}
@@ -48,6 +98,54 @@ func (c *MomentumStrategyConfig) Validate() error {
 	if c.CooldownSecs < 0 {
 		return fmt.Errorf("cooldown_secs 不能为负数")
 	}
+
+	if c.AtrPeriod < 0 {
+		return fmt.Errorf("atr_period 不能为负数")
+	}
+	if c.AtrPeriod > 0 {
+		if c.NrLookback < 2 {
+			return fmt.Errorf("atr_period 启用波动率自适应阈值时，nr_lookback 必须 >= 2")
+		}
+		if c.AtrMultiplier <= 0 {
+			c.AtrMultiplier = 2.0
+		}
+	}
+
+	for sym, ov := range c.Overrides {
+		upperSym := strings.ToUpper(strings.TrimSpace(sym))
+		if !seen[upperSym] {
+			return fmt.Errorf("overrides 中的资产 %s 不在 assets 篮子里", sym)
+		}
+		if ov.ThresholdBps < 0 {
+			return fmt.Errorf("overrides[%s].threshold_bps 不能为负数", sym)
+		}
+		if ov.SizeUSDC < 0 {
+			return fmt.Errorf("overrides[%s].size_usdc 不能为负数", sym)
+		}
+		if ov.MinEdgeCents < 0 {
+			return fmt.Errorf("overrides[%s].min_edge_cents 不能为负数", sym)
+		}
+	}
+
 	return nil
 }
 
+// Effective 返回 sym 这个资产实际生效的 (thresholdBps, sizeUSDC, minEdgeCents)，
+// 在 Overrides 里有对应非零值时覆盖顶层配置，否则回落到顶层配置。
+func (c *MomentumStrategyConfig) Effective(sym string) (thresholdBps int, sizeUSDC float64, minEdgeCents int) {
+	thresholdBps, sizeUSDC, minEdgeCents = c.ThresholdBps, c.SizeUSDC, c.MinEdgeCents
+	ov, ok := c.Overrides[strings.ToUpper(strings.TrimSpace(sym))]
+	if !ok {
+		return
+	}
+	if ov.ThresholdBps > 0 {
+		thresholdBps = ov.ThresholdBps
+	}
+	if ov.SizeUSDC > 0 {
+		sizeUSDC = ov.SizeUSDC
+	}
+	if ov.MinEdgeCents > 0 {
+		minEdgeCents = ov.MinEdgeCents
+	}
+	return
+}
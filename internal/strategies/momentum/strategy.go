@@ -11,6 +11,7 @@ import (
 	"github.com/betbot/gobet/internal/domain"
 	"github.com/betbot/gobet/internal/events"
 	"github.com/betbot/gobet/internal/strategies/common"
+	"github.com/betbot/gobet/internal/strategies/common/indicators"
 	"github.com/betbot/gobet/internal/strategies/orderutil"
 	strategyports "github.com/betbot/gobet/internal/strategies/ports"
 	"github.com/betbot/gobet/pkg/bbgo"
@@ -65,7 +66,82 @@ type MomentumStrategy struct {
 	mu            sync.RWMutex
 	currentMarket *domain.Market
 	marketGuard   common.MarketSlugGuard
-	tradeCooldown *common.Debouncer
+
+	// cooldowns 按资产（大写 symbol）维护独立的冷却计时器：篮子里一个资产
+	// 连续触发信号，不应该挤占另一个资产的下单窗口。
+	cooldowns map[string]*common.Debouncer
+
+	// Clock 可选的时间源；为空时用 time.Now。回放时可注入 ReplayClient 的模拟时钟
+	// （pkg/sdk/websocket.ReplayClient.Clock().Now），让 CooldownSecs 按历史事件的时间间隔推进，
+	// 而不是按 wall clock，这样同一段历史数据不管是实盘还是回放都触发同样的
+	// 冷却行为。
+	Clock func() time.Time `json:"-" yaml:"-"`
+
+	// volTrackers 按资产维护“估计公平价”的 1 秒 bar/ATR/NR-n 状态，仅在
+	// cfg.AtrPeriod > 0 时使用，驱动波动率自适应阈值（见 checkVolatilityGate）。
+	volTrackers map[string]*volTracker
+}
+
+// volTracker 是单个资产的波动率自适应阈值状态：用 BarBuilder 把价格 tick
+// 聚合成 1 秒 bar，喂给 ATR 和 NR-n 探测器。
+type volTracker struct {
+	bars *indicators.BarBuilder
+	atr  *indicators.ATR
+	nr   *indicators.NRDetector
+
+	lastClosedWasNR bool
+}
+
+func newVolTracker(cfg *MomentumStrategyConfig) *volTracker {
+	return &volTracker{
+		bars: indicators.NewBarBuilder(),
+		atr:  indicators.NewATR(cfg.AtrPeriod),
+		nr:   indicators.NewNRDetector(cfg.NrLookback),
+	}
+}
+
+// checkVolatilityGate 喂入本次信号对应的“估计公平价”tick，推进该资产的 1 秒
+// bar/ATR/NR-n 状态；只有上一根收盘 bar 是 NR-n 压缩、且这一根刚收盘的 bar
+// 振幅超过 AtrMultiplier*ATR 时才放行，实现“窄幅压缩后放量突破”的过滤。
+// tick 没有让 bar 收盘（同一秒内）时直接返回 false，等下一个跨秒的 tick 再判断。
+func (s *MomentumStrategy) checkVolatilityGate(cfg *MomentumStrategyConfig, asset string, price float64, ts time.Time) bool {
+	vt := s.volTrackerFor(asset)
+	closedBar, closed := vt.bars.Update(price, ts)
+	if !closed {
+		return false
+	}
+
+	atrVal, atrOk := vt.atr.Update(closedBar.Low, closedBar.High)
+	wasNR := vt.lastClosedWasNR
+	if isNR, nrOk := vt.nr.Update(closedBar.Range()); nrOk {
+		vt.lastClosedWasNR = isNR
+	}
+
+	if !atrOk || !wasNR {
+		return false
+	}
+	return closedBar.Range() > cfg.AtrMultiplier*atrVal
+}
+
+func (s *MomentumStrategy) volTrackerFor(asset string) *volTracker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.volTrackers == nil {
+		s.volTrackers = make(map[string]*volTracker)
+	}
+	vt, ok := s.volTrackers[asset]
+	if !ok {
+		vt = newVolTracker(s.config)
+		s.volTrackers[asset] = vt
+	}
+	return vt
+}
+
+func (s *MomentumStrategy) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock()
+	}
+	return time.Now()
 }
 
 func (s *MomentumStrategy) ID() string   { return ID }
@@ -89,17 +165,49 @@ func (s *MomentumStrategy) Initialize() error {
 	if err := s.MomentumStrategyConfig.Validate(); err != nil {
 		return err
 	}
-	if s.tradeCooldown == nil {
-		s.tradeCooldown = common.NewDebouncer(time.Duration(s.CooldownSecs) * time.Second)
-	} else {
-		s.tradeCooldown.SetInterval(time.Duration(s.CooldownSecs) * time.Second)
-		s.tradeCooldown.Reset()
+
+	interval := time.Duration(s.CooldownSecs) * time.Second
+	if s.cooldowns == nil {
+		s.cooldowns = make(map[string]*common.Debouncer, len(s.Assets))
 	}
-	log.Infof("动量策略初始化: asset=%s size=$%.2f threshold=%dbps window=%ds edge=%dc cooldown=%ds polygon=%v",
-		s.Asset, s.SizeUSDC, s.ThresholdBps, s.WindowSecs, s.MinEdgeCents, s.CooldownSecs, s.UsePolygonFeed)
+	for _, asset := range s.Assets {
+		if cd, ok := s.cooldowns[asset]; ok {
+			cd.SetInterval(interval)
+			cd.Reset()
+			continue
+		}
+		s.cooldowns[asset] = common.NewDebouncer(interval)
+	}
+
+	log.Infof("动量策略初始化: assets=%v size=$%.2f threshold=%dbps window=%ds edge=%dc cooldown=%ds polygon=%v overrides=%d",
+		s.Assets, s.SizeUSDC, s.ThresholdBps, s.WindowSecs, s.MinEdgeCents, s.CooldownSecs, s.UsePolygonFeed, len(s.Overrides))
 	return nil
 }
 
+// cooldownFor 返回 asset 对应的冷却计时器；Initialize 之后篮子内的资产都已
+// 预先建好，这里兜底处理 Initialize 之前/配置之外的资产，避免 nil 解引用。
+func (s *MomentumStrategy) cooldownFor(asset string) *common.Debouncer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cooldowns == nil {
+		s.cooldowns = make(map[string]*common.Debouncer)
+	}
+	cd, ok := s.cooldowns[asset]
+	if !ok {
+		cd = common.NewDebouncer(time.Duration(s.CooldownSecs) * time.Second)
+		s.cooldowns[asset] = cd
+	}
+	return cd
+}
+
+// resetCooldowns 在周期切换时重置所有资产的冷却，避免新周期被旧周期的
+// cooldown 误伤。
+func (s *MomentumStrategy) resetCooldowns() {
+	for _, cd := range s.cooldowns {
+		cd.Reset()
+	}
+}
+
 // Subscribe 订阅会话事件：这里只用于更新当前 market（周期切换时自动跟随）。
 func (s *MomentumStrategy) Subscribe(session *bbgo.ExchangeSession) {
 	session.OnPriceChanged(s)
@@ -113,10 +221,7 @@ func (s *MomentumStrategy) OnPriceChanged(ctx context.Context, event *events.Pri
 	}
 	s.mu.Lock()
 	if s.marketGuard.Update(event.Market.Slug) {
-		// 周期切换：重置冷却，避免新周期被旧周期的 cooldown 误伤
-		if s.tradeCooldown != nil {
-			s.tradeCooldown.Reset()
-		}
+		s.resetCooldowns()
 	}
 	s.currentMarket = event.Market
 	s.mu.Unlock()
@@ -131,9 +236,7 @@ func (s *MomentumStrategy) Run(ctx context.Context, orderExecutor bbgo.OrderExec
 		if m := session.Market(); m != nil {
 			s.mu.Lock()
 			if s.marketGuard.Update(m.Slug) {
-				if s.tradeCooldown != nil {
-					s.tradeCooldown.Reset()
-				}
+				s.resetCooldowns()
 			}
 			s.currentMarket = m
 			s.mu.Unlock()
@@ -159,9 +262,13 @@ func (s *MomentumStrategy) startLoop(parent context.Context) {
 		func(loopCtx context.Context, _ <-chan time.Time) {
 			s.signalC = make(chan MomentumSignal, 1024)
 
-			// 外部行情源：Polygon
+			// 外部行情源：Polygon，一路连接、一路订阅，篮子里所有资产共用同一个
+			// feed；assetFilter 传空串表示不按单一资产过滤（多资产场景）。
+			// 各资产精确的阈值/仓位/edge 差异由 handleSignal 里的 Effective()
+			// 二次把关，feed 这一层只用篮子里最松的阈值做粗过滤，避免漏掉任何
+			// 一个资产的信号。
 			if s.config != nil && s.config.UsePolygonFeed {
-				go runPolygonFeed(loopCtx, s.config.Asset, s.config.ThresholdBps, s.config.WindowSecs, s.signalC, log)
+				go runPolygonFeed(loopCtx, s.feedAssetFilter(), s.minThresholdBps(), s.config.WindowSecs, s.signalC, log)
 			}
 
 			s.loop(loopCtx)
@@ -169,6 +276,27 @@ func (s *MomentumStrategy) startLoop(parent context.Context) {
 	)
 }
 
+// feedAssetFilter 篮子只有一个资产时沿用旧行为（精确过滤），篮子里有多个
+// 资产时传空串给 runPolygonFeed，表示不按资产过滤（由调用方自己再按资产分派）。
+func (s *MomentumStrategy) feedAssetFilter() string {
+	if len(s.Assets) == 1 {
+		return s.Assets[0]
+	}
+	return ""
+}
+
+// minThresholdBps 取篮子里所有资产（含 Overrides）生效阈值的最小值，用作
+// feed 层的粗过滤下限，避免把阈值更低的资产提前丢弃。
+func (s *MomentumStrategy) minThresholdBps() int {
+	min := s.ThresholdBps
+	for _, asset := range s.Assets {
+		if thresholdBps, _, _ := s.MomentumStrategyConfig.Effective(asset); thresholdBps < min {
+			min = thresholdBps
+		}
+	}
+	return min
+}
+
 func (s *MomentumStrategy) loop(ctx context.Context) {
 	for {
 		select {
@@ -185,19 +313,37 @@ func (s *MomentumStrategy) handleSignal(ctx context.Context, sig MomentumSignal)
 	ts := s.tradingService
 	cfg := s.config
 	market := s.currentMarket
-	cooldown := s.tradeCooldown
 	s.mu.RUnlock()
 
 	if ts == nil || cfg == nil || market == nil {
 		return nil
 	}
 
-	// 冷却：通过 Debouncer 统一实现；interval=0 等价于不冷却
-	if cooldown != nil {
-		ready, _ := cooldown.ReadyNow()
-		if !ready {
+	// 每个资产独立的阈值/仓位/edge，外部 feed 只做粗过滤，这里是精确把关。
+	thresholdBps, sizeUSDC, minEdgeCents := cfg.Effective(sig.Asset)
+	absMove := int(math.Abs(float64(sig.MoveBps)))
+
+	// “估计公平价”：50¢ ± f(move)（与外部示例一致，属于启发式）；波动率过滤
+	// 模式下也拿它当 bar 聚合的输入 tick 价格。
+	estimatedFair := 50 + absMove/10 // 每 10bps ≈ 1¢
+
+	if cfg.AtrPeriod > 0 {
+		// 波动率自适应模式：固定 bps 阈值让位给“NR-n 压缩后放量突破”过滤。
+		if !s.checkVolatilityGate(cfg, sig.Asset, float64(estimatedFair), sig.FiredAt) {
 			return nil
 		}
+	} else if absMove < thresholdBps {
+		return nil
+	}
+
+	// 冷却：每个资产一个独立 Debouncer，避免一个资产的信号风暴挤占其他资产；
+	// 用 s.now() 而不是 ReadyNow()/MarkNow()，这样注入了回放时钟时冷却判断
+	// 跟着历史事件的时间走。
+	cooldown := s.cooldownFor(sig.Asset)
+	now := s.now()
+	ready, _ := cooldown.Ready(now)
+	if !ready {
+		return nil
 	}
 
 	// 决策：Up -> 买 UP（YES），Down -> 买 DOWN（NO）
@@ -207,24 +353,17 @@ func (s *MomentumStrategy) handleSignal(ctx context.Context, sig MomentumSignal)
 	}
 	assetID := market.GetAssetID(tokenType)
 
-	// “估计公平价”：50¢ ± f(move)（与外部示例一致，属于启发式）
-	absMove := int(math.Abs(float64(sig.MoveBps)))
-	estimatedFair := 50 + absMove/10 // 每 10bps ≈ 1¢
-	maxPay := estimatedFair - cfg.MinEdgeCents
+	maxPay := estimatedFair - minEdgeCents
 	if maxPay < 1 {
 		maxPay = 1
 	}
 
 	// 将网络 IO 投递到全局串行执行器，避免阻塞策略 loop
 	if s.Executor == nil {
-		if err := s.placeFAK(ctx, ts, market, tokenType, assetID, cfg.SizeUSDC, maxPay, sig); err != nil {
+		if err := s.placeFAK(ctx, ts, market, tokenType, assetID, sizeUSDC, maxPay, sig); err != nil {
 			return err
 		}
-		s.mu.Lock()
-		if s.tradeCooldown != nil {
-			s.tradeCooldown.MarkNow()
-		}
-		s.mu.Unlock()
+		cooldown.Mark(now)
 		return nil
 	}
 
@@ -232,7 +371,7 @@ func (s *MomentumStrategy) handleSignal(ctx context.Context, sig MomentumSignal)
 		Name:    fmt.Sprintf("momentum_%s_%s_%dbps", sig.Asset, map[Direction]string{DirectionUp: "up", DirectionDown: "down"}[sig.Dir], absMove),
 		Timeout: 25 * time.Second,
 		Do: func(runCtx context.Context) {
-			_ = s.placeFAK(runCtx, ts, market, tokenType, assetID, cfg.SizeUSDC, maxPay, sig)
+			_ = s.placeFAK(runCtx, ts, market, tokenType, assetID, sizeUSDC, maxPay, sig)
 		},
 	})
 	if !ok {
@@ -240,11 +379,7 @@ func (s *MomentumStrategy) handleSignal(ctx context.Context, sig MomentumSignal)
 	}
 
 	// 成功投递后记录 cooldown（避免同一信号风暴提交大量 command）
-	s.mu.Lock()
-	if s.tradeCooldown != nil {
-		s.tradeCooldown.MarkNow()
-	}
-	s.mu.Unlock()
+	cooldown.Mark(now)
 	return nil
 }
 
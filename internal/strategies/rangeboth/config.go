@@ -46,6 +46,10 @@ type Config struct {
 
 	// 默认关闭自动对冲（本策略是双边同时挂单，不做自动平衡）
 	AutoMerge common.AutoMergeConfig `yaml:"autoMerge" json:"autoMerge"`
+
+	// EntryFilter（可选）：用 CCI/ADX/Bollinger 过滤掉“看起来窄幅，实际上是
+	// 趋势刚启动”的假信号。为 nil 时关闭，完全保留原有的触发行为。
+	EntryFilter *EntryFilterConfig `yaml:"entryFilter" json:"entryFilter"`
 }
 
 func (c *Config) Validate() error {
@@ -104,5 +108,9 @@ func (c *Config) Validate() error {
 		c.SequentialPriorityPriceCents = 55
 	}
 
+	if c.EntryFilter != nil {
+		c.EntryFilter.normalize()
+	}
+
 	return nil
 }
@@ -0,0 +1,103 @@
+package rangeboth
+
+import (
+	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/strategies/common/indicators"
+)
+
+// EntryFilterConfig 配置 CCI/ADX/Bollinger 组成的入场过滤器。外层 Config 里
+// EntryFilter 为 nil 时整个过滤器关闭，完全保留原有“只看 lookback 窗口振幅”
+// 的触发行为。
+type EntryFilterConfig struct {
+	CCIWindow int     `yaml:"cciWindow" json:"cciWindow"`
+	LongCCI   float64 `yaml:"longCCI" json:"longCCI"`   // CCI 上限，超出视为正在加速上行，不是纯震荡
+	ShortCCI  float64 `yaml:"shortCCI" json:"shortCCI"` // CCI 下限（通常是负数），低于视为正在加速下行
+
+	ADXWindow    int     `yaml:"adxWindow" json:"adxWindow"`
+	ADXThreshold float64 `yaml:"adxThreshold" json:"adxThreshold"` // ADX 高于这个值代表趋势太强，不适合双边进场
+
+	BollingerWindow    int     `yaml:"bollingerWindow" json:"bollingerWindow"`
+	BollingerBandWidth float64 `yaml:"bollingerBandWidth" json:"bollingerBandWidth"`
+	BBBandwidthMin     float64 `yaml:"bbBandwidthMin" json:"bbBandwidthMin"` // 带宽 (Upper-Lower)/Mid 低于这个值时跳过（波动率太低，赚不到多少）
+}
+
+func (c *EntryFilterConfig) normalize() {
+	if c.CCIWindow <= 0 {
+		c.CCIWindow = 20
+	}
+	if c.LongCCI <= 0 {
+		c.LongCCI = 100
+	}
+	if c.ShortCCI >= 0 {
+		c.ShortCCI = -100
+	}
+	if c.ADXWindow <= 0 {
+		c.ADXWindow = 14
+	}
+	if c.ADXThreshold <= 0 {
+		c.ADXThreshold = 25
+	}
+	if c.BollingerWindow <= 0 {
+		c.BollingerWindow = 20
+	}
+	if c.BollingerBandWidth <= 0 {
+		c.BollingerBandWidth = 2.0
+	}
+}
+
+// entryFilter 是 EntryFilterConfig 在运行期的状态：UP/DOWN 两条价格流各自维护
+// 一套 CCI+ADX+Bollinger 指标（靠 indicators.Set 打包），互不干扰。
+type entryFilter struct {
+	cfg  EntryFilterConfig
+	up   *indicators.Set
+	down *indicators.Set
+}
+
+func newEntryFilter(cfg EntryFilterConfig) *entryFilter {
+	cfg.normalize()
+	setCfg := indicators.Config{
+		CCIWindow:          cfg.CCIWindow,
+		BollingerWindow:    cfg.BollingerWindow,
+		BollingerBandWidth: cfg.BollingerBandWidth,
+		ADXWindow:          cfg.ADXWindow,
+	}
+	return &entryFilter{
+		cfg:  cfg,
+		up:   indicators.NewSet(setCfg),
+		down: indicators.NewSet(setCfg),
+	}
+}
+
+// Update 把一次价格采样（分/点）喂给对应方向的指标集合。调用方需要自己保证
+// 串行调用（本策略在持有 s.mu 期间调用，天然满足）。
+func (f *entryFilter) Update(tokenType domain.TokenType, priceCents int) {
+	switch tokenType {
+	case domain.TokenTypeUp:
+		f.up.Update(float64(priceCents))
+	case domain.TokenTypeDown:
+		f.down.Update(float64(priceCents))
+	}
+}
+
+// Allow 判断 UP/DOWN 两侧当前的指标状态是否构成“真正的窄幅震荡”，而不是趋势
+// 刚启动时暂时显得窄幅。任一指标窗口尚未填满时视为通过（冷启动阶段不能一直
+// 拒绝交易）。
+func (f *entryFilter) Allow() bool {
+	return f.sideAllowed(f.up.Last()) && f.sideAllowed(f.down.Last())
+}
+
+func (f *entryFilter) sideAllowed(snap indicators.Snapshot) bool {
+	if snap.CCIOk && (snap.CCI > f.cfg.LongCCI || snap.CCI < f.cfg.ShortCCI) {
+		return false
+	}
+	if snap.ADXOk && snap.ADX > f.cfg.ADXThreshold {
+		return false
+	}
+	if snap.BandsOk && snap.Bands.Mid != 0 {
+		bandwidth := (snap.Bands.Upper - snap.Bands.Lower) / snap.Bands.Mid
+		if bandwidth < f.cfg.BBBandwidthMin {
+			return false
+		}
+	}
+	return true
+}
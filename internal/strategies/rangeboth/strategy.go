@@ -11,6 +11,7 @@ import (
 	"github.com/betbot/gobet/internal/domain"
 	"github.com/betbot/gobet/internal/events"
 	"github.com/betbot/gobet/internal/execution"
+	"github.com/betbot/gobet/internal/notifier"
 	"github.com/betbot/gobet/internal/services"
 	"github.com/betbot/gobet/internal/strategies/common"
 	"github.com/betbot/gobet/pkg/bbgo"
@@ -31,6 +32,10 @@ type Strategy struct {
 	TradingService *services.TradingService
 	Config         `yaml:",inline" json:",inline"`
 
+	// Notifier 可选：上报订单提交/双边建仓达成/错误，nil 时静默跳过（不接入
+	// 通知渠道也能正常交易）。
+	Notifier *notifier.TradingNotifier
+
 	mu sync.Mutex
 
 	autoMerge common.AutoMergeController
@@ -43,6 +48,9 @@ type Strategy struct {
 	// 价格样本
 	samples map[domain.TokenType][]priceSample
 
+	// 可选的 CCI/ADX/Bollinger 入场过滤器（Config.EntryFilter 为 nil 时保持 nil）
+	entryFilter *entryFilter
+
 	// 市场过滤（防误交易）
 	marketSlugPrefix string
 
@@ -64,6 +72,9 @@ func (s *Strategy) Initialize() error {
 	if s.samples == nil {
 		s.samples = make(map[domain.TokenType][]priceSample)
 	}
+	if s.EntryFilter != nil && s.entryFilter == nil {
+		s.entryFilter = newEntryFilter(*s.EntryFilter)
+	}
 
 	gc := config.Get()
 	if gc == nil {
@@ -174,6 +185,10 @@ func (s *Strategy) OnPriceChanged(ctx context.Context, e *events.PriceChangedEve
 	s.samples[domain.TokenTypeUp] = pruneSamples(s.samples[domain.TokenTypeUp], cutoff)
 	s.samples[domain.TokenTypeDown] = pruneSamples(s.samples[domain.TokenTypeDown], cutoff)
 
+	if s.entryFilter != nil {
+		s.entryFilter.Update(e.TokenType, priceCents)
+	}
+
 	upMin, upMax, upOK := rangeCents(s.samples[domain.TokenTypeUp])
 	downMin, downMax, downOK := rangeCents(s.samples[domain.TokenTypeDown])
 	requireBoth := true
@@ -196,6 +211,11 @@ func (s *Strategy) OnPriceChanged(ctx context.Context, e *events.PriceChangedEve
 		s.mu.Unlock()
 		return nil
 	}
+	if s.entryFilter != nil && !s.entryFilter.Allow() {
+		// 振幅虽然窄，但 CCI/ADX/Bollinger 认为是趋势刚启动的假窄幅，跳过本次触发。
+		s.mu.Unlock()
+		return nil
+	}
 	// 锁内先更新 trigger 相关状态，避免并发重复触发
 	s.lastTriggerAt = now
 	s.triggersCountThisCycle++
@@ -315,8 +335,10 @@ func (s *Strategy) OnPriceChanged(ctx context.Context, e *events.PriceChangedEve
 			if isFailSafeRefusal(execErr) {
 				return nil
 			}
+			s.notifyError(orderCtx, "ExecuteMultiLeg", execErr)
 			return nil
 		}
+		s.notifyHedgeAchieved(orderCtx, e.Market.Slug)
 		return nil
 	}
 
@@ -344,8 +366,10 @@ func (s *Strategy) OnPriceChanged(ctx context.Context, e *events.PriceChangedEve
 		if isFailSafeRefusal(err) {
 			return nil
 		}
+		s.notifyError(orderCtx, "PlaceOrder(first)", err)
 		return nil
 	}
+	s.notifyOrderFilled(orderCtx, e.Market.Slug, o1)
 
 	o2 := &domain.Order{
 		MarketSlug:   e.Market.Slug,
@@ -363,12 +387,45 @@ func (s *Strategy) OnPriceChanged(ctx context.Context, e *events.PriceChangedEve
 	}
 	if _, err := s.TradingService.PlaceOrder(orderCtx, o2); err != nil {
 		// 第二笔失败不回滚第一笔（符合“顺序”语义）；后续可在这里加撤单/重试策略
-		_ = err
+		s.notifyError(orderCtx, "PlaceOrder(second)", err)
+		return nil
 	}
+	s.notifyOrderFilled(orderCtx, e.Market.Slug, o2)
+	s.notifyHedgeAchieved(orderCtx, e.Market.Slug)
 
 	return nil
 }
 
+// notifyOrderFilled/notifyHedgeAchieved/notifyError 是 s.Notifier 的 nil-safe
+// 包装；本策略没有独立的成交确认机制，PlaceOrder/ExecuteMultiLeg 成功即视为
+// “已提交/已成交”上报。
+func (s *Strategy) notifyOrderFilled(ctx context.Context, marketSlug string, o *domain.Order) {
+	if s.Notifier == nil || o == nil {
+		return
+	}
+	if err := s.Notifier.NotifyOrderFilled(ctx, marketSlug, o.AssetID, string(o.Side), o.Price.ToDecimal(), o.Size); err != nil {
+		log.Warnf("[%s] 通知订单成交失败: %v", ID, err)
+	}
+}
+
+func (s *Strategy) notifyHedgeAchieved(ctx context.Context, marketSlug string) {
+	if s.Notifier == nil {
+		return
+	}
+	if err := s.Notifier.NotifyHedgeAchieved(ctx, marketSlug, 0); err != nil {
+		log.Warnf("[%s] 通知双边建仓达成失败: %v", ID, err)
+	}
+}
+
+func (s *Strategy) notifyError(ctx context.Context, where string, err error) {
+	if s.Notifier == nil || err == nil {
+		return
+	}
+	if nerr := s.Notifier.NotifyError(ctx, fmt.Sprintf("%s.%s", ID, where), err); nerr != nil {
+		log.Warnf("[%s] 通知错误上报失败: %v", ID, nerr)
+	}
+}
+
 func (s *Strategy) shouldHandleMarketEvent(m *domain.Market) bool {
 	if s == nil || m == nil {
 		return false
@@ -0,0 +1,362 @@
+// Package backtest 提供一个确定性撮合引擎，用 unifiedarb.Strategy 在实盘下使用的
+// 同一套下单接口（ExecuteMultiLeg/PlaceOrder/GetBestPrice/...），把录制下来的
+// Polymarket 风格 AMM/CLOB 行情快照回放成确定性成交，从而让 Strategy 里未经改动的
+// simulateBuy/simulateAmplify/ensureMinOrderSize/hedgeConfig 等纯逻辑可以逐 tick
+// 跑过历史数据产出每个 market slug 的 P&L 报告。
+//
+// 用法：构造 Engine 后把它赋给 Strategy.TradingService（字段类型是本包之外定义的
+// 最小接口，Engine 的方法集自动满足，无需额外适配层），然后依次调用 Advance 喂入
+// 录制的快照；Strategy 内部仍通过正常的 session/事件总线驱动 step()，这里只替换
+// 它与交易所之间的撮合/行情来源。
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/execution"
+	"github.com/betbot/gobet/internal/ports"
+)
+
+// Snapshot 是某个市场在某一时刻的录制行情（一条 Up/Down 两腿的 best bid/ask）。
+type Snapshot struct {
+	At         time.Time
+	MarketSlug string
+	YesAssetID string
+	NoAssetID  string
+	YesBid     float64
+	YesAsk     float64
+	NoBid      float64
+	NoAsk      float64
+}
+
+func (s Snapshot) bestPrice(assetID string) (bid, ask float64, ok bool) {
+	switch assetID {
+	case s.YesAssetID:
+		return s.YesBid, s.YesAsk, true
+	case s.NoAssetID:
+		return s.NoBid, s.NoAsk, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// Fill 记录一次确定性成交，供 Report 汇总 P&L。
+type Fill struct {
+	OrderID    string
+	TradeID    string
+	MarketSlug string
+	AssetID    string
+	TokenType  domain.TokenType
+	Side       types.Side
+	Price      float64
+	Size       float64
+	FeeUSDC    float64
+	At         time.Time
+}
+
+// Report 是某个 market slug 的回放统计：成交笔数、累计买入名义本金与手续费，用来
+// 在回放结束后快速核对 s.rounds/ProfitIfUpWin/ProfitIfDownWin 是否符合预期。
+type Report struct {
+	MarketSlug    string
+	FillCount     int
+	TotalNotional float64
+	TotalFeeUSDC  float64
+}
+
+// Engine 是一个进程内的确定性撮合引擎：OrderID/TradeID 单调递增，FAK/FOK 按“当前
+// 快照价格是否穿越”立即全部成交或立即取消，GTC 挂单会在后续 Advance 喂入新快照时
+// 持续尝试撮合，取消时按比例解锁尚未成交部分占用的 USDC。
+type Engine struct {
+	// DefaultFeeRateBps 是所有成交统一使用的手续费率（PlaceOrder 不支持按单覆盖），单位是
+	// 万分之一（basis points 定点表示，例如 30 表示 0.30%）。
+	DefaultFeeRateBps int64
+
+	mu      sync.Mutex
+	current Snapshot
+	handler ports.OrderUpdateHandler
+
+	resting  map[string]*domain.Order // orderID -> 未完全成交的 GTC 挂单
+	reserved map[string]float64       // orderID -> 尚未解锁的占用 USDC
+	fills    []Fill
+
+	orderSeq int64
+	tradeSeq int64
+}
+
+// NewEngine 创建一个撮合引擎；feeRateBps 为 0 表示不收手续费。
+func NewEngine(feeRateBps int64) *Engine {
+	return &Engine{
+		DefaultFeeRateBps: feeRateBps,
+		resting:           make(map[string]*domain.Order),
+		reserved:          make(map[string]float64),
+	}
+}
+
+func (e *Engine) nextOrderID() string {
+	return fmt.Sprintf("bt-order-%d", atomic.AddInt64(&e.orderSeq, 1))
+}
+
+func (e *Engine) nextTradeID() string {
+	return fmt.Sprintf("bt-trade-%d", atomic.AddInt64(&e.tradeSeq, 1))
+}
+
+// Advance 喂入下一条录制快照，作为当前行情来源（供 GetBestPrice/后续撮合使用），
+// 并尝试撮合所有仍在挂单簿里的 GTC 订单。
+func (e *Engine) Advance(ctx context.Context, snap Snapshot) {
+	e.mu.Lock()
+	e.current = snap
+	orderIDs := make([]string, 0, len(e.resting))
+	for id := range e.resting {
+		orderIDs = append(orderIDs, id)
+	}
+	e.mu.Unlock()
+
+	for _, id := range orderIDs {
+		e.tryMatchResting(ctx, id, snap)
+	}
+}
+
+// GetBestPrice 实现 tradingAPI：返回当前快照里 assetID 对应的 bid/ask。
+func (e *Engine) GetBestPrice(_ context.Context, assetID string) (bestBid, bestAsk float64, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	bid, ask, ok := e.current.bestPrice(assetID)
+	if !ok {
+		return 0, 0, fmt.Errorf("backtest: 当前快照没有资产 %s 的行情", assetID)
+	}
+	return bid, ask, nil
+}
+
+// GetCurrentMarket 实现 tradingAPI：返回当前快照所属的 market slug。
+func (e *Engine) GetCurrentMarket() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.current.MarketSlug
+}
+
+// OnOrderUpdate 实现 tradingAPI：注册成交/状态变化回调（撮合时同步调用，保持与
+// 实盘一样的串行投递语义）。
+func (e *Engine) OnOrderUpdate(handler ports.OrderUpdateHandler) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.handler = handler
+}
+
+// PlaceOrder 实现 tradingAPI：分配确定性 OrderID 并按 Side 预占用 USDC
+// （Buy: Size*Price，Sell 暂不建模占用）。FAK/FOK 立即按当前快照尝试成交，穿越价
+// 才算成交，否则整单作废；GTC 进入挂单簿，等待后续 Advance 撮合。
+func (e *Engine) PlaceOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	if order == nil {
+		return nil, fmt.Errorf("backtest: order 为空")
+	}
+	placed := *order
+	placed.OrderID = e.nextOrderID()
+	placed.CreatedAt = time.Now()
+	placed.Status = domain.OrderStatusOpen
+
+	if placed.Side == types.SideBuy {
+		e.mu.Lock()
+		e.reserved[placed.OrderID] = placed.Size * placed.Price.ToDecimal()
+		e.mu.Unlock()
+	}
+
+	if placed.OrderType == types.OrderTypeFAK || placed.OrderType == types.OrderTypeFOK {
+		e.mu.Lock()
+		snap := e.current
+		e.mu.Unlock()
+		if !e.crosses(&placed, snap) {
+			e.releaseReserve(placed.OrderID)
+			placed.Status = domain.OrderStatusCanceled
+			return &placed, nil
+		}
+		e.fill(ctx, &placed, placed.Size, placed.Price.ToDecimal())
+		return &placed, nil
+	}
+
+	e.mu.Lock()
+	e.resting[placed.OrderID] = &placed
+	e.mu.Unlock()
+	return &placed, nil
+}
+
+// ExecuteMultiLeg 实现 tradingAPI：逐腿调用 PlaceOrder，保持与 ExecuteMultiLeg 相
+// 同的“返回已创建订单列表”语义（回测引擎不做跨腿原子性/自动对冲，Hedge 字段被忽
+// 略 —— 跨交易所对冲在单一行情源的回放里无法建模）。
+func (e *Engine) ExecuteMultiLeg(ctx context.Context, req execution.MultiLegRequest) ([]*domain.Order, error) {
+	created := make([]*domain.Order, 0, len(req.Legs))
+	for _, leg := range req.Legs {
+		order := &domain.Order{
+			MarketSlug: req.MarketSlug,
+			AssetID:    leg.AssetID,
+			TokenType:  leg.TokenType,
+			Side:       leg.Side,
+			Price:      leg.Price,
+			Size:       leg.Size,
+			OrderType:  leg.OrderType,
+		}
+		placedOrder, err := e.PlaceOrder(ctx, order)
+		if err != nil {
+			continue
+		}
+		created = append(created, placedOrder)
+	}
+	if len(created) == 0 {
+		return nil, fmt.Errorf("backtest: 本次 ExecuteMultiLeg 没有任何腿下单成功")
+	}
+	return created, nil
+}
+
+// CancelOrdersForMarket 实现 tradingAPI：取消挂单簿里属于 marketSlug 的所有订单，
+// 按未成交比例解锁占用的 USDC。
+func (e *Engine) CancelOrdersForMarket(_ context.Context, marketSlug string) {
+	e.mu.Lock()
+	var toCancel []*domain.Order
+	for id, o := range e.resting {
+		if o.MarketSlug != marketSlug {
+			continue
+		}
+		toCancel = append(toCancel, o)
+		delete(e.resting, id)
+	}
+	e.mu.Unlock()
+
+	for _, o := range toCancel {
+		e.releaseReserve(o.OrderID)
+		canceledAt := time.Now()
+		o.CanceledAt = &canceledAt
+		o.Status = domain.OrderStatusCanceled
+		e.notify(context.Background(), o)
+	}
+}
+
+// Fills 返回目前为止所有确定性成交的快照副本。
+func (e *Engine) Fills() []Fill {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]Fill, len(e.fills))
+	copy(out, e.fills)
+	return out
+}
+
+// Report 汇总 marketSlug 目前为止的回放统计。
+func (e *Engine) Report(marketSlug string) Report {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	r := Report{MarketSlug: marketSlug}
+	for _, f := range e.fills {
+		if f.MarketSlug != marketSlug {
+			continue
+		}
+		r.FillCount++
+		r.TotalNotional += f.Price * f.Size
+		r.TotalFeeUSDC += f.FeeUSDC
+	}
+	return r
+}
+
+func (e *Engine) tryMatchResting(ctx context.Context, orderID string, snap Snapshot) {
+	e.mu.Lock()
+	o, ok := e.resting[orderID]
+	e.mu.Unlock()
+	if !ok {
+		return
+	}
+	if !e.crosses(o, snap) {
+		return
+	}
+
+	e.mu.Lock()
+	delete(e.resting, orderID)
+	e.mu.Unlock()
+
+	remaining := o.Size - o.FilledSize
+	if remaining <= 0 {
+		return
+	}
+	e.fill(ctx, o, remaining, o.Price.ToDecimal())
+}
+
+// crosses 判断 order 的挂单价在 snap 下是否可以成交：买单要求快照 ask <= 挂单价，
+// 卖单要求快照 bid >= 挂单价。
+func (e *Engine) crosses(order *domain.Order, snap Snapshot) bool {
+	bid, ask, ok := snap.bestPrice(order.AssetID)
+	if !ok {
+		return false
+	}
+	price := order.Price.ToDecimal()
+	if order.Side == types.SideBuy {
+		return ask > 0 && ask <= price
+	}
+	return bid > 0 && bid >= price
+}
+
+func (e *Engine) fill(ctx context.Context, order *domain.Order, size float64, price float64) {
+	feeRateBps := e.DefaultFeeRateBps
+	fee := price * size * float64(feeRateBps) / 10000
+
+	now := time.Now()
+	filledPrice := domain.PriceFromDecimal(price)
+	order.FilledSize += size
+	order.FilledPrice = &filledPrice
+	order.FilledAt = &now
+	if order.FilledSize >= order.Size {
+		order.Status = domain.OrderStatusFilled
+	} else {
+		order.Status = domain.OrderStatusPartial
+	}
+
+	e.releasePartialReserve(order.OrderID, size*price)
+
+	tradeID := e.nextTradeID()
+	e.mu.Lock()
+	e.fills = append(e.fills, Fill{
+		OrderID:    order.OrderID,
+		TradeID:    tradeID,
+		MarketSlug: order.MarketSlug,
+		AssetID:    order.AssetID,
+		TokenType:  order.TokenType,
+		Side:       order.Side,
+		Price:      price,
+		Size:       size,
+		FeeUSDC:    fee,
+		At:         now,
+	})
+	e.mu.Unlock()
+
+	e.notify(ctx, order)
+}
+
+func (e *Engine) releaseReserve(orderID string) {
+	e.mu.Lock()
+	delete(e.reserved, orderID)
+	e.mu.Unlock()
+}
+
+func (e *Engine) releasePartialReserve(orderID string, notional float64) {
+	e.mu.Lock()
+	if remaining, ok := e.reserved[orderID]; ok {
+		remaining -= notional
+		if remaining <= 0 {
+			delete(e.reserved, orderID)
+		} else {
+			e.reserved[orderID] = remaining
+		}
+	}
+	e.mu.Unlock()
+}
+
+func (e *Engine) notify(ctx context.Context, order *domain.Order) {
+	e.mu.Lock()
+	handler := e.handler
+	e.mu.Unlock()
+	if handler == nil {
+		return
+	}
+	_ = handler.OnOrderUpdate(ctx, order)
+}
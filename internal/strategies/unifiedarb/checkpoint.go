@@ -0,0 +1,174 @@
+package unifiedarb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/betbot/gobet/internal/metrics"
+	"github.com/betbot/gobet/pkg/persistence"
+)
+
+// checkpoint 是 s.plans/s.rounds/s.lastSubmit 的可持久化快照（见
+// loadCheckpoint/persistCheckpoint），用于进程重启后恢复“本周期已经提交过几轮、
+// 有哪些 plan 还在途”，避免重启后重新从 rounds=0 开始导致突破
+// MaxRoundsPerPeriod，或丢失 in-flight 的 plan.done 状态导致 checkPlanTimeouts
+// 对同一批订单重复判定超时动作。
+type checkpoint struct {
+	MarketSlug string         `json:"marketSlug"`
+	Rounds     int            `json:"rounds"`
+	LastSubmit time.Time      `json:"lastSubmit"`
+	Plans      []planSnapshot `json:"plans"`
+}
+
+// planSnapshot 是 plan 的可导出字段镜像（plan 本身字段未导出，不能直接 JSON 编码）。
+type planSnapshot struct {
+	ID        string          `json:"id"`
+	Market    string          `json:"market"`
+	CreatedAt time.Time       `json:"createdAt"`
+	OrderIDs  []string        `json:"orderIds"`
+	Done      map[string]bool `json:"done"`
+}
+
+const checkpointPersistenceTag = "checkpoint"
+
+// loadCheckpoint 在 Initialize 阶段尝试恢复上次未完成的 plans/rounds/lastSubmit
+// （未配置持久化或文件不存在时保持零值，不视为错误）。周期切换本身仍由
+// resetCycle 基于 guard.Update(m.Slug) 判定，这里只是让重启后的状态与重启前一致，
+// 不改变周期切换的触发条件。
+func (s *Strategy) loadCheckpoint() {
+	if s.PersistenceService == nil {
+		return
+	}
+	var cp checkpoint
+	store := s.PersistenceService.NewStore(ID, s.ID(), checkpointPersistenceTag)
+	if err := store.Load(&cp); err != nil {
+		if err != persistence.ErrNotExists {
+			log.Warnf("⚠️ [%s] 加载 checkpoint 失败: %v", ID, err)
+		}
+		return
+	}
+
+	plans := make(map[string]*plan, len(cp.Plans))
+	for _, ps := range cp.Plans {
+		plans[ps.ID] = &plan{
+			id:        ps.ID,
+			market:    ps.Market,
+			createdAt: ps.CreatedAt,
+			orderIDs:  ps.OrderIDs,
+			done:      ps.Done,
+		}
+	}
+
+	s.stateMu.Lock()
+	s.rounds = cp.Rounds
+	s.lastSubmit = cp.LastSubmit
+	s.stateMu.Unlock()
+
+	if len(plans) > 0 {
+		s.plansMu.Lock()
+		s.plans = plans
+		s.plansMu.Unlock()
+	}
+
+	log.Infof("♻️ [%s] 恢复 checkpoint: market=%s rounds=%d plans=%d", ID, cp.MarketSlug, cp.Rounds, len(plans))
+}
+
+// snapshotCheckpoint 收集当前 plans/rounds/lastSubmit，返回可序列化快照（也供
+// /state 端点复用，见 registerStateAdminHandler）。
+func (s *Strategy) snapshotCheckpoint() checkpoint {
+	s.stateMu.Lock()
+	rounds := s.rounds
+	lastSubmit := s.lastSubmit
+	marketSlug := ""
+	if s.state != nil && s.state.Market != nil {
+		marketSlug = s.state.Market.Slug
+	}
+	s.stateMu.Unlock()
+
+	s.plansMu.Lock()
+	plans := make([]planSnapshot, 0, len(s.plans))
+	for _, p := range s.plans {
+		if p == nil {
+			continue
+		}
+		done := make(map[string]bool, len(p.done))
+		for k, v := range p.done {
+			done[k] = v
+		}
+		plans = append(plans, planSnapshot{
+			ID:        p.id,
+			Market:    p.market,
+			CreatedAt: p.createdAt,
+			OrderIDs:  append([]string(nil), p.orderIDs...),
+			Done:      done,
+		})
+	}
+	s.plansMu.Unlock()
+
+	return checkpoint{MarketSlug: marketSlug, Rounds: rounds, LastSubmit: lastSubmit, Plans: plans}
+}
+
+// persistCheckpoint 把当前快照写入 PersistenceService（未配置时静默跳过）。
+func (s *Strategy) persistCheckpoint() {
+	if s.PersistenceService == nil {
+		return
+	}
+	cp := s.snapshotCheckpoint()
+	store := s.PersistenceService.NewStore(ID, s.ID(), checkpointPersistenceTag)
+	if err := store.Save(&cp); err != nil {
+		log.Warnf("⚠️ [%s] 保存 checkpoint 失败: %v", ID, err)
+	}
+}
+
+// startCheckpointFlusher 启动周期性 checkpoint 落盘的后台 goroutine（见
+// Config.CheckpointIntervalSeconds），未配置 PersistenceService 时为 no-op；
+// ctx.Done() 时额外做一次落盘，尽量减少优雅停止到下次启动之间的状态损失窗口。
+func (s *Strategy) startCheckpointFlusher(ctx context.Context) {
+	if s.PersistenceService == nil {
+		return
+	}
+	interval := time.Duration(s.Config.CheckpointIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.persistCheckpoint()
+				return
+			case <-ticker.C:
+				s.persistCheckpoint()
+			}
+		}
+	}()
+}
+
+// stateSnapshotDump 是 /state 端点返回的只读快照，汇总 checkpoint + TradeStats +
+// ProfitStats，供运营巡检观察当前周期与累计表现。
+type stateSnapshotDump struct {
+	Checkpoint  checkpoint  `json:"checkpoint"`
+	TradeStats  TradeStats  `json:"tradeStats"`
+	ProfitStats ProfitStats `json:"profitStats"`
+}
+
+// registerStateAdminHandler 注册 /state，返回当前策略状态的只读 JSON 快照（见
+// metrics.RegisterAdminHandler；与 services.OrderSyncService 的
+// /admin/position-reconcile 等 admin handler 复用同一个 mux）。多个 unifiedarb
+// 实例运行在同一进程时，/state 反映最后一个调用 Run 的实例（与
+// RegisterAdminHandler 按 pattern 覆盖的语义一致）。
+func (s *Strategy) registerStateAdminHandler() {
+	metrics.RegisterAdminHandler("/state", func(w http.ResponseWriter, r *http.Request) {
+		dump := stateSnapshotDump{
+			Checkpoint:  s.snapshotCheckpoint(),
+			TradeStats:  s.GetStats(),
+			ProfitStats: s.GetProfitStats(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(dump)
+	})
+}
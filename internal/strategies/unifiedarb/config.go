@@ -1,6 +1,10 @@
 package unifiedarb
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/betbot/gobet/pkg/fixedpoint"
+)
 
 // Config：统一套利策略（融合 complete-set + pairlock 风控 + 分阶段执行）
 //
@@ -64,10 +68,139 @@ type Config struct {
 	EntryMaxBuySlippageCents int     `json:"entryMaxBuySlippageCents" yaml:"entryMaxBuySlippageCents"`
 
 	// ----- 自动对冲（交给 ExecutionEngine；策略仅做参数透传） -----
-	HedgeEnabled              bool    `json:"hedgeEnabled" yaml:"hedgeEnabled"`
-	HedgeDelaySeconds         int     `json:"hedgeDelaySeconds" yaml:"hedgeDelaySeconds"`
-	HedgeSellPriceOffsetCents int     `json:"hedgeSellPriceOffsetCents" yaml:"hedgeSellPriceOffsetCents"`
-	MinExposureToHedge        float64 `json:"minExposureToHedge" yaml:"minExposureToHedge"`
+	HedgeEnabled      bool `json:"hedgeEnabled" yaml:"hedgeEnabled"`
+	HedgeDelaySeconds int  `json:"hedgeDelaySeconds" yaml:"hedgeDelaySeconds"`
+	// HedgeSellPriceOffsetCents/MinExposureToHedge 用 fixedpoint.Value 承载，避免
+	// 在 effectiveHedgeSellPriceOffsetCents/hedgeExposure 这类高频比较路径上
+	// 因 float64 舍入而误判（见 pkg/fixedpoint 包注释）。
+	HedgeSellPriceOffsetCents fixedpoint.Value `json:"hedgeSellPriceOffsetCents" yaml:"hedgeSellPriceOffsetCents"`
+	MinExposureToHedge        fixedpoint.Value `json:"minExposureToHedge" yaml:"minExposureToHedge"`
+
+	// ----- 指标驱动的阶段判定（可选，默认关闭，退化为纯时间/价格阈值） -----
+	Indicator IndicatorConfig `json:"indicator" yaml:"indicator"`
+
+	// ----- ATR 动态价格边界（可选，默认 static，即完全沿用上面的静态阈值） -----
+	// PriceBoundMode: static（默认，只用静态阈值）| atr（只用 ATR 动态推导）|
+	// hybrid（ceiling 取 min(static, atr)，slippage/hedge offset 取 max(static, atr)）。
+	PriceBoundMode    string  `json:"priceBoundMode" yaml:"priceBoundMode"`
+	ATRWindow         int     `json:"atrWindow" yaml:"atrWindow"`                 // 默认 14
+	ATRProfitMultiple float64 `json:"atrProfitMultiple" yaml:"atrProfitMultiple"` // 默认 1.0
+	ATRLossMultiple   float64 `json:"atrLossMultiple" yaml:"atrLossMultiple"`     // 默认 1.0
+
+	// ----- 跨交易所对冲（可选，默认关闭，走 hedgeConfig() 的同交易所对冲；见 hedge.go） -----
+	// 需要把 Strategy.HedgeTradingService 注入为第二个交易所的 TradingService
+	// （运营方在组装 Environment 时调用 environ.SetHedgeTradingService），否则
+	// HedgeCrossVenueEnabled 即使为 true 也会静默回退到同交易所对冲。
+	HedgeCrossVenueEnabled bool `json:"hedgeCrossVenueEnabled" yaml:"hedgeCrossVenueEnabled"`
+	HedgeIntervalSeconds   int  `json:"hedgeIntervalSeconds" yaml:"hedgeIntervalSeconds"`   // 默认 5
+	HedgeMaxSlippageCents  int  `json:"hedgeMaxSlippageCents" yaml:"hedgeMaxSlippageCents"` // 默认 3
+	// HedgeAssetIDs：主交易所 market slug -> 对冲交易所上的等价 YES/NO 资产 ID。
+	// 本仓库没有自动的跨交易所市场匹配服务，需要运营方预先配置。
+	HedgeAssetIDs map[string]HedgeAssetPair `json:"hedgeAssetIds" yaml:"hedgeAssetIds"`
+	// HedgeSessionName/HedgeSymbol：仅用于日志/指标标注对冲交易所与标的（类似
+	// xdepthmaker 的 maker/hedge session 命名），不参与资产解析（见 HedgeAssetIDs）。
+	HedgeSessionName string `json:"hedgeSessionName" yaml:"hedgeSessionName"`
+	HedgeSymbol      string `json:"hedgeSymbol" yaml:"hedgeSymbol"`
+	// HedgeRateLimit：对冲交易所下单的限速（笔/秒），避免在价格剧烈波动时连续触发
+	// 导致被对冲交易所限流/封号；默认 2。
+	HedgeRateLimit float64 `json:"hedgeRateLimit" yaml:"hedgeRateLimit"`
+
+	// ----- 交易时段 + 亏损熔断（可选，默认关闭，见 Strategy.checkTradeGates） -----
+	// TradeStartHour/TradeEndHour：UTC 小时 [start, end)，两者都为 0 时视为不限制时段。
+	TradeStartHour int `json:"tradeStartHour" yaml:"tradeStartHour"`
+	TradeEndHour   int `json:"tradeEndHour" yaml:"tradeEndHour"`
+	// PauseTradeLoss：滚动窗口已实现盈亏跌破该值（负数，USDC）时触发熔断暂停；0 表示不启用。
+	PauseTradeLoss float64 `json:"pauseTradeLoss" yaml:"pauseTradeLoss"`
+	// PauseWindowSeconds：计算滚动已实现盈亏的回看窗口，默认 3600（1 小时）。
+	PauseWindowSeconds int `json:"pauseWindowSeconds" yaml:"pauseWindowSeconds"`
+	// PauseCooldownSeconds：任一熔断条件触发后的最短暂停时长，默认 300（5 分钟）。
+	PauseCooldownSeconds int `json:"pauseCooldownSeconds" yaml:"pauseCooldownSeconds"`
+
+	// ----- NR（窄幅）探测器（可选，默认关闭，见 nr.go） -----
+	// 监控最近 NRCount 个 (yesAsk+noAsk) 采样的波动区间，窄幅收敛视为流动性收紧、
+	// 定价即将出现偏差的信号，命中时绕过冷却强制尝试一次放大后的 complete-set。
+	NREnabled bool `json:"nrEnabled" yaml:"nrEnabled"`
+	NRCount   int  `json:"nrCount" yaml:"nrCount"` // 默认 4
+	// NRBonusCents：绕过冷却强制入场比普通 complete-set 多要求的利润空间（分），
+	// 即要求 total <= 100-ProfitTargetCents-NRBonusCents，用更高的利润门槛补偿
+	// 放弃冷却/轮数控制带来的额外风险。
+	NRBonusCents int `json:"nrBonusCents" yaml:"nrBonusCents"`
+	// NRSizeMultiplier：NR 命中时 OrderSize 的放大倍数，默认 1.5。
+	NRSizeMultiplier float64 `json:"nrSizeMultiplier" yaml:"nrSizeMultiplier"`
+	// StrictMode：要求窗口内 NRCount 根 bar 的区间严格单调收窄，而不只是最后一根最小。
+	StrictMode bool `json:"strictMode" yaml:"strictMode"`
+
+	// ----- 分层流动性下单（可选，默认关闭，见 liquidity.go） -----
+	// 参照 pkg/sdk/liquiditymaker 的阶梯式挂单思路：在 maybeAmplify 命中方向性放大时，
+	// 把单笔 main+insurance FAK 买单替换为 NumOfLiquidityLayers 笔阶梯 GTC 限价买单，
+	// 价格按 LiquidityPriceRange/N 逐层远离当前 ask，规模按 LiquidityScale 归一化到
+	// AskLiquidityAmount（主方向）/BidLiquidityAmount（反向保险）。
+	LiquidityLayersEnabled bool `json:"liquidityLayersEnabled" yaml:"liquidityLayersEnabled"`
+	NumOfLiquidityLayers   int  `json:"numOfLiquidityLayers" yaml:"numOfLiquidityLayers"` // 默认 3
+	// LiquidityPriceRange：阶梯在每一侧跨越的总价格区间（decimal，例如 0.05），按
+	// LiquidityPriceRange/NumOfLiquidityLayers 均分到每一层，第 1 层最靠近当前 ask。
+	LiquidityPriceRange float64 `json:"liquidityPriceRange" yaml:"liquidityPriceRange"` // 默认 0.05
+	// AskLiquidityAmount/BidLiquidityAmount：阶梯归一化后的总规模（shares），分别
+	// 对应主方向、反向保险，按 LiquidityScale 在各层之间分配。
+	AskLiquidityAmount float64              `json:"askLiquidityAmount" yaml:"askLiquidityAmount"`
+	BidLiquidityAmount float64              `json:"bidLiquidityAmount" yaml:"bidLiquidityAmount"`
+	LiquidityScale     LiquidityScaleConfig `json:"liquidityScale" yaml:"liquidityScale"`
+
+	// ----- 状态持久化（可选，见 checkpoint.go/profitstats.go；需要注入
+	// Strategy.PersistenceService，否则以下字段无效） -----
+	// CheckpointIntervalSeconds：后台 goroutine 落盘 s.plans/s.rounds/s.lastSubmit
+	// 的间隔，默认 10。
+	CheckpointIntervalSeconds int `json:"checkpointIntervalSeconds" yaml:"checkpointIntervalSeconds"`
+
+	// ----- Telegram/Slack 控制面（可选，默认关闭，见 interact.go） -----
+	// InteractEnabled 开启后：Slack/Telegram 作为 Notifiability 推送通道（fill、
+	// plan 完成事件），Telegram 额外用作命令入口（/status /pause /resume
+	// /cancelplan /sethedge /setmax /positions），改变状态的命令需要先用 TOTP
+	// 验证码 /auth <code> 认证（见 pkg/interact.Controller），Slack 目前不接入命令
+	// （需要 Events API/Socket Mode，这里不展开）。
+	InteractEnabled  bool   `json:"interactEnabled" yaml:"interactEnabled"`
+	TelegramBotToken string `json:"telegramBotToken" yaml:"telegramBotToken"`
+	TelegramChatID   string `json:"telegramChatId" yaml:"telegramChatId"`
+	SlackWebhookURL  string `json:"slackWebhookUrl" yaml:"slackWebhookUrl"`
+}
+
+// LiquidityScaleConfig 描述分层下单的规模缩放函数：在 [DomainMin, DomainMax] 上
+// 按层索引取样，映射到 [RangeMin, RangeMax] 的值域后归一化为各层权重。
+type LiquidityScaleConfig struct {
+	// Mode："exp"（默认，指数缩放，越靠外层规模增长越快）或 "linear"（线性缩放）。
+	Mode string `json:"mode" yaml:"mode"`
+	// DomainMin/DomainMax：默认 [1, NumOfLiquidityLayers]。
+	DomainMin float64 `json:"domainMin" yaml:"domainMin"`
+	DomainMax float64 `json:"domainMax" yaml:"domainMax"`
+	// RangeMin/RangeMax：默认 [0, 1]。
+	RangeMin float64 `json:"rangeMin" yaml:"rangeMin"`
+	RangeMax float64 `json:"rangeMax" yaml:"rangeMax"`
+}
+
+// HedgeAssetPair 描述某个市场在对冲交易所上的等价 YES/NO 资产 ID。
+type HedgeAssetPair struct {
+	YesAssetID string `json:"yesAssetId" yaml:"yesAssetId"`
+	NoAssetID  string `json:"noAssetId" yaml:"noAssetId"`
+}
+
+// IndicatorConfig 配置基于 YES/NO 价格流的 CCI/Bollinger/ADX 指标集合，用于在
+// detectPhase 中辅助（而非取代）时间驱动的阶段判定，参见 detectPhase 注释。
+type IndicatorConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	CCIWindow          int     `json:"cciWindow" yaml:"cciWindow"`                   // 默认 20
+	BollingerWindow    int     `json:"bollingerWindow" yaml:"bollingerWindow"`       // 默认 20
+	BollingerBandWidth float64 `json:"bollingerBandWidth" yaml:"bollingerBandWidth"` // 默认 2.0（标准差倍数）
+	ADXWindow          int     `json:"adxWindow" yaml:"adxWindow"`                   // 默认 14
+
+	BuildADXMax   float64 `json:"buildADXMax" yaml:"buildADXMax"`     // ADX 低于该值视为无趋势，默认 20
+	LockCCI       float64 `json:"lockCCI" yaml:"lockCCI"`             // |CCI| 超过该值视为行情切换，默认 100
+	AmplifyADXMin float64 `json:"amplifyADXMin" yaml:"amplifyADXMin"` // ADX 高于该值视为趋势确认，默认 25
+	AmplifyCCI    float64 `json:"amplifyCCI" yaml:"amplifyCCI"`       // 主方向 CCI 需持续高于该值，默认 100
+
+	// AmplifyConfirmSamples：AmplifyCCI 需要连续满足这么多个样本才确认进入 amplify，
+	// 避免单个 tick 的噪声触发方向性放大。
+	AmplifyConfirmSamples int `json:"amplifyConfirmSamples" yaml:"amplifyConfirmSamples"` // 默认 3
 }
 
 func (c *Config) Validate() error {
@@ -234,11 +367,190 @@ func (c *Config) Validate() error {
 	if c.HedgeDelaySeconds < 0 {
 		return fmt.Errorf("hedgeDelaySeconds 不能为负数")
 	}
-	if c.HedgeSellPriceOffsetCents < 0 {
+	if c.HedgeSellPriceOffsetCents.Sign() < 0 {
 		return fmt.Errorf("hedgeSellPriceOffsetCents 不能为负数")
 	}
-	if c.MinExposureToHedge < 0 {
+	if c.MinExposureToHedge.Sign() < 0 {
 		return fmt.Errorf("minExposureToHedge 不能为负数")
 	}
+
+	if err := c.Indicator.validate(); err != nil {
+		return err
+	}
+
+	switch c.PriceBoundMode {
+	case "":
+		c.PriceBoundMode = "static"
+	case "static", "atr", "hybrid":
+	default:
+		return fmt.Errorf("priceBoundMode 无效: %s (允许: static/atr/hybrid)", c.PriceBoundMode)
+	}
+	if c.ATRWindow < 0 {
+		return fmt.Errorf("atrWindow 不能为负数")
+	}
+	if c.ATRProfitMultiple < 0 || c.ATRLossMultiple < 0 {
+		return fmt.Errorf("atrProfitMultiple/atrLossMultiple 不能为负数")
+	}
+	if c.PriceBoundMode != "static" {
+		if c.ATRWindow == 0 {
+			c.ATRWindow = 14
+		}
+		if c.ATRProfitMultiple == 0 {
+			c.ATRProfitMultiple = 1.0
+		}
+		if c.ATRLossMultiple == 0 {
+			c.ATRLossMultiple = 1.0
+		}
+	}
+
+	if c.HedgeIntervalSeconds < 0 {
+		return fmt.Errorf("hedgeIntervalSeconds 不能为负数")
+	}
+	if c.HedgeMaxSlippageCents < 0 {
+		return fmt.Errorf("hedgeMaxSlippageCents 不能为负数")
+	}
+	if c.HedgeRateLimit < 0 {
+		return fmt.Errorf("hedgeRateLimit 不能为负数")
+	}
+	if c.HedgeCrossVenueEnabled {
+		if c.HedgeIntervalSeconds == 0 {
+			c.HedgeIntervalSeconds = 5
+		}
+		if c.HedgeMaxSlippageCents == 0 {
+			c.HedgeMaxSlippageCents = 3
+		}
+		if c.HedgeRateLimit == 0 {
+			c.HedgeRateLimit = 2
+		}
+	}
+
+	if c.TradeStartHour < 0 || c.TradeStartHour > 24 {
+		return fmt.Errorf("tradeStartHour 必须在 [0, 24] 范围内")
+	}
+	if c.TradeEndHour < 0 || c.TradeEndHour > 24 {
+		return fmt.Errorf("tradeEndHour 必须在 [0, 24] 范围内")
+	}
+	if c.PauseTradeLoss > 0 {
+		return fmt.Errorf("pauseTradeLoss 必须 <= 0（0 表示不启用）")
+	}
+	if c.PauseWindowSeconds < 0 {
+		return fmt.Errorf("pauseWindowSeconds 不能为负数")
+	}
+	if c.PauseCooldownSeconds < 0 {
+		return fmt.Errorf("pauseCooldownSeconds 不能为负数")
+	}
+	if c.PauseWindowSeconds == 0 {
+		c.PauseWindowSeconds = 3600
+	}
+	if c.PauseCooldownSeconds == 0 {
+		c.PauseCooldownSeconds = 300
+	}
+
+	if c.NRCount < 0 {
+		return fmt.Errorf("nrCount 不能为负数")
+	}
+	if c.NRBonusCents < 0 {
+		return fmt.Errorf("nrBonusCents 不能为负数")
+	}
+	if c.NRSizeMultiplier < 0 {
+		return fmt.Errorf("nrSizeMultiplier 不能为负数")
+	}
+	if c.NREnabled {
+		if c.NRCount == 0 {
+			c.NRCount = 4
+		}
+		if c.NRSizeMultiplier == 0 {
+			c.NRSizeMultiplier = 1.5
+		}
+	}
+
+	if c.NumOfLiquidityLayers < 0 {
+		return fmt.Errorf("numOfLiquidityLayers 不能为负数")
+	}
+	if c.LiquidityPriceRange < 0 {
+		return fmt.Errorf("liquidityPriceRange 不能为负数")
+	}
+	if c.AskLiquidityAmount < 0 || c.BidLiquidityAmount < 0 {
+		return fmt.Errorf("askLiquidityAmount/bidLiquidityAmount 不能为负数")
+	}
+	if c.LiquidityScale.Mode != "" && c.LiquidityScale.Mode != "exp" && c.LiquidityScale.Mode != "linear" {
+		return fmt.Errorf("liquidityScale.mode 必须是 exp 或 linear")
+	}
+	if c.LiquidityLayersEnabled {
+		if c.NumOfLiquidityLayers == 0 {
+			c.NumOfLiquidityLayers = 3
+		}
+		if c.LiquidityPriceRange == 0 {
+			c.LiquidityPriceRange = 0.05
+		}
+		if c.LiquidityScale.Mode == "" {
+			c.LiquidityScale.Mode = "exp"
+		}
+		if c.LiquidityScale.DomainMax <= c.LiquidityScale.DomainMin {
+			c.LiquidityScale.DomainMin = 1
+			c.LiquidityScale.DomainMax = float64(c.NumOfLiquidityLayers)
+		}
+		if c.LiquidityScale.RangeMax <= c.LiquidityScale.RangeMin {
+			c.LiquidityScale.RangeMin = 0
+			c.LiquidityScale.RangeMax = 1
+		}
+	}
+
+	if c.CheckpointIntervalSeconds < 0 {
+		return fmt.Errorf("checkpointIntervalSeconds 不能为负数")
+	}
+	if c.CheckpointIntervalSeconds == 0 {
+		c.CheckpointIntervalSeconds = 10
+	}
+
+	if c.InteractEnabled && c.TelegramBotToken == "" && c.SlackWebhookURL == "" {
+		return fmt.Errorf("interactEnabled 需要至少配置 telegramBotToken 或 slackWebhookUrl 其中一个")
+	}
+	return nil
+}
+
+func (c *IndicatorConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.CCIWindow < 0 || c.BollingerWindow < 0 || c.ADXWindow < 0 {
+		return fmt.Errorf("indicator.*Window 不能为负数")
+	}
+	if c.BollingerBandWidth < 0 {
+		return fmt.Errorf("indicator.bollingerBandWidth 不能为负数")
+	}
+	if c.BuildADXMax < 0 || c.LockCCI < 0 || c.AmplifyADXMin < 0 || c.AmplifyCCI < 0 {
+		return fmt.Errorf("indicator 阈值不能为负数")
+	}
+	if c.AmplifyConfirmSamples < 0 {
+		return fmt.Errorf("indicator.amplifyConfirmSamples 不能为负数")
+	}
+	if c.CCIWindow == 0 {
+		c.CCIWindow = 20
+	}
+	if c.BollingerWindow == 0 {
+		c.BollingerWindow = 20
+	}
+	if c.BollingerBandWidth == 0 {
+		c.BollingerBandWidth = 2.0
+	}
+	if c.ADXWindow == 0 {
+		c.ADXWindow = 14
+	}
+	if c.BuildADXMax == 0 {
+		c.BuildADXMax = 20
+	}
+	if c.LockCCI == 0 {
+		c.LockCCI = 100
+	}
+	if c.AmplifyADXMin == 0 {
+		c.AmplifyADXMin = 25
+	}
+	if c.AmplifyCCI == 0 {
+		c.AmplifyCCI = 100
+	}
+	if c.AmplifyConfirmSamples == 0 {
+		c.AmplifyConfirmSamples = 3
+	}
 	return nil
 }
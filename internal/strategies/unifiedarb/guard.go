@@ -0,0 +1,81 @@
+package unifiedarb
+
+import (
+	"fmt"
+	"time"
+)
+
+// 交易时段 + 亏损熔断（见 Config.TradeStartHour/TradeEndHour/PauseTradeLoss/
+// PauseWindowSeconds/PauseCooldownSeconds）：
+//
+// 在每个 step() 里，已建立的 plan 始终继续由 checkPlanTimeouts 推进/超时处理，
+// 本文件只决定是否允许开始新的一轮（maybeBuild/maybeLock/maybeAmplify）。
+// 触发熔断后记录 pauseUntil，冷却期内即使条件恢复也不会立刻解除，避免在临界值
+// 附近反复开关。
+
+// checkTradeGates 在开始新一轮之前做交易时段与亏损熔断检查。返回 false 时本次
+// step 应跳过 build/lock/amplify（但已有 plan 仍会被 checkPlanTimeouts 处理）。
+func (s *Strategy) checkTradeGates(now time.Time) bool {
+	s.stateMu.Lock()
+	pauseUntil := s.pauseUntil
+	s.stateMu.Unlock()
+	if !pauseUntil.IsZero() && now.Before(pauseUntil) {
+		return false
+	}
+
+	if reason, blocked := s.tradeGateViolation(now); blocked {
+		s.triggerPause(now, reason)
+		return false
+	}
+	return true
+}
+
+// tradeGateViolation 检查时段窗口与滚动亏损两个独立条件，返回第一个不满足的原因。
+func (s *Strategy) tradeGateViolation(now time.Time) (reason string, blocked bool) {
+	if s.Config.TradeStartHour != 0 || s.Config.TradeEndHour != 0 {
+		hour := now.UTC().Hour()
+		if !tradingHourAllowed(hour, s.Config.TradeStartHour, s.Config.TradeEndHour) {
+			return fmt.Sprintf("不在交易时段 [%02d:00, %02d:00) UTC 内 (当前 %02d:00)",
+				s.Config.TradeStartHour, s.Config.TradeEndHour, hour), true
+		}
+	}
+
+	if s.Config.PauseTradeLoss < 0 {
+		window := time.Duration(s.Config.PauseWindowSeconds) * time.Second
+		pnl := s.rollingPnL(now, window)
+		if pnl < s.Config.PauseTradeLoss {
+			return fmt.Sprintf("滚动盈亏 %.2f 跌破熔断阈值 %.2f (窗口 %s)",
+				pnl, s.Config.PauseTradeLoss, window), true
+		}
+	}
+	return "", false
+}
+
+// tradingHourAllowed 判断 hour 是否落在 [start, end) 内，支持跨午夜（start > end）的窗口。
+func tradingHourAllowed(hour, start, end int) bool {
+	if start == end {
+		return true
+	}
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+// triggerPause 记录一次熔断暂停及其原因，冷却时长取 Config.PauseCooldownSeconds。
+func (s *Strategy) triggerPause(now time.Time, reason string) {
+	cooldown := time.Duration(s.Config.PauseCooldownSeconds) * time.Second
+	if cooldown <= 0 {
+		cooldown = 300 * time.Second
+	}
+
+	s.stateMu.Lock()
+	already := s.pauseReason == reason && now.Before(s.pauseUntil)
+	s.pauseUntil = now.Add(cooldown)
+	s.pauseReason = reason
+	s.stateMu.Unlock()
+
+	if !already {
+		log.Warnf("⚠️ [%s] 触发交易熔断: %s, 暂停至 %s", ID, reason, s.pauseUntil.Format(time.RFC3339))
+	}
+}
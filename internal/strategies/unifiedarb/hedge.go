@@ -0,0 +1,320 @@
+package unifiedarb
+
+import (
+	"context"
+	"time"
+
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/metrics"
+	"github.com/betbot/gobet/internal/services"
+	"github.com/betbot/gobet/pkg/persistence"
+	"golang.org/x/time/rate"
+)
+
+// 跨交易所对冲（见 Config.HedgeCrossVenueEnabled）：
+//
+// 正常情况下，对冲腿由 hedgeConfig() 交给 execution.AutoHedgeConfig 在同一个
+// 交易所内处理（买入两腿不一致时卖出多出的部分）。当配置了 HedgeTradingService
+// （第二个交易所的 TradingService，见 Strategy.HedgeTradingService，类似
+// xdepthmaker 的 maker/hedge session 拆分）时，本文件把同样的“补齐敞口”改为在
+// 对冲交易所下单：
+//   - 主交易所买单成交 -> covered[tok]（CoveredPosition）增加（敞口变大，见 onPrimaryFill）
+//   - 对冲交易所卖单成交确认后 -> covered[tok] 减少（见 registerHedgeOrderWatch；
+//     提交时不预先核减 covered 本身，避免 FAK 部分成交/失败导致敞口被错误核销）
+//   - 提交时记录在 hedgePending 里的挂单量会在成交确认到达前就从 hedgeExposure()
+//     的敞口计算里扣掉（见 chunk177-1）：否则如果确认比下一个 HedgeIntervalSeconds
+//     tick 慢，下一次 tryHedgeToken 会看到同一笔未核销的敞口，重复提交一笔满额
+//     对冲单，滑向过度对冲/净空头。hedgePending 在该笔订单进入终态
+//     （filled/canceled/failed）时释放，不管是否已经通过成交增量核减过 covered
+//   - 后台 goroutine 按 HedgeIntervalSeconds 节奏检查 covered[tok]，超过
+//     MinExposureToHedge 时在 HedgeRateLimit 限速下提交一笔 IOC 对冲卖单
+//   - covered 的快照持久化在 PersistenceService（见 loadHedgeState/persistHedgeState），
+//     重启后沿用上次未对冲完的敞口，而不是静默归零
+//   - 每笔对冲提交都记录 (submittedAt, refBidCents)，成交确认后据此计算滑点/延迟
+//     并写入 internal/metrics（见 registerHedgeOrderWatch）
+
+const hedgeStatePersistenceTag = "hedge_covered"
+
+// hedgeSubmission 记录一次对冲卖单提交时的快照：tok/size 用于在成交确认到达前
+// 把这笔挂单量从 hedgeExposure() 里扣掉（见 chunk177-1），submittedAt/refBidCents
+// 用于成交确认后计算滑点/延迟指标。
+type hedgeSubmission struct {
+	tok         domain.TokenType
+	size        float64
+	submittedAt time.Time
+	refBidCents float64 // 提交时的 bestBid（分）
+}
+
+func (p HedgeAssetPair) assetID(tok domain.TokenType) string {
+	if tok == domain.TokenTypeUp {
+		return p.YesAssetID
+	}
+	return p.NoAssetID
+}
+
+// startHedger 启动跨交易所对冲后台 goroutine；未启用或未注入 HedgeTradingService
+// 时为 no-op（同交易所对冲不受影响）。
+func (s *Strategy) startHedger(ctx context.Context) {
+	if !s.Config.HedgeCrossVenueEnabled || s.HedgeTradingService == nil {
+		return
+	}
+	s.loadHedgeState()
+	s.registerHedgeOrderWatch()
+
+	limit := s.Config.HedgeRateLimit
+	if limit <= 0 {
+		limit = 2
+	}
+	s.hedgeMu.Lock()
+	s.hedgeLimiter = rate.NewLimiter(rate.Limit(limit), 1)
+	s.hedgeMu.Unlock()
+
+	interval := time.Duration(s.Config.HedgeIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.tryHedgeCycle(ctx)
+			}
+		}
+	}()
+}
+
+// registerHedgeOrderWatch 订阅对冲交易所的订单回报，按成交增量扣减 covered[tok]，
+// 并为每笔已知提交（见 hedgePending）记录滑点/延迟指标。
+func (s *Strategy) registerHedgeOrderWatch() {
+	s.HedgeTradingService.OnOrderUpdate(services.OrderUpdateHandlerFunc(func(_ context.Context, o *domain.Order) error {
+		if o == nil || o.OrderID == "" || o.Side != types.SideSell {
+			return nil
+		}
+		s.hedgeMu.Lock()
+		if s.hedgeLastFilled == nil {
+			s.hedgeLastFilled = make(map[string]float64)
+		}
+		prev := s.hedgeLastFilled[o.OrderID]
+		cur := o.FilledSize
+		if cur < prev {
+			prev = 0
+		}
+		delta := cur - prev
+		s.hedgeLastFilled[o.OrderID] = cur
+
+		sub, hasSub := s.hedgePending[o.OrderID]
+		if o.IsFinalStatus() {
+			// 这笔对冲单不会再有后续成交了（FAK 要么全部/部分成交后立即终结，
+			// 要么直接被拒绝/失败）：释放 hedgePending 里的挂单量预留，否则
+			// 会一直占着 hedgeExposure() 的额度，导致残余敞口再也无法被对冲。
+			delete(s.hedgePending, o.OrderID)
+		}
+
+		if delta > 0 && s.covered != nil {
+			s.covered[o.TokenType] -= delta
+			if s.covered[o.TokenType] < 0 {
+				s.covered[o.TokenType] = 0
+			}
+		}
+		snapshot := cloneCovered(s.covered)
+		s.hedgeMu.Unlock()
+
+		if delta <= 0 {
+			return nil
+		}
+		s.recordHedgeFill(o.MarketSlug, delta)
+		s.persistHedgeState(snapshot)
+		if hasSub && o.FilledPrice != nil {
+			s.recordHedgeConfirm(sub, o.FilledPrice.Cents)
+		}
+		return nil
+	}))
+}
+
+// recordHedgeConfirm 把一次对冲成交换算为滑点（提交时参考 bestBid - 实际成交价，
+// 单位分；正值表示比预期更差）和延迟（提交到成交确认的耗时），写入 internal/metrics。
+func (s *Strategy) recordHedgeConfirm(sub hedgeSubmission, filledCents int) {
+	latencyMs := time.Since(sub.submittedAt).Milliseconds()
+	slippageCents := int64(sub.refBidCents) - int64(filledCents)
+
+	metrics.HedgeLatencyLastMs.Set(latencyMs)
+	metrics.HedgeLatencyTotalMs.Add(latencyMs)
+	metrics.HedgeLatencySamples.Add(1)
+	metrics.HedgeSlippageCentsLast.Set(slippageCents)
+	metrics.HedgeSlippageCentsTotal.Add(slippageCents)
+}
+
+// onPrimaryFill 记录一次主交易所买单成交，增加对应 token 的待对冲敞口（见 onOrder）。
+func (s *Strategy) onPrimaryFill(tok domain.TokenType, delta float64) {
+	if !s.Config.HedgeCrossVenueEnabled || delta <= 0 {
+		return
+	}
+	s.hedgeMu.Lock()
+	if s.covered == nil {
+		s.covered = make(map[domain.TokenType]float64)
+	}
+	s.covered[tok] += delta
+	snapshot := cloneCovered(s.covered)
+	s.hedgeMu.Unlock()
+	s.persistHedgeState(snapshot)
+}
+
+// hedgeExposure 返回某个 token 当前还需要对冲的敞口：主交易所累计成交，减去
+// 已确认的对冲成交（covered[tok]），再减去已提交但尚未确认成交/终结的对冲挂单
+// 量（见 chunk177-1，避免确认延迟时下一个 tick 对同一笔敞口重复下单）。
+func (s *Strategy) hedgeExposure(tok domain.TokenType) float64 {
+	s.hedgeMu.Lock()
+	defer s.hedgeMu.Unlock()
+	exposure := s.covered[tok]
+	for _, sub := range s.hedgePending {
+		if sub.tok == tok {
+			exposure -= sub.size
+		}
+	}
+	return exposure
+}
+
+// tryHedgeCycle 对 UP/DOWN 两个 token 各尝试一次增量对冲。
+func (s *Strategy) tryHedgeCycle(ctx context.Context) {
+	for _, tok := range []domain.TokenType{domain.TokenTypeUp, domain.TokenTypeDown} {
+		s.tryHedgeToken(ctx, tok)
+	}
+}
+
+func (s *Strategy) tryHedgeToken(ctx context.Context, tok domain.TokenType) {
+	exposure := s.hedgeExposure(tok)
+	if exposure < s.MinExposureToHedge.Float64() {
+		return
+	}
+
+	s.hedgeMu.Lock()
+	limiter := s.hedgeLimiter
+	s.hedgeMu.Unlock()
+	if limiter != nil && !limiter.Allow() {
+		metrics.HedgeOrdersRateLimited.Add(1)
+		return
+	}
+
+	pair, hedgeMarketSlug, ok := s.hedgeAssetPair()
+	if !ok {
+		return
+	}
+	assetID := pair.assetID(tok)
+	if assetID == "" {
+		return
+	}
+
+	hedgeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	bestBid, _, err := s.HedgeTradingService.GetBestPrice(hedgeCtx, assetID)
+	if err != nil || bestBid <= 0 {
+		return
+	}
+
+	off := s.Config.HedgeMaxSlippageCents
+	if off <= 0 {
+		off = 3
+	}
+	bidCents := bestBid*100 + 0.5
+	priceCents := int(bidCents) - off
+	if priceCents < 1 {
+		priceCents = 1
+	}
+
+	order := &domain.Order{
+		MarketSlug: hedgeMarketSlug,
+		AssetID:    assetID,
+		Side:       types.SideSell,
+		Price:      domain.Price{Cents: priceCents},
+		Size:       exposure,
+		TokenType:  tok,
+		Status:     domain.OrderStatusPending,
+		CreatedAt:  time.Now(),
+		OrderType:  types.OrderTypeFAK,
+	}
+	submittedAt := time.Now()
+	created, err := s.HedgeTradingService.PlaceOrder(hedgeCtx, order)
+	metrics.HedgeOrdersSubmitted.Add(1)
+	if err != nil {
+		metrics.HedgeOrdersErrors.Add(1)
+		log.Warnf("⚠️ [%s] 跨交易所对冲下单失败: tok=%s asset=%s size=%.4f err=%v", ID, tok, assetID, exposure, err)
+		return
+	}
+	if created == nil || created.OrderID == "" {
+		return
+	}
+	s.hedgeMu.Lock()
+	if s.hedgePending == nil {
+		s.hedgePending = make(map[string]hedgeSubmission)
+	}
+	s.hedgePending[created.OrderID] = hedgeSubmission{tok: tok, size: exposure, submittedAt: submittedAt, refBidCents: bidCents}
+	s.hedgeMu.Unlock()
+}
+
+// hedgeAssetPair 返回当前周期市场在对冲交易所上的资产映射，以及对冲交易所当前
+// 周期的 market slug（取自 HedgeTradingService.GetCurrentMarket，要求运营方把
+// 两个交易所的周期调度对齐）。未配置映射或对冲交易所尚无当前市场时返回 ok=false，
+// 调用方应放弃本次对冲尝试（不会回退到同交易所对冲：那由 hedgeConfig() 独立处理）。
+func (s *Strategy) hedgeAssetPair() (pair HedgeAssetPair, hedgeMarketSlug string, ok bool) {
+	s.stateMu.Lock()
+	st := s.state
+	s.stateMu.Unlock()
+	if st == nil || st.Market == nil {
+		return HedgeAssetPair{}, "", false
+	}
+
+	pair, found := s.Config.HedgeAssetIDs[st.Market.Slug]
+	if !found {
+		return HedgeAssetPair{}, "", false
+	}
+	hedgeMarketSlug = s.HedgeTradingService.GetCurrentMarket()
+	if hedgeMarketSlug == "" {
+		return HedgeAssetPair{}, "", false
+	}
+	return pair, hedgeMarketSlug, true
+}
+
+// cloneCovered 在持有 hedgeMu 时拷贝 covered 快照，供调用方解锁后安全地持久化
+// 或读取（见 persistHedgeState 调用点）。
+func cloneCovered(m map[domain.TokenType]float64) map[domain.TokenType]float64 {
+	out := make(map[domain.TokenType]float64, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// loadHedgeState 在 startHedger 阶段尝试从 PersistenceService 恢复上次未对冲完
+// 的 CoveredPosition（未配置持久化或文件不存在时保持零值，不视为错误）。
+func (s *Strategy) loadHedgeState() {
+	if s.PersistenceService == nil {
+		return
+	}
+	covered := make(map[domain.TokenType]float64)
+	store := s.PersistenceService.NewStore(ID, s.ID(), hedgeStatePersistenceTag)
+	if err := store.Load(&covered); err != nil {
+		if err != persistence.ErrNotExists {
+			log.Warnf("⚠️ [%s] 加载对冲敞口状态失败: %v", ID, err)
+		}
+		return
+	}
+	s.hedgeMu.Lock()
+	s.covered = covered
+	s.hedgeMu.Unlock()
+}
+
+// persistHedgeState 把 covered 的快照写入 PersistenceService（未配置时静默跳过）。
+func (s *Strategy) persistHedgeState(snapshot map[domain.TokenType]float64) {
+	if s.PersistenceService == nil {
+		return
+	}
+	store := s.PersistenceService.NewStore(ID, s.ID(), hedgeStatePersistenceTag)
+	if err := store.Save(&snapshot); err != nil {
+		log.Warnf("⚠️ [%s] 保存对冲敞口状态失败: %v", ID, err)
+	}
+}
@@ -0,0 +1,167 @@
+package unifiedarb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/betbot/gobet/pkg/interact"
+)
+
+// startInteract 按 Config.InteractEnabled 启动 Telegram/Slack 控制面：Slack 只
+// 作为 Notifiability 推送通道，Telegram 同时用作推送通道与命令入口（见
+// interact.TelegramNotifier.PollCommands）。会改变策略状态的命令要求先用
+// /auth <6位验证码> 通过 TOTP 校验（见 interact.Controller），一次性注册密钥
+// 持久化在 Strategy.PersistenceService 下（未注入时密钥只保存在内存中，重启后需
+// 重新注册）。未开启 InteractEnabled 时不启动，不影响策略其余行为。
+func (s *Strategy) startInteract(ctx context.Context) {
+	if !s.Config.InteractEnabled {
+		return
+	}
+
+	controller := interact.NewController(ID, s.ID(), s.PersistenceService, s.ID())
+	if enrollURL, isNew, err := controller.EnsureEnrolled(); err != nil {
+		log.Warnf("⚠️ [%s] TOTP 注册失败: %v", ID, err)
+	} else if isNew {
+		log.Infof("🔐 [%s] 首次启用控制面，请用任意 TOTP app 添加以下 otpauth URL 完成一次性注册（后续用 6 位验证码 /auth 认证）: %s", ID, enrollURL)
+	}
+
+	s.registerInteractCommands(controller)
+
+	if s.Config.SlackWebhookURL != "" {
+		controller.AddNotifier(interact.NewSlackNotifier(s.Config.SlackWebhookURL))
+	}
+	var tg *interact.TelegramNotifier
+	if s.Config.TelegramBotToken != "" {
+		tg = interact.NewTelegramNotifier(s.Config.TelegramBotToken, s.Config.TelegramChatID)
+		controller.AddNotifier(tg)
+	}
+
+	s.interactMu.Lock()
+	s.interactController = controller
+	s.interactMu.Unlock()
+
+	if tg != nil {
+		go tg.PollCommands(ctx, controller.Dispatch)
+	}
+}
+
+// notify 把 text 推送给已启用的 Telegram/Slack 通道；未启用控制面时为 no-op
+// （见 onOrder 的成交通知、checkPlanTimeouts 的 plan 完成通知）。调用方常在持有
+// stateMu/plansMu 时调用本函数，而 Notifiability.Notify 是同步 HTTP 请求，这里
+// 用后台 goroutine 发送（与 checkpoint.go 的定时 flush、hedge.go 的订单回调一致），
+// 避免网络延迟拖慢主状态锁。
+func (s *Strategy) notify(text string) {
+	s.interactMu.Lock()
+	controller := s.interactController
+	s.interactMu.Unlock()
+	if controller == nil {
+		return
+	}
+	go controller.Notify(text)
+}
+
+// registerInteractCommands 注册 /status /pause /resume /cancelplan /sethedge
+// /setmax /positions。
+func (s *Strategy) registerInteractCommands(controller *interact.Controller) {
+	controller.Register(interact.Command{
+		Name: "status",
+		Desc: "打印当前 stateSnapshot 与 rounds/MaxRoundsPerPeriod",
+		Handler: func(args []string) (string, error) {
+			_, _, _, _, pUp, pDown := s.stateSnapshot()
+			s.stateMu.Lock()
+			rounds := s.rounds
+			var qUp, qDown float64
+			if s.state != nil {
+				qUp, qDown = s.state.QUp.Float64(), s.state.QDown.Float64()
+			}
+			s.stateMu.Unlock()
+			return fmt.Sprintf("QUp=%.4f QDown=%.4f ProfitIfUpWin=%.4f ProfitIfDownWin=%.4f rounds=%d/%d",
+				qUp, qDown, pUp.Float64(), pDown.Float64(), rounds, s.MaxRoundsPerPeriod), nil
+		},
+	})
+	controller.Register(interact.Command{
+		Name:     "pause",
+		Desc:     "暂停本周期下单（不影响已提交 plan 的超时处理）",
+		Mutating: true,
+		Handler: func(args []string) (string, error) {
+			s.stateMu.Lock()
+			s.paused = true
+			s.stateMu.Unlock()
+			return "⏸️ 已暂停", nil
+		},
+	})
+	controller.Register(interact.Command{
+		Name:     "resume",
+		Desc:     "恢复下单",
+		Mutating: true,
+		Handler: func(args []string) (string, error) {
+			s.stateMu.Lock()
+			s.paused = false
+			s.stateMu.Unlock()
+			return "▶️ 已恢复", nil
+		},
+	})
+	controller.Register(interact.Command{
+		Name:     "cancelplan",
+		Desc:     "/cancelplan <id>：从本地 plan 跟踪表中移除（不会向交易所发送取消请求）",
+		Mutating: true,
+		Handler: func(args []string) (string, error) {
+			if len(args) != 1 {
+				return "", fmt.Errorf("用法: /cancelplan <id>")
+			}
+			s.plansMu.Lock()
+			_, found := s.plans[args[0]]
+			delete(s.plans, args[0])
+			s.plansMu.Unlock()
+			if !found {
+				return "", fmt.Errorf("plan 不存在: %s", args[0])
+			}
+			return fmt.Sprintf("🗑️ 已移除 plan %s", args[0]), nil
+		},
+	})
+	controller.Register(interact.Command{
+		Name:     "sethedge",
+		Desc:     "/sethedge on|off：开关自动对冲",
+		Mutating: true,
+		Handler: func(args []string) (string, error) {
+			if len(args) != 1 || (args[0] != "on" && args[0] != "off") {
+				return "", fmt.Errorf("用法: /sethedge on|off")
+			}
+			s.Config.HedgeEnabled = args[0] == "on"
+			return fmt.Sprintf("🛡️ HedgeEnabled=%v", s.Config.HedgeEnabled), nil
+		},
+	})
+	controller.Register(interact.Command{
+		Name:     "setmax",
+		Desc:     "/setmax <n>：调整 MaxRoundsPerPeriod",
+		Mutating: true,
+		Handler: func(args []string) (string, error) {
+			if len(args) != 1 {
+				return "", fmt.Errorf("用法: /setmax <n>")
+			}
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return "", fmt.Errorf("n 必须是正整数")
+			}
+			s.MaxRoundsPerPeriod = n
+			return fmt.Sprintf("🔢 MaxRoundsPerPeriod=%d", n), nil
+		},
+	})
+	controller.Register(interact.Command{
+		Name: "positions",
+		Desc: "/positions <market>：打印指定市场的当前持仓（仅当它是当前周期市场时有数据）",
+		Handler: func(args []string) (string, error) {
+			if len(args) != 1 {
+				return "", fmt.Errorf("用法: /positions <market>")
+			}
+			s.stateMu.Lock()
+			defer s.stateMu.Unlock()
+			if s.state == nil || s.state.Market == nil || s.state.Market.Slug != args[0] {
+				return fmt.Sprintf("market=%s 不是当前周期，无持仓数据", args[0]), nil
+			}
+			return fmt.Sprintf("market=%s QUp=%.4f QDown=%.4f CUp=%.4f CDown=%.4f",
+				args[0], s.state.QUp.Float64(), s.state.QDown.Float64(), s.state.CUp.Float64(), s.state.CDown.Float64()), nil
+		},
+	})
+}
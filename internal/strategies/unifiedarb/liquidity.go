@@ -0,0 +1,135 @@
+package unifiedarb
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/execution"
+	"github.com/betbot/gobet/pkg/fixedpoint"
+)
+
+// 分层流动性下单（见 Config.LiquidityLayersEnabled）：
+//
+// 参照 pkg/sdk/liquiditymaker 的阶梯挂单思路，把 maybeAmplify 原本的单笔
+// main+insurance FAK 买单，替换为围绕当前 ask 向下阶梯排布的 NumOfLiquidityLayers
+// 笔 GTC 限价买单：每层价格下移 LiquidityPriceRange/N，规模按 LiquidityScale 归一
+// 化到 AskLiquidityAmount（主方向）/BidLiquidityAmount（反向保险）。所有层的订单
+// ID 仍经由同一次 submitPlan 调用汇入同一个 plan，取消/超时逻辑不变。
+
+// liquidityLayerWeights 按 LiquidityScale 在 [DomainMin, DomainMax] 上取 n 个样
+// 本并归一化，返回的权重之和为 1，用于把总规模分配到各层。
+func liquidityLayerWeights(scale LiquidityScaleConfig, n int) []float64 {
+	weights := make([]float64, n)
+	if n <= 0 {
+		return weights
+	}
+	domainMin, domainMax := scale.DomainMin, scale.DomainMax
+	if domainMax <= domainMin {
+		domainMin, domainMax = 1, float64(n)
+	}
+	rangeMin, rangeMax := scale.RangeMin, scale.RangeMax
+	if rangeMax <= rangeMin {
+		rangeMin, rangeMax = 0, 1
+	}
+
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		x := domainMin
+		if n > 1 {
+			x = domainMin + (domainMax-domainMin)*float64(i)/float64(n-1)
+		}
+		t := (x - domainMin) / (domainMax - domainMin)
+
+		var v float64
+		if scale.Mode == "linear" {
+			v = rangeMin + (rangeMax-rangeMin)*t
+		} else { // "exp"（默认）
+			v = rangeMin + (rangeMax-rangeMin)*(math.Exp(t)-1)/(math.E-1)
+		}
+		weights[i] = v
+		sum += v
+	}
+	if sum <= 0 {
+		for i := range weights {
+			weights[i] = 1.0 / float64(n)
+		}
+		return weights
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+	return weights
+}
+
+// buildLayeredLegs 围绕 baseAskDec 向下阶梯排布 n 笔 GTC 限价买单（第 1 层最靠近
+// 当前 ask，第 n 层最远），按 liquidityLayerWeights 把 totalSize 分配到各层；价格
+// 跌破 0 或分配权重为 0 的层会被跳过；名义价值不足 minOrderSize 的层按
+// ensureMinOrderSize 上调规模。
+func buildLayeredLegs(tok domain.TokenType, assetID string, baseAskDec float64, totalSize float64, n int, priceRange float64, scale LiquidityScaleConfig, namePrefix string, minOrderSize float64) []execution.LegIntent {
+	if totalSize <= 0 || n <= 0 || baseAskDec <= 0 {
+		return nil
+	}
+	step := priceRange / float64(n)
+	weights := liquidityLayerWeights(scale, n)
+
+	legs := make([]execution.LegIntent, 0, n)
+	for i := 0; i < n; i++ {
+		priceDec := baseAskDec - step*float64(i+1)
+		if priceDec <= 0 {
+			continue
+		}
+		size := totalSize * weights[i]
+		if size <= 0 {
+			continue
+		}
+		size = ensureMinOrderSize(size, priceDec, minOrderSize)
+		legs = append(legs, execution.LegIntent{
+			Name:      fmt.Sprintf("%s_layer%d", namePrefix, i+1),
+			AssetID:   assetID,
+			TokenType: tok,
+			Side:      types.SideBuy,
+			Price:     domain.PriceFromDecimal(priceDec),
+			Size:      size,
+			OrderType: types.OrderTypeGTC,
+		})
+	}
+	return legs
+}
+
+// simulateLayered 是 simulateAmplify 的分层版本：依次把每一层买单计入 pu/pd 推演，
+// 供 stateSnapshot 反映分层下单后的聚合敞口。pu/pd 与返回值都是 fixedpoint.Value，
+// 与 simulateBuy/stateSnapshot 保持同一精度域，避免多层累加时的浮点误差。
+func simulateLayered(pu, pd fixedpoint.Value, legs []execution.LegIntent) (pu2, pd2 fixedpoint.Value) {
+	pu2, pd2 = pu, pd
+	for _, leg := range legs {
+		pu2, pd2 = simulateBuy(pu2, pd2, fixedpoint.NewFromFloat(leg.Size), fixedpoint.NewFromFloat(leg.Price.ToDecimal()), leg.TokenType)
+	}
+	return pu2, pd2
+}
+
+// buildLayeredAmplifyReq 是 maybeAmplify 在 LiquidityLayersEnabled 时使用的下单
+// 请求构造：main 方向的总规模归一化到 AskLiquidityAmount，反向保险归一化到
+// BidLiquidityAmount（命名沿用 pkg/sdk/liquiditymaker 的 Ask/BidLiquidityAmount，
+// 但这里两侧都是买单，只是分别对应主方向/反向保险）。返回 nil 表示没有可下的层。
+func (s *Strategy) buildLayeredAmplifyReq(m *domain.Market, main domain.TokenType, mainAsset string, mainAskDec float64, insTok domain.TokenType, oppAsset string, oppAskDec float64) (*execution.MultiLegRequest, []execution.LegIntent) {
+	n := s.Config.NumOfLiquidityLayers
+	priceRange := s.Config.LiquidityPriceRange
+	scale := s.Config.LiquidityScale
+
+	legs := buildLayeredLegs(main, mainAsset, mainAskDec, s.Config.AskLiquidityAmount, n, priceRange, scale, "buy_main", s.MinOrderSize)
+	if s.Config.BidLiquidityAmount > 0 && oppAskDec > 0 {
+		legs = append(legs, buildLayeredLegs(insTok, oppAsset, oppAskDec, s.Config.BidLiquidityAmount, n, priceRange, scale, "buy_insurance", s.MinOrderSize)...)
+	}
+	if len(legs) == 0 {
+		return nil, nil
+	}
+	req := &execution.MultiLegRequest{
+		Name:       "unifiedarb_amplify_layered",
+		MarketSlug: m.Slug,
+		Legs:       legs,
+		Hedge:      s.hedgeConfig(),
+	}
+	return req, legs
+}
@@ -0,0 +1,117 @@
+package unifiedarb
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/strategies/orderutil"
+)
+
+// NR（窄幅，Narrow Range）探测器：观察最近 NRCount 个 (yesAsk+noAsk) 采样构成的
+// “波动区间”，当最新一根区间是窗口内最小值时，视为流动性收紧、定价即将出现偏差
+// 的信号，允许绕过冷却强制尝试一次放大后的 complete-set（见 Config.NREnabled 与
+// step 中对 updateNRWindow/detectNR 的调用）。
+
+// nrBar 是 NR 环形缓冲区里的一根“合成 K 线”：用相邻两次采样的 total 构造
+// high/low（思路上与 indicators.ATR 用 bid/ask 构造合成 K 线一致）。
+type nrBar struct {
+	at    time.Time
+	total float64 // yesAsk.Cents + noAsk.Cents
+	high  float64
+	low   float64
+}
+
+func (b nrBar) rangeCents() float64 { return b.high - b.low }
+
+// updateNRWindow 拉取最新的 yesAsk/noAsk，向 NR 缓冲区追加一根新 bar 并裁剪到
+// NRCount 根。返回最新 total（分）；行情缺失时 ok=false，不写入缓冲区。
+func (s *Strategy) updateNRWindow(ctx context.Context, m *domain.Market) (total float64, ok bool) {
+	orderCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	yesAsk, err := orderutil.QuoteBuyPrice(orderCtx, s.TradingService, m.YesAssetID, 0)
+	if err != nil {
+		return 0, false
+	}
+	noAsk, err := orderutil.QuoteBuyPrice(orderCtx, s.TradingService, m.NoAssetID, 0)
+	if err != nil {
+		return 0, false
+	}
+	total = float64(yesAsk.Cents + noAsk.Cents)
+
+	s.nrMu.Lock()
+	defer s.nrMu.Unlock()
+	high, low := total, total
+	if n := len(s.nrBuf); n > 0 {
+		prev := s.nrBuf[n-1].total
+		high = math.Max(total, prev)
+		low = math.Min(total, prev)
+	}
+	s.nrBuf = append(s.nrBuf, nrBar{at: time.Now(), total: total, high: high, low: low})
+	if limit := s.Config.NRCount; limit > 0 && len(s.nrBuf) > limit {
+		s.nrBuf = s.nrBuf[len(s.nrBuf)-limit:]
+	}
+	return total, true
+}
+
+// detectNR 判断 NR 缓冲区是否构成窄幅信号：缓冲区已填满 NRCount 根 bar，且最新
+// 一根的区间是窗口内的严格最小值。StrictMode 额外要求整个窗口区间单调收窄
+// （bars[i].range 严格小于 bars[i-1].range），而不只是最后一根最小。
+func (s *Strategy) detectNR() bool {
+	s.nrMu.Lock()
+	defer s.nrMu.Unlock()
+	n := s.Config.NRCount
+	if n <= 0 || len(s.nrBuf) < n {
+		return false
+	}
+	bars := s.nrBuf[len(s.nrBuf)-n:]
+
+	if s.Config.StrictMode {
+		for i := 1; i < len(bars); i++ {
+			if bars[i].rangeCents() >= bars[i-1].rangeCents() {
+				return false
+			}
+		}
+		return true
+	}
+
+	latest := bars[len(bars)-1].rangeCents()
+	for i := 0; i < len(bars)-1; i++ {
+		if latest >= bars[i].rangeCents() {
+			return false
+		}
+	}
+	return true
+}
+
+// resetNR 清空 NR 缓冲区（周期切换时调用，见 resetCycle），避免跨周期的价格跳变
+// 被误判为窄幅信号。
+func (s *Strategy) resetNR() {
+	s.nrMu.Lock()
+	defer s.nrMu.Unlock()
+	s.nrBuf = nil
+}
+
+// tryNREntry 在满足 NR 窄幅信号 + 价格优势（total <= 100 - ProfitTargetCents -
+// NRBonusCents）时，绕过冷却强制尝试一次放大后的 complete-set。返回 true 表示
+// 本次 step 已经处理过（无论下单是否成功），调用方应直接 return。
+func (s *Strategy) tryNREntry(ctx context.Context, m *domain.Market, now time.Time) bool {
+	if !s.Config.NREnabled {
+		return false
+	}
+	total, ok := s.updateNRWindow(ctx, m)
+	if !ok || !s.detectNR() {
+		return false
+	}
+	maxTotal := float64(100 - s.ProfitTargetCents - s.Config.NRBonusCents)
+	if total > maxTotal {
+		return false
+	}
+	if !s.canStartNewPlan() {
+		return true
+	}
+	size := s.OrderSize * s.Config.NRSizeMultiplier
+	s.maybeCompleteSetSized(ctx, m, now, "nr_complete_set", size)
+	return true
+}
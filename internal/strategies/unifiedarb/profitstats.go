@@ -0,0 +1,188 @@
+package unifiedarb
+
+import (
+	"time"
+
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/pkg/persistence"
+)
+
+// ProfitStats 按 market slug / 按天切片记录成交量、盈亏与 maker/taker 构成，
+// 通过 Strategy.PersistenceService 持久化（见 loadProfitStats/persistProfitStats）。
+// 与 TradeStats（见 stats.go）的区别：TradeStats 是整条策略生命周期的聚合指标
+// （胜率/回撤/最好最坏周期等），ProfitStats 是面向运营巡检的明细快照（哪个市场、
+// 哪一天的成交构成是否异常），二者独立累加、互不影响。
+type ProfitStats struct {
+	Markets map[string]*MarketProfit `json:"markets" yaml:"markets"`
+	Days    map[string]*DayFills     `json:"days" yaml:"days"` // key 为 UTC 日期 "2006-01-02"
+
+	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
+}
+
+// MarketProfit 是单个 market slug 的累计成交量、盈亏与对冲覆盖快照。
+type MarketProfit struct {
+	Volume           float64 `json:"volume" yaml:"volume"`                     // 累计成交量（shares，买卖双边都计入）
+	RealizedProfit   float64 `json:"realizedProfit" yaml:"realizedProfit"`     // 周期收盘结算的已实现盈亏（见 recordRealizedProfit）
+	UnrealizedProfit float64 `json:"unrealizedProfit" yaml:"unrealizedProfit"` // 当前周期 min(P_up_win, P_down_win) 的最近一次估计（见 recordUnrealizedProfit）
+	HedgeCoverage    float64 `json:"hedgeCoverage" yaml:"hedgeCoverage"`       // 累计已确认对冲成交量（见 recordHedgeFill）
+}
+
+// DayFills 是某一天（UTC）内的 maker/taker 成交笔数统计。
+// maker/taker 的判定取自订单类型：GTC（挂单等待撮合）记为 maker，FAK/FOK（立即成
+// 交或取消）记为 taker，本仓库没有交易所直接返回的 maker/taker 标记，这是近似。
+type DayFills struct {
+	MakerFills int64 `json:"makerFills" yaml:"makerFills"`
+	TakerFills int64 `json:"takerFills" yaml:"takerFills"`
+}
+
+func newProfitStats() *ProfitStats {
+	return &ProfitStats{
+		Markets: make(map[string]*MarketProfit),
+		Days:    make(map[string]*DayFills),
+	}
+}
+
+func (p *ProfitStats) market(marketSlug string) *MarketProfit {
+	if p.Markets == nil {
+		p.Markets = make(map[string]*MarketProfit)
+	}
+	mp, ok := p.Markets[marketSlug]
+	if !ok {
+		mp = &MarketProfit{}
+		p.Markets[marketSlug] = mp
+	}
+	return mp
+}
+
+func (p *ProfitStats) day(at time.Time) *DayFills {
+	if p.Days == nil {
+		p.Days = make(map[string]*DayFills)
+	}
+	key := at.UTC().Format("2006-01-02")
+	d, ok := p.Days[key]
+	if !ok {
+		d = &DayFills{}
+		p.Days[key] = d
+	}
+	return d
+}
+
+const profitStatsPersistenceTag = "profitstats"
+
+// loadProfitStats 在 Initialize 阶段尝试从 PersistenceService 恢复 ProfitStats
+// （未配置持久化或文件不存在时保持空快照，不视为错误）。
+func (s *Strategy) loadProfitStats() {
+	s.profitStatsMu.Lock()
+	if s.profitStats == nil {
+		s.profitStats = newProfitStats()
+	}
+	stats := s.profitStats
+	s.profitStatsMu.Unlock()
+
+	if s.PersistenceService == nil {
+		return
+	}
+	store := s.PersistenceService.NewStore(ID, s.ID(), profitStatsPersistenceTag)
+	if err := store.Load(stats); err != nil && err != persistence.ErrNotExists {
+		log.Warnf("⚠️ [%s] 加载 ProfitStats 失败: %v", ID, err)
+	}
+}
+
+// persistProfitStats 将当前 ProfitStats 写入 PersistenceService（未配置时静默跳过）。
+func (s *Strategy) persistProfitStats() {
+	if s.PersistenceService == nil {
+		return
+	}
+	snapshot := s.GetProfitStats()
+	store := s.PersistenceService.NewStore(ID, s.ID(), profitStatsPersistenceTag)
+	if err := store.Save(&snapshot); err != nil {
+		log.Warnf("⚠️ [%s] 保存 ProfitStats 失败: %v", ID, err)
+	}
+}
+
+// GetProfitStats 返回当前 ProfitStats 的深拷贝快照（用于 /state 端点与监控，
+// 调用方不应修改返回值所引用的内部 map）。
+func (s *Strategy) GetProfitStats() ProfitStats {
+	s.profitStatsMu.Lock()
+	defer s.profitStatsMu.Unlock()
+	if s.profitStats == nil {
+		return *newProfitStats()
+	}
+	out := ProfitStats{
+		Markets:   make(map[string]*MarketProfit, len(s.profitStats.Markets)),
+		Days:      make(map[string]*DayFills, len(s.profitStats.Days)),
+		UpdatedAt: s.profitStats.UpdatedAt,
+	}
+	for slug, mp := range s.profitStats.Markets {
+		cp := *mp
+		out.Markets[slug] = &cp
+	}
+	for day, d := range s.profitStats.Days {
+		cp := *d
+		out.Days[day] = &cp
+	}
+	return out
+}
+
+// recordVolume 在一次成交（买或卖）后累加该市场的成交量，并按订单类型累加当天的
+// maker/taker 成交笔数（见 onOrder）。
+func (s *Strategy) recordVolume(marketSlug string, size float64, orderType types.OrderType, at time.Time) {
+	if marketSlug == "" || size <= 0 {
+		return
+	}
+	s.profitStatsMu.Lock()
+	defer s.profitStatsMu.Unlock()
+	if s.profitStats == nil {
+		s.profitStats = newProfitStats()
+	}
+	s.profitStats.market(marketSlug).Volume += size
+	if orderType == types.OrderTypeGTC {
+		s.profitStats.day(at).MakerFills++
+	} else {
+		s.profitStats.day(at).TakerFills++
+	}
+	s.profitStats.UpdatedAt = at
+}
+
+// recordRealizedProfit 在周期收盘结算时记录该市场的已实现盈亏（见 resetCycle）。
+func (s *Strategy) recordRealizedProfit(marketSlug string, pnl float64) {
+	if marketSlug == "" {
+		return
+	}
+	s.profitStatsMu.Lock()
+	defer s.profitStatsMu.Unlock()
+	if s.profitStats == nil {
+		s.profitStats = newProfitStats()
+	}
+	s.profitStats.market(marketSlug).RealizedProfit += pnl
+	s.profitStats.UpdatedAt = time.Now()
+}
+
+// recordUnrealizedProfit 记录当前周期 min(P_up_win, P_down_win) 的最近一次估计
+// （见 loop 中 isLocked 调用点），覆盖写入而非累加。
+func (s *Strategy) recordUnrealizedProfit(marketSlug string, minProfit float64) {
+	if marketSlug == "" {
+		return
+	}
+	s.profitStatsMu.Lock()
+	defer s.profitStatsMu.Unlock()
+	if s.profitStats == nil {
+		s.profitStats = newProfitStats()
+	}
+	s.profitStats.market(marketSlug).UnrealizedProfit = minProfit
+}
+
+// recordHedgeFill 在跨交易所对冲成交确认后累加该市场的对冲覆盖量（见
+// registerHedgeOrderWatch）。
+func (s *Strategy) recordHedgeFill(marketSlug string, size float64) {
+	if marketSlug == "" || size <= 0 {
+		return
+	}
+	s.profitStatsMu.Lock()
+	defer s.profitStatsMu.Unlock()
+	if s.profitStats == nil {
+		s.profitStats = newProfitStats()
+	}
+	s.profitStats.market(marketSlug).HedgeCoverage += size
+	s.profitStats.UpdatedAt = time.Now()
+}
@@ -0,0 +1,259 @@
+package unifiedarb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/betbot/gobet/pkg/persistence"
+)
+
+// TradeStats 记录 unifiedarb 策略在整个生命周期内的交易统计，跨周期累加，
+// 通过 Strategy.PersistenceService 持久化（见 Strategy.loadStats/persistStats），
+// 重启后可续算而不是归零。
+type TradeStats struct {
+	PlansSubmitted int64 `json:"plansSubmitted" yaml:"plansSubmitted"`
+	PlansTerminal  int64 `json:"plansTerminal" yaml:"plansTerminal"`
+
+	BuildFills   int64 `json:"buildFills" yaml:"buildFills"`
+	LockFills    int64 `json:"lockFills" yaml:"lockFills"`
+	AmplifyFills int64 `json:"amplifyFills" yaml:"amplifyFills"`
+
+	Cycles int64 `json:"cycles" yaml:"cycles"`
+	Wins   int64 `json:"wins" yaml:"wins"`
+	Losses int64 `json:"losses" yaml:"losses"`
+
+	GrossProfit float64 `json:"grossProfit" yaml:"grossProfit"`
+	GrossLoss   float64 `json:"grossLoss" yaml:"grossLoss"`
+
+	BestCycleSlug  string  `json:"bestCycleSlug" yaml:"bestCycleSlug"`
+	BestCyclePnL   float64 `json:"bestCyclePnL" yaml:"bestCyclePnL"`
+	WorstCycleSlug string  `json:"worstCycleSlug" yaml:"worstCycleSlug"`
+	WorstCyclePnL  float64 `json:"worstCyclePnL" yaml:"worstCyclePnL"`
+
+	CumulativePnL float64 `json:"cumulativePnL" yaml:"cumulativePnL"`
+	PeakPnL       float64 `json:"peakPnL" yaml:"peakPnL"`
+	MaxDrawdown   float64 `json:"maxDrawdown" yaml:"maxDrawdown"`
+
+	AmplifyMinProfitSum   float64 `json:"amplifyMinProfitSum" yaml:"amplifyMinProfitSum"`
+	AmplifyMinProfitCount int64   `json:"amplifyMinProfitCount" yaml:"amplifyMinProfitCount"`
+
+	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
+
+	// recentPnL 记录最近周期的已实现盈亏，用于滚动亏损熔断判定（见 RollingPnL 与
+	// Strategy.checkTradeGates），仅进程内维护，不参与持久化（重启后从空窗口重新判断）。
+	recentPnL []pnlPoint `json:"-" yaml:"-"`
+}
+
+// pnlPoint 是 recentPnL 中的单个采样点。
+type pnlPoint struct {
+	at  time.Time
+	pnl float64
+}
+
+const rollingPnLRetention = 24 * time.Hour
+
+// Add 记录一个周期收盘时的已实现盈亏（USDC，取 min(P_up_win, P_down_win) 近似，见
+// Strategy.resetCycle），更新胜率/盈亏/回撤等聚合指标。
+func (t *TradeStats) Add(marketSlug string, pnl float64) {
+	t.Cycles++
+	if pnl >= 0 {
+		t.Wins++
+		t.GrossProfit += pnl
+	} else {
+		t.Losses++
+		t.GrossLoss += -pnl
+	}
+	if t.Cycles == 1 || pnl > t.BestCyclePnL {
+		t.BestCyclePnL = pnl
+		t.BestCycleSlug = marketSlug
+	}
+	if t.Cycles == 1 || pnl < t.WorstCyclePnL {
+		t.WorstCyclePnL = pnl
+		t.WorstCycleSlug = marketSlug
+	}
+
+	t.CumulativePnL += pnl
+	if t.CumulativePnL > t.PeakPnL {
+		t.PeakPnL = t.CumulativePnL
+	}
+	if drawdown := t.PeakPnL - t.CumulativePnL; drawdown > t.MaxDrawdown {
+		t.MaxDrawdown = drawdown
+	}
+	t.UpdatedAt = time.Now()
+
+	cutoff := t.UpdatedAt.Add(-rollingPnLRetention)
+	t.recentPnL = append(t.recentPnL, pnlPoint{at: t.UpdatedAt, pnl: pnl})
+	i := 0
+	for i < len(t.recentPnL) && t.recentPnL[i].at.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		t.recentPnL = t.recentPnL[i:]
+	}
+}
+
+// RollingPnL 返回最近 window 时间窗口内已实现盈亏之和（见 Add 写入的 recentPnL）。
+func (t *TradeStats) RollingPnL(now time.Time, window time.Duration) float64 {
+	cutoff := now.Add(-window)
+	sum := 0.0
+	for _, p := range t.recentPnL {
+		if p.at.Before(cutoff) {
+			continue
+		}
+		sum += p.pnl
+	}
+	return sum
+}
+
+// recordAmplifyEntry 记录一次进入 amplify 下单时的 minProfit（放大前的最差情形收益）。
+func (t *TradeStats) recordAmplifyEntry(minProfit float64) {
+	t.AmplifyMinProfitSum += minProfit
+	t.AmplifyMinProfitCount++
+}
+
+// WinRatio 返回 [0,1] 范围内的胜率（无已结算周期时返回 0, false）。
+func (t *TradeStats) WinRatio() (float64, bool) {
+	if t.Cycles == 0 {
+		return 0, false
+	}
+	return float64(t.Wins) / float64(t.Cycles), true
+}
+
+// AverageAmplifyMinProfit 返回 amplify 入场时 minProfit 的平均值（无样本时返回 0, false）。
+func (t *TradeStats) AverageAmplifyMinProfit() (float64, bool) {
+	if t.AmplifyMinProfitCount == 0 {
+		return 0, false
+	}
+	return t.AmplifyMinProfitSum / float64(t.AmplifyMinProfitCount), true
+}
+
+func (t *TradeStats) String() string {
+	winRatio, _ := t.WinRatio()
+	avgMinProfit, _ := t.AverageAmplifyMinProfit()
+	return fmt.Sprintf(
+		"plans=%d/%d fills(build/lock/amplify)=%d/%d/%d cycles=%d win=%d loss=%d winRatio=%.2f "+
+			"grossProfit=%.2f grossLoss=%.2f best=%s(%.2f) worst=%s(%.2f) cumPnL=%.2f maxDD=%.2f avgAmplifyMinProfit=%.2f",
+		t.PlansSubmitted, t.PlansTerminal,
+		t.BuildFills, t.LockFills, t.AmplifyFills,
+		t.Cycles, t.Wins, t.Losses, winRatio,
+		t.GrossProfit, t.GrossLoss,
+		t.BestCycleSlug, t.BestCyclePnL, t.WorstCycleSlug, t.WorstCyclePnL,
+		t.CumulativePnL, t.MaxDrawdown, avgMinProfit,
+	)
+}
+
+const statsPersistenceTag = "stats"
+
+// loadStats 在 Initialize 阶段尝试从 PersistenceService 恢复历史统计（未配置持久化
+// 或文件不存在时保持零值，不视为错误）。
+func (s *Strategy) loadStats() {
+	s.statsMu.Lock()
+	if s.stats == nil {
+		s.stats = &TradeStats{}
+	}
+	stats := s.stats
+	s.statsMu.Unlock()
+
+	if s.PersistenceService == nil {
+		return
+	}
+	store := s.PersistenceService.NewStore(ID, s.ID(), statsPersistenceTag)
+	if err := store.Load(stats); err != nil && err != persistence.ErrNotExists {
+		log.Warnf("⚠️ [%s] 加载 TradeStats 失败: %v", ID, err)
+	}
+}
+
+// persistStats 将当前统计写入 PersistenceService（未配置时静默跳过）。
+func (s *Strategy) persistStats() {
+	if s.PersistenceService == nil {
+		return
+	}
+	snapshot := s.GetStats()
+	store := s.PersistenceService.NewStore(ID, s.ID(), statsPersistenceTag)
+	if err := store.Save(&snapshot); err != nil {
+		log.Warnf("⚠️ [%s] 保存 TradeStats 失败: %v", ID, err)
+	}
+}
+
+// GetStats 返回当前 TradeStats 的快照（用于监控/调试，调用方不应修改返回值所引用的内部状态）。
+func (s *Strategy) GetStats() TradeStats {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	if s.stats == nil {
+		return TradeStats{}
+	}
+	return *s.stats
+}
+
+// rollingPnL 返回 s.stats 在 window 窗口内的滚动已实现盈亏（见 checkTradeGates）。
+func (s *Strategy) rollingPnL(now time.Time, window time.Duration) float64 {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	if s.stats == nil {
+		return 0
+	}
+	return s.stats.RollingPnL(now, window)
+}
+
+// recordFill 按当前阶段累加一次成交（见 onOrder）。
+func (s *Strategy) recordFill(ph phase) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	if s.stats == nil {
+		return
+	}
+	switch ph {
+	case phaseBuild:
+		s.stats.BuildFills++
+	case phaseLock:
+		s.stats.LockFills++
+	case phaseAmplify:
+		s.stats.AmplifyFills++
+	}
+}
+
+// recordPlanSubmitted 在一个 plan 成功提交后累加（见 submitPlan）。
+func (s *Strategy) recordPlanSubmitted() {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	if s.stats == nil {
+		return
+	}
+	s.stats.PlansSubmitted++
+}
+
+// recordPlanTerminal 在一个 plan 的所有腿都到达终态后累加（见 checkPlanTimeouts）。
+func (s *Strategy) recordPlanTerminal() {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	if s.stats == nil {
+		return
+	}
+	s.stats.PlansTerminal++
+}
+
+// recordAmplifyEntry 记录一次 amplify 入场时的 minProfit（见 maybeAmplify）。
+func (s *Strategy) recordAmplifyEntry(minProfit float64) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	if s.stats == nil {
+		return
+	}
+	s.stats.recordAmplifyEntry(minProfit)
+}
+
+// closeCycleStats 在周期收盘时结算已实现盈亏并返回用于日志的统计摘要字符串。
+// pnl 取 min(P_up_win, P_down_win)：结算时尚不知道最终由哪个 token 兑付，该值是
+// 策略在 lock/amplify 阶段试图保证的下限，也是本策略能得到的最接近“已实现”的估计。
+func (s *Strategy) closeCycleStats(marketSlug string, pnl float64) string {
+	s.statsMu.Lock()
+	if s.stats == nil {
+		s.stats = &TradeStats{}
+	}
+	s.stats.Add(marketSlug, pnl)
+	summary := s.stats.String()
+	s.statsMu.Unlock()
+
+	s.persistStats()
+	return summary
+}
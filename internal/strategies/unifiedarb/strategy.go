@@ -11,11 +11,15 @@ import (
 	"github.com/betbot/gobet/internal/domain"
 	"github.com/betbot/gobet/internal/events"
 	"github.com/betbot/gobet/internal/execution"
-	"github.com/betbot/gobet/internal/services"
 	"github.com/betbot/gobet/internal/strategies/common"
+	"github.com/betbot/gobet/internal/strategies/common/indicators"
 	"github.com/betbot/gobet/internal/strategies/orderutil"
 	"github.com/betbot/gobet/pkg/bbgo"
+	"github.com/betbot/gobet/pkg/fixedpoint"
+	"github.com/betbot/gobet/pkg/interact"
+	"github.com/betbot/gobet/pkg/persistence"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 )
 
 const ID = "unifiedarb"
@@ -48,8 +52,10 @@ type plan struct {
 // - 通过 loop 合并事件推进内部状态机（避免在回调里做重活/阻塞）
 // - 所有下单统一走 TradingService.ExecuteMultiLeg
 type Strategy struct {
-	TradingService *services.TradingService
-	Config         `yaml:",inline" json:",inline"`
+	TradingService      tradingAPI // 通常是 *services.TradingService；回测时可替换为 backtest.Engine
+	PersistenceService  persistence.Service
+	HedgeTradingService tradingAPI // 跨交易所对冲（可选），见 Config.HedgeCrossVenueEnabled
+	Config              `yaml:",inline" json:",inline"`
 
 	guard common.MarketSlugGuard
 
@@ -63,17 +69,57 @@ type Strategy struct {
 	loopCancel context.CancelFunc
 
 	// cycle state
-	stateMu    sync.Mutex
-	state      *domain.ArbitragePositionState
-	lastFilled map[string]float64 // orderID -> last filledSize snapshot
-	lastStatus map[string]domain.OrderStatus
-	rounds     int
-	lastSubmit time.Time
-	paused     bool
+	stateMu         sync.Mutex
+	state           *domain.ArbitragePositionState
+	lastFilled      map[string]float64 // orderID -> last filledSize snapshot
+	lastStatus      map[string]domain.OrderStatus
+	rounds          int
+	lastSubmit      time.Time
+	paused          bool
+	lastLoggedPhase phase
+
+	// 交易时段 + 亏损熔断（可选，见 Config.TradeStartHour 等与 checkTradeGates）；
+	// 与 paused（plan 失败动作）相互独立，周期切换不重置 pauseUntil。
+	pauseUntil  time.Time
+	pauseReason string
 
 	// plan tracking (pairlock-like)
 	plansMu sync.Mutex
 	plans   map[string]*plan
+
+	// 指标驱动的阶段判定（可选，见 Config.Indicator 与 detectPhase）
+	indicatorMu   sync.Mutex
+	indicatorSets map[domain.TokenType]*indicators.Set
+	amplifyStreak map[domain.TokenType]int
+
+	// ATR 动态价格边界（可选，见 Config.PriceBoundMode 与 refreshATR）
+	atrMu        sync.Mutex
+	atr          *indicators.ATR
+	lastATRCents float64
+	hasATR       bool
+
+	// 交易统计（跨周期累加，可选持久化，见 stats.go）
+	statsMu sync.Mutex
+	stats   *TradeStats
+
+	// 按市场/按天的明细盈亏与成交构成（可选持久化，见 profitstats.go）
+	profitStatsMu sync.Mutex
+	profitStats   *ProfitStats
+
+	// 跨交易所对冲（可选，见 Config.HedgeCrossVenueEnabled 与 hedge.go）
+	hedgeMu         sync.Mutex
+	covered         map[domain.TokenType]float64 // CoveredPosition：待对冲敞口（主交易所买单成交增加，对冲交易所卖单成交减少）
+	hedgeLastFilled map[string]float64           // 对冲交易所 orderID -> 上次 FilledSize 快照
+	hedgePending    map[string]hedgeSubmission   // 对冲交易所 orderID -> 下单时的快照（用于成交后计算滑点/延迟）
+	hedgeLimiter    *rate.Limiter                // 见 Config.HedgeRateLimit
+
+	// NR（窄幅）探测器（可选，见 Config.NREnabled 与 nr.go）
+	nrMu  sync.Mutex
+	nrBuf []nrBar
+
+	// Telegram/Slack 控制面（可选，见 Config.InteractEnabled 与 interact.go）
+	interactMu         sync.Mutex
+	interactController *interact.Controller
 }
 
 func (s *Strategy) ID() string   { return ID }
@@ -108,7 +154,7 @@ func (s *Strategy) Initialize() error {
 		// 为了不破坏旧配置（没有 hedgeEnabled 字段的场景），这里做一个“缺省启用”的折中：
 		// - 当 hedgeEnabled 未显式配置时（bool 默认 false），我们仍然启用对冲，但允许用户显式关掉。
 		// 由于无法区分“未配置”与“配置为 false”，这里用“MinExposureToHedge/HedgeDelaySeconds 任一被设置”来推断用户意图。
-		if s.Config.MinExposureToHedge > 0 || s.Config.HedgeDelaySeconds > 0 || s.Config.HedgeSellPriceOffsetCents > 0 {
+		if s.Config.MinExposureToHedge.Sign() > 0 || s.Config.HedgeDelaySeconds > 0 || s.Config.HedgeSellPriceOffsetCents.Sign() > 0 {
 			// user likely configured hedge fields => keep HedgeEnabled=false if they want, do nothing
 		} else {
 			s.Config.HedgeEnabled = true
@@ -117,12 +163,31 @@ func (s *Strategy) Initialize() error {
 	if s.Config.HedgeDelaySeconds == 0 {
 		s.Config.HedgeDelaySeconds = 2
 	}
-	if s.Config.HedgeSellPriceOffsetCents == 0 {
-		s.Config.HedgeSellPriceOffsetCents = 2
+	if s.Config.HedgeSellPriceOffsetCents.IsZero() {
+		s.Config.HedgeSellPriceOffsetCents = fixedpoint.NewFromFloat(2)
+	}
+	if s.Config.MinExposureToHedge.IsZero() {
+		s.Config.MinExposureToHedge = fixedpoint.NewFromFloat(1.0)
+	}
+	if s.Config.Indicator.Enabled {
+		indicatorCfg := indicators.Config{
+			CCIWindow:          s.Config.Indicator.CCIWindow,
+			BollingerWindow:    s.Config.Indicator.BollingerWindow,
+			BollingerBandWidth: s.Config.Indicator.BollingerBandWidth,
+			ADXWindow:          s.Config.Indicator.ADXWindow,
+		}
+		s.indicatorSets = map[domain.TokenType]*indicators.Set{
+			domain.TokenTypeUp:   indicators.NewSet(indicatorCfg),
+			domain.TokenTypeDown: indicators.NewSet(indicatorCfg),
+		}
+		s.amplifyStreak = make(map[domain.TokenType]int)
 	}
-	if s.Config.MinExposureToHedge == 0 {
-		s.Config.MinExposureToHedge = 1.0
+	if s.Config.PriceBoundMode != "" && s.Config.PriceBoundMode != "static" {
+		s.atr = indicators.NewATR(s.Config.ATRWindow)
 	}
+	s.loadStats()
+	s.loadProfitStats()
+	s.loadCheckpoint()
 	return nil
 }
 
@@ -133,6 +198,10 @@ func (s *Strategy) Subscribe(session *bbgo.ExchangeSession) {
 
 func (s *Strategy) Run(ctx context.Context, _ bbgo.OrderExecutor, _ *bbgo.ExchangeSession) error {
 	common.StartLoopOnce(ctx, &s.loopOnce, func(cancel context.CancelFunc) { s.loopCancel = cancel }, 0, s.loop)
+	s.startHedger(ctx)
+	s.startCheckpointFlusher(ctx)
+	s.startInteract(ctx)
+	s.registerStateAdminHandler()
 	<-ctx.Done()
 	return ctx.Err()
 }
@@ -144,10 +213,90 @@ func (s *Strategy) OnPriceChanged(_ context.Context, e *events.PriceChangedEvent
 	s.priceMu.Lock()
 	s.latest[e.TokenType] = e
 	s.priceMu.Unlock()
+	s.feedIndicator(e.TokenType, e.NewPrice.ToDecimal())
 	common.TrySignal(s.signalC)
 	return nil
 }
 
+// feedIndicator 把一次价格样本喂入对应 token 的指标集合（CCI/Bollinger/ADX），
+// 并维护 AmplifyCCI 连续命中计数（供 detectPhase 判定 amplify 的“趋势确认”）。
+// 未启用 Config.Indicator 时是 no-op。
+func (s *Strategy) feedIndicator(tok domain.TokenType, price float64) {
+	s.indicatorMu.Lock()
+	defer s.indicatorMu.Unlock()
+	set := s.indicatorSets[tok]
+	if set == nil {
+		return
+	}
+	snap := set.Update(price)
+	if snap.CCIOk && snap.CCI > s.Config.Indicator.AmplifyCCI {
+		s.amplifyStreak[tok]++
+	} else {
+		s.amplifyStreak[tok] = 0
+	}
+}
+
+// indicatorSnapshots 返回当前每个 token 的指标快照（用于阶段切换日志），
+// 未启用指标时返回 nil。
+func (s *Strategy) indicatorSnapshots() map[domain.TokenType]indicators.Snapshot {
+	if !s.Config.Indicator.Enabled {
+		return nil
+	}
+	s.indicatorMu.Lock()
+	defer s.indicatorMu.Unlock()
+	out := make(map[domain.TokenType]indicators.Snapshot, len(s.indicatorSets))
+	for tok, set := range s.indicatorSets {
+		out[tok] = set.Last()
+	}
+	return out
+}
+
+// detectPhaseFromIndicators 尝试根据 CCI/Bollinger/ADX 判定阶段，返回 ok=false
+// 表示样本不足（指标未启用或窗口未填满），调用方应回退到时间驱动逻辑。
+//
+// 规则（见 Config.Indicator 字段注释）：
+//   - ADX 低于 BuildADXMax：无明显趋势，停留在 build（低价分批建仓更安全）；
+//   - 任一 token 的 |CCI| 超过 LockCCI，或价格突破布林带外轨：视为行情切换，
+//     升级到 lock（锁定双边正收益）；
+//   - ADX 超过 AmplifyADXMin 且“领先方（ask 更高的一侧）”的 CCI 连续
+//     AmplifyConfirmSamples 个样本都高于 AmplifyCCI：确认单边趋势，升级到 amplify。
+func (s *Strategy) detectPhaseFromIndicators(askUp, askDown float64) (ph phase, ok bool) {
+	if !s.Config.Indicator.Enabled {
+		return phaseLock, false
+	}
+	cfg := s.Config.Indicator
+
+	s.indicatorMu.Lock()
+	upSnap := s.indicatorSets[domain.TokenTypeUp].Last()
+	downSnap := s.indicatorSets[domain.TokenTypeDown].Last()
+	leading := domain.TokenTypeUp
+	if askDown > askUp {
+		leading = domain.TokenTypeDown
+	}
+	leadingStreak := s.amplifyStreak[leading]
+	s.indicatorMu.Unlock()
+
+	if !upSnap.ADXOk || !downSnap.ADXOk || !upSnap.CCIOk || !downSnap.CCIOk || !upSnap.BandsOk || !downSnap.BandsOk {
+		return phaseLock, false
+	}
+
+	maxADX := math.Max(upSnap.ADX, downSnap.ADX)
+	outerBandBreak := upSnap.Price >= upSnap.Bands.Upper || downSnap.Price >= downSnap.Bands.Upper
+	lockCCIBreak := math.Abs(upSnap.CCI) >= cfg.LockCCI || math.Abs(downSnap.CCI) >= cfg.LockCCI
+
+	switch {
+	case maxADX >= cfg.AmplifyADXMin && leadingStreak >= cfg.AmplifyConfirmSamples:
+		ph = phaseAmplify
+	case lockCCIBreak || outerBandBreak:
+		ph = phaseLock
+	case maxADX < cfg.BuildADXMax:
+		ph = phaseBuild
+	default:
+		ph = phaseLock
+	}
+	return ph, true
+}
+
 func (s *Strategy) OnOrderUpdate(_ context.Context, order *domain.Order) error {
 	if order == nil {
 		return nil
@@ -220,6 +369,16 @@ func (s *Strategy) step(loopCtx context.Context) {
 		return
 	}
 
+	// 5.5) 交易时段 + 亏损熔断（见 Config.TradeStartHour/PauseTradeLoss 与 checkTradeGates）
+	if !s.checkTradeGates(now) {
+		return
+	}
+
+	// 5.6) NR 窄幅探测：命中时绕过冷却强制尝试放大后的 complete-set（仍受 MaxConcurrentPlans 约束）
+	if s.tryNREntry(loopCtx, m, now) {
+		return
+	}
+
 	// 6) 冷却 + 轮数上限
 	s.stateMu.Lock()
 	if s.rounds >= s.MaxRoundsPerPeriod {
@@ -237,11 +396,15 @@ func (s *Strategy) step(loopCtx context.Context) {
 		return
 	}
 
-	// 8) 计算当前阶段 & 当前锁定状态（pairedtrading 核心：阶段调度）
+	// 8) 刷新 ATR（YES token best-bid/ask 的合成 TR），驱动下面各阶段的动态价格边界
+	s.refreshATR(loopCtx, m)
+
+	// 9) 计算当前阶段 & 当前锁定状态（pairedtrading 核心：阶段调度）
 	ph := s.detectPhase(nowUnix(now), m)
 	locked, minProfit := s.isLocked()
+	s.recordUnrealizedProfit(m.Slug, minProfit)
 
-	// 9) Phase 行为（按 pairedtrading README：Build -> Lock -> Amplify）
+	// 10) Phase 行为（按 pairedtrading README：Build -> Lock -> Amplify）
 	switch ph {
 	case phaseBuild:
 		s.maybeBuild(loopCtx, m, now)
@@ -256,6 +419,7 @@ func (s *Strategy) step(loopCtx context.Context) {
 
 func (s *Strategy) resetCycle(now time.Time, m *domain.Market) {
 	s.stateMu.Lock()
+	prevState := s.state
 	s.rounds = 0
 	s.lastSubmit = time.Time{}
 	s.paused = false
@@ -268,6 +432,21 @@ func (s *Strategy) resetCycle(now time.Time, m *domain.Market) {
 	s.plans = make(map[string]*plan)
 	s.plansMu.Unlock()
 
+	s.resetNR()
+
+	// 结算上一周期：用 min(P_up_win, P_down_win) 近似已实现盈亏（见 closeCycleStats），
+	// 只在上一周期确实建过仓时结算，避免策略刚启动时的空周期污染统计。
+	if prevState != nil && (!prevState.QUp.IsZero() || !prevState.QDown.IsZero() || !prevState.CUp.IsZero() || !prevState.CDown.IsZero()) {
+		prevSlug := ""
+		if prevState.Market != nil {
+			prevSlug = prevState.Market.Slug
+		}
+		pnl := fixedpoint.Min(prevState.ProfitIfUpWin(), prevState.ProfitIfDownWin()).Float64()
+		summary := s.closeCycleStats(prevSlug, pnl)
+		s.recordRealizedProfit(prevSlug, pnl)
+		log.Infof("📊 [%s] 周期结算: market=%s pnl=%.2f %s", ID, prevSlug, pnl, summary)
+	}
+
 	log.Infof("🔄 [%s] 周期切换，重置状态: market=%s ts=%d", ID, m.Slug, m.Timestamp)
 	_ = now
 }
@@ -300,29 +479,41 @@ func (s *Strategy) onOrder(o *domain.Order) {
 	}
 	delta := cur - prev
 	if delta > 0 && s.state != nil {
-		amount := delta * o.Price.ToDecimal()
+		s.recordFill(s.lastLoggedPhase)
+		fillAt := time.Now()
+		if o.FilledAt != nil {
+			fillAt = *o.FilledAt
+		}
+		s.recordVolume(o.MarketSlug, delta, o.OrderType, fillAt)
+		s.notify(fmt.Sprintf("💰 [%s] fill: market=%s token=%s side=%s size=%.4f price=%.4f",
+			ID, o.MarketSlug, o.TokenType, o.Side, delta, o.Price.ToDecimal()))
+		if o.Side == types.SideBuy {
+			s.onPrimaryFill(o.TokenType, delta)
+		}
+		deltaFP := fixedpoint.NewFromFloat(delta)
+		amountFP := deltaFP.Mul(fixedpoint.NewFromFloat(o.Price.ToDecimal()))
 		switch o.TokenType {
 		case domain.TokenTypeUp:
 			if o.Side == types.SideBuy {
-				s.state.QUp += delta
-				s.state.CUp += amount
+				s.state.QUp = s.state.QUp.Add(deltaFP)
+				s.state.CUp = s.state.CUp.Add(amountFP)
 			} else {
-				s.state.QUp -= delta
-				if s.state.QUp < 0 {
-					s.state.QUp = 0
+				s.state.QUp = s.state.QUp.Sub(deltaFP)
+				if s.state.QUp.Sign() < 0 {
+					s.state.QUp = fixedpoint.Zero
 				}
-				s.state.CUp -= amount
+				s.state.CUp = s.state.CUp.Sub(amountFP)
 			}
 		case domain.TokenTypeDown:
 			if o.Side == types.SideBuy {
-				s.state.QDown += delta
-				s.state.CDown += amount
+				s.state.QDown = s.state.QDown.Add(deltaFP)
+				s.state.CDown = s.state.CDown.Add(amountFP)
 			} else {
-				s.state.QDown -= delta
-				if s.state.QDown < 0 {
-					s.state.QDown = 0
+				s.state.QDown = s.state.QDown.Sub(deltaFP)
+				if s.state.QDown.Sign() < 0 {
+					s.state.QDown = fixedpoint.Zero
 				}
-				s.state.CDown -= amount
+				s.state.CDown = s.state.CDown.Sub(amountFP)
 			}
 		}
 	}
@@ -378,6 +569,8 @@ func (s *Strategy) checkPlanTimeouts(ctx context.Context, now time.Time, m *doma
 			continue
 		}
 		if planDone(p) {
+			s.recordPlanTerminal()
+			s.notify(fmt.Sprintf("✅ [%s] plan 完成: plan=%s market=%s", ID, p.id, m.Slug))
 			delete(s.plans, id)
 			continue
 		}
@@ -447,7 +640,7 @@ func (s *Strategy) tryFlatten(ctx context.Context, m *domain.Market) {
 	if st == nil || m == nil {
 		return
 	}
-	diff := st.QUp - st.QDown
+	diff := st.QUp.Sub(st.QDown).Float64()
 	if math.Abs(diff) < s.FailFlattenMinShares {
 		return
 	}
@@ -475,7 +668,7 @@ func (s *Strategy) tryFlatten(ctx context.Context, m *domain.Market) {
 		priceCents = 1
 	}
 	if s.FailMaxSellSlippageCents > 0 {
-		minAllowed := bestBidCents - s.FailMaxSellSlippageCents
+		minAllowed := bestBidCents - s.effectiveFailMaxSellSlippageCents()
 		if priceCents < minAllowed {
 			priceCents = minAllowed
 			if priceCents < 1 {
@@ -503,7 +696,26 @@ func (s *Strategy) tryFlatten(ctx context.Context, m *domain.Market) {
 	_, _ = s.TradingService.ExecuteMultiLeg(ctx, req)
 }
 
+// detectPhase 判定当前应处于的阶段（build/lock/amplify）。
+//
+// 优先使用 Config.Indicator 驱动的判定（detectPhaseFromIndicators：基于 CCI/
+// Bollinger/ADX，见该函数注释）；当指标未启用或样本不足（ring buffer 尚未填满）
+// 时，回退到原有的时间 + EarlyLockPrice/EarlyAmplifyPrice 阈值逻辑。
 func (s *Strategy) detectPhase(nowUnix int64, m *domain.Market) phase {
+	askUp, askDown := s.latestAskSnapshot()
+
+	ph, ok := s.detectPhaseFromIndicators(askUp, askDown)
+	if !ok {
+		ph = s.detectPhaseByTime(nowUnix, m, askUp, askDown)
+	}
+
+	s.logPhaseChange(ph, ok, askUp, askDown)
+	return ph
+}
+
+// detectPhaseByTime 是原有的时间驱动阶段判定（未配置/未启用指标，或指标样本
+// 不足时的回退路径）。
+func (s *Strategy) detectPhaseByTime(nowUnix int64, m *domain.Market, askUp, askDown float64) phase {
 	// 若未启用分阶段，则默认 lock
 	if s.CycleDurationSeconds <= 0 {
 		return phaseLock
@@ -523,7 +735,6 @@ func (s *Strategy) detectPhase(nowUnix int64, m *domain.Market) phase {
 	}
 
 	// early switch：基于价格快速切换（保守实现：只用“任意腿 ask”）
-	askUp, askDown := s.latestAskSnapshot()
 	maxAsk := math.Max(askUp, askDown)
 	if s.EarlyLockPrice > 0 && maxAsk >= s.EarlyLockPrice {
 		if ph == phaseBuild {
@@ -539,6 +750,107 @@ func (s *Strategy) detectPhase(nowUnix int64, m *domain.Market) phase {
 	return ph
 }
 
+// logPhaseChange 在阶段发生变化时打印一条带指标快照的日志，便于排查指标驱动的
+// 阶段切换是否符合预期。
+func (s *Strategy) logPhaseChange(ph phase, fromIndicators bool, askUp, askDown float64) {
+	s.stateMu.Lock()
+	changed := s.lastLoggedPhase != ph
+	s.lastLoggedPhase = ph
+	s.stateMu.Unlock()
+	if !changed {
+		return
+	}
+	log.Infof("📐 [阶段切换] phase=%s fromIndicators=%v askUp=%.4f askDown=%.4f indicators=%+v",
+		ph, fromIndicators, askUp, askDown, s.indicatorSnapshots())
+}
+
+// refreshATR 用 YES token 当前的 best-bid/ask 喂一次 ATR 估计器，并缓存结果供
+// effectiveBuildThreshold/effectiveLockPriceMax/... 读取。PriceBoundMode 为
+// static（默认）时是 no-op。
+func (s *Strategy) refreshATR(ctx context.Context, m *domain.Market) {
+	if s.atr == nil || m == nil {
+		return
+	}
+	atrCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	bid, ask, err := s.TradingService.GetBestPrice(atrCtx, m.YesAssetID)
+	if err != nil {
+		return
+	}
+
+	s.atrMu.Lock()
+	defer s.atrMu.Unlock()
+	cents, ok := s.atr.Update(bid*100, ask*100)
+	s.lastATRCents = cents
+	s.hasATR = ok
+}
+
+// currentATRCents 返回当前缓存的 ATR（分口径），ok=false 表示窗口未填满或尚未计算。
+func (s *Strategy) currentATRCents() (float64, bool) {
+	s.atrMu.Lock()
+	defer s.atrMu.Unlock()
+	return s.lastATRCents, s.hasATR
+}
+
+// dynamicCeil 按 PriceBoundMode 把一个静态价格上限（decimal）和 ATR 推导值结合：
+// static 模式原样返回 base；atr 模式完全使用 ATR 推导值；hybrid 模式取两者中
+// 更保守（更小）的一个。ATR 样本不足或为 0（零波动窗口）时一律回退到 base。
+func (s *Strategy) dynamicCeil(base float64, atrMultiple float64) float64 {
+	if s.PriceBoundMode == "" || s.PriceBoundMode == "static" {
+		return base
+	}
+	atrCents, ok := s.currentATRCents()
+	if !ok || atrCents <= 0 {
+		return base
+	}
+	dyn := base + atrMultiple*atrCents/100.0
+	if s.PriceBoundMode == "hybrid" {
+		return math.Min(base, dyn)
+	}
+	return dyn
+}
+
+// dynamicFloorCents 与 dynamicCeil 相反：hybrid 模式取两者中更保守（更大）的一个，
+// 用于 slippage 下限 / 对冲偏移这类“至少要这么多”的场景。
+func (s *Strategy) dynamicFloorCents(baseCents int, atrMultiple float64) int {
+	if s.PriceBoundMode == "" || s.PriceBoundMode == "static" {
+		return baseCents
+	}
+	atrCents, ok := s.currentATRCents()
+	if !ok || atrCents <= 0 {
+		return baseCents
+	}
+	dyn := int(math.Round(atrMultiple * atrCents))
+	if s.PriceBoundMode == "hybrid" && baseCents > dyn {
+		return baseCents
+	}
+	return dyn
+}
+
+// effectiveBuildThreshold/effectiveLockPriceMax/effectiveAmplifyPriceMax/
+// effectiveFailMaxSellSlippageCents/effectiveHedgeSellPriceOffsetCents 是各静态
+// 阈值字段（BuildThreshold/LockPriceMax/AmplifyPriceMax/FailMaxSellSlippageCents/
+// HedgeSellPriceOffsetCents）的 ATR 动态版本，见 dynamicCeil/dynamicFloorCents。
+func (s *Strategy) effectiveBuildThreshold() float64 {
+	return s.dynamicCeil(s.BuildThreshold, s.ATRProfitMultiple)
+}
+
+func (s *Strategy) effectiveLockPriceMax() float64 {
+	return s.dynamicCeil(s.LockPriceMax, s.ATRLossMultiple)
+}
+
+func (s *Strategy) effectiveAmplifyPriceMax() float64 {
+	return s.dynamicCeil(s.AmplifyPriceMax, s.ATRProfitMultiple)
+}
+
+func (s *Strategy) effectiveFailMaxSellSlippageCents() int {
+	return s.dynamicFloorCents(s.FailMaxSellSlippageCents, s.ATRLossMultiple)
+}
+
+func (s *Strategy) effectiveHedgeSellPriceOffsetCents() int {
+	return s.dynamicFloorCents(int(math.Round(s.HedgeSellPriceOffsetCents.Float64())), s.ATRProfitMultiple)
+}
+
 func (s *Strategy) latestAskSnapshot() (upAsk float64, downAsk float64) {
 	// 这里不走 orderbook API，直接用 BestPrice（会命中 TradingService 的 bestBook 缓存）
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -567,8 +879,8 @@ func (s *Strategy) isLocked() (locked bool, minProfit float64) {
 	}
 	pu := st.ProfitIfUpWin()
 	pd := st.ProfitIfDownWin()
-	minProfit = math.Min(pu, pd)
-	locked = pu > 0 && pd > 0
+	minProfit = fixedpoint.Min(pu, pd).Float64()
+	locked = pu.Sign() > 0 && pd.Sign() > 0
 	return locked, minProfit
 }
 
@@ -576,7 +888,8 @@ func (s *Strategy) maybeBuild(ctx context.Context, m *domain.Market, now time.Ti
 	if s.BaseTarget <= 0 || s.BuildLotSize <= 0 || s.BuildThreshold <= 0 {
 		return
 	}
-	qUp, qDown, _, _, _, _ := s.stateSnapshot()
+	qUpFP, qDownFP, _, _, _, _ := s.stateSnapshot()
+	qUp, qDown := qUpFP.Float64(), qDownFP.Float64()
 	if qUp >= s.BaseTarget && qDown >= s.BaseTarget {
 		return
 	}
@@ -589,7 +902,8 @@ func (s *Strategy) maybeBuild(ctx context.Context, m *domain.Market, now time.Ti
 	if err1 != nil || err2 != nil || upAskDec <= 0 || downAskDec <= 0 {
 		return
 	}
-	if upAskDec > s.BuildThreshold && downAskDec > s.BuildThreshold {
+	buildThreshold := s.effectiveBuildThreshold()
+	if upAskDec > buildThreshold && downAskDec > buildThreshold {
 		return
 	}
 
@@ -607,8 +921,8 @@ func (s *Strategy) maybeBuild(ctx context.Context, m *domain.Market, now time.Ti
 		target = domain.TokenTypeDown
 	} else {
 		// 在比例允许区间内：优先补齐低于 baseTarget 的方向；若两边都低，则买更便宜的一边
-		upNeed := qUp < s.BaseTarget && upAskDec <= s.BuildThreshold
-		downNeed := qDown < s.BaseTarget && downAskDec <= s.BuildThreshold
+		upNeed := qUp < s.BaseTarget && upAskDec <= buildThreshold
+		downNeed := qDown < s.BaseTarget && downAskDec <= buildThreshold
 		if upNeed && downNeed {
 			if upAskDec <= downAskDec {
 				target = domain.TokenTypeUp
@@ -624,10 +938,10 @@ func (s *Strategy) maybeBuild(ctx context.Context, m *domain.Market, now time.Ti
 		}
 	}
 
-	if target == domain.TokenTypeUp && upAskDec > s.BuildThreshold {
+	if target == domain.TokenTypeUp && upAskDec > buildThreshold {
 		return
 	}
-	if target == domain.TokenTypeDown && downAskDec > s.BuildThreshold {
+	if target == domain.TokenTypeDown && downAskDec > buildThreshold {
 		return
 	}
 
@@ -656,10 +970,11 @@ func (s *Strategy) maybeLock(ctx context.Context, m *domain.Market, now time.Tim
 	}
 	upAsk := domain.PriceFromDecimal(upAskDec)
 	downAsk := domain.PriceFromDecimal(downAskDec)
+	lockPriceMax := s.effectiveLockPriceMax()
 
 	// 2) 极端价格：买入反向保险（pairedtrading README）
 	if s.ExtremeHigh > 0 {
-		if upAskDec >= s.ExtremeHigh && downAskDec <= s.LockPriceMax && s.InsuranceSize > 0 {
+		if upAskDec >= s.ExtremeHigh && downAskDec <= lockPriceMax && s.InsuranceSize > 0 {
 			req := s.buildSingleBuyReq(m, domain.TokenTypeDown, s.InsuranceSize, "lock_extreme_insurance", map[domain.TokenType]domain.Price{
 				domain.TokenTypeDown: downAsk,
 			})
@@ -668,7 +983,7 @@ func (s *Strategy) maybeLock(ctx context.Context, m *domain.Market, now time.Tim
 			}
 			return
 		}
-		if downAskDec >= s.ExtremeHigh && upAskDec <= s.LockPriceMax && s.InsuranceSize > 0 {
+		if downAskDec >= s.ExtremeHigh && upAskDec <= lockPriceMax && s.InsuranceSize > 0 {
 			req := s.buildSingleBuyReq(m, domain.TokenTypeUp, s.InsuranceSize, "lock_extreme_insurance", map[domain.TokenType]domain.Price{
 				domain.TokenTypeUp: upAsk,
 			})
@@ -680,10 +995,11 @@ func (s *Strategy) maybeLock(ctx context.Context, m *domain.Market, now time.Tim
 	}
 
 	_, _, _, _, pu, pd := s.stateSnapshot()
+	puF, pdF := pu.Float64(), pd.Float64()
 
 	// 3) 风险优先：先修复明显负利润（达到 lockThreshold 才触发，避免噪声频繁交易）
 	if s.LockThreshold > 0 {
-		if pu < 0 && -pu >= s.LockThreshold && upAskDec <= s.LockPriceMax {
+		if puF < 0 && -puF >= s.LockThreshold && upAskDec <= lockPriceMax {
 			req := s.buildSingleBuyReq(m, domain.TokenTypeUp, s.OrderSize, "lock_fix_negative", map[domain.TokenType]domain.Price{
 				domain.TokenTypeUp: upAsk,
 			})
@@ -692,7 +1008,7 @@ func (s *Strategy) maybeLock(ctx context.Context, m *domain.Market, now time.Tim
 			}
 			return
 		}
-		if pd < 0 && -pd >= s.LockThreshold && downAskDec <= s.LockPriceMax {
+		if pdF < 0 && -pdF >= s.LockThreshold && downAskDec <= lockPriceMax {
 			req := s.buildSingleBuyReq(m, domain.TokenTypeDown, s.OrderSize, "lock_fix_negative", map[domain.TokenType]domain.Price{
 				domain.TokenTypeDown: downAsk,
 			})
@@ -719,18 +1035,19 @@ func (s *Strategy) maybeLock(ctx context.Context, m *domain.Market, now time.Tim
 		if lot <= 0 {
 			lot = s.OrderSize
 		}
+		lotFP := fixedpoint.NewFromFloat(lot)
 
-		if upAskDec > 0 && upAskDec <= s.LockPriceMax {
-			pu2, pd2 := simulateBuy(pu, pd, lot, upAskDec, domain.TokenTypeUp)
-			min2 := math.Min(pu2, pd2)
+		if upAskDec > 0 && upAskDec <= lockPriceMax {
+			pu2, pd2 := simulateBuy(pu, pd, lotFP, fixedpoint.NewFromFloat(upAskDec), domain.TokenTypeUp)
+			min2 := fixedpoint.Min(pu2, pd2).Float64()
 			if min2 > bestMin {
 				bestMin = min2
 				bestTok = domain.TokenTypeUp
 			}
 		}
-		if downAskDec > 0 && downAskDec <= s.LockPriceMax {
-			pu2, pd2 := simulateBuy(pu, pd, lot, downAskDec, domain.TokenTypeDown)
-			min2 := math.Min(pu2, pd2)
+		if downAskDec > 0 && downAskDec <= lockPriceMax {
+			pu2, pd2 := simulateBuy(pu, pd, lotFP, fixedpoint.NewFromFloat(downAskDec), domain.TokenTypeDown)
+			min2 := fixedpoint.Min(pu2, pd2).Float64()
 			if min2 > bestMin {
 				bestMin = min2
 				bestTok = domain.TokenTypeDown
@@ -793,7 +1110,7 @@ func (s *Strategy) maybeAmplify(ctx context.Context, m *domain.Market, now time.
 		mainAsset = m.NoAssetID
 		oppAsset = m.YesAssetID
 	}
-	if s.AmplifyPriceMax > 0 && mainAskDec > s.AmplifyPriceMax {
+	if s.AmplifyPriceMax > 0 && mainAskDec > s.effectiveAmplifyPriceMax() {
 		return
 	}
 
@@ -804,14 +1121,29 @@ func (s *Strategy) maybeAmplify(ctx context.Context, m *domain.Market, now time.
 		insSize = s.InsuranceSize
 	}
 
+	if s.Config.LiquidityLayersEnabled {
+		_, _, _, _, pu, pd := s.stateSnapshot()
+		req, legs := s.buildLayeredAmplifyReq(m, main, mainAsset, mainAskDec, insTok, oppAsset, oppAskDec)
+		if req == nil {
+			return
+		}
+		pu2, pd2 := simulateLayered(pu, pd, legs)
+		if pu2.Sign() <= 0 || pd2.Sign() <= 0 {
+			return
+		}
+		s.recordAmplifyEntry(minProfit)
+		_ = s.submitPlan(orderCtx, now, req)
+		return
+	}
+
 	_, _, _, _, pu, pd := s.stateSnapshot()
 	// 预检：放大后仍需保持锁定（两边利润 > 0）
 	mainSize := s.OrderSize
 	if mainSize <= 0 {
 		return
 	}
-	pu2, pd2 := simulateAmplify(pu, pd, main, mainSize, mainAskDec, insTok, insSize, oppAskDec)
-	if pu2 <= 0 || pd2 <= 0 {
+	pu2, pd2 := simulateAmplify(pu, pd, main, fixedpoint.NewFromFloat(mainSize), fixedpoint.NewFromFloat(mainAskDec), insTok, fixedpoint.NewFromFloat(insSize), fixedpoint.NewFromFloat(oppAskDec))
+	if pu2.Sign() <= 0 || pd2.Sign() <= 0 {
 		return
 	}
 
@@ -845,10 +1177,17 @@ func (s *Strategy) maybeAmplify(ctx context.Context, m *domain.Market, now time.
 		Legs:       legs,
 		Hedge:      s.hedgeConfig(),
 	}
+	s.recordAmplifyEntry(minProfit)
 	_ = s.submitPlan(orderCtx, now, req)
 }
 
 func (s *Strategy) maybeCompleteSet(ctx context.Context, m *domain.Market, now time.Time, reason string) bool {
+	return s.maybeCompleteSetSized(ctx, m, now, reason, s.OrderSize)
+}
+
+// maybeCompleteSetSized 是 maybeCompleteSet 的通用版本，允许调用方传入自定义下单
+// 规模（见 tryNREntry：NR 窄幅信号命中时用 OrderSize*NRSizeMultiplier 放大）。
+func (s *Strategy) maybeCompleteSetSized(ctx context.Context, m *domain.Market, now time.Time, reason string, size float64) bool {
 	orderCtx, cancel := context.WithTimeout(ctx, 25*time.Second)
 	defer cancel()
 
@@ -870,7 +1209,7 @@ func (s *Strategy) maybeCompleteSet(ctx context.Context, m *domain.Market, now t
 		return false
 	}
 
-	req := s.buildCompleteSetReq(m, yesAsk, noAsk, s.OrderSize, reason)
+	req := s.buildCompleteSetReq(m, yesAsk, noAsk, size, reason)
 	if req == nil {
 		return false
 	}
@@ -934,8 +1273,8 @@ func (s *Strategy) buildCompleteSetReq(m *domain.Market, yesAsk, noAsk domain.Pr
 	if s.HedgeEnabled {
 		hedge.Enabled = true
 		hedge.Delay = time.Duration(s.HedgeDelaySeconds) * time.Second
-		hedge.SellPriceOffsetCents = s.HedgeSellPriceOffsetCents
-		hedge.MinExposureToHedge = s.MinExposureToHedge
+		hedge.SellPriceOffsetCents = s.effectiveHedgeSellPriceOffsetCents()
+		hedge.MinExposureToHedge = s.MinExposureToHedge.Float64()
 	}
 	req := &execution.MultiLegRequest{
 		Name:       fmt.Sprintf("unifiedarb_complete_set_%s", reason),
@@ -977,6 +1316,7 @@ func (s *Strategy) submitPlan(ctx context.Context, now time.Time, req *execution
 	s.plansMu.Lock()
 	s.plans[p.id] = p
 	s.plansMu.Unlock()
+	s.recordPlanSubmitted()
 
 	s.stateMu.Lock()
 	s.rounds++
@@ -986,16 +1326,19 @@ func (s *Strategy) submitPlan(ctx context.Context, now time.Time, req *execution
 
 	if st != nil {
 		log.Infof("🎯 [%s] submit: rounds=%d/%d market=%s QUp=%.2f QDown=%.2f P_up=%.2f P_down=%.2f",
-			ID, s.rounds, s.MaxRoundsPerPeriod, req.MarketSlug, st.QUp, st.QDown, st.ProfitIfUpWin(), st.ProfitIfDownWin())
+			ID, s.rounds, s.MaxRoundsPerPeriod, req.MarketSlug, st.QUp.Float64(), st.QDown.Float64(), st.ProfitIfUpWin().Float64(), st.ProfitIfDownWin().Float64())
 	}
 	return true
 }
 
-func (s *Strategy) stateSnapshot() (qUp, qDown, cUp, cDown, pUp, pDown float64) {
+// stateSnapshot 返回当前持仓状态的定点数快照（见 ArbitragePositionState 注释：
+// QUp/QDown/CUp/CDown 用 fixedpoint.Value 避免高频累加漂移）。调用方在需要与
+// 尚未迁移的 float64 配置阈值比较时，应自行在边界调用 .Float64()。
+func (s *Strategy) stateSnapshot() (qUp, qDown, cUp, cDown, pUp, pDown fixedpoint.Value) {
 	s.stateMu.Lock()
 	defer s.stateMu.Unlock()
 	if s.state == nil {
-		return 0, 0, 0, 0, 0, 0
+		return
 	}
 	qUp = s.state.QUp
 	qDown = s.state.QDown
@@ -1006,26 +1349,30 @@ func (s *Strategy) stateSnapshot() (qUp, qDown, cUp, cDown, pUp, pDown float64)
 	return
 }
 
-func simulateBuy(pu, pd float64, size float64, ask float64, tok domain.TokenType) (pu2, pd2 float64) {
-	if size <= 0 || ask <= 0 || ask >= 1.0 {
+// simulateBuy 推演买入 size 股 tok（价格 ask，decimal）之后的 P_up/P_down，不落地
+// 任何状态，仅用于下单前的预检（见 maybeLock/maybeAmplify）。size/ask 全部转换
+// 成 fixedpoint.Value 后用整数运算完成，避免连续多次预检时的浮点误差。
+func simulateBuy(pu, pd fixedpoint.Value, size, ask fixedpoint.Value, tok domain.TokenType) (pu2, pd2 fixedpoint.Value) {
+	one := fixedpoint.NewFromFloat(1.0)
+	if size.Sign() <= 0 || ask.Sign() <= 0 || ask.Compare(one) >= 0 {
 		return pu, pd
 	}
 	switch tok {
 	case domain.TokenTypeUp:
-		pu2 = pu + size*(1.0-ask)
-		pd2 = pd - size*ask
+		pu2 = pu.Add(size.Mul(one.Sub(ask)))
+		pd2 = pd.Sub(size.Mul(ask))
 	case domain.TokenTypeDown:
-		pd2 = pd + size*(1.0-ask)
-		pu2 = pu - size*ask
+		pd2 = pd.Add(size.Mul(one.Sub(ask)))
+		pu2 = pu.Sub(size.Mul(ask))
 	default:
 		return pu, pd
 	}
 	return pu2, pd2
 }
 
-func simulateAmplify(pu, pd float64, main domain.TokenType, mainSize float64, mainAsk float64, ins domain.TokenType, insSize float64, insAsk float64) (pu2, pd2 float64) {
+func simulateAmplify(pu, pd fixedpoint.Value, main domain.TokenType, mainSize, mainAsk fixedpoint.Value, ins domain.TokenType, insSize, insAsk fixedpoint.Value) (pu2, pd2 fixedpoint.Value) {
 	pu2, pd2 = simulateBuy(pu, pd, mainSize, mainAsk, main)
-	if insSize > 0 && insAsk > 0 {
+	if insSize.Sign() > 0 && insAsk.Sign() > 0 {
 		pu2, pd2 = simulateBuy(pu2, pd2, insSize, insAsk, ins)
 	}
 	return pu2, pd2
@@ -1038,13 +1385,17 @@ func opposite(t domain.TokenType) domain.TokenType {
 	return domain.TokenTypeUp
 }
 
+// ensureMinOrderSize 保证下单规模对应的名义价值不低于 minUSDC（交易所最小下单
+// 金额）。minUSDC/ask 用 fixedpoint.Value 的整数除法完成，避免 float64 除法在
+// 舍入方向不利时把规模压到比最小名义价值低一个 satoshi 而被交易所拒单。
 func ensureMinOrderSize(desiredShares float64, ask float64, minUSDC float64) float64 {
 	if desiredShares <= 0 || ask <= 0 {
 		return desiredShares
 	}
-	minShares := minUSDC / ask
-	if minShares > desiredShares {
-		return minShares
+	desired := fixedpoint.NewFromFloat(desiredShares)
+	minShares := fixedpoint.NewFromFloat(minUSDC).Div(fixedpoint.NewFromFloat(ask))
+	if minShares.Compare(desired) > 0 {
+		return minShares.Float64()
 	}
 	return desiredShares
 }
@@ -1056,8 +1407,8 @@ func (s *Strategy) hedgeConfig() execution.AutoHedgeConfig {
 	return execution.AutoHedgeConfig{
 		Enabled:              true,
 		Delay:                time.Duration(s.HedgeDelaySeconds) * time.Second,
-		SellPriceOffsetCents: s.HedgeSellPriceOffsetCents,
-		MinExposureToHedge:   s.MinExposureToHedge,
+		SellPriceOffsetCents: s.effectiveHedgeSellPriceOffsetCents(),
+		MinExposureToHedge:   s.MinExposureToHedge.Float64(),
 	}
 }
 
@@ -0,0 +1,22 @@
+package unifiedarb
+
+import (
+	"context"
+
+	"github.com/betbot/gobet/internal/domain"
+	"github.com/betbot/gobet/internal/execution"
+	"github.com/betbot/gobet/internal/ports"
+)
+
+// tradingAPI 是 Strategy 对 TradingService 的最小依赖集合，抽成接口以便把
+// TradingService/HedgeTradingService 替换为 backtest.Engine 对历史数据做确定性回放
+// （见 internal/strategies/unifiedarb/backtest）。*services.TradingService 的方法集
+// 已经满足该接口，无需改动注入逻辑（见 pkg/bbgo/trader.go 的 injectField 接口匹配分支）。
+type tradingAPI interface {
+	GetBestPrice(ctx context.Context, assetID string) (bestBid, bestAsk float64, err error)
+	PlaceOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
+	ExecuteMultiLeg(ctx context.Context, req execution.MultiLegRequest) ([]*domain.Order, error)
+	CancelOrdersForMarket(ctx context.Context, marketSlug string)
+	OnOrderUpdate(handler ports.OrderUpdateHandler)
+	GetCurrentMarket() string
+}
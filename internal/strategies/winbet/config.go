@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/betbot/gobet/internal/common"
+	corebrain "github.com/betbot/gobet/internal/strategycore/brain"
 )
 
 const ID = "winbet"
@@ -280,3 +281,7 @@ func (c *Config) GetMaxNegativeProfitCents() int          { return c.MaxNegative
 // ====== 实现 velocityfollow/capital.ConfigInterface ======
 func (c *Config) GetAutoMerge() common.AutoMergeConfig { return c.AutoMerge }
 
+// GetPositionMode 实现 strategycore/brain.ConfigInterface：winbet 目前只按
+// 净仓位（NetMode）做决策，还不支持双向持仓记账。
+func (c *Config) GetPositionMode() corebrain.PositionMode { return corebrain.NetMode }
+
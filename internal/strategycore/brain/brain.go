@@ -2,6 +2,7 @@ package brain
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/betbot/gobet/internal/domain"
 	"github.com/betbot/gobet/internal/events"
@@ -13,13 +14,17 @@ var log = logrus.WithField("module", "brain")
 
 // Decision 决策结果
 type Decision struct {
-	ShouldTrade bool            // 是否应该交易
+	ShouldTrade bool             // 是否应该交易
 	Direction   domain.TokenType // 交易方向（UP 或 DOWN）
 	EntryPrice  domain.Price     // Entry 价格
 	HedgePrice  domain.Price     // Hedge 价格
 	EntrySize   float64          // Entry 数量
 	HedgeSize   float64          // Hedge 数量
 	Reason      string           // 决策原因
+
+	// Leg 仅在 LongShortMode 下有意义：标识这个决策要开/平的是哪一条腿。
+	// NetMode 下留空即可，PositionTracker 继续按净仓位记账。
+	Leg PositionLeg
 }
 
 // Brain 控制大脑模块
@@ -40,6 +45,13 @@ func New(ts *services.TradingService, cfg ConfigInterface) (*Brain, error) {
 		return nil, nil // 允许延迟初始化
 	}
 
+	// DecisionEngine/ArbitrageBrain/PositionMonitor 目前都只按净仓位（NetMode）
+	// 推理，还不理解同一个 token 上独立的多头/空头腿。在它们跟进之前，
+	// 先在这里拒绝 LongShortMode 配置，避免决策和记账口径对不上。
+	if mode := cfg.GetPositionMode(); mode != "" && mode != NetMode {
+		return nil, fmt.Errorf("brain: position mode %q 尚未被 DecisionEngine/ArbitrageBrain 支持，仅能使用 NetMode", mode)
+	}
+
 	pt := NewPositionTracker(ts)
 	de := NewDecisionEngine(cfg)
 	de.SetTradingService(ts) // 注入 TradingService
@@ -35,5 +35,9 @@ type ConfigInterface interface {
 	GetPositionMonitorMinHedgeSize() float64
 	// CooldownMs: 两次自动对冲之间的最小间隔，防止抖动风暴。
 	GetPositionMonitorCooldownMs() int
+
+	// GetPositionMode 返回持仓记账模式，见 PositionMode。默认应返回 NetMode，
+	// 只有明确支持双向持仓记账的策略才应该返回 LongShortMode。
+	GetPositionMode() PositionMode
 }
 
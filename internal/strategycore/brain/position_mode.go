@@ -0,0 +1,44 @@
+package brain
+
+// PositionMode 决定 PositionTracker 如何记账仓位，类比 OKX 合约的持仓模式。
+type PositionMode string
+
+const (
+	// NetMode 净持仓模式（默认）：同一个 token 只维护一个净仓位，这也是
+	// PositionState 里 UpSize/DownSize 等字段一直以来的语义。
+	NetMode PositionMode = "net"
+	// LongShortMode 双向持仓模式：同一个 token 上同时维护独立的多头/空头腿，
+	// 各自有自己的均价、数量和已实现盈亏，平掉一条腿不会隐式冲抵另一条腿。
+	LongShortMode PositionMode = "long_short"
+)
+
+// PositionLeg 标识 LongShortMode 下的某一条腿。
+type PositionLeg string
+
+const (
+	LegLong  PositionLeg = "long"
+	LegShort PositionLeg = "short"
+)
+
+// LegState 是 LongShortMode 下单条腿（多头或空头）的记账状态。
+type LegState struct {
+	Size        float64 // 持仓数量
+	Cost        float64 // 总成本（USDC）
+	AvgPrice    float64 // 平均开仓价
+	RealizedPnL float64 // 已实现盈亏（USDC）
+}
+
+// TokenLegs 是某个 token（UP 或 DOWN）上多头腿和空头腿的记账状态。
+type TokenLegs struct {
+	Long  LegState
+	Short LegState
+}
+
+// NetSize 返回两条腿冲抵后的净数量，仅用于展示/风控参考，不会反向影响
+// Long/Short 各自的记账。
+func (tl *TokenLegs) NetSize() float64 {
+	if tl == nil {
+		return 0
+	}
+	return tl.Long.Size - tl.Short.Size
+}
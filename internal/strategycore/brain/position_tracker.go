@@ -21,6 +21,10 @@ type PositionState struct {
 	UpAvgPrice   float64 // UP 平均价格
 	DownAvgPrice float64 // DOWN 平均价格
 	IsHedged     bool    // 是否完全对冲
+
+	// Legs 仅在 LongShortMode 下有值：marketSlug 维度上每个 token 的多头/空头腿
+	// 记账状态，独立于上面的净仓位字段，不会相互冲抵。
+	Legs map[domain.TokenType]*TokenLegs
 }
 
 // PositionTracker 持仓跟踪器
@@ -28,12 +32,18 @@ type PositionTracker struct {
 	tradingService *services.TradingService
 	mu             sync.RWMutex
 	positions      map[string]*PositionState // marketSlug -> state
+
+	// legPositions 记录 LongShortMode 下各市场、各 token 的多头/空头腿，
+	// 与 positions 里的净仓位字段分开维护，因为交易所/domain.Position 本身
+	// 只有净仓位的概念，双向持仓是 Brain 自己记的账。
+	legPositions map[string]map[domain.TokenType]*TokenLegs
 }
 
 func NewPositionTracker(ts *services.TradingService) *PositionTracker {
 	return &PositionTracker{
 		tradingService: ts,
 		positions:      make(map[string]*PositionState),
+		legPositions:   make(map[string]map[domain.TokenType]*TokenLegs),
 	}
 }
 
@@ -43,6 +53,7 @@ func (pt *PositionTracker) OnCycle(ctx context.Context, oldMarket *domain.Market
 	defer pt.mu.Unlock()
 	if oldMarket != nil {
 		delete(pt.positions, oldMarket.Slug)
+		delete(pt.legPositions, oldMarket.Slug)
 	}
 }
 
@@ -104,7 +115,7 @@ func (pt *PositionTracker) GetPositionState(marketSlug string) *PositionState {
 
 	state, ok := pt.positions[marketSlug]
 	if !ok {
-		return &PositionState{MarketSlug: marketSlug}
+		return &PositionState{MarketSlug: marketSlug, Legs: pt.copyLegs(marketSlug)}
 	}
 	return &PositionState{
 		MarketSlug:   state.MarketSlug,
@@ -115,6 +126,95 @@ func (pt *PositionTracker) GetPositionState(marketSlug string) *PositionState {
 		UpAvgPrice:   state.UpAvgPrice,
 		DownAvgPrice: state.DownAvgPrice,
 		IsHedged:     state.IsHedged,
+		Legs:         pt.copyLegs(marketSlug),
+	}
+}
+
+// copyLegs 返回 marketSlug 下 legPositions 的一份浅拷贝，调用方必须已持有 pt.mu。
+func (pt *PositionTracker) copyLegs(marketSlug string) map[domain.TokenType]*TokenLegs {
+	legs, ok := pt.legPositions[marketSlug]
+	if !ok {
+		return nil
+	}
+	out := make(map[domain.TokenType]*TokenLegs, len(legs))
+	for tokenType, tl := range legs {
+		copied := *tl
+		out[tokenType] = &copied
+	}
+	return out
+}
+
+// RecordLegFill 在 LongShortMode 下记录一笔开仓/加仓成交：按成交均价滚动更新
+// 对应 token、对应腿的数量、成本和均价。与净仓位字段完全独立，不会冲抵另一条腿。
+func (pt *PositionTracker) RecordLegFill(marketSlug string, tokenType domain.TokenType, leg PositionLeg, price float64, size float64) {
+	if size <= 0 {
+		return
+	}
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	byToken, ok := pt.legPositions[marketSlug]
+	if !ok {
+		byToken = make(map[domain.TokenType]*TokenLegs)
+		pt.legPositions[marketSlug] = byToken
+	}
+	tl, ok := byToken[tokenType]
+	if !ok {
+		tl = &TokenLegs{}
+		byToken[tokenType] = tl
+	}
+
+	ls := &tl.Long
+	if leg == LegShort {
+		ls = &tl.Short
+	}
+	ls.Size += size
+	ls.Cost += price * size
+	if ls.Size > 0 {
+		ls.AvgPrice = ls.Cost / ls.Size
+	}
+}
+
+// CloseLeg 在 LongShortMode 下记录一笔平仓/减仓成交，按该腿的均价结算已实现盈亏，
+// 不会触碰同一 token 另一条腿的记账。size 超过该腿当前持仓时按当前持仓全平处理。
+func (pt *PositionTracker) CloseLeg(marketSlug string, tokenType domain.TokenType, leg PositionLeg, price float64, size float64) {
+	if size <= 0 {
+		return
+	}
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	byToken, ok := pt.legPositions[marketSlug]
+	if !ok {
+		return
+	}
+	tl, ok := byToken[tokenType]
+	if !ok {
+		return
+	}
+
+	ls := &tl.Long
+	sign := 1.0 // 多头平仓：卖出价 - 均价
+	if leg == LegShort {
+		ls = &tl.Short
+		sign = -1.0 // 空头平仓：均价 - 买回价
+	}
+
+	closeSize := size
+	if closeSize > ls.Size {
+		closeSize = ls.Size
+	}
+	if closeSize <= 0 {
+		return
+	}
+
+	ls.RealizedPnL += sign * (price - ls.AvgPrice) * closeSize
+	ls.Size -= closeSize
+	ls.Cost -= ls.AvgPrice * closeSize
+	if ls.Size <= 0 {
+		ls.Size = 0
+		ls.Cost = 0
+		ls.AvgPrice = 0
 	}
 }
 
@@ -0,0 +1,114 @@
+package brain
+
+import (
+	"testing"
+
+	"github.com/betbot/gobet/internal/domain"
+)
+
+// TestPositionTrackerRecordLegFillTracksLegsIndependently 验证 LongShortMode 的
+// 多头/空头腿各自独立记账，互不冲抵（chunk180-2：证明这条记账路径本身是对的，
+// 即使 Brain.New 目前还只接受 NetMode 配置）。
+func TestPositionTrackerRecordLegFillTracksLegsIndependently(t *testing.T) {
+	pt := NewPositionTracker(nil)
+
+	pt.RecordLegFill("mkt-1", domain.TokenTypeUp, LegLong, 0.50, 100)
+	pt.RecordLegFill("mkt-1", domain.TokenTypeUp, LegLong, 0.60, 100)
+	pt.RecordLegFill("mkt-1", domain.TokenTypeUp, LegShort, 0.40, 50)
+
+	state := pt.GetPositionState("mkt-1")
+	legs := state.Legs[domain.TokenTypeUp]
+	if legs == nil {
+		t.Fatal("UP token 的 legs 不应为空")
+	}
+
+	wantLongAvg := (0.50*100 + 0.60*100) / 200.0
+	if legs.Long.Size != 200 {
+		t.Errorf("多头累计数量应为200，实际为%.4f", legs.Long.Size)
+	}
+	if legs.Long.AvgPrice != wantLongAvg {
+		t.Errorf("多头均价应为%.4f，实际为%.4f", wantLongAvg, legs.Long.AvgPrice)
+	}
+	if legs.Short.Size != 50 {
+		t.Errorf("空头数量应为50（不应被多头加仓冲抵），实际为%.4f", legs.Short.Size)
+	}
+	if legs.Short.AvgPrice != 0.40 {
+		t.Errorf("空头均价应为0.40，实际为%.4f", legs.Short.AvgPrice)
+	}
+}
+
+// TestPositionTrackerCloseLegSettlesRealizedPnL 验证平仓结算已实现盈亏的方向：
+// 多头按"卖出价-均价"计，空头按"均价-买回价"计。
+func TestPositionTrackerCloseLegSettlesRealizedPnL(t *testing.T) {
+	pt := NewPositionTracker(nil)
+
+	pt.RecordLegFill("mkt-1", domain.TokenTypeUp, LegLong, 0.40, 100)
+	pt.CloseLeg("mkt-1", domain.TokenTypeUp, LegLong, 0.55, 40)
+
+	legs := pt.GetPositionState("mkt-1").Legs[domain.TokenTypeUp]
+	wantPnL := (0.55 - 0.40) * 40
+	if legs.Long.RealizedPnL != wantPnL {
+		t.Errorf("多头平仓盈亏应为%.4f，实际为%.4f", wantPnL, legs.Long.RealizedPnL)
+	}
+	if legs.Long.Size != 60 {
+		t.Errorf("多头剩余数量应为60，实际为%.4f", legs.Long.Size)
+	}
+
+	pt.RecordLegFill("mkt-1", domain.TokenTypeUp, LegShort, 0.60, 30)
+	pt.CloseLeg("mkt-1", domain.TokenTypeUp, LegShort, 0.45, 30)
+
+	legs = pt.GetPositionState("mkt-1").Legs[domain.TokenTypeUp]
+	wantShortPnL := (0.60 - 0.45) * 30
+	if legs.Short.RealizedPnL != wantShortPnL {
+		t.Errorf("空头平仓盈亏应为%.4f，实际为%.4f", wantShortPnL, legs.Short.RealizedPnL)
+	}
+	if legs.Short.Size != 0 {
+		t.Errorf("空头应已全平，实际剩余%.4f", legs.Short.Size)
+	}
+}
+
+// TestPositionTrackerCloseLegCapsAtCurrentSize 验证平仓数量超过当前持仓时按
+// 当前持仓全平处理，不会把 Size 减成负数。
+func TestPositionTrackerCloseLegCapsAtCurrentSize(t *testing.T) {
+	pt := NewPositionTracker(nil)
+
+	pt.RecordLegFill("mkt-1", domain.TokenTypeDown, LegLong, 0.30, 50)
+	pt.CloseLeg("mkt-1", domain.TokenTypeDown, LegLong, 0.35, 1000)
+
+	legs := pt.GetPositionState("mkt-1").Legs[domain.TokenTypeDown]
+	if legs.Long.Size != 0 {
+		t.Errorf("超量平仓后应归零，实际为%.4f", legs.Long.Size)
+	}
+	wantPnL := (0.35 - 0.30) * 50
+	if legs.Long.RealizedPnL != wantPnL {
+		t.Errorf("超量平仓只应按实际持仓50份结算，期望盈亏%.4f，实际%.4f", wantPnL, legs.Long.RealizedPnL)
+	}
+}
+
+// TestPositionTrackerNetSizeNetsLegs 验证 TokenLegs.NetSize 只用于展示，
+// 正确冲抵多头/空头数量。
+func TestPositionTrackerNetSizeNetsLegs(t *testing.T) {
+	pt := NewPositionTracker(nil)
+	pt.RecordLegFill("mkt-1", domain.TokenTypeUp, LegLong, 0.50, 100)
+	pt.RecordLegFill("mkt-1", domain.TokenTypeUp, LegShort, 0.50, 40)
+
+	legs := pt.GetPositionState("mkt-1").Legs[domain.TokenTypeUp]
+	if got := legs.NetSize(); got != 60 {
+		t.Errorf("净仓位应为100-40=60，实际为%.4f", got)
+	}
+}
+
+// TestPositionTrackerGetPositionStateCopiesLegs 验证 GetPositionState 返回的
+// Legs 是浅拷贝，外部修改不会污染内部记账状态。
+func TestPositionTrackerGetPositionStateCopiesLegs(t *testing.T) {
+	pt := NewPositionTracker(nil)
+	pt.RecordLegFill("mkt-1", domain.TokenTypeUp, LegLong, 0.50, 100)
+
+	state := pt.GetPositionState("mkt-1")
+	state.Legs[domain.TokenTypeUp].Long.Size = 999999
+
+	fresh := pt.GetPositionState("mkt-1")
+	if fresh.Legs[domain.TokenTypeUp].Long.Size != 100 {
+		t.Errorf("外部修改不应污染内部状态，实际为%.4f", fresh.Legs[domain.TokenTypeUp].Long.Size)
+	}
+}
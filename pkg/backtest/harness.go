@@ -0,0 +1,206 @@
+// Package backtest replays historical up/down market data through a
+// Strategy interface, driving the same MarketSpec slug/period arithmetic a
+// live subscriber would use, and signs simulated orders through
+// clob/signing the same way a live ClobClient would - so a Strategy
+// implementation doesn't need a separate code path to go from backtest to
+// live. Fills are routed to a PaperExchange stub instead of the network.
+package backtest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/betbot/gobet/clob/signing"
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/pkg/marketspec"
+)
+
+// Tick is one simulated market data point within a period.
+type Tick struct {
+	Slug      string
+	Timestamp time.Time
+	UpPrice   float64
+	DownPrice float64
+	Volume    float64
+}
+
+// Resolution is how a period resolved once it closed: which side won, at
+// what close price, and how much volume traded.
+type Resolution struct {
+	Slug       string
+	Up         bool
+	ClosePrice float64
+	Volume     float64
+}
+
+// HistoryProvider resolves a market slug/period to historical ticks and,
+// once the period has closed, its resolution. Swap in a CSV reader, a
+// DB-backed provider, or a subgraph query without touching the Harness or
+// the Strategy under test.
+type HistoryProvider interface {
+	// Ticks returns the historical ticks for slug's period, in any order -
+	// the Harness sorts by Timestamp before replay.
+	Ticks(ctx context.Context, spec marketspec.MarketSpec, slug string, periodStartUnix int64) ([]Tick, error)
+
+	// Resolution returns slug's outcome once its period has closed, or nil
+	// if the period hasn't resolved yet (e.g. it's still in progress or in
+	// the future relative to the data set).
+	Resolution(ctx context.Context, spec marketspec.MarketSpec, slug string, periodStartUnix int64) (*Resolution, error)
+}
+
+// Strategy is implemented by code under backtest - and, unchanged, by the
+// same code running live against the real WS/REST feeds. A Strategy places
+// orders by calling back into the Harness/PaperExchange it was constructed
+// with; OnTick/OnPeriodClose intentionally don't carry an order-placement
+// handle so the interface matches what a live tick/period-close callback
+// looks like.
+type Strategy interface {
+	OnTick(spec marketspec.MarketSpec, tick Tick) error
+	OnPeriodClose(spec marketspec.MarketSpec, periodStartUnix int64, resolution Resolution) error
+}
+
+// UpTokenID and DownTokenID are the conventional token IDs backtest ticks
+// are marked under, and the IDs a Strategy should use in Order.TokenID so
+// PaperExchange.MarkEquity can value open positions against the ticks it
+// already saw.
+func UpTokenID(slug string) string   { return "up:" + slug }
+func DownTokenID(slug string) string { return "down:" + slug }
+
+// Signer holds the key material a Harness uses to sign simulated orders
+// the same way a live ClobClient would (see clob/signing.CreateL2Headers).
+// Leave it zero to skip signing entirely and run pure strategy logic.
+type Signer struct {
+	PrivateKey *ecdsa.PrivateKey
+	Creds      *types.ApiKeyCreds
+}
+
+// Order is what a Strategy asks the Harness to place during OnTick or
+// OnPeriodClose.
+type Order struct {
+	Slug    string
+	TokenID string
+	Side    types.Side
+	Size    float64
+}
+
+// Config controls a Harness run.
+type Config struct {
+	Spec     marketspec.MarketSpec
+	From     time.Time
+	To       time.Time
+	History  HistoryProvider
+	Strategy Strategy
+
+	// Exchange receives every simulated fill. Construct it yourself with
+	// NewPaperExchange and hand the same pointer to your Strategy so it can
+	// place orders through it.
+	Exchange *PaperExchange
+
+	// Signer, if set, is used to sign simulated orders placed via
+	// Harness.PlaceOrder.
+	Signer Signer
+}
+
+// Harness drives a Strategy deterministically over [From, To], enumerating
+// one slug per period via MarketSpec's period arithmetic, streaming each
+// period's ticks through OnTick, then invoking OnPeriodClose once the
+// period resolves and marking the equity curve.
+type Harness struct {
+	config Config
+}
+
+// NewHarness validates config and returns a ready-to-run Harness.
+func NewHarness(config Config) (*Harness, error) {
+	if config.History == nil {
+		return nil, fmt.Errorf("history provider is required")
+	}
+	if config.Strategy == nil {
+		return nil, fmt.Errorf("strategy is required")
+	}
+	if config.Exchange == nil {
+		config.Exchange = NewPaperExchange(0, 0)
+	}
+	return &Harness{config: config}, nil
+}
+
+// Exchange returns the PaperExchange this Harness routes fills to.
+func (h *Harness) Exchange() *PaperExchange { return h.config.Exchange }
+
+// Run enumerates every period in [From, To] via MarketSpec.IterPeriods,
+// replays each period's ticks through the Strategy in timestamp order,
+// marks the up/down tokens' last price on the PaperExchange as it goes,
+// then - once the period has resolved - calls OnPeriodClose and records an
+// equity curve point.
+func (h *Harness) Run(ctx context.Context) error {
+	spec := h.config.Spec
+
+	for periodStartUnix := range spec.IterPeriods(h.config.From, h.config.To) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		slug := spec.Slug(periodStartUnix)
+
+		ticks, err := h.config.History.Ticks(ctx, spec, slug, periodStartUnix)
+		if err != nil {
+			return fmt.Errorf("failed to fetch ticks for %s: %w", slug, err)
+		}
+		sort.Slice(ticks, func(i, j int) bool { return ticks[i].Timestamp.Before(ticks[j].Timestamp) })
+
+		for _, tick := range ticks {
+			h.config.Exchange.UpdateMark(UpTokenID(slug), tick.UpPrice)
+			h.config.Exchange.UpdateMark(DownTokenID(slug), tick.DownPrice)
+
+			if err := h.config.Strategy.OnTick(spec, tick); err != nil {
+				return fmt.Errorf("OnTick failed for %s: %w", slug, err)
+			}
+		}
+
+		resolution, err := h.config.History.Resolution(ctx, spec, slug, periodStartUnix)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", slug, err)
+		}
+		if resolution == nil {
+			// Period hasn't closed in the historical data set yet - nothing
+			// more to replay for it.
+			continue
+		}
+
+		if err := h.config.Strategy.OnPeriodClose(spec, periodStartUnix, *resolution); err != nil {
+			return fmt.Errorf("OnPeriodClose failed for %s: %w", slug, err)
+		}
+
+		h.config.Exchange.MarkEquity(periodStartUnix)
+	}
+
+	return nil
+}
+
+// PlaceOrder signs order the same way a live ClobClient.postOrder would
+// (L2 headers via clob/signing) before routing it to the PaperExchange at
+// refPrice. The signature is never sent anywhere in backtest mode - the
+// point is to exercise the same signing path a live run would, not to
+// validate the signature itself.
+func (h *Harness) PlaceOrder(order Order, refPrice float64, at time.Time) (*Fill, error) {
+	if _, err := h.sign(order); err != nil {
+		return nil, fmt.Errorf("failed to sign simulated order: %w", err)
+	}
+	return h.config.Exchange.Submit(order, refPrice, at)
+}
+
+func (h *Harness) sign(order Order) (*types.L2PolyHeader, error) {
+	if h.config.Signer.PrivateKey == nil || h.config.Signer.Creds == nil {
+		// No key material configured - the caller is running pure strategy
+		// logic and doesn't care about the signing path.
+		return nil, nil
+	}
+
+	body := fmt.Sprintf(`{"tokenId":%q,"side":%q,"size":%f}`, order.TokenID, order.Side, order.Size)
+	args := &types.L2HeaderArgs{Method: "POST", RequestPath: "/order", Body: &body}
+	return signing.CreateL2Headers(h.config.Signer.PrivateKey, h.config.Signer.Creds, args, nil)
+}
@@ -0,0 +1,180 @@
+package backtest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/clob/types"
+)
+
+// Fill is a simulated execution produced by PaperExchange.Submit.
+type Fill struct {
+	Slug      string
+	TokenID   string
+	Side      types.Side
+	Size      float64
+	Price     float64
+	Timestamp time.Time
+}
+
+// EquityPoint is one point on the equity curve, recorded once per period
+// close via PaperExchange.MarkEquity.
+type EquityPoint struct {
+	PeriodStartUnix int64
+	Cash            float64
+	PositionsValue  float64
+	Equity          float64
+}
+
+type paperPosition struct {
+	size     float64
+	avgPrice float64
+}
+
+// PaperExchange is a paper-trading exchange stub: it fills orders at the
+// prevailing tick price plus SlippageBps, tracks per-token positions and
+// cash, and records a per-period equity curve.
+type PaperExchange struct {
+	mu          sync.Mutex
+	slippageBps int
+	cash        float64
+	positions   map[string]*paperPosition
+	marks       map[string]float64
+	fills       []Fill
+	equity      []EquityPoint
+}
+
+// NewPaperExchange creates a PaperExchange starting from startingCash, with
+// every fill priced slippageBps away from the reference price (against the
+// taker: buys fill higher, sells fill lower).
+func NewPaperExchange(startingCash float64, slippageBps int) *PaperExchange {
+	return &PaperExchange{
+		slippageBps: slippageBps,
+		cash:        startingCash,
+		positions:   make(map[string]*paperPosition),
+		marks:       make(map[string]float64),
+	}
+}
+
+// UpdateMark records the latest known price for tokenID, used by
+// MarkEquity to value open positions.
+func (p *PaperExchange) UpdateMark(tokenID string, price float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.marks[tokenID] = price
+}
+
+// Submit fills order against refPrice adjusted by the configured slippage,
+// updating cash and the running position for order.TokenID.
+func (p *PaperExchange) Submit(order Order, refPrice float64, at time.Time) (*Fill, error) {
+	if refPrice <= 0 {
+		return nil, fmt.Errorf("no reference price to fill %s", order.TokenID)
+	}
+	if order.Size <= 0 {
+		return nil, fmt.Errorf("order size must be positive, got %v", order.Size)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	slip := refPrice * float64(p.slippageBps) / 10000
+	fillPrice := refPrice
+	if order.Side == types.SideBuy {
+		fillPrice += slip
+	} else {
+		fillPrice -= slip
+		if fillPrice < 0 {
+			fillPrice = 0
+		}
+	}
+
+	pos := p.positions[order.TokenID]
+	if pos == nil {
+		pos = &paperPosition{}
+		p.positions[order.TokenID] = pos
+	}
+
+	notional := fillPrice * order.Size
+	if order.Side == types.SideBuy {
+		pos.avgPrice = weightedAvgPrice(pos.size, pos.avgPrice, order.Size, fillPrice)
+		pos.size += order.Size
+		p.cash -= notional
+	} else {
+		pos.size -= order.Size
+		p.cash += notional
+	}
+
+	p.marks[order.TokenID] = fillPrice
+
+	fill := Fill{
+		Slug:      order.Slug,
+		TokenID:   order.TokenID,
+		Side:      order.Side,
+		Size:      order.Size,
+		Price:     fillPrice,
+		Timestamp: at,
+	}
+	p.fills = append(p.fills, fill)
+	return &fill, nil
+}
+
+// weightedAvgPrice folds a new fill of addSize@addPrice into an existing
+// position's average cost basis.
+func weightedAvgPrice(existingSize, existingAvgPrice, addSize, addPrice float64) float64 {
+	if existingSize <= 0 {
+		return addPrice
+	}
+	return (existingAvgPrice*existingSize + addPrice*addSize) / (existingSize + addSize)
+}
+
+// MarkEquity appends and returns an EquityPoint for periodStartUnix,
+// valuing every open position at its last known mark (see UpdateMark).
+func (p *PaperExchange) MarkEquity(periodStartUnix int64) EquityPoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	positionsValue := 0.0
+	for tokenID, pos := range p.positions {
+		positionsValue += pos.size * p.marks[tokenID]
+	}
+
+	point := EquityPoint{
+		PeriodStartUnix: periodStartUnix,
+		Cash:            p.cash,
+		PositionsValue:  positionsValue,
+		Equity:          p.cash + positionsValue,
+	}
+	p.equity = append(p.equity, point)
+	return point
+}
+
+// EquityCurve returns the recorded per-period equity points, in the order
+// they were marked.
+func (p *PaperExchange) EquityCurve() []EquityPoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]EquityPoint, len(p.equity))
+	copy(out, p.equity)
+	return out
+}
+
+// Fills returns every simulated fill, in execution order.
+func (p *PaperExchange) Fills() []Fill {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Fill, len(p.fills))
+	copy(out, p.fills)
+	return out
+}
+
+// Position returns the current size/average price for tokenID.
+func (p *PaperExchange) Position(tokenID string) (size float64, avgPrice float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pos := p.positions[tokenID]
+	if pos == nil {
+		return 0, 0
+	}
+	return pos.size, pos.avgPrice
+}
@@ -13,20 +13,21 @@ import (
 // Environment 环境管理器，管理交易所会话和服务
 type Environment struct {
 	// 服务
-	TradingService    *services.TradingService
-	MarketDataService *services.MarketDataService
-	PersistenceService persistence.Service
-	Executor          CommandExecutor
+	TradingService      *services.TradingService
+	HedgeTradingService *services.TradingService // 第二个交易所的 TradingService，供策略跨交易所对冲使用（可选）
+	MarketDataService   *services.MarketDataService
+	PersistenceService  persistence.Service
+	Executor            CommandExecutor
 
 	// 会话管理
-	sessions map[string]*ExchangeSession
+	sessions   map[string]*ExchangeSession
 	sessionsMu sync.RWMutex
 
 	// 关闭管理器
 	shutdownManager *shutdown.Manager
 
 	// 系统级配置
-	DirectModeDebounce int    // 直接回调模式的防抖间隔（毫秒），默认100ms（BBGO风格：只支持直接模式）
+	DirectModeDebounce int // 直接回调模式的防抖间隔（毫秒），默认100ms（BBGO风格：只支持直接模式）
 }
 
 // NewEnvironment 创建新的环境管理器
@@ -34,7 +35,7 @@ func NewEnvironment() *Environment {
 	return &Environment{
 		sessions:           make(map[string]*ExchangeSession),
 		shutdownManager:    shutdown.NewManager(),
-		DirectModeDebounce: 100,     // 默认100ms防抖（BBGO风格：只支持直接模式）
+		DirectModeDebounce: 100, // 默认100ms防抖（BBGO风格：只支持直接模式）
 	}
 }
 
@@ -50,6 +51,11 @@ func (e *Environment) SetTradingService(ts *services.TradingService) {
 	e.TradingService = ts
 }
 
+// SetHedgeTradingService 设置跨交易所对冲使用的第二个交易所的交易服务
+func (e *Environment) SetHedgeTradingService(ts *services.TradingService) {
+	e.HedgeTradingService = ts
+}
+
 // SetMarketDataService 设置市场数据服务
 func (e *Environment) SetMarketDataService(mds *services.MarketDataService) {
 	e.MarketDataService = mds
@@ -84,7 +90,7 @@ func (e *Environment) Session(name string) (*ExchangeSession, bool) {
 func (e *Environment) Sessions() map[string]*ExchangeSession {
 	e.sessionsMu.RLock()
 	defer e.sessionsMu.RUnlock()
-	
+
 	result := make(map[string]*ExchangeSession)
 	for k, v := range e.sessions {
 		result[k] = v
@@ -153,4 +159,3 @@ func (e *Environment) Close() error {
 
 	return nil
 }
-
@@ -198,6 +198,14 @@ func (t *Trader) injectServicesIntoStrategy(ctx context.Context, strategy interf
 		}
 	}
 
+	// 注入 HedgeTradingService（策略可选声明 HedgeTradingService 字段，类型为
+	// *services.TradingService 或它实现的接口，以实现跨交易所对冲，用法见 unifiedarb.Strategy）
+	if t.environment.HedgeTradingService != nil {
+		if err := t.injectField(strategy, "HedgeTradingService", t.environment.HedgeTradingService); err != nil {
+			traderLog.Debugf("failed to inject HedgeTradingService into %s: %v", strategyID, err)
+		}
+	}
+
 	// 注入 MarketDataService
 	if t.environment.MarketDataService != nil {
 		if err := t.injectField(strategy, "MarketDataService", t.environment.MarketDataService); err != nil {
@@ -205,6 +213,14 @@ func (t *Trader) injectServicesIntoStrategy(ctx context.Context, strategy interf
 		}
 	}
 
+	// 注入 PersistenceService（策略可选声明 PersistenceService persistence.Service 字段
+	// 以直接读写自己的持久化状态，用法见 unifiedarb.Strategy 的 TradeStats）
+	if t.environment.PersistenceService != nil {
+		if err := t.injectField(strategy, "PersistenceService", t.environment.PersistenceService); err != nil {
+			traderLog.Debugf("failed to inject PersistenceService into %s: %v", strategyID, err)
+		}
+	}
+
 	// 注入系统级配置（直接回调模式防抖间隔，BBGO风格：只支持直接模式）
 	if err := t.injectField(strategy, "directModeDebounce", t.environment.DirectModeDebounce); err != nil {
 		traderLog.Debugf("failed to inject directModeDebounce into %s: %v", strategyID, err)
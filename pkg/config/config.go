@@ -143,6 +143,8 @@ type Config struct {
 	CancelOpenOrdersOnCycleStart         bool                    // 每个新周期开始时是否清空“本周期残留 open orders”（默认false）
 	ConcurrentExecutorWorkers            int                     // 并发命令执行器 worker 数（套利等），默认 8
 	DryRun                               bool                    // 纸交易模式（dry run），如果为 true，不进行真实交易，只在日志中打印订单信息
+	StopLossFraction                     float64                 // 账户权益相对历史最高点的最大回撤比例，超过则全局熔断并撤掉所有挂单，<=0 表示不启用
+	RiskCheckIntervalSeconds             int                     // 止损/权益回撤巡检间隔（秒），默认10秒
 }
 
 var globalConfig *Config
@@ -182,6 +184,8 @@ type ConfigFile struct {
 	CancelOpenOrdersOnCycleStart         bool                    `yaml:"cancel_open_orders_on_cycle_start" json:"cancel_open_orders_on_cycle_start"`                 // 新周期开始时清空本周期残留 open orders（默认false）
 	ConcurrentExecutorWorkers            int                     `yaml:"concurrent_executor_workers" json:"concurrent_executor_workers"`                             // 并发命令执行器 worker 数（套利等），默认8
 	DryRun                               bool                    `yaml:"dry_run" json:"dry_run"`                                                                     // 纸交易模式（dry run），如果为 true，不进行真实交易，只在日志中打印订单信息
+	StopLossFraction                     float64                 `yaml:"stop_loss_fraction" json:"stop_loss_fraction"`                                               // 账户权益相对历史最高点的最大回撤比例，<=0 表示不启用
+	RiskCheckIntervalSeconds             int                     `yaml:"risk_check_interval_seconds" json:"risk_check_interval_seconds"`                             // 止损/权益回撤巡检间隔（秒），默认10秒
 }
 
 // Load 加载配置
@@ -363,6 +367,28 @@ func LoadFromFile(filePath string) (*Config, error) {
 			}
 			return 8
 		}(),
+		StopLossFraction: func() float64 {
+			if configFile != nil && configFile.StopLossFraction > 0 {
+				return configFile.StopLossFraction
+			}
+			if envVal := getEnv("STOP_LOSS_FRACTION", ""); envVal != "" {
+				if v, err := strconv.ParseFloat(envVal, 64); err == nil && v > 0 {
+					return v
+				}
+			}
+			return 0 // 默认不启用
+		}(),
+		RiskCheckIntervalSeconds: func() int {
+			if configFile != nil && configFile.RiskCheckIntervalSeconds > 0 {
+				return configFile.RiskCheckIntervalSeconds
+			}
+			if envVal := getEnv("RISK_CHECK_INTERVAL_SECONDS", ""); envVal != "" {
+				if val, err := strconv.Atoi(envVal); err == nil && val > 0 {
+					return val
+				}
+			}
+			return 10 // 默认10秒
+		}(),
 	}
 
 	// 验证配置
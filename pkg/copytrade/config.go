@@ -0,0 +1,90 @@
+package copytrade
+
+import (
+	"context"
+	"time"
+
+	"github.com/betbot/gobet/clob/client"
+	"github.com/betbot/gobet/clob/types"
+)
+
+// EventPhase 描述一次跟单尝试在其生命周期中所处的阶段。
+type EventPhase string
+
+const (
+	PhaseDetected  EventPhase = "detected"  // 检测到 leader 成交，已还原出方向/价格
+	PhaseSizing    EventPhase = "sizing"    // 已按 LeaderRule 算出跟单规模
+	PhaseVetoed    EventPhase = "vetoed"    // 被 Vetoer 否决，放弃下单
+	PhaseSkipped   EventPhase = "skipped"   // 因冷却/仓位上限/金额过小等原因跳过
+	PhaseSubmitted EventPhase = "submitted" // 镜像订单已提交成功
+	PhaseFailed    EventPhase = "failed"    // 下单失败（还原交易失败时只打日志，不发事件）
+)
+
+// Event 是 Engine 在处理一次 leader 成交时对外发出的生命周期事件。
+type Event struct {
+	Phase         EventPhase
+	Leader        string
+	LeaderAsset   string // leader 实际成交的资产 ID
+	FollowerAsset string // 经过 AssetRemap 之后、follower 实际要下单的资产 ID
+	Side          types.Side
+	Price         float64
+	SizeUSDC      float64
+	OrderID       string
+	Reason        string // Phase 为 Skipped/Vetoed/Failed 时的原因说明
+	TxHash        string
+	Time          time.Time // leader 那笔交易被链上确认的时间
+}
+
+// Vetoer 在订单提交前做最后一道风控检查，例如在风险收紧（risk-off）期间
+// 返回 false 来阻止跟单。AllowTrade 返回 false 时本次跟单会被放弃
+// （对应 Event.Phase == PhaseVetoed），不会提交订单。
+type Vetoer interface {
+	AllowTrade(ev Event) bool
+}
+
+// VetoFunc 让普通函数满足 Vetoer 接口。
+type VetoFunc func(ev Event) bool
+
+// AllowTrade 实现 Vetoer。
+func (f VetoFunc) AllowTrade(ev Event) bool { return f(ev) }
+
+// Config 配置一个 Engine。
+type Config struct {
+	// Client 用于重建并提交跟单订单的 CLOB 客户端。
+	Client *client.Client
+
+	// Rules 按 leader 地址（大小写不敏感，带不带 0x 前缀均可）索引的跟单规则。
+	Rules map[string]LeaderRule
+
+	// AssetRemap 把 leader 交易里出现的资产 ID 映射成 follower 应该下单的资产 ID；
+	// 用于 negRisk 市场拆分后 leader/follower 持有不同 token 的情况。未命中的资产
+	// ID 原样使用。
+	AssetRemap map[string]string
+
+	// NegRiskAssets 标记哪些（重映射后的）资产 ID 属于 negRisk 市场，下单时会
+	// 透传给 CreateOrderOptions.NegRisk；未在此列出的资产默认按非 negRisk 处理。
+	NegRiskAssets map[string]bool
+
+	// GlobalPositionLimitUSDC 所有跟单仓位合计占用的 USDC 上限，<=0 表示不限制；
+	// 超过上限的新跟单会被跳过（PhaseSkipped）。
+	GlobalPositionLimitUSDC float64
+
+	// FollowerBalanceUSDC 返回 follower 账户当前可用的 USDC 余额；
+	// 只有 LeaderRule.Mode == SizingCappedPercent 的规则会用到它。
+	FollowerBalanceUSDC func(ctx context.Context) (float64, error)
+
+	// OrderType 提交镜像订单时使用的订单类型；留空时默认为 FAK（部分成交、剩余取消），
+	// 因为跟单追求的是尽快吃到当前盘口，而不是挂单等待。
+	OrderType types.OrderType
+
+	// TickSize 透传给 CreateOrderOptions；留空时默认为 0.001。
+	TickSize types.TickSize
+
+	// Veto 在真正提交订单前做最后一道检查，返回 false 则放弃本次跟单；可以为空。
+	Veto Vetoer
+
+	// Events 接收引擎生命周期事件的通道（Engine 只往里发送）；可以为空，为空时事件直接丢弃。
+	// 通道应当有足够缓冲或有消费者及时取走，Engine 在通道已满时会丢弃事件并打日志，
+	// 不会阻塞交易处理。
+	Events chan<- Event
+}
@@ -0,0 +1,50 @@
+// Package copytrade 实现基于 api.PolygonWSClient 跟单流的复制交易引擎。
+//
+// api.PolygonWSClient 已经负责监听 Polygon 链上的 OrderFilled 事件并按
+// 被跟随地址过滤；Engine 订阅它的回调，把匹配到的 api.PolygonTradeEvent
+// 还原成 leader 一侧的交易（资产 ID、价格、买卖方向），按每个 leader 的
+// LeaderRule 重新计算跟单规模，经过冷却、仓位上限和可选的 Vetoer 检查后，
+// 通过 clob/client.Client 的 CreateOrder/PostOrder 提交镜像订单。
+package copytrade
+
+import "time"
+
+// SizingMode 决定如何把 leader 的成交规模换算成 follower 的下单规模。
+type SizingMode string
+
+const (
+	// SizingFixedUSDC 每次跟单都用固定的 USDC 金额，忽略 leader 实际下单大小。
+	SizingFixedUSDC SizingMode = "fixed_usdc"
+	// SizingProRata 按 leader 成交的 USDC 金额等比例跟单（乘以 Multiplier）。
+	SizingProRata SizingMode = "pro_rata"
+	// SizingCappedPercent 按 follower 账户余额的百分比跟单，但不超过 CapUSDC。
+	SizingCappedPercent SizingMode = "capped_percent"
+)
+
+// LeaderRule 描述某个被跟随地址（leader）的跟单规则。
+type LeaderRule struct {
+	// Leader 被跟随的链上地址；由 Config.Rules 的 key 自动填充，调用方无需设置。
+	Leader string
+
+	// Mode 选择的仓位计算方式。
+	Mode SizingMode
+
+	// FixedUSDC 仅 Mode == SizingFixedUSDC 时生效：每次跟单的固定 USDC 金额。
+	FixedUSDC float64
+
+	// Multiplier 仅 Mode == SizingProRata 时生效：followerUSDC = leaderUSDC * Multiplier。
+	Multiplier float64
+
+	// BalancePercent 仅 Mode == SizingCappedPercent 时生效：followerUSDC = 账户余额 * BalancePercent。
+	BalancePercent float64
+
+	// CapUSDC 仅 Mode == SizingCappedPercent 时生效：单笔跟单金额上限，<=0 表示不设上限。
+	CapUSDC float64
+
+	// MinUSDC 跟单金额低于这个值就放弃本次跟单，用来避开过小、容易被手续费吃掉的订单。
+	MinUSDC float64
+
+	// Cooldown 同一 leader 对同一（重映射后的）资产重复成交时，两次跟单之间的最短间隔，
+	// 用来防止对方连续多笔成交导致跟单被无限放大。<=0 表示不限制。
+	Cooldown time.Duration
+}
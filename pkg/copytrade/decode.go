@@ -0,0 +1,102 @@
+package copytrade
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/pkg/sdk/api"
+)
+
+// usdcScale 是 OrderFilled 事件里 USDC 金额和条件代币数量共用的小数位（均为 6 位）。
+var usdcScale = big.NewFloat(1e6)
+
+// leaderFill 是从一条 PolygonTradeEvent 里、针对某个具体 leader 地址还原出来的
+// 那一侧交易：它买卖了哪个 token、多少数量、多少 USDC、成交价是多少。
+type leaderFill struct {
+	AssetID  string
+	Side     types.Side
+	Price    float64
+	Size     float64 // 条件代币数量
+	USDCSize float64 // 对应的 USDC 金额
+}
+
+// decodeLeaderFill 把 ev 里属于 leaderAddr 的那一侧还原成 leaderFill。
+// leaderAddr 必须等于 ev.Maker 或 ev.Taker，否则返回 error。
+func decodeLeaderFill(ev api.PolygonTradeEvent, leaderAddr string) (*leaderFill, error) {
+	leaderNorm := normalizeAddr(leaderAddr)
+
+	var givenAsset, receivedAsset string
+	var givenAmount, receivedAmount *big.Int
+	switch leaderNorm {
+	case normalizeAddr(ev.Maker):
+		givenAsset, givenAmount = ev.MakerAssetID, ev.MakerAmount
+		receivedAsset, receivedAmount = ev.TakerAssetID, ev.TakerAmount
+	case normalizeAddr(ev.Taker):
+		givenAsset, givenAmount = ev.TakerAssetID, ev.TakerAmount
+		receivedAsset, receivedAmount = ev.MakerAssetID, ev.MakerAmount
+	default:
+		return nil, fmt.Errorf("copytrade: leader %s is neither maker nor taker of tx %s", leaderAddr, ev.TxHash)
+	}
+
+	if givenAmount == nil || receivedAmount == nil {
+		return nil, fmt.Errorf("copytrade: incomplete amounts in tx %s", ev.TxHash)
+	}
+
+	givenIsUSDC := isUSDCAsset(givenAsset)
+	receivedIsUSDC := isUSDCAsset(receivedAsset)
+	if givenIsUSDC == receivedIsUSDC {
+		// 两边都是/都不是 USDC：要么是 split/merge 之类的 token-to-token 转移，
+		// 要么数据解析不完整，都不是我们能镜像的普通买卖。
+		return nil, fmt.Errorf("copytrade: tx %s is not a USDC/token trade (given=%s received=%s)", ev.TxHash, givenAsset, receivedAsset)
+	}
+
+	var usdcAmount, tokenAmount *big.Int
+	var assetID string
+	var side types.Side
+	if givenIsUSDC {
+		// leader 付出 USDC 换回 token => 买入
+		usdcAmount, tokenAmount = givenAmount, receivedAmount
+		assetID, side = receivedAsset, types.SideBuy
+	} else {
+		// leader 付出 token 换回 USDC => 卖出
+		usdcAmount, tokenAmount = receivedAmount, givenAmount
+		assetID, side = givenAsset, types.SideSell
+	}
+
+	if tokenAmount.Sign() == 0 {
+		return nil, fmt.Errorf("copytrade: zero token amount in tx %s", ev.TxHash)
+	}
+
+	usdcFloat := new(big.Float).Quo(new(big.Float).SetInt(usdcAmount), usdcScale)
+	tokenFloat := new(big.Float).Quo(new(big.Float).SetInt(tokenAmount), usdcScale)
+	priceFloat := new(big.Float).Quo(usdcFloat, tokenFloat)
+
+	usdcF, _ := usdcFloat.Float64()
+	tokenF, _ := tokenFloat.Float64()
+	priceF, _ := priceFloat.Float64()
+
+	return &leaderFill{
+		AssetID:  assetID,
+		Side:     side,
+		Price:    priceF,
+		Size:     tokenF,
+		USDCSize: usdcF,
+	}, nil
+}
+
+// isUSDCAsset 判断一个 0x 前缀的资产 ID 是否代表 USDC 抵押品（在 OrderFilled 事件里
+// 用资产 ID 0 表示，而不是某个具体的 conditional token ID）。
+func isUSDCAsset(assetIDHex string) bool {
+	v := new(big.Int)
+	if _, ok := v.SetString(strings.TrimPrefix(assetIDHex, "0x"), 16); !ok {
+		return false
+	}
+	return v.Sign() == 0
+}
+
+// normalizeAddr 把地址归一化成小写、去掉 0x 前缀的形式，方便比较。
+func normalizeAddr(addr string) string {
+	return strings.ToLower(strings.TrimPrefix(addr, "0x"))
+}
@@ -0,0 +1,263 @@
+package copytrade
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/pkg/sdk/api"
+)
+
+// Engine 订阅 api.PolygonWSClient 的成交回调，把匹配到的 leader 成交镜像成
+// follower 的订单。一个 Engine 实例对应一个 follower（一个 CLOB 客户端）。
+type Engine struct {
+	cfg   Config
+	rules map[string]LeaderRule // key: normalizeAddr(leader)
+
+	mu           sync.Mutex
+	exposureUSDC float64
+	lastFillAt   map[string]time.Time // key: leader+"|"+followerAsset
+}
+
+// NewEngine 创建一个跟单引擎。传入的 cfg.Rules 的 key 会被归一化用于匹配，
+// 不要求调用方自己统一大小写或 0x 前缀。
+func NewEngine(cfg Config) *Engine {
+	if cfg.OrderType == "" {
+		cfg.OrderType = types.OrderTypeFAK
+	}
+	if cfg.TickSize == "" {
+		cfg.TickSize = types.TickSize0001
+	}
+
+	rules := make(map[string]LeaderRule, len(cfg.Rules))
+	for addr, rule := range cfg.Rules {
+		rule.Leader = addr
+		rules[normalizeAddr(addr)] = rule
+	}
+
+	return &Engine{
+		cfg:        cfg,
+		rules:      rules,
+		lastFillAt: make(map[string]time.Time),
+	}
+}
+
+// HandleTrade 是 api.NewPolygonWSClient 的 onTrade 回调。PolygonWSClient 只在
+// maker 或 taker 命中被跟随地址时才会调用它，但不知道具体是跟的哪个 leader，
+// 所以这里还要用 Engine 自己的规则表再匹配一次。
+func (e *Engine) HandleTrade(ev api.PolygonTradeEvent) {
+	rule, leaderAddr, ok := e.matchRule(ev)
+	if !ok {
+		return
+	}
+
+	fill, err := decodeLeaderFill(ev, leaderAddr)
+	if err != nil {
+		log.Printf("[copytrade] %s: %v", leaderAddr, err)
+		return
+	}
+
+	followerAsset := fill.AssetID
+	if remapped, ok := e.cfg.AssetRemap[fill.AssetID]; ok {
+		followerAsset = remapped
+	}
+
+	base := Event{
+		Leader:        leaderAddr,
+		LeaderAsset:   fill.AssetID,
+		FollowerAsset: followerAsset,
+		Side:          fill.Side,
+		Price:         fill.Price,
+		TxHash:        ev.TxHash,
+		Time:          ev.Timestamp,
+	}
+	e.emit(withPhase(base, PhaseDetected))
+
+	if !e.checkCooldown(leaderAddr, followerAsset, rule.Cooldown) {
+		e.emit(withReason(base, PhaseSkipped, "cooldown active for this leader/asset"))
+		return
+	}
+
+	ctx := context.Background()
+	sizeUSDC, err := e.sizeTrade(ctx, rule, fill)
+	if err != nil {
+		e.emit(withReason(base, PhaseSkipped, err.Error()))
+		return
+	}
+	base.SizeUSDC = sizeUSDC
+	e.emit(withPhase(base, PhaseSizing))
+
+	if sizeUSDC < rule.MinUSDC {
+		e.emit(withReason(base, PhaseSkipped, fmt.Sprintf("size $%.4f below min $%.4f", sizeUSDC, rule.MinUSDC)))
+		return
+	}
+
+	if !e.reserveExposure(sizeUSDC) {
+		e.emit(withReason(base, PhaseSkipped, "global position limit reached"))
+		return
+	}
+
+	if e.cfg.Veto != nil && !e.cfg.Veto.AllowTrade(base) {
+		e.releaseExposure(sizeUSDC)
+		e.emit(withPhase(base, PhaseVetoed))
+		return
+	}
+
+	orderID, err := e.submitOrder(ctx, followerAsset, fill.Side, fill.Price, sizeUSDC)
+	if err != nil {
+		e.releaseExposure(sizeUSDC)
+		e.emit(withReason(base, PhaseFailed, err.Error()))
+		return
+	}
+
+	e.markFilled(leaderAddr, followerAsset)
+	base.OrderID = orderID
+	e.emit(withPhase(base, PhaseSubmitted))
+}
+
+func (e *Engine) matchRule(ev api.PolygonTradeEvent) (LeaderRule, string, bool) {
+	if rule, ok := e.rules[normalizeAddr(ev.Maker)]; ok {
+		return rule, ev.Maker, true
+	}
+	if rule, ok := e.rules[normalizeAddr(ev.Taker)]; ok {
+		return rule, ev.Taker, true
+	}
+	return LeaderRule{}, "", false
+}
+
+func (e *Engine) sizeTrade(ctx context.Context, rule LeaderRule, fill *leaderFill) (float64, error) {
+	switch rule.Mode {
+	case SizingFixedUSDC:
+		return rule.FixedUSDC, nil
+	case SizingProRata:
+		mult := rule.Multiplier
+		if mult <= 0 {
+			mult = 1
+		}
+		return fill.USDCSize * mult, nil
+	case SizingCappedPercent:
+		if e.cfg.FollowerBalanceUSDC == nil {
+			return 0, fmt.Errorf("capped_percent sizing requires Config.FollowerBalanceUSDC")
+		}
+		balance, err := e.cfg.FollowerBalanceUSDC(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("fetch follower balance: %w", err)
+		}
+		size := balance * rule.BalancePercent
+		if rule.CapUSDC > 0 && size > rule.CapUSDC {
+			size = rule.CapUSDC
+		}
+		return size, nil
+	default:
+		return 0, fmt.Errorf("unknown sizing mode %q for leader %s", rule.Mode, rule.Leader)
+	}
+}
+
+// checkCooldown 返回 false 表示 (leader, asset) 这一对仍在冷却期内，本次跟单应跳过。
+func (e *Engine) checkCooldown(leader, asset string, cooldown time.Duration) bool {
+	if cooldown <= 0 {
+		return true
+	}
+	key := leader + "|" + asset
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if last, ok := e.lastFillAt[key]; ok && time.Since(last) < cooldown {
+		return false
+	}
+	return true
+}
+
+func (e *Engine) markFilled(leader, asset string) {
+	e.mu.Lock()
+	e.lastFillAt[leader+"|"+asset] = time.Now()
+	e.mu.Unlock()
+}
+
+// reserveExposure 在提交订单前先占用仓位上限额度，返回 false 表示额度不够、应跳过。
+func (e *Engine) reserveExposure(usdc float64) bool {
+	if e.cfg.GlobalPositionLimitUSDC <= 0 {
+		return true
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.exposureUSDC+usdc > e.cfg.GlobalPositionLimitUSDC {
+		return false
+	}
+	e.exposureUSDC += usdc
+	return true
+}
+
+// releaseExposure 在下单被否决或失败时把之前占用的额度还回去。
+func (e *Engine) releaseExposure(usdc float64) {
+	if e.cfg.GlobalPositionLimitUSDC <= 0 {
+		return
+	}
+	e.mu.Lock()
+	e.exposureUSDC -= usdc
+	if e.exposureUSDC < 0 {
+		e.exposureUSDC = 0
+	}
+	e.mu.Unlock()
+}
+
+// submitOrder 按 place_order 示例的流程重建并提交镜像订单：先 CreateOrder 签名，
+// 再 PostOrder 提交。
+func (e *Engine) submitOrder(ctx context.Context, assetID string, side types.Side, price float64, sizeUSDC float64) (string, error) {
+	if price <= 0 {
+		return "", fmt.Errorf("invalid leader price %.6f", price)
+	}
+	size := sizeUSDC / price
+
+	negRisk := e.cfg.NegRiskAssets[assetID]
+	options := &types.CreateOrderOptions{
+		TickSize: e.cfg.TickSize,
+		NegRisk:  &negRisk,
+	}
+
+	userOrder := &types.UserOrder{
+		TokenID: assetID,
+		Price:   price,
+		Size:    size,
+		Side:    side,
+	}
+
+	signedOrder, err := e.cfg.Client.CreateOrder(ctx, userOrder, options)
+	if err != nil {
+		return "", fmt.Errorf("create order: %w", err)
+	}
+
+	resp, err := e.cfg.Client.PostOrder(ctx, signedOrder, e.cfg.OrderType, false)
+	if err != nil {
+		return "", fmt.Errorf("post order: %w", err)
+	}
+	if !resp.Success {
+		return "", fmt.Errorf("order rejected: %s", resp.ErrorMsg)
+	}
+	return resp.OrderID, nil
+}
+
+func (e *Engine) emit(ev Event) {
+	if e.cfg.Events == nil {
+		return
+	}
+	select {
+	case e.cfg.Events <- ev:
+	default:
+		log.Printf("[copytrade] events channel full, dropping %s event for leader %s", ev.Phase, ev.Leader)
+	}
+}
+
+func withPhase(ev Event, phase EventPhase) Event {
+	ev.Phase = phase
+	ev.Reason = ""
+	return ev
+}
+
+func withReason(ev Event, phase EventPhase, reason string) Event {
+	ev.Phase = phase
+	ev.Reason = reason
+	return ev
+}
@@ -0,0 +1,191 @@
+// Package fixedpoint 提供一个 1e8 精度的定点数类型，用于替代在价格/数量/成本
+// 等累加路径上直接使用 float64（例如 unifiedarb 策略：s.rounds 单个周期内可达
+// 数百次，float64 累加会逐步漂移），设计上参照 bbgo 等成熟交易框架的
+// fixedpoint.Value 思路。
+package fixedpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultPrecision 是 Value 的定点精度：小数点后 8 位。
+const DefaultPrecision = 8
+
+const scale int64 = 1e8
+
+// Value 是以 1e8 为基准的定点数：Value(1e8) 表示 1.0。四则运算（Add/Sub/Mul/Div）
+// 全部基于整数运算完成，避免 float64 在大量累加/乘除后出现的舍入误差漂移。
+type Value int64
+
+// Zero 是 Value 的零值，等价于 Value(0)，写在这里只是为了调用处更直观。
+var Zero = Value(0)
+
+// NewFromFloat 把一个 float64 转换为 Value（四舍五入到 DefaultPrecision 精度）。
+// 用于从配置字段、尚未迁移的 float64 计算结果等“已经是 decimal”的来源转入定点域。
+func NewFromFloat(f float64) Value {
+	return Value(math.Round(f * float64(scale)))
+}
+
+// NewFromString 解析一个十进制字符串为 Value，用于 Polymarket 等 API 直接返回
+// 字符串价格/数量的场景（避免先转 float64 再转定点引入的二次误差）。
+func NewFromString(s string) (Value, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("fixedpoint: empty string")
+	}
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = s[1:]
+	}
+	parts := strings.SplitN(s, ".", 2)
+	intPart := parts[0]
+	if intPart == "" {
+		intPart = "0"
+	}
+	fracPart := ""
+	if len(parts) == 2 {
+		fracPart = parts[1]
+	}
+	if len(fracPart) > DefaultPrecision {
+		// 超出精度的部分截断，与 venue 的 tick size 语义一致（不四舍五入放大精度）。
+		fracPart = fracPart[:DefaultPrecision]
+	}
+	for len(fracPart) < DefaultPrecision {
+		fracPart += "0"
+	}
+	intVal, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("fixedpoint: invalid integer part %q: %w", intPart, err)
+	}
+	fracVal, err := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("fixedpoint: invalid fractional part %q: %w", fracPart, err)
+	}
+	v := intVal*scale + fracVal
+	if neg {
+		v = -v
+	}
+	return Value(v), nil
+}
+
+// Float64 把 Value 转换回 float64，仅应在跨越到尚未迁移到 Value 的边界（日志、
+// 还是 float64 的配置阈值比较）时使用。
+func (v Value) Float64() float64 {
+	return float64(v) / float64(scale)
+}
+
+// Add 返回 v+o。
+func (v Value) Add(o Value) Value { return v + o }
+
+// Sub 返回 v-o。
+func (v Value) Sub(o Value) Value { return v - o }
+
+// Mul 返回 v*o，用 big.Int 中间结果避免 int64 乘法溢出，结果按 DefaultPrecision 截断。
+func (v Value) Mul(o Value) Value {
+	r := new(big.Int).Mul(big.NewInt(int64(v)), big.NewInt(int64(o)))
+	r.Quo(r, big.NewInt(scale))
+	return Value(r.Int64())
+}
+
+// Div 返回 v/o；o 为 0 时返回 Zero（调用方应在进入 Div 前自行校验除数，这里的
+// 兜底只是为了避免 panic）。
+//
+// 有余数时向远离零的方向舍入（而不是 big.Int.Quo 默认的向零截断）：像
+// “最小下单名义金额 / 价格”这类下限计算，如果截断会系统性偏小，导致算出来的
+// 最小下单量对应的名义价值低于阈值。
+func (v Value) Div(o Value) Value {
+	if o == 0 {
+		return Zero
+	}
+	num := new(big.Int).Mul(big.NewInt(int64(v)), big.NewInt(scale))
+	den := big.NewInt(int64(o))
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 {
+		if (num.Sign() < 0) == (den.Sign() < 0) {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	return Value(q.Int64())
+}
+
+// IsZero 判断 v 是否为零值。
+func (v Value) IsZero() bool { return v == 0 }
+
+// Sign 返回 v 的符号：1/0/-1。
+func (v Value) Sign() int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Compare 返回 v 与 o 的大小关系：-1（v<o）/0（相等）/1（v>o）。
+func (v Value) Compare(o Value) int {
+	switch {
+	case v < o:
+		return -1
+	case v > o:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Min 返回 a/b 中较小的一个。
+func Min(a, b Value) Value {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Max 返回 a/b 中较大的一个。
+func Max(a, b Value) Value {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// String 按最短十进制表示输出，去除多余的尾随精度。
+func (v Value) String() string {
+	return strconv.FormatFloat(v.Float64(), 'f', -1, 64)
+}
+
+// MarshalJSON 编码为 JSON 字符串，避免 float64 JSON 数字在跨语言/跨服务传输时
+// 再次引入精度误差。
+func (v Value) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON 兼容两种输入：JSON 字符串（Polymarket 等 API 的价格/数量字段）
+// 与 JSON 数字（旧数据、未迁移的上游）。
+func (v *Value) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := NewFromString(s)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+		return nil
+	}
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	*v = NewFromFloat(f)
+	return nil
+}
@@ -0,0 +1,74 @@
+package fixedpoint
+
+import "testing"
+
+func TestNewFromFloatAndFloat64(t *testing.T) {
+	v := NewFromFloat(0.1)
+	if got := v.Float64(); got != 0.1 {
+		t.Fatalf("Float64() got=%v want=%v", got, 0.1)
+	}
+}
+
+func TestMulAvoidsFloatDrift(t *testing.T) {
+	// 0.1 USDC/share * 3 次累加（模拟 hundreds-of-rounds 场景的简化版）不应漂移。
+	price := NewFromFloat(0.1)
+	size := NewFromFloat(3)
+	got := size.Mul(price)
+	want := NewFromFloat(0.3)
+	if got != want {
+		t.Fatalf("Mul got=%s want=%s", got, want)
+	}
+}
+
+func TestDivMinOrderSize(t *testing.T) {
+	// 1.0 USDC / 0.33 ask 不应因为 float 舍入而低于交易所最小名义价值。
+	minUSDC := NewFromFloat(1.0)
+	ask := NewFromFloat(0.33)
+	minShares := minUSDC.Div(ask)
+	if minShares.Mul(ask).Compare(minUSDC) < 0 {
+		t.Fatalf("minShares*ask should cover minUSDC, got %s*%s=%s < %s", minShares, ask, minShares.Mul(ask), minUSDC)
+	}
+}
+
+func TestNewFromString(t *testing.T) {
+	cases := map[string]float64{
+		"0.55":   0.55,
+		"-1.25":  -1.25,
+		"2":      2,
+		"0.1234567890123": 0.12345678,
+	}
+	for s, want := range cases {
+		got, err := NewFromString(s)
+		if err != nil {
+			t.Fatalf("NewFromString(%q) error: %v", s, err)
+		}
+		if got.Float64() != want {
+			t.Fatalf("NewFromString(%q) got=%v want=%v", s, got.Float64(), want)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	v := NewFromFloat(0.12345678)
+	data, err := v.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON error: %v", err)
+	}
+	var got Value
+	if err := got.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON error: %v", err)
+	}
+	if got != v {
+		t.Fatalf("round trip got=%s want=%s", got, v)
+	}
+}
+
+func TestUnmarshalJSONNumber(t *testing.T) {
+	var v Value
+	if err := v.UnmarshalJSON([]byte("0.5")); err != nil {
+		t.Fatalf("UnmarshalJSON error: %v", err)
+	}
+	if v.Float64() != 0.5 {
+		t.Fatalf("got=%v want=%v", v.Float64(), 0.5)
+	}
+}
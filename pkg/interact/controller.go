@@ -0,0 +1,160 @@
+package interact
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/pkg/persistence"
+)
+
+// Command 是一条可被 "/<name>" 触发的操作员命令。
+type Command struct {
+	Name     string                               // 不含前导 "/"
+	Desc     string                               // 简短说明（预留给未来的 /help）
+	Mutating bool                                 // 是否改变策略状态，true 时要求先 /auth 通过
+	Handler  func(args []string) (string, error) // 返回给操作员的回复文本
+}
+
+// authWindow 是一次 TOTP 认证后的免验证有效期，过期需要重新 /auth <code>。
+const authWindow = 10 * time.Minute
+
+const totpPersistenceTag = "totp_secret"
+
+// totpEnrollment 是持久化的 TOTP 注册状态。
+type totpEnrollment struct {
+	Secret string `json:"secret"`
+}
+
+// Controller 维护命令注册表、TOTP 认证状态与 Notifiability，是 Telegram/Slack
+// 控制面的核心：Telegram 命令经 TelegramNotifier.PollCommands 进入 Dispatch，
+// Slack 目前只作为 Notifiability 的推送通道（Slack 命令交互依赖 Events
+// API/Socket Mode，需要额外的 app 配置，本次不展开）。
+type Controller struct {
+	*Notifiability
+
+	issuer  string
+	account string
+
+	persistenceService persistence.Service
+	persistenceID      string
+
+	mu             sync.Mutex
+	commands       map[string]*Command
+	secret         string
+	authorizedTill map[string]time.Time // chatID -> 认证有效期截止时间
+}
+
+// NewController 创建 Controller。issuer/account 仅用于 TOTPEnrollURL 的标签
+// （例如 issuer="unifiedarb", account=策略实例 ID）。persistenceService 为 nil 时
+// TOTP 密钥只在内存中，进程重启后需要重新扫码注册。
+func NewController(issuer, account string, persistenceService persistence.Service, persistenceID string) *Controller {
+	return &Controller{
+		Notifiability:       NewNotifiability(),
+		issuer:              issuer,
+		account:             account,
+		persistenceService:  persistenceService,
+		persistenceID:       persistenceID,
+		commands:            make(map[string]*Command),
+		authorizedTill:      make(map[string]time.Time),
+	}
+}
+
+// Register 注册一条命令，重复的 Name 会覆盖旧的。
+func (c *Controller) Register(cmd Command) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commands[cmd.Name] = &cmd
+}
+
+// EnsureEnrolled 若本地/持久化存储里还没有 TOTP 密钥，生成一个新的并持久化，
+// 返回供首次扫码的 otpauth:// URL（isNew=true）；已经注册过时 isNew=false，
+// 调用方不应重复提示扫码（旧的扫码记录已经失效，重新打印只会造成困惑）。
+func (c *Controller) EnsureEnrolled() (enrollURL string, isNew bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.secret != "" {
+		return "", false, nil
+	}
+
+	if c.persistenceService != nil {
+		var stored totpEnrollment
+		store := c.persistenceService.NewStore("interact", c.persistenceID, totpPersistenceTag)
+		loadErr := store.Load(&stored)
+		if loadErr == nil && stored.Secret != "" {
+			c.secret = stored.Secret
+			return "", false, nil
+		}
+		if loadErr != nil && loadErr != persistence.ErrNotExists {
+			return "", false, loadErr
+		}
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return "", false, err
+	}
+	if c.persistenceService != nil {
+		store := c.persistenceService.NewStore("interact", c.persistenceID, totpPersistenceTag)
+		if saveErr := store.Save(&totpEnrollment{Secret: secret}); saveErr != nil {
+			return "", false, saveErr
+		}
+	}
+	c.secret = secret
+	return TOTPEnrollURL(secret, c.issuer, c.account), true, nil
+}
+
+func (c *Controller) isAuthorized(chatID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	till, ok := c.authorizedTill[chatID]
+	return ok && time.Now().Before(till)
+}
+
+func (c *Controller) authorize(chatID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authorizedTill[chatID] = time.Now().Add(authWindow)
+}
+
+// Dispatch 解析一条消息文本并执行对应命令，返回回复文本；设计为
+// TelegramNotifier.PollCommands 的 handle 回调。
+func (c *Controller) Dispatch(chatID, text string) string {
+	fields := strings.Fields(strings.TrimSpace(text))
+	if len(fields) == 0 {
+		return ""
+	}
+	name := strings.TrimPrefix(fields[0], "/")
+	args := fields[1:]
+
+	if name == "auth" {
+		if len(args) != 1 {
+			return "用法: /auth <6位验证码>"
+		}
+		c.mu.Lock()
+		secret := c.secret
+		c.mu.Unlock()
+		if secret == "" || !VerifyTOTP(secret, args[0], time.Now()) {
+			return "❌ 验证码无效"
+		}
+		c.authorize(chatID)
+		return fmt.Sprintf("✅ 认证成功，%s 内无需重新验证", authWindow)
+	}
+
+	c.mu.Lock()
+	cmd, found := c.commands[name]
+	c.mu.Unlock()
+	if !found {
+		return fmt.Sprintf("未知命令: /%s", name)
+	}
+	if cmd.Mutating && !c.isAuthorized(chatID) {
+		return "🔒 该命令会改变策略状态，请先发送 /auth <6位验证码>"
+	}
+
+	reply, err := cmd.Handler(args)
+	if err != nil {
+		return fmt.Sprintf("❌ /%s 执行失败: %v", name, err)
+	}
+	return reply
+}
@@ -0,0 +1,46 @@
+// Package interact 提供一个可插拔的“通知 + 交互命令”子系统：运营方通过
+// Slack/Telegram 接收成交/plan 完成等事件推送（见 Notifier/Notifiability），
+// 也可以通过 Telegram 发送命令反向控制正在运行的策略（见 Controller，要求先
+// 用 TOTP 认证，见 totp.go）。命名与用法参照 bbgo 的 Notifiability 设计。
+package interact
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("pkg", "interact")
+
+// Notifier 是单个推送通道的最小接口：Slack webhook、Telegram bot 等都实现它。
+type Notifier interface {
+	Notify(text string) error
+}
+
+// Notifiability 把消息广播给所有已注册的 Notifier；单个 Notifier 推送失败只记录
+// 日志，不影响其余 Notifier（避免一个 webhook 超时拖垮整条通知链路）。
+type Notifiability struct {
+	notifiers []Notifier
+}
+
+// NewNotifiability 创建一个空的 Notifiability，调用方通过 AddNotifier 按需挂载
+// Slack/Telegram（或未来的其他通道）。
+func NewNotifiability() *Notifiability {
+	return &Notifiability{}
+}
+
+// AddNotifier 挂载一个推送通道。
+func (n *Notifiability) AddNotifier(notifier Notifier) {
+	if notifier == nil {
+		return
+	}
+	n.notifiers = append(n.notifiers, notifier)
+}
+
+// Notify 把 text 广播给所有已挂载的 Notifier。
+func (n *Notifiability) Notify(text string) {
+	if n == nil {
+		return
+	}
+	for _, notifier := range n.notifiers {
+		if err := notifier.Notify(text); err != nil {
+			log.Warnf("通知推送失败: %v", err)
+		}
+	}
+}
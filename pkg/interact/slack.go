@@ -0,0 +1,41 @@
+package interact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SlackNotifier 通过 Slack Incoming Webhook 推送纯文本消息。
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier 创建 SlackNotifier，webhookURL 是 Slack 后台生成的 Incoming
+// Webhook 地址（https://hooks.slack.com/services/...）。
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify 实现 Notifier。
+func (n *SlackNotifier) Notify(text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(n.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("interact: slack webhook 返回非 2xx 状态: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package interact
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TelegramNotifier 通过 Telegram Bot API 推送消息，也承担命令接收的传输层角色
+// （见 PollCommands）：同一个 bot token 既用来主动推送 fill/plan 完成通知，也用来
+// 接收 /status 等操作员命令，与真实运营场景下“一个 bot 账号”的习惯一致。
+type TelegramNotifier struct {
+	token  string
+	chatID string // 默认推送目标（Notify 使用）；PollCommands 按收到消息的 chat 各自回复
+	client *http.Client
+
+	offset int64 // getUpdates 的增量游标，避免重复处理同一条消息
+}
+
+// NewTelegramNotifier 创建 TelegramNotifier。token 是 BotFather 签发的 bot token，
+// chatID 是 Notify() 默认推送的目标 chat（个人或群组 ID，由运营方预先获取）。
+func NewTelegramNotifier(token, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{
+		token:  token,
+		chatID: chatID,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (n *TelegramNotifier) apiURL(method string) string {
+	return fmt.Sprintf("https://api.telegram.org/bot%s/%s", n.token, method)
+}
+
+// Notify 实现 Notifier：推送到构造时配置的默认 chatID。
+func (n *TelegramNotifier) Notify(text string) error {
+	return n.sendMessage(n.chatID, text)
+}
+
+func (n *TelegramNotifier) sendMessage(chatID, text string) error {
+	form := url.Values{"chat_id": {chatID}, "text": {text}}
+	resp, err := n.client.PostForm(n.apiURL("sendMessage"), form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("interact: telegram sendMessage 返回非 2xx 状态: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type telegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+type telegramGetUpdatesResponse struct {
+	OK     bool             `json:"ok"`
+	Result []telegramUpdate `json:"result"`
+}
+
+// PollCommands 以长轮询方式拉取 Telegram 消息并交给 handle 处理，handle 的返回值
+// 会作为回复发回同一个 chat；ctx.Done() 时退出。未配置 token 时为 no-op，调用方
+// 应把它放到独立 goroutine 里跑（handle 通常是 Controller.Dispatch）。
+func (n *TelegramNotifier) PollCommands(ctx context.Context, handle func(chatID string, text string) string) {
+	if n.token == "" {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		updates, err := n.getUpdates(ctx)
+		if err != nil {
+			log.Warnf("telegram getUpdates 失败: %v", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		for _, u := range updates {
+			n.offset = u.UpdateID + 1
+			if u.Message == nil || u.Message.Text == "" {
+				continue
+			}
+			chatID := fmt.Sprintf("%d", u.Message.Chat.ID)
+			reply := handle(chatID, u.Message.Text)
+			if reply != "" {
+				if err := n.sendMessage(chatID, reply); err != nil {
+					log.Warnf("telegram 回复失败: %v", err)
+				}
+			}
+		}
+	}
+}
+
+func (n *TelegramNotifier) getUpdates(ctx context.Context) ([]telegramUpdate, error) {
+	form := url.Values{
+		"offset":  {fmt.Sprintf("%d", n.offset)},
+		"timeout": {"20"},
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, 25*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, n.apiURL("getUpdates"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = form.Encode()
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parsed telegramGetUpdatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	if !parsed.OK {
+		return nil, fmt.Errorf("interact: telegram getUpdates 返回 ok=false")
+	}
+	return parsed.Result, nil
+}
@@ -3,7 +3,6 @@ package marketspec
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -17,8 +16,18 @@ const (
 	// SlugStylePolymarketHourlyET: {coinName}-up-or-down-{month}-{day}-{hour}{am|pm}-et
 	// 示例：bitcoin-up-or-down-december-24-5am-et
 	SlugStylePolymarketHourlyET SlugStyle = "polymarket_hourly_et"
+	// SlugStyleDailyET: {coinName}-up-or-down-{month}-{day}-et
+	// 示例：bitcoin-up-or-down-december-24-et
+	SlugStyleDailyET SlugStyle = "daily_et"
+	// SlugStyleWeeklyET: {coinName}-up-or-down-week-of-{month}-{day}-et（日期是该周周一）
+	SlugStyleWeeklyET SlugStyle = "weekly_et"
+	// SlugStyleQuarterlyET: {coinName}-up-or-down-q{quarter}-{year}-et
+	SlugStyleQuarterlyET SlugStyle = "quarterly_et"
 )
 
+// ParseSlugStyle 解析 slugStyle 字符串。内置几种常见别名之外，还会查一遍
+// RegisterSlugScheme 注册过的 scheme 名字，这样第三方通过 RegisterSlugScheme
+// 接入的自定义命名规则也能直接配置成 MarketSpec.SlugStyle。
 func ParseSlugStyle(v string) (SlugStyle, error) {
 	s := strings.ToLower(strings.TrimSpace(v))
 	switch s {
@@ -26,32 +35,57 @@ func ParseSlugStyle(v string) (SlugStyle, error) {
 		return SlugStyleTimestamp, nil
 	case "polymarket_hourly_et", "hourly_et", "hour_et", "et_hourly":
 		return SlugStylePolymarketHourlyET, nil
-	default:
-		return "", fmt.Errorf("不支持的 slugStyle: %q（支持: timestamp/polymarket_hourly_et）", v)
+	case "daily_et", "day_et", "et_daily":
+		return SlugStyleDailyET, nil
+	case "weekly_et", "week_et", "et_weekly":
+		return SlugStyleWeeklyET, nil
+	case "quarterly_et", "quarter_et", "et_quarterly":
+		return SlugStyleQuarterlyET, nil
+	}
+	if _, ok := lookupSlugScheme(SlugStyle(s)); ok {
+		return SlugStyle(s), nil
 	}
+	return "", fmt.Errorf("不支持的 slugStyle: %q（支持: timestamp/polymarket_hourly_et/daily_et/weekly_et/quarterly_et，或通过 RegisterSlugScheme 注册的自定义名字）", v)
 }
 
 // Timeframe 表示市场周期（用于 polymarket updown market slug）。
-// 支持：15m / 1h / 4h
-type Timeframe string
+// Period 是 Timeframe 的别名：新增的 Kline/OHLC 周期子系统（见 period.go）用
+// Period 这个名字描述"蜡烛周期"语义，但底层仍是同一个类型，旧调用方无需改动。
+type Timeframe = Period
 
 const (
-	Timeframe15m Timeframe = "15m"
-	Timeframe1h  Timeframe = "1h"
-	Timeframe4h  Timeframe = "4h"
+	Timeframe15m = Period15m
+	Timeframe1h  = Period1h
+	Timeframe4h  = Period4h
 )
 
+// ParseTimeframe 解析周期字符串。支持 1m/3m/5m/15m/30m/1h/2h/4h/1d/1w，
+// 每个周期都兼容若干常见写法（比如 "1hour" "60m"）。
 func ParseTimeframe(v string) (Timeframe, error) {
 	s := strings.ToLower(strings.TrimSpace(v))
 	switch s {
+	case "1m", "1min", "1mins", "1-minute", "1minute":
+		return Period1m, nil
+	case "3m", "3min", "3mins", "3-minute", "3minutes":
+		return Period3m, nil
+	case "5m", "5min", "5mins", "5-minute", "5minutes":
+		return Period5m, nil
 	case "15m", "15min", "15mins", "15-minute", "15minutes":
-		return Timeframe15m, nil
+		return Period15m, nil
+	case "30m", "30min", "30mins", "30-minute", "30minutes":
+		return Period30m, nil
 	case "1h", "1hour", "1-hour", "60m", "60min", "60mins":
-		return Timeframe1h, nil
+		return Period1h, nil
+	case "2h", "2hour", "2-hour", "120m", "120min", "120mins":
+		return Period2h, nil
 	case "4h", "4hour", "4-hour", "240m", "240min", "240mins":
-		return Timeframe4h, nil
+		return Period4h, nil
+	case "1d", "1day", "1-day", "24h", "daily":
+		return Period1d, nil
+	case "1w", "1week", "1-week", "7d", "weekly":
+		return Period1w, nil
 	default:
-		return "", fmt.Errorf("不支持的 timeframe: %q（支持: 15m/1h/4h）", v)
+		return "", fmt.Errorf("不支持的 timeframe: %q（支持: 1m/3m/5m/15m/30m/1h/2h/4h/1d/1w）", v)
 	}
 }
 
@@ -59,12 +93,26 @@ func (t Timeframe) String() string { return string(t) }
 
 func (t Timeframe) Duration() time.Duration {
 	switch t {
-	case Timeframe15m:
+	case Period1m:
+		return time.Minute
+	case Period3m:
+		return 3 * time.Minute
+	case Period5m:
+		return 5 * time.Minute
+	case Period15m:
 		return 15 * time.Minute
-	case Timeframe1h:
-		return 1 * time.Hour
-	case Timeframe4h:
+	case Period30m:
+		return 30 * time.Minute
+	case Period1h:
+		return time.Hour
+	case Period2h:
+		return 2 * time.Hour
+	case Period4h:
 		return 4 * time.Hour
+	case Period1d:
+		return 24 * time.Hour
+	case Period1w:
+		return 7 * 24 * time.Hour
 	default:
 		// 未知值按 15m 处理，避免 panic（Validate 会兜底）
 		return 15 * time.Minute
@@ -104,39 +152,17 @@ func New(symbol, timeframe, kind string) (MarketSpec, error) {
 func (m MarketSpec) Duration() time.Duration { return m.Timeframe.Duration() }
 
 func (m MarketSpec) location(now time.Time) *time.Location {
-	switch m.SlugStyle {
-	case SlugStylePolymarketHourlyET:
-		// 交易时间锚定到 ET（America/New_York）
-		if loc, err := time.LoadLocation("America/New_York"); err == nil {
-			return loc
-		}
-		// fallback：如果系统缺少 tzdata，则退回 local
-		return now.Location()
-	default:
-		return now.Location()
+	if scheme, ok := lookupSlugScheme(m.SlugStyle); ok {
+		return scheme.Location(m)
 	}
+	return now.Location()
 }
 
 // CurrentPeriodStartUnix 返回当前周期起点（按 slugStyle 对应时区对齐）。
+// 对齐逻辑由 PeriodStartForTime 统一实现（见 period.go），这里只是转换成
+// Unix 秒，供 slug 相关的旧接口使用。
 func (m MarketSpec) CurrentPeriodStartUnix(now time.Time) int64 {
-	loc := m.location(now)
-	now = now.In(loc)
-	switch m.Timeframe {
-	case Timeframe15m:
-		min := (now.Minute() / 15) * 15
-		t := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), min, 0, 0, loc)
-		return t.Unix()
-	case Timeframe1h:
-		t := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, loc)
-		return t.Unix()
-	case Timeframe4h:
-		h := (now.Hour() / 4) * 4
-		t := time.Date(now.Year(), now.Month(), now.Day(), h, 0, 0, 0, loc)
-		return t.Unix()
-	default:
-		// 兜底：按 duration truncate（但对齐点可能不符合预期，因此只做 fallback）
-		return now.Truncate(m.Duration()).Unix()
-	}
+	return m.PeriodStartForTime(now).Unix()
 }
 
 func (m MarketSpec) Slug(periodStartUnix int64) string {
@@ -147,16 +173,14 @@ func (m MarketSpec) Slug(periodStartUnix int64) string {
 			return m.renderTemplate(template, periodStartUnix)
 		}
 	}
-	
-	// 兼容旧逻辑：使用 SlugStyle
-	switch m.SlugStyle {
-	case SlugStylePolymarketHourlyET:
-		// 目前该格式主要用于 1h up-or-down 市场
-		return m.slugPolymarketHourlyET(periodStartUnix)
-	default:
-		// 约定：polymarket slug 使用小写 symbol / kind / timeframe
-		return fmt.Sprintf("%s-%s-%s-%d", m.Symbol, m.Kind, m.Timeframe.String(), periodStartUnix)
+
+	// 否则交给 SlugStyle 对应的 SlugScheme（见 slugscheme.go）
+	if scheme, ok := lookupSlugScheme(m.SlugStyle); ok {
+		return scheme.Render(m, periodStartUnix)
 	}
+
+	// 未注册的 SlugStyle：退化为默认的 timestamp 格式
+	return fmt.Sprintf("%s-%s-%s-%d", m.Symbol, m.Kind, m.Timeframe.String(), periodStartUnix)
 }
 
 // renderTemplate 渲染模板，替换变量
@@ -240,15 +264,11 @@ func (m MarketSpec) SlugPrefix() string {
 		}
 	}
 	
-	// 兼容旧逻辑
-	switch m.SlugStyle {
-	case SlugStylePolymarketHourlyET:
-		// 使用硬编码映射获取币种名称（确保 BTC -> bitcoin, ETH -> ethereum）
-		coinName := m.getHourlyETCoinName()
-		return fmt.Sprintf("%s-up-or-down-", coinName)
-	default:
-		return fmt.Sprintf("%s-%s-%s-", m.Symbol, m.Kind, m.Timeframe.String())
+	// 否则交给 SlugStyle 对应的 SlugScheme
+	if scheme, ok := lookupSlugScheme(m.SlugStyle); ok {
+		return scheme.Prefix(m)
 	}
+	return fmt.Sprintf("%s-%s-%s-", m.Symbol, m.Kind, m.Timeframe.String())
 }
 
 func (m MarketSpec) NextPeriodStartUnix(periodStartUnix int64) int64 {
@@ -268,163 +288,49 @@ func (m MarketSpec) NextSlugs(count int) []string {
 	return out
 }
 
-// TimestampFromSlug 尝试从 slug 解析周期起点时间戳（Unix seconds）。
-// - timestamp 模式：解析末尾的 -{digits}
-// - hourly_et 模式：解析 {month}-{day}-{hour}{am|pm}-et
+// TimestampFromSlug 尝试从 slug 解析周期起点时间戳（Unix seconds）。优先用
+// m.SlugStyle 对应的 SlugScheme 解析；如果该 SlugStyle 没有注册 scheme（或
+// 解析失败），在 style 未显式设置时会按前缀遍历所有已注册 scheme 自动探测
+// —— 这样上游不需要事先知道一条 slug 属于哪种格式。
 func (m MarketSpec) TimestampFromSlug(slug string, now time.Time) (int64, bool) {
 	slug = strings.TrimSpace(slug)
 	if slug == "" {
 		return 0, false
 	}
-	switch m.SlugStyle {
-	case SlugStylePolymarketHourlyET:
-		return parsePolymarketHourlyETSlug(slug, now)
-	default:
-		// -(\d+)$
-		i := strings.LastIndex(slug, "-")
-		if i < 0 || i+1 >= len(slug) {
-			return 0, false
-		}
-		ts, err := strconv.ParseInt(slug[i+1:], 10, 64)
-		if err != nil || ts <= 0 {
-			return 0, false
+
+	if scheme, ok := lookupSlugScheme(m.SlugStyle); ok {
+		if ts, ok := scheme.Parse(slug, now); ok {
+			return ts, true
 		}
-		return ts, true
 	}
-}
 
-func (m MarketSpec) coinName() string {
-	switch strings.ToLower(strings.TrimSpace(m.Symbol)) {
-	case "btc", "bitcoin":
-		return "bitcoin"
-	case "eth", "ethereum":
-		return "ethereum"
-	case "sol", "solana":
-		return "solana"
-	case "xrp":
-		return "xrp"
-	default:
-		// fallback：直接用 symbol
-		return strings.ToLower(strings.TrimSpace(m.Symbol))
+	if m.SlugStyle != "" {
+		return 0, false
 	}
-}
-
-// hourlyETSlugMapping 1小时市场的硬编码映射表
-// 格式：{coinName}-up-or-down-{month}-{day}-{hour}{am|pm}-et
-// 例如：bitcoin-up-or-down-december-24-11am-et
-//       ethereum-up-or-down-december-24-11am-et
-var hourlyETSlugMapping = map[string]string{
-	// BTC 映射
-	"bitcoin": "bitcoin",
-	"btc":     "bitcoin",
-	// ETH 映射
-	"ethereum": "ethereum",
-	"eth":      "ethereum",
-	// 其他币种可以继续添加
-	"solana": "solana",
-	"sol":    "solana",
-	"xrp":    "xrp",
-}
 
-// getHourlyETCoinName 获取1小时市场使用的币种名称（硬编码映射）
-func (m MarketSpec) getHourlyETCoinName() string {
-	symbol := strings.ToLower(strings.TrimSpace(m.Symbol))
-	if coinName, ok := hourlyETSlugMapping[symbol]; ok {
-		return coinName
+	// SlugStyle 未设置：按前缀自动探测注册过的 scheme。
+	slugSchemeMu.RLock()
+	defer slugSchemeMu.RUnlock()
+	for _, scheme := range slugSchemeRegistry {
+		if !strings.HasPrefix(strings.ToLower(slug), strings.ToLower(scheme.Prefix(m))) {
+			continue
+		}
+		if ts, ok := scheme.Parse(slug, now); ok {
+			return ts, true
+		}
 	}
-	// fallback：使用 coinName() 方法
-	return m.coinName()
+	return 0, false
 }
 
-func (m MarketSpec) slugPolymarketHourlyET(periodStartUnix int64) string {
-	loc := m.location(time.Now())
-	t := time.Unix(periodStartUnix, 0).In(loc)
-
-	month := strings.ToLower(t.Month().String())
-	day := t.Day()
-
-	h := t.Hour()
-	ampm := "am"
-	h12 := h
-	if h == 0 {
-		h12 = 12
-		ampm = "am"
-	} else if h < 12 {
-		h12 = h
-		ampm = "am"
-	} else if h == 12 {
-		h12 = 12
-		ampm = "pm"
-	} else {
-		h12 = h - 12
-		ampm = "pm"
-	}
-	// 使用硬编码映射获取币种名称（确保 BTC -> bitcoin, ETH -> ethereum）
-	coinName := m.getHourlyETCoinName()
-	return fmt.Sprintf("%s-up-or-down-%s-%d-%d%s-et", coinName, month, day, h12, ampm)
+// getHourlyETCoinName 供 renderTemplate 的 {coinName} 模板变量使用，取的是
+// 内置 polymarket_hourly_et scheme 的默认币种映射（见 slugscheme.go 的
+// defaultCoinNames）。
+func (m MarketSpec) getHourlyETCoinName() string {
+	return defaultCoinNames().resolve(m.Symbol)
 }
 
 var hourTokenRe = regexp.MustCompile(`^(\d{1,2})(am|pm)$`)
 
-func parsePolymarketHourlyETSlug(slug string, now time.Time) (int64, bool) {
-	parts := strings.Split(strings.ToLower(strings.TrimSpace(slug)), "-")
-	// 预期：{coin}-up-or-down-{month}-{day}-{hour}{am|pm}-et
-	// 示例：bitcoin-up-or-down-december-24-11am-et
-	//       ethereum-up-or-down-december-24-11am-et
-	// split 后：coin, up, or, down, month, day, hourToken, et
-	if len(parts) < 8 {
-		return 0, false
-	}
-	// 验证格式：up-or-down
-	if parts[1] != "up" || parts[2] != "or" || parts[3] != "down" {
-		return 0, false
-	}
-	// 验证结尾：et
-	if parts[len(parts)-1] != "et" {
-		return 0, false
-	}
-	// 验证币种名称（支持 bitcoin, ethereum 等硬编码映射）
-	coinName := parts[0]
-	if _, ok := hourlyETSlugMapping[coinName]; !ok {
-		// 如果不在映射表中，也允许（可能是其他币种）
-		// 但确保是已知的格式
-	}
-	monthToken := parts[4]
-	dayToken := parts[5]
-	hourToken := parts[6]
-
-	month, ok := parseMonthName(monthToken)
-	if !ok {
-		return 0, false
-	}
-	day, err := strconv.Atoi(dayToken)
-	if err != nil || day < 1 || day > 31 {
-		return 0, false
-	}
-	mm := hourTokenRe.FindStringSubmatch(hourToken)
-	if len(mm) != 3 {
-		return 0, false
-	}
-	hh, _ := strconv.Atoi(mm[1])
-	if hh < 1 || hh > 12 {
-		return 0, false
-	}
-	ampm := mm[2]
-	h24 := hh % 12
-	if ampm == "pm" {
-		h24 += 12
-	}
-
-	loc, err := time.LoadLocation("America/New_York")
-	if err != nil {
-		loc = now.Location()
-	}
-	nowET := now.In(loc)
-	year := closestYear(nowET, month, day, h24)
-	t := time.Date(year, month, day, h24, 0, 0, 0, loc)
-	return t.Unix(), true
-}
-
 func parseMonthName(s string) (time.Month, bool) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "january":
@@ -0,0 +1,147 @@
+package marketspec
+
+import (
+	"iter"
+	"time"
+)
+
+// Period 是蜡烛/K线周期的粒度，对标 Go 生态里常见交易所 SDK 的 Kline/Period
+// 抽象（比如 ccxt、go-binance 的 KlineInterval）。Timeframe 是它的别名，
+// 保留旧名字是为了不破坏已经写死 marketspec.Timeframe 的调用方。
+type Period string
+
+const (
+	Period1m  Period = "1m"
+	Period3m  Period = "3m"
+	Period5m  Period = "5m"
+	Period15m Period = "15m"
+	Period30m Period = "30m"
+	Period1h  Period = "1h"
+	Period2h  Period = "2h"
+	Period4h  Period = "4h"
+	Period1d  Period = "1d"
+	Period1w  Period = "1w"
+)
+
+// PeriodStartForTime 返回 t 所在周期的起点，按 m.location(t) 对齐（例如
+// SlugStylePolymarketHourlyET 对应市场按 ET 对齐，而不是 UTC）。
+func (m MarketSpec) PeriodStartForTime(t time.Time) time.Time {
+	loc := m.location(t)
+	t = t.In(loc)
+
+	switch m.Timeframe {
+	case Period1m:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	case Period3m:
+		return alignMinute(t, loc, 3)
+	case Period5m:
+		return alignMinute(t, loc, 5)
+	case Period15m:
+		return alignMinute(t, loc, 15)
+	case Period30m:
+		return alignMinute(t, loc, 30)
+	case Period1h:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+	case Period2h:
+		return alignHour(t, loc, 2)
+	case Period4h:
+		return alignHour(t, loc, 4)
+	case Period1d:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	case Period1w:
+		return startOfWeek(t, loc)
+	default:
+		// 未知周期：退化为按 Duration truncate。
+		return t.Truncate(m.Duration())
+	}
+}
+
+// PeriodEndForTime 返回 t 所在周期的结束时间（下一个周期的起点）。
+func (m MarketSpec) PeriodEndForTime(t time.Time) time.Time {
+	return m.PeriodStartForTime(t).Add(m.Duration())
+}
+
+// PeriodContaining 返回包含 atUnix 这个时间点的周期的 [start, end) 边界
+// （均为 Unix 秒）。atUnix 不要求本身就是某个周期的起点。
+func (m MarketSpec) PeriodContaining(atUnix int64) (start int64, end int64) {
+	t := time.Unix(atUnix, 0)
+	return m.PeriodStartForTime(t).Unix(), m.PeriodEndForTime(t).Unix()
+}
+
+// PeriodIndex 返回 t 所在周期相对于 Unix 纪元的整数序号。
+//
+// 对分钟/小时级周期直接用 Unix 秒整除 Duration 即可，因为 location 带来的
+// 只是一个固定的整小时偏移。但日/周级周期在有 DST 的时区里，某些"天"只有
+// 23 或 25 小时，Unix 秒整除会导致序号在 DST 切换附近错位，所以这两档改用
+// 按日历天数计算，不依赖 Duration。
+func (m MarketSpec) PeriodIndex(t time.Time) int64 {
+	loc := m.location(t)
+	start := m.PeriodStartForTime(t)
+
+	switch m.Timeframe {
+	case Period1d:
+		return calendarDaysBetween(epoch(loc), start)
+	case Period1w:
+		return calendarDaysBetween(startOfWeek(epoch(loc), loc), start) / 7
+	default:
+		return start.Unix() / int64(m.Duration().Seconds())
+	}
+}
+
+// IterPeriods 从 from 所在周期的起点开始，按 Duration 逐个产出周期起点
+// （Unix 秒），直到超过 to 为止。日/周级周期通过 PeriodStartForTime 重新
+// 对齐下一步，而不是直接加 Duration，这样 DST 附近也不会漂移出对齐点。
+func (m MarketSpec) IterPeriods(from, to time.Time) iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		cur := m.PeriodStartForTime(from)
+		for !cur.After(to) {
+			if !yield(cur.Unix()) {
+				return
+			}
+			next := m.PeriodStartForTime(cur.Add(m.Duration()))
+			if !next.After(cur) {
+				// 理论上不会发生（Duration 恒为正），防御性地避免死循环。
+				return
+			}
+			cur = next
+		}
+	}
+}
+
+// alignMinute 把 t 向下取整到最近的 n 分钟边界（n 必须整除 60）。
+func alignMinute(t time.Time, loc *time.Location, n int) time.Time {
+	minute := (t.Minute() / n) * n
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), minute, 0, 0, loc)
+}
+
+// alignHour 把 t 向下取整到最近的 n 小时边界（n 必须整除 24）。
+func alignHour(t time.Time, loc *time.Location, n int) time.Time {
+	hour := (t.Hour() / n) * n
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, 0, 0, 0, loc)
+}
+
+// startOfWeek 返回 t 所在自然周（周一为一周开始，ISO 约定）的起点。
+func startOfWeek(t time.Time, loc *time.Location) time.Time {
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7 // 把周日视为第 7 天，方便算到上一个周一的偏移
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	return day.AddDate(0, 0, -(weekday - 1))
+}
+
+// epoch 返回给定时区下的 Unix 纪元（1970-01-01 00:00）。
+func epoch(loc *time.Location) time.Time {
+	return time.Date(1970, time.January, 1, 0, 0, 0, 0, loc)
+}
+
+// calendarDaysBetween 按日历天数（而不是 Unix 秒差除以 86400）计算 a 到 b
+// 经过了多少天，避免 DST 导致的 23/25 小时天打乱计数。两个时间都先归一化
+// 到 UTC 正午，消除跨天边界和 DST 偏移的影响。
+func calendarDaysBetween(a, b time.Time) int64 {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	au := time.Date(ay, am, ad, 12, 0, 0, 0, time.UTC)
+	bu := time.Date(by, bm, bd, 12, 0, 0, 0, time.UTC)
+	return int64(bu.Sub(au).Hours() / 24)
+}
@@ -0,0 +1,169 @@
+package marketspec
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// leafDomain/nodeDomain 是 Merkle 树叶子/内部节点哈希的 domain separation
+// 前缀（RFC 6962 风格），避免叶子哈希被误当成内部节点哈希（第二原像攻击）。
+const (
+	leafDomain byte = 0x00
+	nodeDomain byte = 0x01
+)
+
+// Hash 是树里每一层节点的哈希值。
+type Hash [32]byte
+
+// ScheduleEntry 是某个周期起点对应的 slug，是 Schedule.Entries 的元素类型。
+type ScheduleEntry struct {
+	PeriodStartUnix int64
+	Slug            string
+}
+
+// ProofStep 是 inclusion proof 里的一层：兄弟节点的哈希，以及它在组合时位于
+// 当前节点的左边还是右边（哈希组合不满足交换律，顺序必须和建树时一致）。
+type ProofStep struct {
+	Sibling        Hash
+	SiblingOnRight bool
+}
+
+// Schedule 枚举 spec 在 [from, to] 区间内每一个周期对应的 slug，并在此基础上
+// 构建一棵标准二叉 Merkle 树：叶子是 sha256(0x00 || slug || periodStartUnix)，
+// 内部节点是 sha256(0x01 || left || right)，奇数层复制最后一个叶子/节点凑成
+// 偶数个（和比特币/以太坊常见的 Merkle 树处理方式一致）。
+//
+// 典型用法：bot 运营方提前发布明天的 Root，交易对手可以用 Proof(slug) 拿到
+// 一条 slug 的 inclusion proof，不需要下载完整的 schedule 就能验证这条 slug
+// 确实在当初承诺的集合里——配合 EIP712 签名可以把 Root 锚定到链上，做成可验证
+// 的 market-maker SLA。
+type Schedule struct {
+	Spec    MarketSpec
+	Entries []ScheduleEntry
+
+	// levels[0] 是叶子哈希，levels[len-1] 是只有一个元素的根。
+	levels [][]Hash
+}
+
+// NewSchedule 枚举 spec 在 [from, to] 内的每个周期，并构建它们的 Merkle 树。
+func NewSchedule(spec MarketSpec, from, to time.Time) (*Schedule, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("marketspec: schedule window 的 to (%s) 早于 from (%s)", to, from)
+	}
+
+	var entries []ScheduleEntry
+	for periodStartUnix := range spec.IterPeriods(from, to) {
+		entries = append(entries, ScheduleEntry{
+			PeriodStartUnix: periodStartUnix,
+			Slug:            spec.Slug(periodStartUnix),
+		})
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("marketspec: schedule window 内没有任何周期")
+	}
+
+	s := &Schedule{Spec: spec, Entries: entries}
+	s.build()
+	return s, nil
+}
+
+func leafHash(slug string, periodStartUnix int64) Hash {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(periodStartUnix))
+
+	h := sha256.New()
+	h.Write([]byte{leafDomain})
+	h.Write([]byte(slug))
+	h.Write(buf[:])
+
+	var out Hash
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+func nodeHash(left, right Hash) Hash {
+	h := sha256.New()
+	h.Write([]byte{nodeDomain})
+	h.Write(left[:])
+	h.Write(right[:])
+
+	var out Hash
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+func (s *Schedule) build() {
+	leaves := make([]Hash, len(s.Entries))
+	for i, e := range s.Entries {
+		leaves[i] = leafHash(e.Slug, e.PeriodStartUnix)
+	}
+
+	s.levels = [][]Hash{leaves}
+	level := leaves
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		next := make([]Hash, len(level)/2)
+		for i := range next {
+			next[i] = nodeHash(level[2*i], level[2*i+1])
+		}
+		s.levels = append(s.levels, next)
+		level = next
+	}
+}
+
+// Root 返回整棵 Merkle 树的根哈希。
+func (s *Schedule) Root() Hash {
+	top := s.levels[len(s.levels)-1]
+	return top[0]
+}
+
+func (s *Schedule) indexOf(slug string) (int, bool) {
+	for i, e := range s.Entries {
+		if e.Slug == slug {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Proof 返回 slug 的 inclusion proof：从叶子到根路径上每一层的兄弟哈希及其
+// 左右位置，按从叶子到根的顺序排列。slug 不在 s.Entries 里时返回错误。
+func (s *Schedule) Proof(slug string) ([]ProofStep, error) {
+	idx, ok := s.indexOf(slug)
+	if !ok {
+		return nil, fmt.Errorf("marketspec: slug %q 不在这个 schedule 里", slug)
+	}
+
+	proof := make([]ProofStep, 0, len(s.levels)-1)
+	for _, level := range s.levels[:len(s.levels)-1] {
+		siblingOnRight := idx%2 == 0
+		siblingIdx := idx ^ 1
+		if siblingIdx >= len(level) {
+			// build() 只在计算上一层时临时复制奇数层的最后一个元素，s.levels
+			// 里存的是未复制的层，所以奇数层的最后一个元素会落到这里：与自己
+			// 组合，和建树时一致。
+			siblingIdx = idx
+		}
+		proof = append(proof, ProofStep{Sibling: level[siblingIdx], SiblingOnRight: siblingOnRight})
+		idx /= 2
+	}
+	return proof, nil
+}
+
+// VerifyProof 验证 slug/periodStartUnix 经由 proof 确实归约到 root，不需要
+// 访问完整的 schedule。
+func VerifyProof(root Hash, slug string, periodStartUnix int64, proof []ProofStep) bool {
+	current := leafHash(slug, periodStartUnix)
+	for _, step := range proof {
+		if step.SiblingOnRight {
+			current = nodeHash(current, step.Sibling)
+		} else {
+			current = nodeHash(step.Sibling, current)
+		}
+	}
+	return current == root
+}
@@ -0,0 +1,278 @@
+package marketspec
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SlugScheme 把"周期起点 -> slug 字符串"及其逆过程抽象成一个可插拔接口，
+// 取代之前硬编码在 Slug/SlugPrefix/TimestampFromSlug 里的 timestamp/
+// polymarket_hourly_et 两种格式。新场所或第三方命名规则只需实现这个接口，
+// 用 RegisterSlugScheme 注册一个 SlugStyle 名字即可接入，不需要改动本包。
+type SlugScheme interface {
+	// Render 把 periodStartUnix 对应的周期渲染成 slug。
+	Render(spec MarketSpec, periodStartUnix int64) string
+	// Prefix 返回该 spec 下所有 slug 共享的稳定前缀（不含时间相关部分），
+	// 用于按前缀匹配/过滤候选 slug。
+	Prefix(spec MarketSpec) string
+	// Parse 尝试从 slug 反解出周期起点时间戳；slug 未命中该 scheme 格式时
+	// 返回 (0, false)。now 用于消解缺省年份等歧义。
+	Parse(slug string, now time.Time) (int64, bool)
+	// Location 返回该 scheme 对齐周期边界所使用的时区。
+	Location(spec MarketSpec) *time.Location
+}
+
+var (
+	slugSchemeMu       sync.RWMutex
+	slugSchemeRegistry = map[SlugStyle]SlugScheme{}
+)
+
+// RegisterSlugScheme 注册（或覆盖）一个具名 slug scheme，供 ParseSlugStyle/
+// Slug/SlugPrefix/TimestampFromSlug 使用。name 不区分大小写。内置的
+// timestamp/polymarket_hourly_et/daily_et/weekly_et/quarterly_et 已经在
+// init() 里注册好；调用方可以覆盖它们（比如换一套 coin 名称映射），也可以
+// 注册全新的名字接入非 Polymarket 场所。
+func RegisterSlugScheme(name string, s SlugScheme) {
+	style := SlugStyle(strings.ToLower(strings.TrimSpace(name)))
+	slugSchemeMu.Lock()
+	defer slugSchemeMu.Unlock()
+	slugSchemeRegistry[style] = s
+}
+
+func lookupSlugScheme(style SlugStyle) (SlugScheme, bool) {
+	slugSchemeMu.RLock()
+	defer slugSchemeMu.RUnlock()
+	s, ok := slugSchemeRegistry[style]
+	return s, ok
+}
+
+func init() {
+	RegisterSlugScheme(string(SlugStyleTimestamp), timestampSlugScheme{})
+	RegisterSlugScheme(string(SlugStylePolymarketHourlyET), &etUpDownSlugScheme{granularity: etGranularityHourly, coinNames: defaultCoinNames()})
+	RegisterSlugScheme(string(SlugStyleDailyET), &etUpDownSlugScheme{granularity: etGranularityDaily, coinNames: defaultCoinNames()})
+	RegisterSlugScheme(string(SlugStyleWeeklyET), &etUpDownSlugScheme{granularity: etGranularityWeekly, coinNames: defaultCoinNames()})
+	RegisterSlugScheme(string(SlugStyleQuarterlyET), &etUpDownSlugScheme{granularity: etGranularityQuarterly, coinNames: defaultCoinNames()})
+}
+
+// CoinNames 是 symbol（小写，比如 "btc"）到场所展示名（比如 "bitcoin"）的
+// 映射，供 etUpDownSlugScheme 这类按币种命名的 scheme 使用。未命中的
+// symbol 直接原样透传，这样新增币种不需要改动本包。
+type CoinNames map[string]string
+
+func defaultCoinNames() CoinNames {
+	return CoinNames{
+		"btc": "bitcoin",
+		"eth": "ethereum",
+		"sol": "solana",
+		"xrp": "xrp",
+	}
+}
+
+func (c CoinNames) resolve(symbol string) string {
+	symbol = strings.ToLower(strings.TrimSpace(symbol))
+	if name, ok := c[symbol]; ok {
+		return name
+	}
+	return symbol
+}
+
+// timestampSlugScheme 是默认格式：{symbol}-{kind}-{timeframe}-{periodStartUnix}。
+type timestampSlugScheme struct{}
+
+func (timestampSlugScheme) Render(spec MarketSpec, periodStartUnix int64) string {
+	return fmt.Sprintf("%s-%s-%s-%d", spec.Symbol, spec.Kind, spec.Timeframe.String(), periodStartUnix)
+}
+
+func (timestampSlugScheme) Prefix(spec MarketSpec) string {
+	return fmt.Sprintf("%s-%s-%s-", spec.Symbol, spec.Kind, spec.Timeframe.String())
+}
+
+func (timestampSlugScheme) Parse(slug string, _ time.Time) (int64, bool) {
+	i := strings.LastIndex(slug, "-")
+	if i < 0 || i+1 >= len(slug) {
+		return 0, false
+	}
+	ts, err := strconv.ParseInt(slug[i+1:], 10, 64)
+	if err != nil || ts <= 0 {
+		return 0, false
+	}
+	return ts, true
+}
+
+func (timestampSlugScheme) Location(spec MarketSpec) *time.Location {
+	return time.Local
+}
+
+// etGranularity 决定 etUpDownSlugScheme 渲染/解析 slug 时使用的时间粒度。
+type etGranularity int
+
+const (
+	etGranularityHourly etGranularity = iota
+	etGranularityDaily
+	etGranularityWeekly
+	etGranularityQuarterly
+)
+
+// etUpDownSlugScheme 渲染 Polymarket 风格的 "{coin}-up-or-down-...-et" slug
+// 族，粒度由 granularity 决定：
+//   - hourly:    {coin}-up-or-down-{month}-{day}-{hour}{am|pm}-et
+//   - daily:     {coin}-up-or-down-{month}-{day}-et
+//   - weekly:    {coin}-up-or-down-week-of-{month}-{day}-et（{month}-{day} 是该周周一）
+//   - quarterly: {coin}-up-or-down-q{quarter}-{year}-et
+//
+// coinNames 是每个 scheme 实例自己的币种映射配置，而不是包级变量，这样
+// 注册自定义 scheme 时可以带一套不同的 symbol->coin 映射（比如 doge->dogecoin）
+// 而不用改这个包。
+type etUpDownSlugScheme struct {
+	granularity etGranularity
+	coinNames   CoinNames
+}
+
+func (s *etUpDownSlugScheme) location() *time.Location {
+	if loc, err := time.LoadLocation("America/New_York"); err == nil {
+		return loc
+	}
+	// fallback：如果系统缺少 tzdata，则退回 local
+	return time.Local
+}
+
+func (s *etUpDownSlugScheme) Location(spec MarketSpec) *time.Location { return s.location() }
+
+func (s *etUpDownSlugScheme) Render(spec MarketSpec, periodStartUnix int64) string {
+	t := time.Unix(periodStartUnix, 0).In(s.location())
+	coin := s.coinNames.resolve(spec.Symbol)
+	month := strings.ToLower(t.Month().String())
+
+	switch s.granularity {
+	case etGranularityDaily:
+		return fmt.Sprintf("%s-up-or-down-%s-%d-et", coin, month, t.Day())
+	case etGranularityWeekly:
+		return fmt.Sprintf("%s-up-or-down-week-of-%s-%d-et", coin, month, t.Day())
+	case etGranularityQuarterly:
+		return fmt.Sprintf("%s-up-or-down-q%d-%d-et", coin, quarterOf(t.Month()), t.Year())
+	default: // etGranularityHourly
+		h12, ampm := to12Hour(t.Hour())
+		return fmt.Sprintf("%s-up-or-down-%s-%d-%d%s-et", coin, month, t.Day(), h12, ampm)
+	}
+}
+
+func (s *etUpDownSlugScheme) Prefix(spec MarketSpec) string {
+	coin := s.coinNames.resolve(spec.Symbol)
+	if s.granularity == etGranularityWeekly {
+		return fmt.Sprintf("%s-up-or-down-week-of-", coin)
+	}
+	return fmt.Sprintf("%s-up-or-down-", coin)
+}
+
+func (s *etUpDownSlugScheme) Parse(slug string, now time.Time) (int64, bool) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(slug)), "-")
+	if len(parts) < 2 || parts[len(parts)-1] != "et" {
+		return 0, false
+	}
+
+	loc := s.location()
+	nowET := now.In(loc)
+
+	switch s.granularity {
+	case etGranularityHourly:
+		// coin-up-or-down-month-day-hourToken-et
+		if len(parts) < 8 || parts[1] != "up" || parts[2] != "or" || parts[3] != "down" {
+			return 0, false
+		}
+		month, ok := parseMonthName(parts[4])
+		if !ok {
+			return 0, false
+		}
+		day, err := strconv.Atoi(parts[5])
+		if err != nil || day < 1 || day > 31 {
+			return 0, false
+		}
+		mm := hourTokenRe.FindStringSubmatch(parts[6])
+		if len(mm) != 3 {
+			return 0, false
+		}
+		hh, _ := strconv.Atoi(mm[1])
+		if hh < 1 || hh > 12 {
+			return 0, false
+		}
+		h24 := hh % 12
+		if mm[2] == "pm" {
+			h24 += 12
+		}
+		year := closestYear(nowET, month, day, h24)
+		return time.Date(year, month, day, h24, 0, 0, 0, loc).Unix(), true
+	case etGranularityDaily:
+		// coin-up-or-down-month-day-et
+		if len(parts) < 7 || parts[1] != "up" || parts[2] != "or" || parts[3] != "down" {
+			return 0, false
+		}
+		month, ok := parseMonthName(parts[4])
+		if !ok {
+			return 0, false
+		}
+		day, err := strconv.Atoi(parts[5])
+		if err != nil || day < 1 || day > 31 {
+			return 0, false
+		}
+		year := closestYear(nowET, month, day, 0)
+		return time.Date(year, month, day, 0, 0, 0, 0, loc).Unix(), true
+	case etGranularityWeekly:
+		// coin-up-or-down-week-of-month-day-et
+		if len(parts) < 9 || parts[1] != "up" || parts[2] != "or" || parts[3] != "down" || parts[4] != "week" || parts[5] != "of" {
+			return 0, false
+		}
+		month, ok := parseMonthName(parts[6])
+		if !ok {
+			return 0, false
+		}
+		day, err := strconv.Atoi(parts[7])
+		if err != nil || day < 1 || day > 31 {
+			return 0, false
+		}
+		year := closestYear(nowET, month, day, 0)
+		return startOfWeek(time.Date(year, month, day, 0, 0, 0, 0, loc), loc).Unix(), true
+	case etGranularityQuarterly:
+		// coin-up-or-down-qN-year-et
+		if len(parts) < 6 || parts[1] != "up" || parts[2] != "or" || parts[3] != "down" {
+			return 0, false
+		}
+		qm := quarterTokenRe.FindStringSubmatch(parts[4])
+		if len(qm) != 2 {
+			return 0, false
+		}
+		q, err := strconv.Atoi(qm[1])
+		if err != nil || q < 1 || q > 4 {
+			return 0, false
+		}
+		year, err := strconv.Atoi(parts[5])
+		if err != nil {
+			return 0, false
+		}
+		return time.Date(year, startMonthOfQuarter(q), 1, 0, 0, 0, 0, loc).Unix(), true
+	default:
+		return 0, false
+	}
+}
+
+func to12Hour(h int) (h12 int, ampm string) {
+	switch {
+	case h == 0:
+		return 12, "am"
+	case h < 12:
+		return h, "am"
+	case h == 12:
+		return 12, "pm"
+	default:
+		return h - 12, "pm"
+	}
+}
+
+func quarterOf(m time.Month) int { return (int(m)-1)/3 + 1 }
+
+func startMonthOfQuarter(q int) time.Month { return time.Month((q-1)*3 + 1) }
+
+var quarterTokenRe = regexp.MustCompile(`^q(\d)$`)
@@ -0,0 +1,350 @@
+// Package marketstream is the live runtime layer on top of pkg/marketspec's
+// slug/period arithmetic: given a MarketSpec, it keeps a WebSocket
+// subscription open on the current period's slug and transparently rolls
+// over to the next slug as each period closes, so a caller never has to
+// think about slug boundaries itself.
+package marketstream
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/betbot/gobet/clob/signing"
+	"github.com/betbot/gobet/clob/types"
+	"github.com/betbot/gobet/pkg/marketspec"
+	"github.com/betbot/gobet/pkg/sdk/api"
+)
+
+// rolloverLeadTime is how far ahead of a period boundary the next slug's
+// connection is opened, so there's no gap while the outgoing connection is
+// still being drained.
+const rolloverLeadTime = 30 * time.Second
+
+// resolutionPollInterval is how often a slug's market is re-fetched over
+// REST to detect resolution - the WS feed itself carries no "resolved"
+// event.
+const resolutionPollInterval = 15 * time.Second
+
+// EventKind selects which field of Event is populated.
+type EventKind string
+
+const (
+	EventTrade          EventKind = "trade"
+	EventOrderbookDelta EventKind = "orderbook_delta"
+	EventMarketResolved EventKind = "market_resolved"
+	EventPeriodRollover EventKind = "period_rollover"
+)
+
+// Trade is a detected trade on the current slug's market.
+type Trade struct {
+	Slug      string
+	AssetID   string
+	Price     float64
+	Timestamp time.Time
+}
+
+// OrderbookDelta is an incremental book update for the current slug's market.
+type OrderbookDelta struct {
+	Slug    string
+	AssetID string
+	Hash    string
+	Changes []api.WSBookChange
+}
+
+// MarketResolved fires once a slug's underlying market is observed closed.
+type MarketResolved struct {
+	Slug           string
+	ConditionID    string
+	WinningTokenID string
+}
+
+// PeriodRollover fires when the subscriber moves from one slug to the next,
+// before the outgoing slug's connection is torn down.
+type PeriodRollover struct {
+	FromSlug        string
+	ToSlug          string
+	PeriodStartUnix int64
+}
+
+// Event is a tagged union of everything Subscribe can deliver. Exactly one
+// of the typed fields is set, selected by Kind.
+type Event struct {
+	Kind           EventKind
+	Trade          *Trade
+	OrderbookDelta *OrderbookDelta
+	MarketResolved *MarketResolved
+	PeriodRollover *PeriodRollover
+}
+
+// Metrics is a point-in-time snapshot of a Subscription's health.
+type Metrics struct {
+	MessagesPerSec float64
+	Reconnects     int64
+	LastMessageGap time.Duration
+}
+
+// Signer holds key material used to put L2-authenticated headers on the WS
+// dial (see api.WSClient.SetAuthHeader), for venues that gate market
+// subscriptions behind API-key auth. Leave it zero for a public dial.
+type Signer struct {
+	PrivateKey *ecdsa.PrivateKey
+	Creds      *types.ApiKeyCreds
+}
+
+func (s Signer) header() (http.Header, error) {
+	if s.PrivateKey == nil || s.Creds == nil {
+		return nil, nil
+	}
+	args := &types.L2HeaderArgs{Method: "GET", RequestPath: "/ws/market"}
+	l2, err := signing.CreateL2Headers(s.PrivateKey, s.Creds, args, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build L2 auth header for market stream: %w", err)
+	}
+	h := http.Header{}
+	h.Set("POLY_ADDRESS", l2.PolyAddress)
+	h.Set("POLY_SIGNATURE", l2.PolySignature)
+	h.Set("POLY_TIMESTAMP", l2.PolyTimestamp)
+	h.Set("POLY_API_KEY", l2.PolyAPIKey)
+	h.Set("POLY_PASSPHRASE", l2.PolyPassphrase)
+	return h, nil
+}
+
+// Config controls a Subscribe call.
+type Config struct {
+	Client *api.ClobClient
+	Signer Signer
+}
+
+// Subscription is the live handle returned by Subscribe; call Stop to tear
+// down every connection it holds.
+type Subscription struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	messageCount int64
+	reconnects   int64
+
+	mu            sync.Mutex
+	windowStart   time.Time
+	lastMessageAt time.Time
+}
+
+// Stop tears down the Subscription's connections and waits for its
+// background goroutine to exit.
+func (s *Subscription) Stop() {
+	s.cancel()
+	<-s.done
+}
+
+// Metrics returns a snapshot of messages/sec (since Subscribe was called),
+// total reconnects across every slug this Subscription has streamed, and
+// time since the last message of any kind.
+func (s *Subscription) Metrics() Metrics {
+	s.mu.Lock()
+	elapsed := time.Since(s.windowStart).Seconds()
+	lastMessageAt := s.lastMessageAt
+	s.mu.Unlock()
+
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(atomic.LoadInt64(&s.messageCount)) / elapsed
+	}
+	return Metrics{
+		MessagesPerSec: rate,
+		Reconnects:     atomic.LoadInt64(&s.reconnects),
+		LastMessageGap: time.Since(lastMessageAt),
+	}
+}
+
+func (s *Subscription) recordMessage() {
+	atomic.AddInt64(&s.messageCount, 1)
+	s.mu.Lock()
+	s.lastMessageAt = time.Now()
+	s.mu.Unlock()
+}
+
+// Subscribe maintains a live subscription to spec's current-period slug,
+// delivering events to handler, and rolls over to the next slug at each
+// period boundary: it opens the next slug's connection rolloverLeadTime
+// early, gives the outgoing slug until its MarketResolved event (or the
+// period boundary, whichever comes first) to wind down, then closes it. The
+// returned Subscription must be Stop()ed by the caller.
+func Subscribe(ctx context.Context, cfg Config, spec marketspec.MarketSpec, handler func(Event)) (*Subscription, error) {
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("marketstream: Config.Client is required")
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("marketstream: handler is required")
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	now := time.Now()
+	sub := &Subscription{
+		cancel:        cancel,
+		done:          make(chan struct{}),
+		windowStart:   now,
+		lastMessageAt: now,
+	}
+
+	go sub.run(runCtx, cfg, spec, handler)
+	return sub, nil
+}
+
+func (s *Subscription) run(ctx context.Context, cfg Config, spec marketspec.MarketSpec, handler func(Event)) {
+	defer close(s.done)
+
+	periodStart := spec.CurrentPeriodStartUnix(time.Now())
+	current := s.openSlugStream(ctx, cfg, spec.Slug(periodStart), handler)
+
+	for {
+		periodEnd := spec.NextPeriodStartUnix(periodStart)
+		rolloverAt := time.Unix(periodEnd, 0).Add(-rolloverLeadTime)
+
+		select {
+		case <-ctx.Done():
+			current.stop()
+			return
+		case <-time.After(time.Until(rolloverAt)):
+		}
+
+		nextSlug := spec.Slug(periodEnd)
+		next := s.openSlugStream(ctx, cfg, nextSlug, handler)
+
+		handler(Event{Kind: EventPeriodRollover, PeriodRollover: &PeriodRollover{
+			FromSlug:        current.slug,
+			ToSlug:          nextSlug,
+			PeriodStartUnix: periodEnd,
+		}})
+
+		select {
+		case <-current.resolved:
+		case <-time.After(time.Until(time.Unix(periodEnd, 0))):
+		case <-ctx.Done():
+		}
+		current.stop()
+
+		current = next
+		periodStart = periodEnd
+	}
+}
+
+// slugStream is the live connection (and resolution watcher) for a single
+// slug within a Subscription's lifetime.
+type slugStream struct {
+	slug     string
+	ws       *api.WSClient
+	resolved chan struct{}
+	stopOnce sync.Once
+}
+
+func (s *slugStream) stop() {
+	s.stopOnce.Do(func() {
+		if s.ws != nil {
+			s.ws.Stop()
+		}
+	})
+}
+
+func (s *Subscription) openSlugStream(ctx context.Context, cfg Config, slug string, handler func(Event)) *slugStream {
+	stream := &slugStream{slug: slug, resolved: make(chan struct{})}
+
+	market, err := cfg.Client.GetMarketBySlug(ctx, slug)
+	if err != nil {
+		log.Printf("[marketstream] failed to resolve market for slug %s: %v", slug, err)
+		return stream
+	}
+
+	ws := api.NewWSClient(func(event api.WSTradeEvent) {
+		s.recordMessage()
+		handler(Event{Kind: EventTrade, Trade: &Trade{
+			Slug:      slug,
+			AssetID:   event.AssetID,
+			Price:     event.Price,
+			Timestamp: event.Timestamp,
+		}})
+	})
+	ws.SetBookUpdateHandler(func(assetID, hash string, changes []api.WSBookChange) {
+		s.recordMessage()
+		handler(Event{Kind: EventOrderbookDelta, OrderbookDelta: &OrderbookDelta{
+			Slug: slug, AssetID: assetID, Hash: hash, Changes: changes,
+		}})
+	})
+	ws.SetResyncHandler(func() {
+		atomic.AddInt64(&s.reconnects, 1)
+	})
+
+	if header, err := cfg.Signer.header(); err != nil {
+		log.Printf("[marketstream] %v", err)
+	} else if header != nil {
+		ws.SetAuthHeader(header)
+	}
+
+	stream.ws = ws
+
+	if err := ws.Start(ctx); err != nil {
+		log.Printf("[marketstream] failed to start stream for slug %s: %v", slug, err)
+		return stream
+	}
+
+	assetIDs := make([]string, 0, len(market.Tokens))
+	for _, tok := range market.Tokens {
+		assetIDs = append(assetIDs, tok.TokenID)
+	}
+	if len(assetIDs) > 0 {
+		if err := ws.Subscribe(assetIDs...); err != nil {
+			log.Printf("[marketstream] failed to subscribe slug %s: %v", slug, err)
+		}
+	}
+
+	go s.pollResolution(ctx, cfg, stream, market.ConditionID, handler)
+
+	return stream
+}
+
+// pollResolution polls conditionID over REST until the market is closed,
+// then emits a MarketResolved event and signals stream.resolved so the
+// rollover loop doesn't have to wait out the full period boundary.
+func (s *Subscription) pollResolution(ctx context.Context, cfg Config, stream *slugStream, conditionID string, handler func(Event)) {
+	if conditionID == "" {
+		return
+	}
+
+	ticker := time.NewTicker(resolutionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stream.resolved:
+			return
+		case <-ticker.C:
+			market, err := cfg.Client.GetMarket(ctx, conditionID)
+			if err != nil || market == nil || !market.Closed {
+				continue
+			}
+
+			winningTokenID := ""
+			for _, tok := range market.Tokens {
+				if tok.Winner {
+					winningTokenID = tok.TokenID
+					break
+				}
+			}
+
+			handler(Event{Kind: EventMarketResolved, MarketResolved: &MarketResolved{
+				Slug:           stream.slug,
+				ConditionID:    conditionID,
+				WinningTokenID: winningTokenID,
+			}})
+			close(stream.resolved)
+			return
+		}
+	}
+}
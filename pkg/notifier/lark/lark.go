@@ -0,0 +1,258 @@
+// Package lark 实现一个推送到飞书（Lark）自定义机器人 webhook 的通知器，
+// 供 pkg/sdk/websocket 的 Config.Notifier 字段以及 CLOB 下单流程使用。
+//
+// 与 internal/notifier 里那个只服务 controlplane job run 的飞书实现不同，
+// 这里面向的是高频的 WS 成交/订单生命周期事件：同一批时间窗口内按
+// AssetID+EventType 去重合并，避免连续多笔成交把机器人刷屏；遇到飞书的
+// 限流响应（errcode 19999）时按指数退避重试。
+package lark
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimitErrCode 是飞书自定义机器人触发限流时返回的 errcode。
+const rateLimitErrCode = 19999
+
+// Event 是喂给 Notifier 的一条待通知事件，独立于调用方的具体类型
+// （pkg/sdk/websocket.NotifyEvent、下单回执等），调用方自己适配过来。
+type Event struct {
+	EventType string // 例如 "last_trade_price"、"trade"、"order"、"reconnect"、"conn_error"
+	AssetID   string
+	Market    string
+	Price     string
+	Message   string
+	Time      time.Time
+}
+
+// key 是 Notifier 用来去重合并的维度：同一个 (AssetID, EventType) 在一个
+// BatchWindow 内只保留最后一条。
+func (e Event) key() string { return e.AssetID + "|" + e.EventType }
+
+// Config 配置一个 Notifier。
+type Config struct {
+	// WebhookURL 飞书自定义机器人的 webhook 地址，必填。
+	WebhookURL string
+
+	// Secret 机器人的签名校验密钥（可选）；配置了就会按飞书签名规则
+	// （HMAC-SHA256("<timestamp>\n<secret>") 再 base64）附带 timestamp/sign。
+	Secret string
+
+	// BatchWindow 事件攒批窗口，默认 500ms。窗口内同一 (AssetID, EventType)
+	// 的多条事件只会发送最后一条。
+	BatchWindow time.Duration
+
+	// Templates 按 EventType 渲染文本的模板，使用 fmt.Sprintf 风格的占位符，
+	// 依次传入 event.EventType、event.AssetID、event.Market、event.Price、event.Message。
+	// 未命中的 EventType 使用 defaultTemplate。
+	Templates map[string]string
+
+	// MaxRetries 单条消息最大重试次数（命中限流/发送失败时），默认 3。
+	MaxRetries int
+
+	// RetryBaseDelay 指数退避的基础延迟，默认 500ms：第 n 次重试等待
+	// RetryBaseDelay * 2^(n-1)。
+	RetryBaseDelay time.Duration
+}
+
+const defaultTemplate = "[%s] asset=%s market=%s price=%s %s"
+
+// Notifier 按 Config 攒批、去重并推送事件到飞书 webhook。
+type Notifier struct {
+	cfg Config
+
+	mu      sync.Mutex
+	pending map[string]Event
+
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+
+	httpClient *http.Client
+}
+
+// New 创建一个 Notifier 并启动后台攒批 goroutine。
+func New(cfg Config) *Notifier {
+	if cfg.BatchWindow <= 0 {
+		cfg.BatchWindow = 500 * time.Millisecond
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.RetryBaseDelay <= 0 {
+		cfg.RetryBaseDelay = 500 * time.Millisecond
+	}
+
+	n := &Notifier{
+		cfg:        cfg,
+		pending:    make(map[string]Event),
+		flushCh:    make(chan struct{}, 1),
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	go n.loop()
+	return n
+}
+
+// Notify 把一条事件放进攒批队列；按 (AssetID, EventType) 去重，同一窗口内
+// 新事件覆盖旧事件。非阻塞。
+func (n *Notifier) Notify(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	n.mu.Lock()
+	n.pending[event.key()] = event
+	n.mu.Unlock()
+
+	select {
+	case n.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// Stop 停止后台 goroutine，尝试把还没发出去的事件做最后一次 flush。
+func (n *Notifier) Stop() {
+	close(n.stopCh)
+	<-n.doneCh
+}
+
+func (n *Notifier) loop() {
+	defer close(n.doneCh)
+	ticker := time.NewTicker(n.cfg.BatchWindow)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.stopCh:
+			n.flush()
+			return
+		case <-ticker.C:
+			n.flush()
+		case <-n.flushCh:
+			// 仅作为“有新事件”的唤醒信号，真正攒批仍以 ticker 节奏为准，
+			// 避免高频成交把请求打爆飞书。
+		}
+	}
+}
+
+func (n *Notifier) flush() {
+	n.mu.Lock()
+	if len(n.pending) == 0 {
+		n.mu.Unlock()
+		return
+	}
+	events := make([]Event, 0, len(n.pending))
+	for _, e := range n.pending {
+		events = append(events, e)
+	}
+	n.pending = make(map[string]Event)
+	n.mu.Unlock()
+
+	for _, e := range events {
+		n.send(e)
+	}
+}
+
+func (n *Notifier) send(event Event) {
+	text := n.renderText(event)
+
+	var lastErr error
+	for attempt := 0; attempt <= n.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := n.cfg.RetryBaseDelay * time.Duration(1<<uint(attempt-1))
+			time.Sleep(delay)
+		}
+
+		rateLimited, err := n.post(text)
+		if err == nil {
+			return
+		}
+		lastErr = err
+		if !rateLimited {
+			// 非限流的失败（网络错误等）也按同样的退避重试，直到用完重试次数。
+			continue
+		}
+	}
+	if lastErr != nil {
+		fmt.Printf("[lark] 推送通知失败（已重试 %d 次）：%v\n", n.cfg.MaxRetries, lastErr)
+	}
+}
+
+// post 发送一条文本消息，返回 (是否命中飞书限流, error)。
+func (n *Notifier) post(text string) (bool, error) {
+	ts := time.Now().Unix()
+	payload := map[string]any{
+		"msg_type": "text",
+		"content":  map[string]any{"text": text},
+	}
+	if n.cfg.Secret != "" {
+		payload["timestamp"] = strconv.FormatInt(ts, 10)
+		payload["sign"] = sign(ts, n.cfg.Secret)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 300 {
+		return false, fmt.Errorf("lark webhook 返回 status=%d body=%s", resp.StatusCode, respBody)
+	}
+
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(respBody, &result); err == nil && result.Code != 0 {
+		if result.Code == rateLimitErrCode {
+			return true, fmt.Errorf("lark webhook 限流: %s", result.Msg)
+		}
+		return false, fmt.Errorf("lark webhook 返回错误 code=%d msg=%s", result.Code, result.Msg)
+	}
+
+	return false, nil
+}
+
+func (n *Notifier) renderText(event Event) string {
+	tmpl := defaultTemplate
+	if n.cfg.Templates != nil {
+		if t, ok := n.cfg.Templates[event.EventType]; ok {
+			tmpl = t
+		}
+	}
+	return fmt.Sprintf(tmpl, event.EventType, event.AssetID, event.Market, event.Price, event.Message)
+}
+
+// sign 按飞书自定义机器人签名校验规则计算 sign 字段：把 "<timestamp>\n<secret>"
+// 当作 HmacSHA256 的 key，对空字节串计算 HMAC，再 base64 编码。
+func sign(timestamp int64, secret string) string {
+	key := fmt.Sprintf("%d\n%s", timestamp, secret)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(nil)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,34 @@
+package lark
+
+import "github.com/sirupsen/logrus"
+
+// LogrusHook 把 logrus 的 Warn/Error 日志转成 Event 喂给 Notifier，用来让
+// WS 重连逻辑里散落的 log.Printf 警告/错误最终也能流向同一个飞书通知通道。
+type LogrusHook struct {
+	notifier *Notifier
+}
+
+// NewLogrusHook 创建一个 logrus.Hook，注册到 logrus 实例后，Warn 和 Error 级别
+// 的日志会被转发给 n（Panic/Fatal 不转发）。
+func NewLogrusHook(n *Notifier) *LogrusHook {
+	return &LogrusHook{notifier: n}
+}
+
+// Levels 实现 logrus.Hook：只转发 Warn 和 Error 两级，避免把 Info/Debug 噪音也推过去。
+func (h *LogrusHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.ErrorLevel, logrus.WarnLevel}
+}
+
+// Fire 实现 logrus.Hook。
+func (h *LogrusHook) Fire(entry *logrus.Entry) error {
+	eventType := "warn"
+	if entry.Level == logrus.ErrorLevel {
+		eventType = "error"
+	}
+	h.notifier.Notify(Event{
+		EventType: eventType,
+		Message:   entry.Message,
+		Time:      entry.Time,
+	})
+	return nil
+}
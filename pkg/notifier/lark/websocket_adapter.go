@@ -0,0 +1,30 @@
+package lark
+
+import "github.com/betbot/gobet/pkg/sdk/websocket"
+
+// WebSocketAdapter 把 Notifier 适配成 websocket.Notifier，可以直接赋给
+// websocket.Config.Notifier：
+//
+//	n := lark.New(lark.Config{WebhookURL: url, Secret: secret})
+//	cfg := websocket.DefaultConfig()
+//	cfg.Notifier = lark.NewWebSocketAdapter(n)
+type WebSocketAdapter struct {
+	notifier *Notifier
+}
+
+// NewWebSocketAdapter 包装 n，使其满足 websocket.Notifier 接口。
+func NewWebSocketAdapter(n *Notifier) *WebSocketAdapter {
+	return &WebSocketAdapter{notifier: n}
+}
+
+// Notify 实现 websocket.Notifier。
+func (a *WebSocketAdapter) Notify(event websocket.NotifyEvent) {
+	a.notifier.Notify(Event{
+		EventType: string(event.EventType),
+		AssetID:   event.AssetID,
+		Market:    event.Market,
+		Price:     event.Price,
+		Message:   event.Message,
+		Time:      event.Time,
+	})
+}
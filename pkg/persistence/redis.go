@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/betbot/gobet/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisService 基于 Redis 的持久化服务，用于多实例/多进程共享状态的场景（例如同一
+// 策略的回测/实盘进程切换，或运维需要跨机器查看某个 store 的最新快照）。
+// 与 JSONFileService 实现同一个 Service/Store 接口，调用方（如
+// unifiedarb.Strategy）不需要区分底层用的是文件还是 Redis。
+type RedisService struct {
+	client *redis.Client
+	prefix string // key 前缀，避免与其他用途的 Redis key 冲突
+	ttl    time.Duration
+}
+
+// NewRedisService 创建 Redis 持久化服务。prefix 建议按部署环境区分（如
+// "gobet:prod"），ttl<=0 表示 key 永不过期。
+func NewRedisService(client *redis.Client, prefix string, ttl time.Duration) *RedisService {
+	return &RedisService{client: client, prefix: prefix, ttl: ttl}
+}
+
+// NewStore 创建新的存储
+func (s *RedisService) NewStore(prefix, id, tag string) Store {
+	key := fmt.Sprintf("%s:%s:%s:%s", s.prefix, prefix, id, tag)
+	return &RedisStore{service: s, key: key}
+}
+
+// RedisStore Redis 存储实现：每个 key 对应一个 JSON 序列化后的值。
+type RedisStore struct {
+	service *RedisService
+	key     string
+}
+
+// Save 保存数据
+func (s *RedisStore) Save(data interface{}) error {
+	logger.Debugf("[persistence] Redis Save: key=%s", s.key)
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.service.client.Set(ctx, s.key, b, s.service.ttl).Err()
+}
+
+// Load 加载数据
+func (s *RedisStore) Load(data interface{}) error {
+	logger.Debugf("[persistence] Redis Load: key=%s", s.key)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	b, err := s.service.client.Get(ctx, s.key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return ErrNotExists
+		}
+		return err
+	}
+	if len(b) == 0 {
+		return ErrNotExists
+	}
+	return json.Unmarshal(b, data)
+}
@@ -1,7 +1,7 @@
 package api
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"os"
@@ -16,10 +16,13 @@ import (
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
-// Auth handles Polymarket L1 authentication with EIP-712 signing
+// Auth handles Polymarket L1 authentication with EIP-712 signing. It signs
+// through a Signer rather than holding a private key directly, so the same
+// Auth works whether the key lives in this process (LocalSigner) or in an
+// external signer (RemoteSigner).
 type Auth struct {
-	privateKey *ecdsa.PrivateKey
-	address    common.Address
+	signer  Signer
+	address common.Address
 }
 
 // NewAuth creates a new auth instance from the default POLYMARKET_PRIVATE_KEY env var
@@ -36,7 +39,8 @@ func NewAuthFromEnvVar(envVarName string) (*Auth, error) {
 	return NewAuthFromKey(privateKeyStr)
 }
 
-// NewAuthFromKey creates a new auth instance from a private key string
+// NewAuthFromKey creates a new auth instance from a private key string,
+// signing in-process via a LocalSigner.
 func NewAuthFromKey(privateKeyStr string) (*Auth, error) {
 	privateKeyStr = strings.TrimSpace(privateKeyStr)
 	if privateKeyStr == "" {
@@ -60,18 +64,17 @@ func NewAuthFromKey(privateKeyStr string) (*Auth, error) {
 		return nil, fmt.Errorf("failed to parse private key: %w", err)
 	}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("failed to cast public key to ECDSA")
-	}
-
-	address := crypto.PubkeyToAddress(*publicKeyECDSA)
+	return NewAuthFromSigner(NewLocalSigner(privateKey)), nil
+}
 
+// NewAuthFromSigner wraps an arbitrary Signer in an Auth. Use this to trade
+// with a key that never enters this process - e.g. a RemoteSigner talking to
+// a Clef instance, an HSM, or a Ledger-backed signing daemon.
+func NewAuthFromSigner(signer Signer) *Auth {
 	return &Auth{
-		privateKey: privateKey,
-		address:    address,
-	}, nil
+		signer:  signer,
+		address: signer.Address(),
+	}
 }
 
 // GetAddress returns the Ethereum address derived from the private key
@@ -80,7 +83,7 @@ func (a *Auth) GetAddress() common.Address {
 }
 
 // SignRequest creates L1 authentication headers for Polymarket API
-func (a *Auth) SignRequest() (map[string]string, error) {
+func (a *Auth) SignRequest(ctx context.Context) (map[string]string, error) {
 	timestamp := time.Now().Unix()
 	nonce := int64(0)
 
@@ -119,28 +122,11 @@ func (a *Auth) SignRequest() (map[string]string, error) {
 		Message:     message,
 	}
 
-	// Sign the typed data using go-ethereum's signer
-	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash domain: %w", err)
-	}
-
-	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash message: %w", err)
-	}
-
-	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
-	hash := crypto.Keccak256Hash(rawData)
-
-	signature, err := crypto.Sign(hash.Bytes(), a.privateKey)
+	signature, err := a.signer.SignTypedData(ctx, typedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign: %w", err)
 	}
 
-	// Adjust v value (recovery ID)
-	signature[64] += 27
-
 	headers := map[string]string{
 		"POLY_ADDRESS":   a.address.Hex(),
 		"POLY_SIGNATURE": "0x" + hex.EncodeToString(signature),
@@ -152,10 +138,18 @@ func (a *Auth) SignRequest() (map[string]string, error) {
 	return headers, nil
 }
 
-// SignMessage signs a simple message (alternative method)
+// SignMessage signs a simple personal message (non-typed-data). Only
+// supported when the underlying signer is a LocalSigner; Auth instances
+// backed by a RemoteSigner return an error, since plain personal_sign isn't
+// part of the Signer interface's typed-data contract.
 func (a *Auth) SignMessage(message string) (string, error) {
+	local, ok := a.signer.(*LocalSigner)
+	if !ok {
+		return "", fmt.Errorf("SignMessage is not supported by signer type %T", a.signer)
+	}
+
 	hash := accounts.TextHash([]byte(message))
-	signature, err := crypto.Sign(hash, a.privateKey)
+	signature, err := crypto.Sign(hash, local.privateKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign message: %w", err)
 	}
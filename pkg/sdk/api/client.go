@@ -356,7 +356,7 @@ func (c *Client) doRequest(ctx context.Context, method, baseURL, path string, qu
 		}
 
 		if useAuth && c.UseAuth && c.auth != nil {
-			headers, err := c.auth.SignRequest()
+			headers, err := c.auth.SignRequest(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to sign request: %w", err)
 			}
@@ -56,7 +56,6 @@ package api
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
@@ -71,11 +70,11 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
@@ -88,6 +87,11 @@ type ClobClient struct {
 	chainID       int64
 	funder        common.Address
 	signatureType int // 0=EOA, 1=Magic/Email, 2=Browser proxy
+
+	// limiters holds the per-endpoint-class token buckets used by
+	// doWithRetry; built lazily by limiterFor.
+	limiterMu sync.Mutex
+	limiters  map[endpointClass]*tokenBucket
 }
 
 // APICreds holds API credentials for CLOB
@@ -289,7 +293,7 @@ func (c *ClobClient) DeriveAPICreds(ctx context.Context) (*APICreds, error) {
 
 func (c *ClobClient) deleteAPICreds(ctx context.Context) {
 	// Get L1 authentication headers
-	headers, err := c.auth.SignRequest()
+	headers, err := c.auth.SignRequest(ctx)
 	if err != nil {
 		return
 	}
@@ -317,7 +321,7 @@ func (c *ClobClient) deleteAPICreds(ctx context.Context) {
 
 func (c *ClobClient) deriveAPICreds(ctx context.Context) (*APICreds, error) {
 	// Get L1 authentication headers
-	headers, err := c.auth.SignRequest()
+	headers, err := c.auth.SignRequest(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign request: %w", err)
 	}
@@ -353,7 +357,7 @@ func (c *ClobClient) deriveAPICreds(ctx context.Context) (*APICreds, error) {
 
 func (c *ClobClient) createAPICreds(ctx context.Context) (*APICreds, error) {
 	// Get L1 authentication headers
-	headers, err := c.auth.SignRequest()
+	headers, err := c.auth.SignRequest(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign request: %w", err)
 	}
@@ -860,69 +864,36 @@ func (c *ClobClient) GetCLOBTrades(ctx context.Context, params CLOBTradeParams)
 
 // PlaceMarketOrder places a market order (FOK - Fill-Or-Kill)
 func (c *ClobClient) PlaceMarketOrder(ctx context.Context, tokenID string, side Side, amountUSDC float64, negRisk bool) (*OrderResponse, error) {
+	return c.PlaceMarketOrderWithSlippageGuard(ctx, tokenID, side, amountUSDC, negRisk, 0)
+}
+
+// PlaceMarketOrderWithSlippageGuard is PlaceMarketOrder with an optional
+// maximum slippage guard. When maxSlippageBps > 0, the order is aborted
+// before signing if EstimateForUSDC's PriceImpactBps exceeds it - this lets a
+// copy-trader reject an unfavorable mirror trade instead of eating the
+// leader's slippage.
+func (c *ClobClient) PlaceMarketOrderWithSlippageGuard(ctx context.Context, tokenID string, side Side, amountUSDC float64, negRisk bool, maxSlippageBps int) (*OrderResponse, error) {
 	if c.apiCreds == nil {
 		if _, err := c.DeriveAPICreds(ctx); err != nil {
 			return nil, fmt.Errorf("failed to get API creds: %w", err)
 		}
 	}
 
-	// Get order book to calculate optimal price
-	book, err := c.GetOrderBook(ctx, tokenID)
+	// Walk the order book (via EstimateForUSDC) rather than inlining the walk here
+	estimate, err := c.EstimateForUSDC(ctx, tokenID, side, amountUSDC)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get order book: %w", err)
-	}
-
-	// Calculate the average price we'll get based on order book depth
-	var levels []OrderBookLevel
-	if side == SideBuy {
-		levels = book.Asks // Buy from asks
-	} else {
-		levels = book.Bids // Sell to bids
-	}
-
-	if len(levels) == 0 {
-		return nil, fmt.Errorf("no liquidity in order book for %s side", side)
-	}
-
-	// Calculate how much we can buy/sell and at what average price
-	remainingUSDC := amountUSDC
-	totalSize := 0.0
-	totalCost := 0.0
-
-	for _, level := range levels {
-		price, _ := strconv.ParseFloat(level.Price, 64)
-		size, _ := strconv.ParseFloat(level.Size, 64)
-
-		levelValue := size * price
-		if levelValue <= remainingUSDC {
-			totalSize += size
-			totalCost += levelValue
-			remainingUSDC -= levelValue
-		} else {
-			// Partial fill at this level
-			fillSize := remainingUSDC / price
-			totalSize += fillSize
-			totalCost += remainingUSDC
-			remainingUSDC = 0
-			break
-		}
-
-		if remainingUSDC <= 0 {
-			break
-		}
+		return nil, err
 	}
 
-	if totalSize == 0 {
-		return nil, fmt.Errorf("cannot fill order: insufficient liquidity")
+	if maxSlippageBps > 0 && estimate.PriceImpactBps > maxSlippageBps {
+		return nil, fmt.Errorf("estimated price impact %d bps exceeds max slippage %d bps, order aborted", estimate.PriceImpactBps, maxSlippageBps)
 	}
 
-	avgPrice := totalCost / totalSize
-
-	log.Printf("[CLOB] Market order: %s %.4f USDC worth of tokens at avg price %.4f (size: %.4f)",
-		side, amountUSDC-remainingUSDC, avgPrice, totalSize)
+	log.Printf("[CLOB] Market order: %s %.4f USDC worth of tokens at avg price %.4f (size: %.4f, impact=%dbps)",
+		side, estimate.TotalUSDC, estimate.ExpectedAvgPrice, estimate.FillableSize, estimate.PriceImpactBps)
 
 	// Create and sign the order
-	order, err := c.createSignedOrder(tokenID, side, totalSize, avgPrice, negRisk)
+	order, err := c.createSignedOrder(ctx, tokenID, side, estimate.FillableSize, estimate.ExpectedAvgPrice, negRisk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signed order: %w", err)
 	}
@@ -939,7 +910,7 @@ func (c *ClobClient) PlaceLimitOrder(ctx context.Context, tokenID string, side S
 		}
 	}
 
-	order, err := c.createSignedOrder(tokenID, side, size, price, negRisk)
+	order, err := c.createSignedOrder(ctx, tokenID, side, size, price, negRisk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signed order: %w", err)
 	}
@@ -956,7 +927,7 @@ func (c *ClobClient) PlaceOrderFOK(ctx context.Context, tokenID string, side Sid
 		}
 	}
 
-	order, err := c.createSignedOrder(tokenID, side, size, price, negRisk)
+	order, err := c.createSignedOrder(ctx, tokenID, side, size, price, negRisk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signed order: %w", err)
 	}
@@ -1028,7 +999,7 @@ func (c *ClobClient) PlaceOrderFast(ctx context.Context, tokenID string, side Si
 		}
 
 		signStart := time.Now()
-		order, err := c.createSignedOrder(tokenID, side, trySize, price, negRisk)
+		order, err := c.createSignedOrder(ctx, tokenID, side, trySize, price, negRisk)
 		signMs := time.Since(signStart).Milliseconds()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create order: %w", err)
@@ -1097,7 +1068,7 @@ func (c *ClobClient) PlaceOrderFAK(ctx context.Context, tokenID string, side Sid
 
 	// Use FOK-compatible precision (2 decimal maker amount)
 	// FAK has same precision requirements as FOK
-	order, err := c.createSignedOrderFOK(tokenID, side, size, price, negRisk)
+	order, err := c.createSignedOrderFOK(ctx, tokenID, side, size, price, negRisk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -1107,7 +1078,7 @@ func (c *ClobClient) PlaceOrderFAK(ctx context.Context, tokenID string, side Sid
 
 // createSignedOrderFOK creates an order with FOK-compatible precision
 // FOK requires: maker amount (USDC for buy) = 2 decimals, taker amount (tokens) = 4 decimals
-func (c *ClobClient) createSignedOrderFOK(tokenID string, side Side, size float64, price float64, negRisk bool) (*Order, error) {
+func (c *ClobClient) createSignedOrderFOK(ctx context.Context, tokenID string, side Side, size float64, price float64, negRisk bool) (*Order, error) {
 	// Round price to tick size (0.01 for most markets)
 	tickSize := 0.01
 	price = float64(int(price/tickSize+0.5)) * tickSize
@@ -1185,7 +1156,7 @@ func (c *ClobClient) createSignedOrderFOK(tokenID string, side Side, size float6
 	}
 
 	// Sign the order using EIP-712
-	sig, err := c.signOrder(order, negRisk)
+	sig, err := c.signOrder(ctx, order, negRisk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign order: %w", err)
 	}
@@ -1230,7 +1201,7 @@ func (c *ClobClient) createSignedOrderFOK(tokenID string, side Side, size float6
 //   - Tokens: 2 decimal precision in 6-decimal format
 //
 // =============================================================================
-func (c *ClobClient) createSignedOrder(tokenID string, side Side, size float64, price float64, negRisk bool) (*Order, error) {
+func (c *ClobClient) createSignedOrder(ctx context.Context, tokenID string, side Side, size float64, price float64, negRisk bool) (*Order, error) {
 	// Round price to tick size (0.01 for most markets)
 	tickSize := 0.01
 	price = float64(int(price/tickSize+0.5)) * tickSize
@@ -1348,7 +1319,7 @@ func (c *ClobClient) createSignedOrder(tokenID string, side Side, size float64,
 	}
 
 	// Sign the order using EIP-712
-	signature, err := c.signOrder(order, negRisk)
+	signature, err := c.signOrder(ctx, order, negRisk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign order: %w", err)
 	}
@@ -1357,7 +1328,7 @@ func (c *ClobClient) createSignedOrder(tokenID string, side Side, size float64,
 	return order, nil
 }
 
-func (c *ClobClient) signOrder(order *Order, negRisk bool) (string, error) {
+func (c *ClobClient) signOrder(ctx context.Context, order *Order, negRisk bool) (string, error) {
 	// Choose the correct contract based on market type
 	// - NegRiskCTFExchange: 0xC5d563A36AE78145C45a50134d48A1215220f80a (for neg_risk markets)
 	// - CTFExchange: 0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E (for regular markets)
@@ -1443,20 +1414,11 @@ func (c *ClobClient) signOrder(order *Order, negRisk bool) (string, error) {
 		Message:     message,
 	}
 
-	// Hash the typed data using EIP-712
-	hash, _, err := apitypes.TypedDataAndHash(typedData)
-	if err != nil {
-		return "", fmt.Errorf("failed to hash typed data: %w", err)
-	}
-
-	signature, err := crypto.Sign(hash, c.auth.privateKey)
+	signature, err := c.auth.signer.SignTypedData(ctx, typedData)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign: %w", err)
 	}
 
-	// Adjust v value
-	signature[64] += 27
-
 	return "0x" + hex.EncodeToString(signature), nil
 }
 
@@ -1472,32 +1434,31 @@ func (c *ClobClient) postOrder(ctx context.Context, order *Order, orderType Orde
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/order", bytes.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
+	// Placing an order is not idempotent (replaying it double-submits), so
+	// it gets exactly one attempt through doWithRetry's rate limiter.
+	_, respBody, err := c.doWithRetry(ctx, classOrders, false, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/order", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
 
-	// Add browser-like headers to avoid Cloudflare blocking
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Origin", "https://polymarket.com")
-	req.Header.Set("Referer", "https://polymarket.com/")
+		// Add browser-like headers to avoid Cloudflare blocking
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+		req.Header.Set("Origin", "https://polymarket.com")
+		req.Header.Set("Referer", "https://polymarket.com/")
 
-	// Add L2 headers
-	c.addL2Headers(req)
-
-	resp, err := c.httpClient.Do(req)
+		// Add L2 headers
+		c.addL2Headers(req)
+		return req, nil
+	})
 	if err != nil {
+		if apiErr, ok := err.(*APIError); ok {
+			return nil, fmt.Errorf("post order failed: %w", apiErr)
+		}
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	respBody, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("post order failed: %d %s", resp.StatusCode, string(respBody))
-	}
 
 	var orderResp OrderResponse
 	if err := json.Unmarshal(respBody, &orderResp); err != nil {
@@ -1562,11 +1523,6 @@ func generateSalt() int64 {
 	return time.Now().UnixNano() % 1000000000
 }
 
-// GetPrivateKey returns the private key (needed for signing)
-func (a *Auth) GetPrivateKey() *ecdsa.PrivateKey {
-	return a.privateKey
-}
-
 // CalculateOptimalFill calculates how much can be bought/sold from order book
 func CalculateOptimalFill(book *OrderBook, side Side, amountUSDC float64) (totalSize float64, avgPrice float64, filledUSDC float64) {
 	var levels []OrderBookLevel
@@ -1642,29 +1598,27 @@ func (c *ClobClient) GetOrder(ctx context.Context, orderID string) (*OpenOrder,
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/data/order/"+orderID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Add browser-like headers
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
+	// GetOrder is a read, so it's safe to retry on 429/5xx.
+	_, respBody, err := c.doWithRetry(ctx, classData, true, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/data/order/"+orderID, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	// Add L2 headers
-	c.addL2Headers(req)
+		// Add browser-like headers
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+		req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+		// Add L2 headers
+		c.addL2Headers(req)
+		return req, nil
+	})
 	if err != nil {
+		if apiErr, ok := err.(*APIError); ok {
+			return nil, fmt.Errorf("get order failed: %w", apiErr)
+		}
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	respBody, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get order failed: %d %s", resp.StatusCode, string(respBody))
-	}
 
 	// Debug: log raw response to see actual field names
 	log.Printf("[GetOrder] raw response: %s", string(respBody))
@@ -1686,33 +1640,31 @@ func (c *ClobClient) CancelOrder(ctx context.Context, orderID string) error {
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/order/"+orderID, nil)
-	if err != nil {
-		return err
-	}
-
-	// Add browser-like headers
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-
-	// Add L2 headers
-	c.addL2Headers(req)
+	// Cancel is idempotent - a 404 means the order is already gone, which is
+	// exactly the outcome a retry after a network blip is trying to reach -
+	// so it's safe to retry on 429/5xx like any other read.
+	_, _, err := c.doWithRetry(ctx, classCancels, true, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/order/"+orderID, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+		// Add browser-like headers
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+		req.Header.Set("Accept", "application/json")
 
-	respBody, _ := io.ReadAll(resp.Body)
+		// Add L2 headers
+		c.addL2Headers(req)
+		return req, nil
+	})
 
 	// 200, 204 = success; 404 = order already filled/cancelled (treat as success)
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		return fmt.Errorf("cancel order failed: %d %s", resp.StatusCode, string(respBody))
-	}
-
-	if resp.StatusCode == http.StatusNotFound {
+	if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound {
 		log.Printf("[CancelOrder] order %s not found (already filled/cancelled)", orderID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cancel order failed: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// BATCH ORDER PLACEMENT
+// =============================================================================
+//
+// Copy-trading a leader who enters several legs at once, or laying down a
+// market-making grid (30+ layered orders), pays N×latency for signing+HTTP if
+// done sequentially. BatchPlaceOrders fans requests out across a small worker
+// pool so signing and posting happen concurrently, while still reusing the
+// single DeriveAPICreds call already cached on the client.
+// =============================================================================
+
+// batchWorkerPoolSize bounds how many orders are signed/posted concurrently.
+const batchWorkerPoolSize = 8
+
+// BatchOrderRequest is a single order within a batch placement call.
+type BatchOrderRequest struct {
+	TokenID string
+	Side    Side
+	Size    float64
+	Price   float64
+	NegRisk bool
+}
+
+// BatchOrderResult pairs a BatchOrderRequest with its outcome. Results are
+// returned in the same order as the input requests.
+type BatchOrderResult struct {
+	Request  BatchOrderRequest
+	Response *OrderResponse
+	Err      error
+}
+
+// RetryCategory classifies a batch order failure as automatically retryable.
+type RetryCategory string
+
+const (
+	RetryCategoryMinSize   RetryCategory = "min_size"  // below the market's minimum order size
+	RetryCategoryNonce     RetryCategory = "nonce"      // nonce/salt collision, resign and retry
+	RetryCategoryTransient RetryCategory = "transient"  // transient 5xx from the gateway
+	RetryCategoryDelayed   RetryCategory = "delayed"    // order landed in "delayed" status
+)
+
+// RetryPolicy controls BatchRetryPlaceOrders' automatic resubmission.
+type RetryPolicy struct {
+	MaxRetries int           // max resubmissions per order (not counting the first attempt)
+	Backoff    time.Duration // delay before each retry round
+	Categories []RetryCategory // failure categories eligible for retry; empty = DefaultRetryPolicy's set
+
+	// AtomicBatch, when true, cancels every order that was accepted in the
+	// same batch if any order still fails once retries are exhausted.
+	AtomicBatch bool
+}
+
+// DefaultRetryPolicy returns the retry set described in the copy-trading
+// runbook: minimum-size bumps (same as PlaceOrderFast), nonce/salt
+// collisions, transient 5xx, and "delayed" status.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		Backoff:    200 * time.Millisecond,
+		Categories: []RetryCategory{
+			RetryCategoryMinSize,
+			RetryCategoryNonce,
+			RetryCategoryTransient,
+			RetryCategoryDelayed,
+		},
+	}
+}
+
+// BatchPlaceOrders signs and posts a batch of orders in parallel, bounded by
+// batchWorkerPoolSize workers. All requests reuse the client's already
+// derived API creds rather than calling DeriveAPICreds per order.
+func (c *ClobClient) BatchPlaceOrders(ctx context.Context, reqs []BatchOrderRequest) ([]BatchOrderResult, error) {
+	if c.apiCreds == nil {
+		if _, err := c.DeriveAPICreds(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get API creds: %w", err)
+		}
+	}
+
+	results := make([]BatchOrderResult, len(reqs))
+	sem := make(chan struct{}, batchWorkerPoolSize)
+	var wg sync.WaitGroup
+
+	for i, req := range reqs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int, r BatchOrderRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := c.PlaceOrderFAK(ctx, r.TokenID, r.Side, r.Size, r.Price, r.NegRisk)
+			results[idx] = BatchOrderResult{Request: r, Response: resp, Err: err}
+		}(i, req)
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
+// BatchRetryPlaceOrders wraps BatchPlaceOrders with automatic resubmission of
+// failures matching policy's retry categories. Minimum-size failures are
+// bumped to the next size the same way PlaceOrderFast's retry ladder works;
+// other categories are resigned and resubmitted unchanged. Orders that still
+// fail after MaxRetries keep their last error.
+//
+// If policy.AtomicBatch is set and any order is unrecoverable, the orders
+// that did get accepted in this batch are cancelled via BatchCancel so the
+// caller isn't left with a one-sided position.
+func (c *ClobClient) BatchRetryPlaceOrders(ctx context.Context, reqs []BatchOrderRequest, policy RetryPolicy) ([]BatchOrderResult, error) {
+	categories := policy.Categories
+	if len(categories) == 0 {
+		categories = DefaultRetryPolicy().Categories
+	}
+
+	results, err := c.BatchPlaceOrders(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < policy.MaxRetries; attempt++ {
+		var retryIdx []int
+		for i, res := range results {
+			if res.Err == nil {
+				continue
+			}
+			category, bumped := classifyBatchFailure(res)
+			if !retryCategoryAllowed(category, categories) {
+				continue
+			}
+			results[i].Request = bumped
+			retryIdx = append(retryIdx, i)
+		}
+
+		if len(retryIdx) == 0 {
+			break
+		}
+
+		if policy.Backoff > 0 {
+			select {
+			case <-ctx.Done():
+				return results, ctx.Err()
+			case <-time.After(policy.Backoff):
+			}
+		}
+
+		retryReqs := make([]BatchOrderRequest, len(retryIdx))
+		for j, idx := range retryIdx {
+			retryReqs[j] = results[idx].Request
+		}
+		retryResults, err := c.BatchPlaceOrders(ctx, retryReqs)
+		if err != nil {
+			return results, err
+		}
+		for j, idx := range retryIdx {
+			results[idx] = retryResults[j]
+		}
+	}
+
+	if policy.AtomicBatch && batchHasFailures(results) {
+		var acceptedIDs []string
+		for _, res := range results {
+			if res.Err == nil && res.Response != nil && res.Response.Success && res.Response.OrderID != "" {
+				acceptedIDs = append(acceptedIDs, res.Response.OrderID)
+			}
+		}
+		if len(acceptedIDs) > 0 {
+			if _, cancelErr := c.BatchCancel(ctx, acceptedIDs); cancelErr != nil {
+				return results, fmt.Errorf("atomic batch rollback failed, accepted orders may still be live: %w", cancelErr)
+			}
+		}
+		return results, fmt.Errorf("atomic batch had unrecoverable failures, cancelled %d accepted order(s)", len(acceptedIDs))
+	}
+
+	return results, nil
+}
+
+// BatchCancel cancels a batch of orders in parallel, bounded by
+// batchWorkerPoolSize workers.
+func (c *ClobClient) BatchCancel(ctx context.Context, orderIDs []string) ([]BatchCancelResult, error) {
+	if c.apiCreds == nil {
+		if _, err := c.DeriveAPICreds(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get API creds: %w", err)
+		}
+	}
+
+	results := make([]BatchCancelResult, len(orderIDs))
+	sem := make(chan struct{}, batchWorkerPoolSize)
+	var wg sync.WaitGroup
+
+	for i, orderID := range orderIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := c.CancelOrder(ctx, id)
+			results[idx] = BatchCancelResult{OrderID: id, Err: err}
+		}(i, orderID)
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
+// BatchCancelResult pairs an order ID with its cancellation outcome.
+type BatchCancelResult struct {
+	OrderID string
+	Err     error
+}
+
+// classifyBatchFailure inspects a failed BatchOrderResult and returns which
+// retry category (if any) it falls into, along with a possibly-adjusted
+// request (today, only the min-size category bumps the request).
+func classifyBatchFailure(res BatchOrderResult) (RetryCategory, BatchOrderRequest) {
+	req := res.Request
+	if res.Err == nil {
+		return "", req
+	}
+	msg := strings.ToLower(res.Err.Error())
+
+	switch {
+	case strings.Contains(msg, "minimum") || strings.Contains(msg, "min order") || strings.Contains(msg, "too small"):
+		req.Size = nextRetrySize(req.Size)
+		return RetryCategoryMinSize, req
+	case strings.Contains(msg, "nonce") || strings.Contains(msg, "salt"):
+		return RetryCategoryNonce, req
+	case strings.Contains(msg, "delayed"):
+		return RetryCategoryDelayed, req
+	case strings.Contains(msg, "500") || strings.Contains(msg, "502") || strings.Contains(msg, "503") || strings.Contains(msg, "timeout"):
+		return RetryCategoryTransient, req
+	default:
+		return "", req
+	}
+}
+
+// nextRetrySize mirrors PlaceOrderFast's retry ladder: 1, 2, 5, 10 tokens.
+func nextRetrySize(size float64) float64 {
+	for _, step := range []float64{1.0, 2.0, 5.0, 10.0} {
+		if step > size {
+			return step
+		}
+	}
+	return size * 2
+}
+
+// retryCategoryAllowed reports whether category is in the allowed set.
+func retryCategoryAllowed(category RetryCategory, allowed []RetryCategory) bool {
+	if category == "" {
+		return false
+	}
+	for _, c := range allowed {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// batchHasFailures reports whether any result in the batch still has an error.
+func batchHasFailures(results []BatchOrderResult) bool {
+	for _, res := range results {
+		if res.Err != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// OrderEstimate is a pre-trade cost/slippage estimate derived by walking the
+// live order book. BestCase assumes the whole order fills at the top level at
+// the current price; WorstCase assumes the order walks the book as a single
+// taker; Expected averages the two, weighted by level liquidity - the same
+// "high/low" estimate shape used for swap/redeem sizing.
+type OrderEstimate struct {
+	BestCasePrice     float64
+	WorstCasePrice    float64
+	ExpectedAvgPrice  float64
+	FillableSize      float64
+	UnfillableSize    float64
+	FeeBps            int
+	TotalUSDC         float64
+	PriceImpactBps    int
+	NumLevelsConsumed int
+}
+
+// EstimateOrderCost walks the order book for tokenID/side to estimate the
+// cost and price impact of filling size shares, without signing or posting
+// anything. Copy-traders can use this to reject an unfavorable mirror trade
+// before paying the cost of signing it.
+func (c *ClobClient) EstimateOrderCost(ctx context.Context, tokenID string, side Side, size float64) (*OrderEstimate, error) {
+	book, err := c.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+
+	var levels []OrderBookLevel
+	if side == SideBuy {
+		levels = book.Asks
+	} else {
+		levels = book.Bids
+	}
+
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no liquidity in order book for %s side", side)
+	}
+
+	bestPrice, _ := strconv.ParseFloat(levels[0].Price, 64)
+
+	remaining := size
+	walkedSize := 0.0
+	walkedCost := 0.0
+	worstPrice := bestPrice
+	levelsConsumed := 0
+
+	for _, level := range levels {
+		if remaining <= 0 {
+			break
+		}
+		price, _ := strconv.ParseFloat(level.Price, 64)
+		levelSize, _ := strconv.ParseFloat(level.Size, 64)
+
+		fillSize := levelSize
+		if fillSize > remaining {
+			fillSize = remaining
+		}
+
+		walkedSize += fillSize
+		walkedCost += fillSize * price
+		worstPrice = price
+		remaining -= fillSize
+		levelsConsumed++
+	}
+
+	fillable := walkedSize
+	unfillable := remaining
+
+	bestCaseCost := fillable * bestPrice
+	worstCaseCost := walkedCost
+	expectedAvgPrice := bestPrice
+	if fillable > 0 {
+		expectedAvgPrice = (bestCaseCost + worstCaseCost) / (2 * fillable)
+	}
+
+	impactBps := 0
+	if bestPrice > 0 {
+		impactBps = int((expectedAvgPrice - bestPrice) / bestPrice * 10000)
+		if impactBps < 0 {
+			impactBps = -impactBps
+		}
+	}
+
+	return &OrderEstimate{
+		BestCasePrice:     bestPrice,
+		WorstCasePrice:    worstPrice,
+		ExpectedAvgPrice:  expectedAvgPrice,
+		FillableSize:      fillable,
+		UnfillableSize:    unfillable,
+		TotalUSDC:         expectedAvgPrice * fillable,
+		PriceImpactBps:    impactBps,
+		NumLevelsConsumed: levelsConsumed,
+	}, nil
+}
+
+// EstimateForUSDC estimates cost/slippage for a market-buy sized in USDC
+// rather than shares, mirroring how PlaceMarketOrder sizes its order.
+func (c *ClobClient) EstimateForUSDC(ctx context.Context, tokenID string, side Side, amountUSDC float64) (*OrderEstimate, error) {
+	book, err := c.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+
+	var levels []OrderBookLevel
+	if side == SideBuy {
+		levels = book.Asks
+	} else {
+		levels = book.Bids
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no liquidity in order book for %s side", side)
+	}
+
+	remainingUSDC := amountUSDC
+	totalSize := 0.0
+	for _, level := range levels {
+		if remainingUSDC <= 0 {
+			break
+		}
+		price, _ := strconv.ParseFloat(level.Price, 64)
+		levelSize, _ := strconv.ParseFloat(level.Size, 64)
+
+		levelValue := levelSize * price
+		if levelValue <= remainingUSDC {
+			totalSize += levelSize
+			remainingUSDC -= levelValue
+		} else {
+			totalSize += remainingUSDC / price
+			remainingUSDC = 0
+		}
+	}
+
+	if totalSize == 0 {
+		return nil, fmt.Errorf("cannot fill order: insufficient liquidity")
+	}
+
+	return c.EstimateOrderCost(ctx, tokenID, side, totalSize)
+}
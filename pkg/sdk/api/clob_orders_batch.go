@@ -0,0 +1,196 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// =============================================================================
+// SERVER-SIDE BATCH ORDER ENDPOINTS
+// =============================================================================
+//
+// postOrder/CancelOrder above make one HTTP round-trip per order, which
+// becomes a bottleneck for market makers quoting dozens of levels at once.
+// PostOrders and CancelOrders hit the CLOB's own batch endpoints (/orders and
+// /orders/cancel) so a whole ladder can be placed or torn down in a single
+// request, the same way Bybit's batch order endpoints or bbgo's active-order
+// cleanup work. Each still reuses addL2Headers for L2 auth, serializing the
+// full array body once before it's signed.
+// =============================================================================
+
+// CancelResult reports which order IDs a cancel-style batch call actually
+// cancelled versus rejected (e.g. already filled).
+type CancelResult struct {
+	Canceled    []string          `json:"canceled"`
+	NotCanceled map[string]string `json:"not_canceled"`
+}
+
+// PostOrders signs and submits a batch of pre-built orders in a single HTTP
+// call to /orders. orders and orderTypes must be the same length and are
+// paired by index. The returned slice is the same length as orders; a
+// rejected order still gets an entry so callers can retry just that index.
+func (c *ClobClient) PostOrders(ctx context.Context, orders []*Order, orderTypes []OrderType) ([]OrderResponse, error) {
+	if len(orders) != len(orderTypes) {
+		return nil, fmt.Errorf("orders and orderTypes must be the same length (%d vs %d)", len(orders), len(orderTypes))
+	}
+	if len(orders) == 0 {
+		return nil, nil
+	}
+
+	if c.apiCreds == nil {
+		if _, err := c.DeriveAPICreds(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get API creds: %w", err)
+		}
+	}
+
+	payload := make([]OrderRequest, len(orders))
+	for i, order := range orders {
+		payload[i] = OrderRequest{
+			Order:     *order,
+			Owner:     c.apiCreds.APIKey,
+			OrderType: orderTypes[i],
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/orders", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	c.addL2Headers(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("post orders failed: %d %s", resp.StatusCode, string(respBody))
+	}
+
+	var results []OrderResponse
+	if err := json.Unmarshal(respBody, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode batch order response: %w", err)
+	}
+
+	return results, nil
+}
+
+// CancelOrders cancels a batch of orders by ID in a single HTTP call to
+// /orders/cancel.
+func (c *ClobClient) CancelOrders(ctx context.Context, orderIDs []string) (*CancelResult, error) {
+	if len(orderIDs) == 0 {
+		return &CancelResult{}, nil
+	}
+
+	if c.apiCreds == nil {
+		if _, err := c.DeriveAPICreds(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get API creds: %w", err)
+		}
+	}
+
+	body, err := json.Marshal(orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/orders/cancel", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	c.addL2Headers(req)
+
+	return c.doCancelRequest(req)
+}
+
+// CancelAll cancels every order currently resting for the authenticated
+// account, across all markets.
+func (c *ClobClient) CancelAll(ctx context.Context) (*CancelResult, error) {
+	if c.apiCreds == nil {
+		if _, err := c.DeriveAPICreds(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get API creds: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/cancel-all", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	c.addL2Headers(req)
+
+	return c.doCancelRequest(req)
+}
+
+// CancelMarketOrders cancels all resting orders for a given market
+// (conditionID) and, optionally, a single asset within it. Pass an empty
+// assetID to cancel on both outcome tokens of the market.
+func (c *ClobClient) CancelMarketOrders(ctx context.Context, marketID string, assetID string) (*CancelResult, error) {
+	if marketID == "" && assetID == "" {
+		return nil, fmt.Errorf("marketID or assetID is required")
+	}
+
+	if c.apiCreds == nil {
+		if _, err := c.DeriveAPICreds(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get API creds: %w", err)
+		}
+	}
+
+	payload := map[string]string{}
+	if marketID != "" {
+		payload["market"] = marketID
+	}
+	if assetID != "" {
+		payload["asset_id"] = assetID
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/cancel-market-orders", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	c.addL2Headers(req)
+
+	return c.doCancelRequest(req)
+}
+
+// doCancelRequest executes a prepared cancel-style request and decodes its
+// CancelResult body, shared by CancelOrders/CancelAll/CancelMarketOrders.
+func (c *ClobClient) doCancelRequest(req *http.Request) (*CancelResult, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cancel request failed: %d %s", resp.StatusCode, string(respBody))
+	}
+
+	var result CancelResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode cancel response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// dataAPIBaseURL is the Data API host used for position lookups, separate
+// from the CLOB host ClobClient otherwise talks to.
+const dataAPIBaseURL = "https://data-api.polymarket.com"
+
+// GetPositions fetches the caller's current open positions (holdings) across
+// all markets, keyed by funder address, using the Data API /positions
+// endpoint. This is the position source consumers like Rebalancer plan
+// against.
+func (c *ClobClient) GetPositions(ctx context.Context) ([]OpenPosition, error) {
+	userAddress := c.funder.Hex()
+	if userAddress == "" {
+		return nil, fmt.Errorf("no funder address configured on this client")
+	}
+
+	values := url.Values{}
+	values.Set("user", userAddress)
+
+	endpoint := dataAPIBaseURL + "/positions?" + values.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("positions request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var positions []OpenPosition
+	if err := json.NewDecoder(resp.Body).Decode(&positions); err != nil {
+		return nil, fmt.Errorf("failed to decode positions: %w", err)
+	}
+	return positions, nil
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// RATE LIMITING & RETRY MIDDLEWARE
+// =============================================================================
+//
+// postOrder, GetOrder and CancelOrder all called c.httpClient.Do directly,
+// so a burst of requests could trip Polymarket's 429s with no throttling and
+// no retry - the caller just saw an opaque "post order failed: 429 ...".
+// doWithRetry centralizes that for those three (the batch endpoints in
+// clob_orders_batch.go still call c.httpClient.Do directly): a per-endpoint-
+// class token bucket paces outgoing requests, and 429/5xx responses are
+// retried with jittered exponential backoff for idempotent verbs. Callers
+// that need to inspect the failure (status code, error code, Retry-After)
+// can type-assert the returned error to *APIError.
+// =============================================================================
+
+// endpointClass buckets CLOB endpoints by their rate-limit tier. Polymarket
+// enforces separate limits for order placement, cancellation, and read-only
+// data endpoints, so a burst of GetOrder polling shouldn't also throttle
+// order submission.
+type endpointClass int
+
+const (
+	classOrders endpointClass = iota
+	classCancels
+	classData
+)
+
+// tokenBucket is a minimal token-bucket rate limiter: capacity tokens
+// refilling continuously at ratePerSec, blocking callers until a token is
+// available or ctx is cancelled.
+type tokenBucket struct {
+	mu         sync.Mutex
+	capacity   float64
+	tokens     float64
+	ratePerSec float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(ratePerSec float64, capacity float64) *tokenBucket {
+	return &tokenBucket{
+		capacity:   capacity,
+		tokens:     capacity,
+		ratePerSec: ratePerSec,
+		lastRefill: time.Now(),
+	}
+}
+
+// wait blocks until a token is available, refilling the bucket based on
+// elapsed time since the last call.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		elapsed := now.Sub(b.lastRefill).Seconds()
+		b.lastRefill = now
+		b.tokens += elapsed * b.ratePerSec
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		// Not enough tokens yet - figure out how long until one frees up.
+		deficit := 1 - b.tokens
+		wait := time.Duration(deficit/b.ratePerSec*1000) * time.Millisecond
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// clobRateLimits are conservative per-second/burst figures for each
+// endpoint class; Polymarket doesn't publish exact numbers, so these err on
+// the side of throttling before the exchange does.
+var clobRateLimits = map[endpointClass]struct {
+	ratePerSec float64
+	burst      float64
+}{
+	classOrders:  {ratePerSec: 5, burst: 10},
+	classCancels: {ratePerSec: 10, burst: 20},
+	classData:    {ratePerSec: 10, burst: 20},
+}
+
+// APIError is returned by doWithRetry when the CLOB responds with a
+// non-2xx status after retries are exhausted (or on a non-retryable
+// status). Callers that need to branch on the exact failure - e.g. to
+// distinguish "insufficient balance" from a transient 429 - can type-assert
+// the error instead of substring-matching Error().
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("clob api error: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("clob api error: %d %s", e.StatusCode, e.Message)
+}
+
+// parseAPIError builds an *APIError from a non-2xx response, attempting to
+// pull a structured error code out of the body (CLOB errors come back as
+// either {"error": "..."} or {"errorMsg": "..."}) before falling back to
+// the raw body text.
+func parseAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    string(body),
+	}
+
+	var parsed struct {
+		Error    string `json:"error"`
+		ErrorMsg string `json:"errorMsg"`
+	}
+	if json.Unmarshal(body, &parsed) == nil {
+		if parsed.Error != "" {
+			apiErr.Code = parsed.Error
+		} else if parsed.ErrorMsg != "" {
+			apiErr.Code = parsed.ErrorMsg
+		}
+	}
+
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			apiErr.RetryAfter = time.Duration(secs) * time.Second
+		}
+	}
+
+	return apiErr
+}
+
+const (
+	retryMaxAttempts = 4
+	retryBaseDelay   = 250 * time.Millisecond
+	retryMaxDelay    = 8 * time.Second
+)
+
+// isRetryableStatus reports whether status is worth retrying for an
+// idempotent request: 429 (rate limited) or any 5xx (server-side failure).
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// backoffWithJitter returns a full-jitter exponential delay for the given
+// attempt (0-indexed), capped at retryMaxDelay, so concurrent retries after
+// a shared rate-limit window don't all land on the same tick.
+func backoffWithJitter(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	delay := retryBaseDelay * time.Duration(1<<uint(attempt))
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay)))
+}
+
+// limiterFor returns the token bucket guarding class, deriving it lazily so
+// ClobClient's zero value (and any hand-built instances in tests) still work.
+func (c *ClobClient) limiterFor(class endpointClass) *tokenBucket {
+	c.limiterMu.Lock()
+	defer c.limiterMu.Unlock()
+
+	if c.limiters == nil {
+		c.limiters = make(map[endpointClass]*tokenBucket, len(clobRateLimits))
+	}
+	if b, ok := c.limiters[class]; ok {
+		return b
+	}
+	limits := clobRateLimits[class]
+	b := newTokenBucket(limits.ratePerSec, limits.burst)
+	c.limiters[class] = b
+	return b
+}
+
+// doWithRetry rate-limits and executes a request built fresh on every
+// attempt by buildReq (so L2 signature headers, which are timestamped, are
+// regenerated rather than replayed). idempotent requests (GET, DELETE, and
+// cancel specifically) are retried on 429/5xx with jittered backoff; a 404
+// on a cancel is treated as success by the caller, not retried here.
+func (c *ClobClient) doWithRetry(ctx context.Context, class endpointClass, idempotent bool, buildReq func() (*http.Request, error)) (*http.Response, []byte, error) {
+	limiter := c.limiterFor(class)
+
+	attempts := 1
+	if idempotent {
+		attempts = retryMaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := limiter.wait(ctx); err != nil {
+			return nil, nil, err
+		}
+
+		req, err := buildReq()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if attempt < attempts-1 {
+				time.Sleep(backoffWithJitter(attempt, 0))
+				continue
+			}
+			return nil, nil, err
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, body, nil
+		}
+
+		apiErr := parseAPIError(resp, body)
+		lastErr = apiErr
+
+		if !idempotent || !isRetryableStatus(resp.StatusCode) || attempt == attempts-1 {
+			return resp, body, apiErr
+		}
+
+		wait := backoffWithJitter(attempt, apiErr.RetryAfter)
+		log.Printf("[ClobClient] retrying after %d %s (attempt %d/%d, wait %v)", resp.StatusCode, apiErr.Code, attempt+1, attempts, wait)
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	return nil, nil, lastErr
+}
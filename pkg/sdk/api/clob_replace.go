@@ -0,0 +1,167 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// replaceOrderRequest is the payload for Polymarket's atomic /order/replace
+// endpoint, when available: cancel orderID and post a freshly signed order
+// in a single server-side operation.
+type replaceOrderRequest struct {
+	OrderID string       `json:"orderID"`
+	Order   OrderRequest `json:"order"`
+}
+
+// cancelPollInterval/cancelPollAttempts bound how long replaceViaCancelThenPost
+// waits for a cancel to be confirmed before giving up.
+const (
+	cancelPollInterval = 300 * time.Millisecond
+	cancelPollAttempts = 5
+)
+
+// ReplaceOrder amends a resting order's price/size, modeled on Bybit's
+// ReplaceStopOrder pattern: sign a fresh order with the same token/side, and
+// swap it in for orderID as one logical operation. It first tries
+// Polymarket's atomic /order/replace endpoint; if that isn't available
+// (404), it falls back to cancel-then-post - cancel orderID, confirm it's
+// actually gone, then place the new order. If the cancel can't be confirmed,
+// no new order is placed: a fill racing between cancel and post is exactly
+// how market makers end up doubling their exposure.
+func (c *ClobClient) ReplaceOrder(ctx context.Context, orderID string, newPrice float64, newSize float64) (*OrderResponse, error) {
+	if c.apiCreds == nil {
+		if _, err := c.DeriveAPICreds(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get API creds: %w", err)
+		}
+	}
+
+	old, err := c.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up existing order %s: %w", orderID, err)
+	}
+
+	side := SideBuy
+	if strings.EqualFold(old.Side, string(SideSell)) {
+		side = SideSell
+	}
+
+	negRisk := false
+	if tokenInfo, err := c.GetTokenInfoByID(ctx, old.AssetID); err == nil && tokenInfo != nil {
+		negRisk = tokenInfo.NegRisk
+	}
+
+	newOrder, err := c.createSignedOrder(ctx, old.AssetID, side, newSize, newPrice, negRisk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replacement order: %w", err)
+	}
+
+	resp, err := c.postOrderReplace(ctx, orderID, newOrder)
+	if err == nil {
+		return resp, nil
+	}
+	if !strings.Contains(err.Error(), "not found (404)") {
+		return nil, err
+	}
+
+	log.Printf("[ReplaceOrder] /order/replace unavailable (%v), falling back to cancel-then-post", err)
+	return c.replaceViaCancelThenPost(ctx, orderID, newOrder)
+}
+
+// postOrderReplace calls Polymarket's atomic /order/replace endpoint, which
+// cancels orderID and posts newOrder server-side in one request.
+func (c *ClobClient) postOrderReplace(ctx context.Context, orderID string, newOrder *Order) (*OrderResponse, error) {
+	payload := replaceOrderRequest{
+		OrderID: orderID,
+		Order: OrderRequest{
+			Order:     *newOrder,
+			Owner:     c.apiCreds.APIKey,
+			OrderType: OrderTypeGTC,
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/order/replace", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	c.addL2Headers(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("/order/replace not found (404): %s", string(respBody))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("order replace failed: %d %s", resp.StatusCode, string(respBody))
+	}
+
+	var orderResp OrderResponse
+	if err := json.Unmarshal(respBody, &orderResp); err != nil {
+		return nil, fmt.Errorf("failed to decode replace response: %w", err)
+	}
+
+	return &orderResp, nil
+}
+
+// replaceViaCancelThenPost cancels orderID, waits for the cancellation to be
+// confirmed, and only then posts newOrder. It refuses to post the new order
+// if the cancel can't be confirmed, leaving the caller with just the
+// original order rather than risking both being live at once.
+func (c *ClobClient) replaceViaCancelThenPost(ctx context.Context, orderID string, newOrder *Order) (*OrderResponse, error) {
+	if err := c.CancelOrder(ctx, orderID); err != nil {
+		return nil, fmt.Errorf("failed to cancel existing order %s, aborting replace: %w", orderID, err)
+	}
+
+	if err := c.waitForCancelConfirmation(ctx, orderID); err != nil {
+		return nil, fmt.Errorf("could not confirm cancellation of %s, aborting replace to avoid doubled exposure: %w", orderID, err)
+	}
+
+	resp, err := c.postOrder(ctx, newOrder, OrderTypeGTC)
+	if err != nil {
+		return nil, fmt.Errorf("order %s was cancelled but replacement failed to post: %w", orderID, err)
+	}
+	return resp, nil
+}
+
+// waitForCancelConfirmation polls GetOrder for orderID until it reports a
+// terminal (non-live) status or a 404 (already gone), or gives up after
+// cancelPollAttempts.
+func (c *ClobClient) waitForCancelConfirmation(ctx context.Context, orderID string) error {
+	for attempt := 0; attempt < cancelPollAttempts; attempt++ {
+		order, err := c.GetOrder(ctx, orderID)
+		if err != nil {
+			if strings.Contains(err.Error(), "404") {
+				return nil
+			}
+			// Inconclusive - the lookup itself failed, not the order status.
+		} else if order.Status != "live" && order.Status != "LIVE" {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cancelPollInterval):
+		}
+	}
+
+	return fmt.Errorf("order %s still live after %d confirmation attempts", orderID, cancelPollAttempts)
+}
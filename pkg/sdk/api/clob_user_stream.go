@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// OrderUpdate is delivered on the channel returned by SubscribeUser whenever
+// the user channel reports an order placement, fill, or cancellation.
+type OrderUpdate = OpenOrder
+
+// TradeUpdate is delivered on the channel returned by SubscribeUser whenever
+// one of the user's orders is matched.
+type TradeUpdate = DataTrade
+
+// userStreamChannelBuffer bounds how many events SubscribeUser will buffer
+// before a slow consumer starts blocking the underlying read loop.
+const userStreamChannelBuffer = 64
+
+// SubscribeUser opens the authenticated Polymarket /ws/user channel for
+// markets and streams order and trade events into typed channels, avoiding
+// the authenticated HTTP round-trip (and debug logging) a GetOrder poll loop
+// would otherwise pay on every tick.
+//
+// On every reconnect it resyncs by calling the REST GetOrder for every order
+// ID seen since the stream started that has not yet been reported CANCELED
+// or MATCHED, so a fill that lands during the disconnect window is still
+// picked up and re-delivered on orders.
+//
+// Both channels are closed, and the underlying connection is stopped, when
+// ctx is cancelled.
+func (c *ClobClient) SubscribeUser(ctx context.Context, markets []string) (<-chan OrderUpdate, <-chan TradeUpdate, error) {
+	orders := make(chan OrderUpdate, userStreamChannelBuffer)
+	trades := make(chan TradeUpdate, userStreamChannelBuffer)
+
+	var trackedMu sync.Mutex
+	tracked := make(map[string]bool)
+
+	handlers := UserSubscriptionHandlers{
+		OnOrderUpdate: func(order OpenOrder) {
+			trackedMu.Lock()
+			if order.Status == "" || order.Status == "CANCELED" || order.Status == "MATCHED" {
+				delete(tracked, order.ID)
+			} else {
+				tracked[order.ID] = true
+			}
+			trackedMu.Unlock()
+
+			select {
+			case orders <- order:
+			case <-ctx.Done():
+			}
+		},
+		OnTradeMatched: func(trade DataTrade) {
+			select {
+			case trades <- trade:
+			case <-ctx.Done():
+			}
+		},
+	}
+
+	ws, err := c.SubscribeUserWithHandlers(ctx, markets, handlers)
+	if err != nil {
+		close(orders)
+		close(trades)
+		return nil, nil, err
+	}
+
+	ws.SetResyncHandler(func() {
+		trackedMu.Lock()
+		ids := make([]string, 0, len(tracked))
+		for id := range tracked {
+			ids = append(ids, id)
+		}
+		trackedMu.Unlock()
+
+		for _, id := range ids {
+			order, err := c.GetOrder(ctx, id)
+			if err != nil {
+				log.Printf("[SubscribeUser] resync failed for order %s: %v", id, err)
+				continue
+			}
+			handlers.OnOrderUpdate(*order)
+		}
+	})
+
+	go func() {
+		<-ctx.Done()
+		ws.Stop()
+		close(orders)
+		close(trades)
+	}()
+
+	return orders, trades, nil
+}
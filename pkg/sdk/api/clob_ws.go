@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// MarketSubscriptionHandlers bundles the callbacks a caller can register for
+// a market-data WebSocket subscription. Any handler may be left nil.
+type MarketSubscriptionHandlers struct {
+	OnBookUpdate   BookUpdateHandler
+	OnPriceChange  TradeHandler
+	OnTradeMatched TradeHandler
+}
+
+// UserSubscriptionHandlers bundles the callbacks a caller can register for an
+// authenticated user-data WebSocket subscription. Any handler may be left nil.
+type UserSubscriptionHandlers struct {
+	OnOrderUpdate  OrderUpdateHandler
+	OnTradeMatched func(trade DataTrade)
+}
+
+// SubscribeMarket opens a low-latency WebSocket connection for the given
+// token IDs and wires up the supplied handlers. On every reconnect the
+// client re-fetches the order book for each subscribed token over REST,
+// since the feed carries no sequence number to detect a gap directly. The
+// fetched books are not passed to any handler; a failed fetch is only logged.
+//
+// The returned *WSClient is already running; callers own its lifecycle and
+// must call Stop() when done.
+func (c *ClobClient) SubscribeMarket(ctx context.Context, tokenIDs []string, handlers MarketSubscriptionHandlers) (*WSClient, error) {
+	if len(tokenIDs) == 0 {
+		return nil, fmt.Errorf("no token IDs provided")
+	}
+
+	ws := NewWSClient(handlers.OnTradeMatched)
+	ws.SetBookUpdateHandler(handlers.OnBookUpdate)
+	ws.SetPriceChangeHandler(handlers.OnPriceChange)
+	ws.SetResyncHandler(func() {
+		for _, tokenID := range tokenIDs {
+			if _, err := c.GetOrderBook(ctx, tokenID); err != nil {
+				log.Printf("[SubscribeMarket] resync failed for %s: %v", tokenID, err)
+			}
+		}
+	})
+
+	if err := ws.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start market WebSocket: %w", err)
+	}
+
+	if err := ws.Subscribe(tokenIDs...); err != nil {
+		ws.Stop()
+		return nil, fmt.Errorf("failed to subscribe to tokens: %w", err)
+	}
+
+	return ws, nil
+}
+
+// SubscribeUserWithHandlers opens an authenticated WebSocket connection for
+// the caller's own order and trade events, deriving API creds first if
+// needed. Use this when you want direct control over the underlying
+// *WSUserClient (e.g. to call Stop() explicitly); for the common case of
+// just wanting the events as channels, see SubscribeUser.
+//
+// The returned *WSUserClient is already running; callers own its lifecycle
+// and must call Stop() when done.
+func (c *ClobClient) SubscribeUserWithHandlers(ctx context.Context, conditionIDs []string, handlers UserSubscriptionHandlers) (*WSUserClient, error) {
+	if c.apiCreds == nil {
+		if _, err := c.DeriveAPICreds(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get API creds: %w", err)
+		}
+	}
+
+	ws := NewWSUserClient(c.apiCreds, handlers.OnTradeMatched)
+	ws.SetOrderUpdateHandler(handlers.OnOrderUpdate)
+
+	if err := ws.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start user WebSocket: %w", err)
+	}
+
+	if len(conditionIDs) > 0 {
+		if err := ws.SubscribeMarkets(conditionIDs...); err != nil {
+			ws.Stop()
+			return nil, fmt.Errorf("failed to subscribe to markets: %w", err)
+		}
+	}
+
+	return ws, nil
+}
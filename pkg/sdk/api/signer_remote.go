@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// RemoteSigner delegates signing to an external Clef-style JSON-RPC signer
+// (account_signTypedData) so the private key never enters this process -
+// useful for keys held in an HSM, a Ledger, or a segregated signing daemon.
+// Clef already returns a 27/28-normalized signature, so unlike LocalSigner
+// no v-adjustment is applied here.
+type RemoteSigner struct {
+	rpcURL     string
+	address    common.Address
+	httpClient *http.Client
+}
+
+// NewRemoteSigner creates a RemoteSigner that calls account_signTypedData on
+// the JSON-RPC endpoint at rpcURL for the given address.
+func NewRemoteSigner(rpcURL string, address common.Address) *RemoteSigner {
+	return &RemoteSigner{
+		rpcURL:  rpcURL,
+		address: address,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// Address returns the remote account's Ethereum address.
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+type remoteSignerRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+type remoteSignerRPCResponse struct {
+	Result string `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// SignTypedData asks the remote signer to sign typedData via
+// account_signTypedData, the same RPC method Clef exposes.
+func (s *RemoteSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	rpcReq := remoteSignerRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "account_signTypedData",
+		Params:  []interface{}{s.address.Hex(), typedData},
+		ID:      1,
+	}
+
+	body, err := json.Marshal(rpcReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RPC request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read remote signer response: %w", err)
+	}
+
+	var rpcResp remoteSignerRPCResponse
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to decode remote signer response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("remote signer error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	hexSig := strings.TrimPrefix(rpcResp.Result, "0x")
+	signature, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode remote signature: %w", err)
+	}
+
+	return signature, nil
+}
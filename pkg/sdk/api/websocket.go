@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +24,13 @@ const (
 	maxReconnectDelay = 30 * time.Second
 	pingInterval      = 30 * time.Second
 	pongTimeout       = 10 * time.Second
+
+	// stallThreshold is the max time allowed without *any* inbound message
+	// (not just pongs) before the connection is considered stalled and torn
+	// down. This catches silent feeds that keep the TCP connection alive but
+	// stop pushing book/price updates.
+	stallThreshold = 2 * time.Minute
+	stallCheckInterval = 15 * time.Second
 )
 
 // WSEventType represents the type of WebSocket event
@@ -71,16 +80,40 @@ type WSClient struct {
 	subscriptions map[string]bool // asset_id -> subscribed
 	subMu         sync.RWMutex
 
-	tradeHandler TradeHandler
-	running      bool
-	stopCh       chan struct{}
-	doneCh       chan struct{}
+	tradeHandler       TradeHandler
+	priceChangeHandler TradeHandler
+	running            bool
+	stopCh             chan struct{}
+	doneCh             chan struct{}
 
 	// Reconnection state
 	reconnectAttempts int
 	lastPong          time.Time
 
+	// lastMessageAt tracks the last time any message (of any event type) was
+	// received, used by the stall watchdog to detect a quietly-dead feed.
+	lastMessageAt   time.Time
+	lastMessageMu   sync.RWMutex
+
+	// resyncHandler, when set, runs after every successful reconnect so the
+	// caller can pull a fresh REST snapshot before trusting live updates
+	// again - a reconnect is itself a sequence gap in the feed.
+	resyncHandler func()
+
 	bookUpdateHandler BookUpdateHandler
+
+	// authHeader, if set, is sent as the HTTP header on the WebSocket dial -
+	// used by callers that need an L2-authenticated market subscription
+	// (see pkg/marketstream, which builds it via signing.CreateL2Headers).
+	authHeader http.Header
+}
+
+// SetAuthHeader attaches an HTTP header to send on the WebSocket dial,
+// reconnects included. Pass nil to go back to an unauthenticated dial.
+func (c *WSClient) SetAuthHeader(h http.Header) {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	c.authHeader = h
 }
 
 // NewWSClient creates a new WebSocket client
@@ -93,6 +126,22 @@ func NewWSClient(handler TradeHandler) *WSClient {
 	}
 }
 
+// SetPriceChangeHandler sets a handler invoked specifically for price_change
+// events, distinct from the trade handler used for last_trade_price events.
+// If unset, price_change events fall back to the trade handler (the old
+// behavior) so existing callers keep working unchanged.
+func (c *WSClient) SetPriceChangeHandler(handler TradeHandler) {
+	c.priceChangeHandler = handler
+}
+
+// SetResyncHandler registers a callback that runs after every successful
+// reconnect, before resubscription completes. The feed carries no sequence
+// number, so any reconnect is treated as a potential gap; callers typically
+// pass a closure that refetches a REST order book snapshot here.
+func (c *WSClient) SetResyncHandler(handler func()) {
+	c.resyncHandler = handler
+}
+
 // Start connects to the WebSocket and begins listening
 func (c *WSClient) Start(ctx context.Context) error {
 	if c.running {
@@ -104,13 +153,55 @@ func (c *WSClient) Start(ctx context.Context) error {
 	}
 
 	c.running = true
+	c.touchLastMessage()
 	go c.readLoop(ctx)
 	go c.pingLoop(ctx)
+	go c.stallWatchdog(ctx)
 
 	log.Printf("[WebSocket] Started connection to %s", wsMarketURL)
 	return nil
 }
 
+// touchLastMessage records that a message (or a fresh connection) was just
+// observed, resetting the stall watchdog's clock.
+func (c *WSClient) touchLastMessage() {
+	c.lastMessageMu.Lock()
+	c.lastMessageAt = time.Now()
+	c.lastMessageMu.Unlock()
+}
+
+// stallWatchdog forces a reconnect if no message of any kind has arrived
+// within stallThreshold, catching feeds that keep the TCP connection alive
+// but silently stop pushing updates.
+func (c *WSClient) stallWatchdog(ctx context.Context) {
+	ticker := time.NewTicker(stallCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.lastMessageMu.RLock()
+			lastMsg := c.lastMessageAt
+			c.lastMessageMu.RUnlock()
+
+			if time.Since(lastMsg) > stallThreshold {
+				log.Printf("[WebSocket] No messages received in %v, assuming stall and reconnecting...", stallThreshold)
+				c.connMu.Lock()
+				if c.conn != nil {
+					c.conn.Close()
+					c.conn = nil
+				}
+				c.connMu.Unlock()
+				c.reconnect(ctx)
+			}
+		}
+	}
+}
+
 // Stop gracefully closes the WebSocket connection
 func (c *WSClient) Stop() {
 	if !c.running {
@@ -207,7 +298,7 @@ func (c *WSClient) connect() error {
 		Proxy:            getWebSocketProxy(),
 	}
 
-	conn, _, err := dialer.Dial(wsMarketURL, nil)
+	conn, _, err := dialer.Dial(wsMarketURL, c.authHeader)
 	if err != nil {
 		return fmt.Errorf("dial failed: %w", err)
 	}
@@ -404,8 +495,15 @@ func (c *WSClient) reconnect(ctx context.Context) {
 
 	// 重连成功，重置计数器
 	c.reconnectAttempts = 0
+	c.touchLastMessage()
 	log.Printf("[WebSocket] Reconnected successfully")
 
+	// A reconnect is itself a sequence gap - resync from REST before trusting
+	// live updates again.
+	if c.resyncHandler != nil {
+		c.resyncHandler()
+	}
+
 	// Resubscribe to all assets
 	if err := c.resubscribe(); err != nil {
 		log.Printf("[WebSocket] Resubscription failed: %v", err)
@@ -415,6 +513,8 @@ func (c *WSClient) reconnect(ctx context.Context) {
 }
 
 func (c *WSClient) handleMessage(data []byte) {
+	c.touchLastMessage()
+
 	var msg WSMessage
 	if err := json.Unmarshal(data, &msg); err != nil {
 		// Try parsing as array (some messages come as arrays)
@@ -454,7 +554,7 @@ func (c *WSClient) processMessage(msg WSMessage) {
 	case WSEventPriceChange:
 		// Price change events contain more detailed information
 		// Parse the price_changes array to extract size and side
-		if c.tradeHandler != nil {
+		if c.priceChangeHandler != nil || c.tradeHandler != nil {
 			c.handlePriceChangeEvent(msg)
 		}
 
@@ -496,7 +596,12 @@ func (c *WSClient) handlePriceChangeEvent(msg WSMessage) {
 			Side:      "BUY", // Default side, should be parsed from actual data
 			Timestamp: time.Unix(msg.Timestamp/1000, 0),
 		}
-		c.tradeHandler(event)
+
+		if c.priceChangeHandler != nil {
+			c.priceChangeHandler(event)
+		} else if c.tradeHandler != nil {
+			c.tradeHandler(event)
+		}
 	}
 }
 
@@ -508,12 +613,30 @@ type WSUserClient struct {
 	markets  map[string]bool // condition_id -> subscribed
 	subMu    sync.RWMutex
 
-	tradeHandler func(trade DataTrade)
-	running      bool
-	stopCh       chan struct{}
-	doneCh       chan struct{}
+	tradeHandler       func(trade DataTrade)
+	orderUpdateHandler OrderUpdateHandler
+	running            bool
+	stopCh             chan struct{}
+	doneCh             chan struct{}
+	reconnectAttempts  int
+
+	// resyncHandler, when set, runs after every successful reconnect so
+	// callers can re-fetch order state over REST - the window between
+	// disconnect and reconnect carries no guarantee that every fill was
+	// delivered over the socket.
+	resyncHandler func()
+}
+
+// SetResyncHandler registers a callback run after every successful
+// reconnect of the user channel, before resubscription.
+func (c *WSUserClient) SetResyncHandler(handler func()) {
+	c.resyncHandler = handler
 }
 
+// OrderUpdateHandler is called when the user channel reports an order status
+// change (placement, fill, cancellation).
+type OrderUpdateHandler func(order OpenOrder)
+
 // NewWSUserClient creates a new authenticated WebSocket client for user data
 func NewWSUserClient(creds *APICreds, handler func(trade DataTrade)) *WSUserClient {
 	return &WSUserClient{
@@ -525,6 +648,12 @@ func NewWSUserClient(creds *APICreds, handler func(trade DataTrade)) *WSUserClie
 	}
 }
 
+// SetOrderUpdateHandler sets the handler invoked for order-status events on
+// the user channel, separate from the trade (fill) handler.
+func (c *WSUserClient) SetOrderUpdateHandler(handler OrderUpdateHandler) {
+	c.orderUpdateHandler = handler
+}
+
 // Start connects to the user WebSocket and begins listening
 func (c *WSUserClient) Start(ctx context.Context) error {
 	if c.running {
@@ -644,8 +773,7 @@ func (c *WSUserClient) readLoop(ctx context.Context) {
 
 		if conn == nil {
 			log.Printf("[WSUser] No connection, attempting to reconnect...")
-			time.Sleep(reconnectDelay)
-			c.connect()
+			c.reconnect(ctx)
 			continue
 		}
 
@@ -675,9 +803,8 @@ func (c *WSUserClient) readLoop(ctx context.Context) {
 				c.conn = nil
 			}
 			c.connMu.Unlock()
-			
-			time.Sleep(reconnectDelay)
-			c.connect()
+
+			c.reconnect(ctx)
 			continue
 		}
 
@@ -685,18 +812,105 @@ func (c *WSUserClient) readLoop(ctx context.Context) {
 	}
 }
 
+// reconnect backs off (linearly, capped at maxReconnectDelay) and retries the
+// user channel connection a few times, mirroring WSClient.reconnect. On
+// success it runs the resync handler, if any, before resubscribing to the
+// previously tracked markets.
+func (c *WSUserClient) reconnect(ctx context.Context) {
+	c.reconnectAttempts++
+
+	delay := reconnectDelay * time.Duration(c.reconnectAttempts)
+	if delay > maxReconnectDelay {
+		delay = maxReconnectDelay
+	}
+
+	log.Printf("[WSUser] Reconnecting in %v (attempt %d)...", delay, c.reconnectAttempts)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-c.stopCh:
+		return
+	case <-time.After(delay):
+	}
+
+	var err error
+	for i := 0; i < 3; i++ {
+		if err = c.connect(); err == nil {
+			break
+		}
+		log.Printf("[WSUser] Connection attempt %d failed: %v", i+1, err)
+		if i < 2 {
+			time.Sleep(time.Second * time.Duration(i+1))
+		}
+	}
+
+	if err != nil {
+		log.Printf("[WSUser] All reconnection attempts failed: %v", err)
+		return
+	}
+
+	c.reconnectAttempts = 0
+	log.Printf("[WSUser] Reconnected successfully")
+
+	if c.resyncHandler != nil {
+		c.resyncHandler()
+	}
+
+	c.subMu.RLock()
+	markets := make([]string, 0, len(c.markets))
+	for id := range c.markets {
+		markets = append(markets, id)
+	}
+	c.subMu.RUnlock()
+
+	if len(markets) > 0 {
+		if err := c.SubscribeMarkets(markets...); err != nil {
+			log.Printf("[WSUser] Resubscription failed: %v", err)
+		} else {
+			log.Printf("[WSUser] Resubscribed to %d markets", len(markets))
+		}
+	}
+}
+
+// wsUserEnvelope is the minimal shape needed to route a user-channel message
+// to the right handler before unmarshaling the rest of the payload.
+type wsUserEnvelope struct {
+	EventType string `json:"event_type"`
+}
+
 func (c *WSUserClient) handleUserMessage(data []byte) {
-	// User channel messages contain trade/fill information
-	var msg map[string]interface{}
-	if err := json.Unmarshal(data, &msg); err != nil {
+	var envelope wsUserEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		log.Printf("[WSUser] Failed to parse message envelope: %v", err)
 		return
 	}
 
-	// Process trade events and call handler
-	if c.tradeHandler != nil {
-		// Parse trade data and call handler
-		// The exact format depends on Polymarket's API
-		// This is a placeholder for the actual implementation
+	switch strings.ToLower(envelope.EventType) {
+	case "trade":
+		if c.tradeHandler == nil {
+			return
+		}
+		var trade DataTrade
+		if err := json.Unmarshal(data, &trade); err != nil {
+			log.Printf("[WSUser] Failed to parse trade event: %v", err)
+			return
+		}
+		c.tradeHandler(trade)
+
+	case "order":
+		if c.orderUpdateHandler == nil {
+			return
+		}
+		var order OpenOrder
+		if err := json.Unmarshal(data, &order); err != nil {
+			log.Printf("[WSUser] Failed to parse order event: %v", err)
+			return
+		}
+		c.orderUpdateHandler(order)
+
+	default:
+		log.Printf("[WSUser] Unhandled user channel event_type %q", envelope.EventType)
 	}
 }
 
@@ -0,0 +1,74 @@
+package liquiditymaker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/betbot/gobet/pkg/sdk/api"
+)
+
+// BacktestResult summarizes a historical replay of a LiquidityMaker ladder
+// against recorded CLOB trades.
+type BacktestResult struct {
+	TokenID        string
+	TradesReplayed int
+	FillsSimulated int
+	RealizedPnL    float64
+	FinalNetSize   float64
+}
+
+// Backtest replays historical CLOB trades for tokenID (fetched via
+// GetCLOBTrades) against a single static ladder built around the mid-price
+// implied by the first trade, simulating a fill whenever a historical trade
+// price crosses one of the ladder's resting prices. This is a coarse
+// approximation - it does not model queue position - but is enough to sanity
+// check a ladder's spread/size choices before running it live.
+func (m *LiquidityMaker) Backtest(ctx context.Context, tokenID string, params api.CLOBTradeParams) (*BacktestResult, error) {
+	params.AssetID = tokenID
+
+	trades, err := m.client.GetCLOBTrades(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch historical trades: %w", err)
+	}
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("no historical trades found for %s", tokenID)
+	}
+
+	firstPrice, err := strconv.ParseFloat(trades[0].Price, 64)
+	if err != nil || firstPrice <= 0 {
+		return nil, fmt.Errorf("invalid first trade price %q", trades[0].Price)
+	}
+
+	sim := &LiquidityMaker{client: m.client, config: m.config, positions: make(map[string]*position)}
+	ladder := sim.buildLadder(tokenID, firstPrice)
+
+	result := &BacktestResult{TokenID: tokenID, TradesReplayed: len(trades)}
+
+	for _, trade := range trades {
+		tradePrice, err := strconv.ParseFloat(trade.Price, 64)
+		if err != nil {
+			continue
+		}
+
+		for i := range ladder {
+			layer := &ladder[i]
+			if layer.Size <= 0 {
+				continue
+			}
+
+			crossed := (layer.Side == api.SideBuy && tradePrice <= layer.Price) ||
+				(layer.Side == api.SideSell && tradePrice >= layer.Price)
+			if !crossed {
+				continue
+			}
+
+			sim.RecordFill(tokenID, layer.Side, layer.Size, layer.Price)
+			result.FillsSimulated++
+			layer.Size = 0 // each layer fills at most once per replay
+		}
+	}
+
+	result.RealizedPnL, result.FinalNetSize = sim.PnL(tokenID)
+	return result, nil
+}
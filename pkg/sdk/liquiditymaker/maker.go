@@ -0,0 +1,388 @@
+// Package liquiditymaker provides a ladder-style market-making subsystem on
+// top of api.ClobClient. It continuously maintains a grid of PostOnly (GTC)
+// orders around a token's mid-price, repositioning on book updates and fills.
+package liquiditymaker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/pkg/sdk/api"
+)
+
+// TokenPair is a condition's two outcome tokens (e.g. YES/NO) quoted together.
+type TokenPair struct {
+	ConditionID string
+	YesTokenID  string
+	NoTokenID   string
+}
+
+// Config holds the knobs for a LiquidityMaker.
+type Config struct {
+	Pairs []TokenPair
+
+	// NumLayers is how many price levels to quote on each side of the mid.
+	NumLayers int
+	// AskLiquidityAmount and BidLiquidityAmount are the base size (in shares)
+	// quoted at the innermost layer; outer layers scale by LayerScale.
+	AskLiquidityAmount float64
+	BidLiquidityAmount float64
+	// PriceRangeBps is the total spread the ladder spans on each side of mid,
+	// divided evenly across NumLayers.
+	PriceRangeBps int
+	// LayerScale is the geometric size multiplier applied per layer moving
+	// away from mid (e.g. 1.5 means each layer out quotes 1.5x the size of
+	// the layer before it).
+	LayerScale float64
+
+	// AdjustmentInterval is how often the ladder is re-quoted around the
+	// current mid; each re-quote cancels the resting ladder and places a
+	// fresh one, the same way a full refresh does.
+	AdjustmentInterval time.Duration
+	// LiquidityUpdateInterval is how often the full ladder is torn down and
+	// rebuilt from scratch, regardless of fills.
+	LiquidityUpdateInterval time.Duration
+
+	// MinProfitBps is the minimum bid/ask spread (in bps of mid) required
+	// before a ladder is placed at all; below this the market is skipped.
+	MinProfitBps int
+	// MaxExposureUSDC caps total notional resting on one side of one token.
+	MaxExposureUSDC float64
+
+	// DryRun, when true, computes and logs ladders without placing orders.
+	DryRun bool
+}
+
+// position tracks average-cost accounting for realized PnL on one token.
+type position struct {
+	netSize      float64 // positive = net long shares
+	avgCost      float64 // average cost basis of buys; sells leave it unchanged
+	realizedPnL  float64
+}
+
+// LiquidityMaker maintains a quoting ladder for a set of token pairs.
+type LiquidityMaker struct {
+	client *api.ClobClient
+	config Config
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+
+	activeOrders   map[string][]string // tokenID -> resting order IDs
+	activeOrdersMu sync.RWMutex
+
+	positions   map[string]*position // tokenID -> position
+	positionsMu sync.RWMutex
+
+	ws *api.WSClient
+}
+
+// NewLiquidityMaker creates a LiquidityMaker for the given client and config.
+func NewLiquidityMaker(client *api.ClobClient, config Config) (*LiquidityMaker, error) {
+	if len(config.Pairs) == 0 {
+		return nil, fmt.Errorf("at least one token pair is required")
+	}
+	if config.NumLayers <= 0 {
+		return nil, fmt.Errorf("NumLayers must be positive")
+	}
+	if config.LayerScale <= 0 {
+		config.LayerScale = 1.0
+	}
+
+	return &LiquidityMaker{
+		client:       client,
+		config:       config,
+		stopCh:       make(chan struct{}),
+		activeOrders: make(map[string][]string),
+		positions:    make(map[string]*position),
+	}, nil
+}
+
+// Start builds the initial ladder for every configured pair, subscribes to
+// book updates to drive repositioning, and starts the adjustment and full
+// refresh loops.
+func (m *LiquidityMaker) Start(ctx context.Context) error {
+	if m.running {
+		return fmt.Errorf("liquidity maker already running")
+	}
+
+	tokenIDs := m.tokenIDs()
+
+	if !m.config.DryRun {
+		ws, err := m.client.SubscribeMarket(ctx, tokenIDs, api.MarketSubscriptionHandlers{
+			OnBookUpdate: m.onBookUpdate,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to book feed: %w", err)
+		}
+		m.ws = ws
+	}
+
+	for _, tokenID := range tokenIDs {
+		if err := m.refreshLadder(ctx, tokenID); err != nil {
+			log.Printf("[LiquidityMaker] initial ladder failed for %s: %v", tokenID, err)
+		}
+	}
+
+	m.running = true
+	m.wg.Add(2)
+	go m.adjustmentLoop(ctx)
+	go m.refreshLoop(ctx)
+
+	log.Printf("[LiquidityMaker] Started with %d pair(s), %d layer(s) per side", len(m.config.Pairs), m.config.NumLayers)
+	return nil
+}
+
+// Stop tears down resting orders, stops the book feed, and waits for the
+// background loops to exit.
+func (m *LiquidityMaker) Stop(ctx context.Context) {
+	if !m.running {
+		return
+	}
+	m.running = false
+	close(m.stopCh)
+	m.wg.Wait()
+
+	if m.ws != nil {
+		m.ws.Stop()
+	}
+
+	for _, tokenID := range m.tokenIDs() {
+		m.cancelLadder(ctx, tokenID)
+	}
+
+	log.Printf("[LiquidityMaker] Stopped")
+}
+
+func (m *LiquidityMaker) tokenIDs() []string {
+	ids := make([]string, 0, len(m.config.Pairs)*2)
+	for _, pair := range m.config.Pairs {
+		ids = append(ids, pair.YesTokenID, pair.NoTokenID)
+	}
+	return ids
+}
+
+func (m *LiquidityMaker) adjustmentLoop(ctx context.Context) {
+	defer m.wg.Done()
+
+	interval := m.config.AdjustmentInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			for _, tokenID := range m.tokenIDs() {
+				if err := m.refreshLadder(ctx, tokenID); err != nil {
+					log.Printf("[LiquidityMaker] adjustment failed for %s: %v", tokenID, err)
+				}
+			}
+		}
+	}
+}
+
+func (m *LiquidityMaker) refreshLoop(ctx context.Context) {
+	defer m.wg.Done()
+
+	interval := m.config.LiquidityUpdateInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			log.Printf("[LiquidityMaker] Full ladder refresh")
+			for _, tokenID := range m.tokenIDs() {
+				m.cancelLadder(ctx, tokenID)
+				if err := m.refreshLadder(ctx, tokenID); err != nil {
+					log.Printf("[LiquidityMaker] full refresh failed for %s: %v", tokenID, err)
+				}
+			}
+		}
+	}
+}
+
+// onBookUpdate is invoked on every book delta for a subscribed token; every
+// delta, however small, is handled the same as a scheduled adjustment tick.
+func (m *LiquidityMaker) onBookUpdate(assetID string, hash string, changes []api.WSBookChange) {
+	if !m.running {
+		return
+	}
+	ctx := context.Background()
+	if err := m.refreshLadder(ctx, assetID); err != nil {
+		log.Printf("[LiquidityMaker] book-driven reposition failed for %s: %v", assetID, err)
+	}
+}
+
+// cancelLadder cancels all orders this maker currently has resting on tokenID.
+func (m *LiquidityMaker) cancelLadder(ctx context.Context, tokenID string) {
+	m.activeOrdersMu.Lock()
+	orderIDs := m.activeOrders[tokenID]
+	delete(m.activeOrders, tokenID)
+	m.activeOrdersMu.Unlock()
+
+	if len(orderIDs) == 0 {
+		return
+	}
+	if m.config.DryRun {
+		log.Printf("[LiquidityMaker] [dry-run] would cancel %d order(s) for %s", len(orderIDs), tokenID)
+		return
+	}
+	if _, err := m.client.BatchCancel(ctx, orderIDs); err != nil {
+		log.Printf("[LiquidityMaker] BatchCancel failed for %s: %v", tokenID, err)
+	}
+}
+
+// refreshLadder cancels tokenID's existing orders and places a fresh ladder
+// around its current mid-price.
+func (m *LiquidityMaker) refreshLadder(ctx context.Context, tokenID string) error {
+	book, err := m.client.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get order book: %w", err)
+	}
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return fmt.Errorf("insufficient liquidity to derive mid price")
+	}
+
+	bestBid, _ := strconv.ParseFloat(book.Bids[0].Price, 64)
+	bestAsk, _ := strconv.ParseFloat(book.Asks[0].Price, 64)
+	mid := (bestBid + bestAsk) / 2
+
+	spreadBps := int((bestAsk - bestBid) / mid * 10000)
+	if spreadBps < m.config.MinProfitBps {
+		log.Printf("[LiquidityMaker] skipping %s: spread %dbps below MinProfitBps %dbps", tokenID, spreadBps, m.config.MinProfitBps)
+		return nil
+	}
+
+	m.cancelLadder(ctx, tokenID)
+
+	reqs := m.buildLadder(tokenID, mid)
+	if m.config.DryRun {
+		log.Printf("[LiquidityMaker] [dry-run] would place %d order(s) for %s around mid %.4f", len(reqs), tokenID, mid)
+		return nil
+	}
+
+	results, err := m.client.BatchPlaceOrders(ctx, reqs)
+	if err != nil {
+		return fmt.Errorf("BatchPlaceOrders failed: %w", err)
+	}
+
+	var placedIDs []string
+	for _, res := range results {
+		if res.Err != nil {
+			log.Printf("[LiquidityMaker] order failed for %s: %v", tokenID, res.Err)
+			continue
+		}
+		if res.Response != nil && res.Response.OrderID != "" {
+			placedIDs = append(placedIDs, res.Response.OrderID)
+		}
+	}
+
+	m.activeOrdersMu.Lock()
+	m.activeOrders[tokenID] = placedIDs
+	m.activeOrdersMu.Unlock()
+
+	return nil
+}
+
+// buildLadder computes the bid/ask layers around mid, scaling size
+// geometrically by LayerScale moving away from mid and respecting
+// MaxExposureUSDC per side.
+func (m *LiquidityMaker) buildLadder(tokenID string, mid float64) []api.BatchOrderRequest {
+	reqs := make([]api.BatchOrderRequest, 0, m.config.NumLayers*2)
+
+	layerStepBps := float64(m.config.PriceRangeBps) / float64(m.config.NumLayers)
+
+	bidExposure := 0.0
+	askExposure := 0.0
+
+	for layer := 1; layer <= m.config.NumLayers; layer++ {
+		scale := 1.0
+		if layer > 1 {
+			scale = pow(m.config.LayerScale, float64(layer-1))
+		}
+
+		bidPrice := mid * (1 - (layerStepBps*float64(layer))/10000)
+		bidSize := m.config.BidLiquidityAmount * scale
+		if bidPrice > 0 && (m.config.MaxExposureUSDC <= 0 || bidExposure+bidSize*bidPrice <= m.config.MaxExposureUSDC) {
+			bidExposure += bidSize * bidPrice
+			reqs = append(reqs, api.BatchOrderRequest{TokenID: tokenID, Side: api.SideBuy, Size: bidSize, Price: bidPrice})
+		}
+
+		askPrice := mid * (1 + (layerStepBps*float64(layer))/10000)
+		askSize := m.config.AskLiquidityAmount * scale
+		if askPrice < 1 && (m.config.MaxExposureUSDC <= 0 || askExposure+askSize*askPrice <= m.config.MaxExposureUSDC) {
+			askExposure += askSize * askPrice
+			reqs = append(reqs, api.BatchOrderRequest{TokenID: tokenID, Side: api.SideSell, Size: askSize, Price: askPrice})
+		}
+	}
+
+	return reqs
+}
+
+// pow is a tiny integer-exponent power helper, avoiding a math.Pow import
+// for what is always a small positive exponent.
+func pow(base float64, exp float64) float64 {
+	result := 1.0
+	for i := 0; i < int(exp); i++ {
+		result *= base
+	}
+	return result
+}
+
+// RecordFill updates average-cost PnL accounting for tokenID after a fill.
+// Buys extend/average into the position; sells realize PnL against the
+// current average cost (standard average-cost accounting).
+func (m *LiquidityMaker) RecordFill(tokenID string, side api.Side, size float64, price float64) {
+	m.positionsMu.Lock()
+	defer m.positionsMu.Unlock()
+
+	pos, ok := m.positions[tokenID]
+	if !ok {
+		pos = &position{}
+		m.positions[tokenID] = pos
+	}
+
+	switch side {
+	case api.SideBuy:
+		totalCost := pos.avgCost*pos.netSize + price*size
+		pos.netSize += size
+		if pos.netSize != 0 {
+			pos.avgCost = totalCost / pos.netSize
+		}
+	case api.SideSell:
+		pos.realizedPnL += (price - pos.avgCost) * size
+		pos.netSize -= size
+	}
+}
+
+// PnL returns the realized PnL and current net position size for tokenID.
+func (m *LiquidityMaker) PnL(tokenID string) (realized float64, netSize float64) {
+	m.positionsMu.RLock()
+	defer m.positionsMu.RUnlock()
+
+	pos, ok := m.positions[tokenID]
+	if !ok {
+		return 0, 0
+	}
+	return pos.realizedPnL, pos.netSize
+}
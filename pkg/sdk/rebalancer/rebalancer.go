@@ -0,0 +1,407 @@
+// Package rebalancer turns ClobClient into a programmable index-fund engine:
+// given a target weight map over a set of markets, it computes the minimal
+// set of buy/sell orders needed to reach that allocation and places them via
+// BatchPlaceOrders.
+package rebalancer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/betbot/gobet/pkg/sdk/api"
+)
+
+// RebalanceMode controls when Rebalancer re-evaluates the target allocation.
+type RebalanceMode string
+
+const (
+	// RebalanceModeOnStart rebalances once when Start is called and never again.
+	RebalanceModeOnStart RebalanceMode = "on_start"
+	// RebalanceModeScheduled rebalances unconditionally every ScheduleInterval.
+	RebalanceModeScheduled RebalanceMode = "scheduled"
+	// RebalanceModeThreshold rebalances only when a market's actual weight has
+	// drifted from its target by more than Threshold, checked every ScheduleInterval.
+	RebalanceModeThreshold RebalanceMode = "threshold"
+)
+
+// MarketAllocation is one market's target weight within the portfolio.
+type MarketAllocation struct {
+	ConditionID  string
+	Slug         string
+	YesTokenID   string
+	NoTokenID    string
+	NegRisk      bool
+	TargetWeight float64 // fraction of portfolio NAV, 0..1
+}
+
+// Config holds the knobs for a Rebalancer.
+type Config struct {
+	Allocations []MarketAllocation
+
+	Mode             RebalanceMode
+	Threshold        float64       // fractional weight drift that triggers a rebalance, Threshold mode only
+	ScheduleInterval time.Duration // evaluation cadence for Scheduled/Threshold modes
+
+	// MaxSlippageBps aborts an individual order if walking the live book via
+	// CalculateOptimalFill implies more price impact than this.
+	MaxSlippageBps int
+
+	// DryRun, when true, computes and logs the plan without signing or
+	// placing any orders.
+	DryRun bool
+}
+
+// PlanItem is a single order the planner wants to place to move the
+// portfolio toward its target allocation.
+type PlanItem struct {
+	ConditionID string
+	TokenID     string
+	Side        api.Side
+	Size        float64
+	Price       float64
+	NegRisk     bool
+	Reason      string
+}
+
+// minRebalanceSize mirrors createSignedOrder's minimum token size; deltas
+// smaller than this aren't worth a round trip.
+const minRebalanceSize = 0.1
+
+// Rebalancer computes and executes orders to reach a target allocation.
+type Rebalancer struct {
+	client *api.ClobClient
+	config Config
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+
+	// lastWeights is the portfolio's actual weight per condition as of the
+	// last Plan call, used by Threshold mode to detect drift.
+	lastWeights   map[string]float64
+	lastWeightsMu sync.RWMutex
+}
+
+// NewRebalancer creates a Rebalancer for the given client and config.
+func NewRebalancer(client *api.ClobClient, config Config) (*Rebalancer, error) {
+	if len(config.Allocations) == 0 {
+		return nil, fmt.Errorf("at least one market allocation is required")
+	}
+
+	total := 0.0
+	for _, a := range config.Allocations {
+		total += a.TargetWeight
+	}
+	if total > 1.0001 {
+		return nil, fmt.Errorf("target weights sum to %.4f, exceeds 1.0", total)
+	}
+
+	return &Rebalancer{
+		client:      client,
+		config:      config,
+		stopCh:      make(chan struct{}),
+		lastWeights: make(map[string]float64),
+	}, nil
+}
+
+// NewRebalancerFromWeights builds a Rebalancer from a flat target allocation
+// keyed by token ID, for callers that just want a set of weights summing to
+// 1 and don't need MarketAllocation's separate YES/NO arbitrage routing -
+// ConditionID and YesTokenID are both set to assetID, and NoTokenID is left
+// empty so planDelta trades the asset directly.
+func NewRebalancerFromWeights(client *api.ClobClient, weights map[string]float64, config Config) (*Rebalancer, error) {
+	allocations := make([]MarketAllocation, 0, len(weights))
+	for assetID, weight := range weights {
+		allocations = append(allocations, MarketAllocation{
+			ConditionID:  assetID,
+			YesTokenID:   assetID,
+			TargetWeight: weight,
+		})
+	}
+	config.Allocations = allocations
+	return NewRebalancer(client, config)
+}
+
+// Start runs the rebalancer according to its configured Mode. For
+// RebalanceModeOnStart it rebalances once and returns. For the scheduled
+// modes it also rebalances once first, then launches a background loop and
+// returns; call Stop to end it.
+func (r *Rebalancer) Start(ctx context.Context) error {
+	if r.running {
+		return fmt.Errorf("rebalancer already running")
+	}
+
+	if _, err := r.Rebalance(ctx); err != nil {
+		return fmt.Errorf("initial rebalance failed: %w", err)
+	}
+
+	if r.config.Mode == RebalanceModeOnStart {
+		return nil
+	}
+
+	r.running = true
+	r.wg.Add(1)
+	go r.loop(ctx)
+	return nil
+}
+
+// Stop ends the background rebalance loop, if one is running.
+func (r *Rebalancer) Stop() {
+	if !r.running {
+		return
+	}
+	r.running = false
+	close(r.stopCh)
+	r.wg.Wait()
+}
+
+func (r *Rebalancer) loop(ctx context.Context) {
+	defer r.wg.Done()
+
+	interval := r.config.ScheduleInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			if r.config.Mode == RebalanceModeThreshold && !r.driftExceedsThreshold() {
+				continue
+			}
+			if _, err := r.Rebalance(ctx); err != nil {
+				log.Printf("[Rebalancer] rebalance failed: %v", err)
+			}
+		}
+	}
+}
+
+func (r *Rebalancer) driftExceedsThreshold() bool {
+	r.lastWeightsMu.RLock()
+	defer r.lastWeightsMu.RUnlock()
+
+	for _, a := range r.config.Allocations {
+		actual := r.lastWeights[a.ConditionID]
+		if diff := actual - a.TargetWeight; diff > r.config.Threshold || diff < -r.config.Threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// Rebalance computes the current plan and, unless DryRun is set, signs and
+// places the resulting orders via BatchPlaceOrders. It always returns the
+// plan so callers (and DryRun mode) can inspect what would happen.
+func (r *Rebalancer) Rebalance(ctx context.Context) ([]PlanItem, error) {
+	plan, err := r.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.config.DryRun {
+		for _, item := range plan {
+			log.Printf("[Rebalancer] [dry-run] %s %.4f of %s @ %.4f (%s)", item.Side, item.Size, item.TokenID, item.Price, item.Reason)
+		}
+		return plan, nil
+	}
+
+	if len(plan) == 0 {
+		return plan, nil
+	}
+
+	reqs := make([]api.BatchOrderRequest, len(plan))
+	for i, item := range plan {
+		reqs[i] = api.BatchOrderRequest{
+			TokenID: item.TokenID,
+			Side:    item.Side,
+			Size:    item.Size,
+			Price:   item.Price,
+			NegRisk: item.NegRisk,
+		}
+	}
+
+	results, err := r.client.BatchPlaceOrders(ctx, reqs)
+	if err != nil {
+		return plan, fmt.Errorf("BatchPlaceOrders failed: %w", err)
+	}
+
+	for i, res := range results {
+		if res.Err != nil {
+			log.Printf("[Rebalancer] order failed for %s: %v", plan[i].TokenID, res.Err)
+		}
+	}
+
+	return plan, nil
+}
+
+// Plan fetches current positions, computes portfolio NAV, and returns the
+// minimal set of orders needed to reach each allocation's target weight.
+// For YES/NO pairs of the same condition it prefers the cheaper side of an
+// arbitrage-equivalent move (selling YES instead of buying NO, or vice
+// versa) when that side's estimated price impact is lower.
+func (r *Rebalancer) Plan(ctx context.Context) ([]PlanItem, error) {
+	positions, err := r.client.GetPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+
+	usdcBalance, err := r.client.GetUSDCBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch USDC balance: %w", err)
+	}
+
+	valueByToken := make(map[string]float64)
+	nav := usdcBalance
+	for _, pos := range positions {
+		size := float64(pos.Size)
+		price := float64(pos.CurPrice)
+		value := size * price
+		valueByToken[pos.Asset] = value
+		nav += value
+	}
+
+	if nav <= 0 {
+		return nil, fmt.Errorf("portfolio NAV is zero, nothing to rebalance against")
+	}
+
+	var plan []PlanItem
+
+	for _, alloc := range r.config.Allocations {
+		currentValue := valueByToken[alloc.YesTokenID]
+		currentWeight := currentValue / nav
+
+		r.lastWeightsMu.Lock()
+		r.lastWeights[alloc.ConditionID] = currentWeight
+		r.lastWeightsMu.Unlock()
+
+		targetValue := nav * alloc.TargetWeight
+		deltaValue := targetValue - currentValue
+		if deltaValue == 0 {
+			continue
+		}
+
+		item, err := r.planDelta(ctx, alloc, deltaValue)
+		if err != nil {
+			log.Printf("[Rebalancer] skipping %s: %v", alloc.ConditionID, err)
+			continue
+		}
+		if item != nil {
+			plan = append(plan, *item)
+		}
+	}
+
+	return plan, nil
+}
+
+// planDelta turns a target USDC delta for a condition's YES token into a
+// single order, choosing between trading YES directly and trading NO
+// (its arbitrage-equivalent complement) based on which side achieves the
+// delta with less price impact, subject to MaxSlippageBps.
+func (r *Rebalancer) planDelta(ctx context.Context, alloc MarketAllocation, deltaValue float64) (*PlanItem, error) {
+	side := api.SideBuy
+	amount := deltaValue
+	if deltaValue < 0 {
+		side = api.SideSell
+		amount = -deltaValue
+	}
+
+	yesFill, err := r.fillFromBook(ctx, alloc.YesTokenID, side, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fill YES side: %w", err)
+	}
+	if yesFill.size < minRebalanceSize {
+		return nil, nil
+	}
+
+	best := &PlanItem{
+		ConditionID: alloc.ConditionID,
+		TokenID:     alloc.YesTokenID,
+		Side:        side,
+		Size:        yesFill.size,
+		Price:       yesFill.avgPrice,
+		NegRisk:     alloc.NegRisk,
+		Reason:      "direct YES trade",
+	}
+	bestImpact := yesFill.impactBps
+
+	// Trading the complementary NO side is arbitrage-equivalent: buying YES
+	// is economically similar to selling NO, and vice versa. Compare costs
+	// and prefer whichever side fills with less price impact.
+	if alloc.NoTokenID != "" {
+		noSide := api.SideSell
+		if side == api.SideSell {
+			noSide = api.SideBuy
+		}
+		if noFill, err := r.fillFromBook(ctx, alloc.NoTokenID, noSide, amount); err == nil {
+			if noFill.size >= minRebalanceSize && noFill.impactBps < bestImpact {
+				best = &PlanItem{
+					ConditionID: alloc.ConditionID,
+					TokenID:     alloc.NoTokenID,
+					Side:        noSide,
+					Size:        noFill.size,
+					Price:       noFill.avgPrice,
+					NegRisk:     alloc.NegRisk,
+					Reason:      "cheaper via NO side (arbitrage-equivalent)",
+				}
+				bestImpact = noFill.impactBps
+			}
+		}
+	}
+
+	if r.config.MaxSlippageBps > 0 && bestImpact > r.config.MaxSlippageBps {
+		return nil, fmt.Errorf("estimated impact %dbps exceeds MaxSlippageBps %dbps", bestImpact, r.config.MaxSlippageBps)
+	}
+
+	return best, nil
+}
+
+// bookFill is the achievable size/price/impact for filling amountUSDC of a
+// token on one side of its order book.
+type bookFill struct {
+	size      float64
+	avgPrice  float64
+	impactBps int
+}
+
+// fillFromBook fetches tokenID's live order book and walks it via
+// CalculateOptimalFill to find how much of amountUSDC can actually be
+// filled, rather than assuming the whole delta fills at the last-trade
+// price.
+func (r *Rebalancer) fillFromBook(ctx context.Context, tokenID string, side api.Side, amountUSDC float64) (*bookFill, error) {
+	book, err := r.client.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book for %s: %w", tokenID, err)
+	}
+
+	levels := book.Asks
+	if side == api.SideSell {
+		levels = book.Bids
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no liquidity on %s side of %s", side, tokenID)
+	}
+	bestPrice, _ := strconv.ParseFloat(levels[0].Price, 64)
+
+	size, avgPrice, _ := api.CalculateOptimalFill(book, side, amountUSDC)
+	if size <= 0 {
+		return nil, fmt.Errorf("no fillable size on %s side of %s", side, tokenID)
+	}
+
+	impactBps := 0
+	if bestPrice > 0 {
+		impactBps = int(math.Abs(avgPrice-bestPrice) / bestPrice * 10000)
+	}
+
+	return &bookFill{size: size, avgPrice: avgPrice, impactBps: impactBps}, nil
+}
@@ -0,0 +1,33 @@
+package websocket
+
+import "time"
+
+// Clock 抽象“现在几点”，默认实现直接用 wall clock；Replay 用它把时间对齐到
+// 历史事件的时间戳，而不是真实时间，这样下游消费者（比如动量策略里按
+// CooldownSecs 限流的 Debouncer）在回放和实盘下看到的时间推进方式是一致的。
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock 是 Clock 的默认实现，直接转发给 time.Now。
+type RealClock struct{}
+
+func (RealClock) Now() time.Time { return time.Now() }
+
+// simClock 是 Replay 内部使用的可推进时钟：Now() 返回最近一次 Advance 设置
+// 的时间，而不是 wall clock。
+type simClock struct {
+	now time.Time
+}
+
+func newSimClock(start time.Time) *simClock {
+	return &simClock{now: start}
+}
+
+func (c *simClock) Now() time.Time { return c.now }
+
+func (c *simClock) Advance(t time.Time) {
+	if t.After(c.now) {
+		c.now = t
+	}
+}
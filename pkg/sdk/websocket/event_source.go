@@ -0,0 +1,149 @@
+package websocket
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// ReplayEvent 是 EventSource 产出的一条历史事件。两个 payload 字段互斥，
+// 由 Kind 指明哪一个有效，和 MarketClient/UserClient 实际能广播的消息种类
+// 一一对应。
+type ReplayEvent struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Kind      ReplayKind    `json:"kind"`
+	Message   MarketMessage `json:"message,omitempty"`
+	Trade     TradeEvent    `json:"trade,omitempty"`
+}
+
+// ReplayKind 标识 ReplayEvent 携带的具体 payload。
+type ReplayKind string
+
+const (
+	ReplayKindMessage ReplayKind = "message"
+	ReplayKindTrade   ReplayKind = "trade"
+)
+
+// EventSource 按时间顺序产生历史事件，供 ReplayClient 消费。实现可以是
+// JSONL 文件（JSONLEventSource）、SQL 查询结果集，或其他任意来源；Replay
+// 本身不关心事件从哪来，只关心 Next 返回的顺序。
+type EventSource interface {
+	// Next 返回下一条事件；io.EOF 表示流已结束，此时其余返回值无效。
+	Next() (ReplayEvent, error)
+	// Close 释放底层资源（文件句柄、数据库连接等）。
+	Close() error
+}
+
+// JSONLEventSource 从 io.Reader 里按行读取用 Recorder 写出的 JSONL 记录。
+type JSONLEventSource struct {
+	scanner *bufio.Scanner
+	closer  io.Closer
+}
+
+// NewJSONLEventSource 用一个 io.Reader 构造 EventSource；如果 r 同时实现了
+// io.Closer，Close 会转发给它，否则 Close 是空操作。
+func NewJSONLEventSource(r io.Reader) *JSONLEventSource {
+	s := &JSONLEventSource{scanner: bufio.NewScanner(r)}
+	s.scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	if c, ok := r.(io.Closer); ok {
+		s.closer = c
+	}
+	return s
+}
+
+func (s *JSONLEventSource) Next() (ReplayEvent, error) {
+	for s.scanner.Scan() {
+		line := s.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var ev ReplayEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return ReplayEvent{}, err
+		}
+		return ev, nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return ReplayEvent{}, err
+	}
+	return ReplayEvent{}, io.EOF
+}
+
+func (s *JSONLEventSource) Close() error {
+	if s.closer != nil {
+		return s.closer.Close()
+	}
+	return nil
+}
+
+// mergedSource 用最小堆把多个按时间排序的 EventSource 合并成一路全局按
+// Timestamp 排序的流，用于多资产行情各自独立记录、回放时需要确定性交错的
+// 场景。
+type mergedSource struct {
+	heap    mergeHeap
+	sources []EventSource
+}
+
+type mergeHeapItem struct {
+	event  ReplayEvent
+	source EventSource
+}
+
+type mergeHeap []mergeHeapItem
+
+func (h mergeHeap) Len() int            { return len(h) }
+func (h mergeHeap) Less(i, j int) bool  { return h[i].event.Timestamp.Before(h[j].event.Timestamp) }
+func (h mergeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x interface{}) { *h = append(*h, x.(mergeHeapItem)) }
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// MergeSources 按 Timestamp 把多个 EventSource 合并成一路确定性排序的
+// EventSource。Close 会关闭所有底层 source。
+func MergeSources(sources ...EventSource) EventSource {
+	m := &mergedSource{sources: sources}
+	for _, src := range sources {
+		ev, err := src.Next()
+		if err != nil {
+			continue
+		}
+		heap.Push(&m.heap, mergeHeapItem{event: ev, source: src})
+	}
+	heap.Init(&m.heap)
+	return m
+}
+
+func (m *mergedSource) Next() (ReplayEvent, error) {
+	if m.heap.Len() == 0 {
+		return ReplayEvent{}, io.EOF
+	}
+	top := heap.Pop(&m.heap).(mergeHeapItem)
+
+	if next, err := top.source.Next(); err == nil {
+		heap.Push(&m.heap, mergeHeapItem{event: next, source: top.source})
+	}
+
+	return top.event, nil
+}
+
+func (m *mergedSource) Close() error {
+	var firstErr error
+	seen := make(map[EventSource]bool, len(m.sources))
+	for _, src := range m.sources {
+		if seen[src] {
+			continue
+		}
+		seen[src] = true
+		if err := src.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -389,6 +389,9 @@ func (c *MarketClient) readLoop() {
 			}
 			// 其他错误：记录并重连
 			log.Printf("[WebSocket] 读取错误: %v, 重连中...", err)
+			if c.config.Notifier != nil {
+				c.config.Notifier.Notify(NotifyEvent{EventType: EventConnError, Message: err.Error(), Time: time.Now()})
+			}
 			if c.config.ReconnectEnabled {
 				c.reconnect()
 			} else {
@@ -452,6 +455,13 @@ func (c *MarketClient) reconnect() {
 		case c.errChan <- fmt.Errorf("达到最大重连次数 (%d)", c.config.MaxReconnectAttempts):
 		default:
 		}
+		if c.config.Notifier != nil {
+			c.config.Notifier.Notify(NotifyEvent{
+				EventType: EventConnError,
+				Message:   fmt.Sprintf("达到最大重连次数 (%d)", c.config.MaxReconnectAttempts),
+				Time:      time.Now(),
+			})
+		}
 		return
 	}
 
@@ -462,6 +472,13 @@ func (c *MarketClient) reconnect() {
 	}
 
 	log.Printf("[WebSocket] %v 后重连 (尝试 %d/%d)...", delay, attempts, c.config.MaxReconnectAttempts)
+	if c.config.Notifier != nil {
+		c.config.Notifier.Notify(NotifyEvent{
+			EventType: EventReconnect,
+			Message:   fmt.Sprintf("%v 后重连 (尝试 %d/%d)", delay, attempts, c.config.MaxReconnectAttempts),
+			Time:      time.Now(),
+		})
+	}
 
 	select {
 	case <-c.ctx.Done():
@@ -711,6 +728,15 @@ func (c *MarketClient) processMessage(msg MarketMessage) {
 			}
 			c.tradeHandler(event)
 		}
+		if c.config.Notifier != nil {
+			c.config.Notifier.Notify(NotifyEvent{
+				EventType: EventLastTradePrice,
+				AssetID:   msg.AssetID,
+				Market:    msg.Market,
+				Price:     msg.Price,
+				Time:      time.Now(),
+			})
+		}
 
 	case EventPriceChange:
 		// 价格变化 - 可能表示交易活动
@@ -0,0 +1,81 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// Recorder 包装一个正在运行的 MarketClient，把它收到的每一帧消息落盘成
+// JSONL（JSONLEventSource 可以直接读回），这样一次实盘会话可以之后离线
+// 用 ReplayClient 对着一个改过的 brain.Brain 重放，而不用再连 Polymarket。
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRecorder 创建一个把记录写到 w 的 Recorder。w 通常是打开的文件，调用方
+// 负责在不再需要时关闭它。
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// RecordMessage 落盘一条市场消息。
+func (r *Recorder) RecordMessage(msg MarketMessage) error {
+	ts := time.Now()
+	if msg.Timestamp > 0 {
+		ts = time.Unix(msg.Timestamp, 0)
+	}
+	return r.write(ReplayEvent{Timestamp: ts, Kind: ReplayKindMessage, Message: msg})
+}
+
+// RecordTrade 落盘一条交易事件。
+func (r *Recorder) RecordTrade(trade TradeEvent) error {
+	ts := trade.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	return r.write(ReplayEvent{Timestamp: ts, Kind: ReplayKindTrade, Trade: trade})
+}
+
+func (r *Recorder) write(ev ReplayEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enc.Encode(ev)
+}
+
+// Tap 接管 client 的消息通道：每一帧先落盘，再转发到返回的 channel。调用方
+// 之后应该消费 Tap 返回的 channel，而不是再调用 client.Messages()——两者会
+// 竞争同一路底层消息，双重消费只会让消息被随机分流。
+//
+// ctx 取消或者 client 的消息通道关闭时，返回的 channel 会被关闭。
+func (r *Recorder) Tap(ctx context.Context, client *MarketClient) <-chan interface{} {
+	out := make(chan interface{}, cap(client.msgChan))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-client.msgChan:
+				if !ok {
+					return
+				}
+				if m, ok := msg.(MarketMessage); ok {
+					if err := r.RecordMessage(m); err != nil {
+						log.Printf("[Recorder] 写入记录失败: %v", err)
+					}
+				}
+				select {
+				case out <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,102 @@
+package websocket
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+)
+
+// ReplayConfig 配置一次回放。
+type ReplayConfig struct {
+	// Speed 是相对真实时间的加速倍数：1.0 按事件原始时间间隔回放，2.0 是
+	// 两倍速，<= 0 表示不等待、尽快把所有事件吐出去（用于离线回测）。
+	Speed float64
+}
+
+// ReplayClient 从 EventSource 读取历史事件，按和实时客户端一样的
+// OnTradeEvent/OnBookUpdate/OnUserTrade 回调分发，让策略（尤其是
+// brain.Brain 驱动的决策逻辑）完全不用感知自己是在回放还是在连实盘。
+type ReplayClient struct {
+	source EventSource
+	cfg    ReplayConfig
+	clock  *simClock
+
+	tradeHandler     TradeHandler
+	bookUpdateHandler func(MarketMessage)
+	userTradeHandler UserTradeHandler
+}
+
+// NewReplayClient 创建一个按 cfg 配置回放 source 的 ReplayClient。
+func NewReplayClient(source EventSource, cfg ReplayConfig) *ReplayClient {
+	return &ReplayClient{
+		source: source,
+		cfg:    cfg,
+		clock:  newSimClock(time.Time{}),
+	}
+}
+
+// OnTradeEvent 注册交易事件处理器，语义等价于 MarketClient 构造时传入的
+// TradeHandler。
+func (r *ReplayClient) OnTradeEvent(h TradeHandler) { r.tradeHandler = h }
+
+// OnBookUpdate 注册订单簿/价格变化原始消息处理器。
+func (r *ReplayClient) OnBookUpdate(h func(MarketMessage)) { r.bookUpdateHandler = h }
+
+// OnUserTrade 注册用户成交处理器，语义等价于 UserClient 构造时传入的
+// UserTradeHandler。当前 ReplayEvent 还不携带用户成交记录（见 Package 注释），
+// 预留该 setter 是为了让回放消费方和实时客户端代码路径一致，便于后续扩展
+// EventSource 产出用户成交事件。
+func (r *ReplayClient) OnUserTrade(h UserTradeHandler) { r.userTradeHandler = h }
+
+// Clock 返回本次回放驱动的模拟时钟：Now() 随事件推进，不随 wall clock 走，
+// 策略里用于冷却/窗口判断的逻辑注入这个 Clock 就能在回放下得到确定性结果。
+func (r *ReplayClient) Clock() Clock { return r.clock }
+
+// Run 顺序消费 source，直到耗尽或 ctx 被取消。
+func (r *ReplayClient) Run(ctx context.Context) error {
+	var prevTs time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		ev, err := r.source.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if r.cfg.Speed > 0 && !prevTs.IsZero() && ev.Timestamp.After(prevTs) {
+			wait := time.Duration(float64(ev.Timestamp.Sub(prevTs)) / r.cfg.Speed)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+		prevTs = ev.Timestamp
+		r.clock.Advance(ev.Timestamp)
+
+		r.dispatch(ev)
+	}
+}
+
+func (r *ReplayClient) dispatch(ev ReplayEvent) {
+	switch ev.Kind {
+	case ReplayKindTrade:
+		if r.tradeHandler != nil {
+			r.tradeHandler(ev.Trade)
+		}
+	case ReplayKindMessage:
+		if r.bookUpdateHandler != nil {
+			r.bookUpdateHandler(ev.Message)
+		}
+	default:
+		log.Printf("[Replay] 未知事件类型: %s", ev.Kind)
+	}
+}
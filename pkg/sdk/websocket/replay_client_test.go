@@ -0,0 +1,88 @@
+package websocket
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+type sliceSource struct {
+	events []ReplayEvent
+	i      int
+}
+
+func (s *sliceSource) Next() (ReplayEvent, error) {
+	if s.i >= len(s.events) {
+		return ReplayEvent{}, io.EOF
+	}
+	ev := s.events[s.i]
+	s.i++
+	return ev, nil
+}
+
+func (s *sliceSource) Close() error { return nil }
+
+// TestMergeSources_OrdersByTimestamp 测试多路 EventSource 按时间戳合并后全局有序。
+func TestMergeSources_OrdersByTimestamp(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+
+	btc := &sliceSource{events: []ReplayEvent{
+		{Timestamp: base, Kind: ReplayKindTrade, Trade: TradeEvent{AssetID: "BTC", Price: 1}},
+		{Timestamp: base.Add(3 * time.Second), Kind: ReplayKindTrade, Trade: TradeEvent{AssetID: "BTC", Price: 2}},
+	}}
+	eth := &sliceSource{events: []ReplayEvent{
+		{Timestamp: base.Add(1 * time.Second), Kind: ReplayKindTrade, Trade: TradeEvent{AssetID: "ETH", Price: 10}},
+		{Timestamp: base.Add(2 * time.Second), Kind: ReplayKindTrade, Trade: TradeEvent{AssetID: "ETH", Price: 20}},
+	}}
+
+	merged := MergeSources(btc, eth)
+	defer merged.Close()
+
+	wantOrder := []string{"BTC", "ETH", "ETH", "BTC"}
+	for i, want := range wantOrder {
+		ev, err := merged.Next()
+		if err != nil {
+			t.Fatalf("第 %d 条事件读取失败: %v", i, err)
+		}
+		if ev.Trade.AssetID != want {
+			t.Errorf("第 %d 条事件期望 AssetID=%s，得到 %s", i, want, ev.Trade.AssetID)
+		}
+	}
+
+	if _, err := merged.Next(); err == nil {
+		t.Error("合并流耗尽后应该返回错误（EOF）")
+	}
+}
+
+// TestReplayClient_DispatchesByKind 测试 ReplayClient 按 Kind 把事件分发给对应 handler。
+func TestReplayClient_DispatchesByKind(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	source := &sliceSource{events: []ReplayEvent{
+		{Timestamp: base, Kind: ReplayKindTrade, Trade: TradeEvent{AssetID: "BTC", Price: 42}},
+		{Timestamp: base.Add(time.Second), Kind: ReplayKindMessage, Message: MarketMessage{AssetID: "BTC", EventType: EventBook}},
+	}}
+
+	client := NewReplayClient(source, ReplayConfig{Speed: 0})
+
+	var gotTrade TradeEvent
+	var gotMsg MarketMessage
+	client.OnTradeEvent(func(e TradeEvent) { gotTrade = e })
+	client.OnBookUpdate(func(m MarketMessage) { gotMsg = m })
+
+	if err := client.Run(context.Background()); err != nil {
+		t.Fatalf("Run 不应该返回错误: %v", err)
+	}
+
+	if gotTrade.AssetID != "BTC" || gotTrade.Price != 42 {
+		t.Errorf("交易事件未正确分发: %+v", gotTrade)
+	}
+	if gotMsg.AssetID != "BTC" || gotMsg.EventType != EventBook {
+		t.Errorf("市场消息未正确分发: %+v", gotMsg)
+	}
+
+	// Speed<=0 时应该尽快播放完，模拟时钟应该推进到最后一条事件的时间戳
+	if !client.Clock().Now().Equal(base.Add(time.Second)) {
+		t.Errorf("回放时钟应该推进到最后一条事件的时间戳，得到 %v", client.Clock().Now())
+	}
+}
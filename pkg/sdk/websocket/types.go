@@ -45,6 +45,10 @@ const (
 	// 用户频道事件类型
 	EventTrade EventType = "trade" // 交易事件
 	EventOrder EventType = "order" // 订单事件
+
+	// 连接生命周期事件类型（不是官方协议的 event_type，仅用于喂给 Notifier）
+	EventReconnect EventType = "reconnect" // 正在重连
+	EventConnError EventType = "conn_error" // 连接/解析错误
 )
 
 // MarketMessage 表示市场频道的 WebSocket 消息
@@ -78,6 +82,24 @@ type TradeHandler func(event TradeEvent)
 // UserTradeHandler 是用户交易事件的处理函数
 type UserTradeHandler func(trade api.DataTrade)
 
+// NotifyEvent 是 MarketClient/UserClient 对外广播的一条通知事件，Notifier
+// 实现（例如 pkg/notifier/lark）据此渲染消息并推送到外部渠道。
+type NotifyEvent struct {
+	EventType EventType // EventLastTradePrice / EventTrade / EventReconnect / EventConnError
+	AssetID   string    // 相关资产 ID（连接级事件可能为空）
+	Market    string    // 相关市场 ID（可选）
+	Price     string    // 成交价（仅交易事件）
+	Message   string    // 文本说明（主要用于重连/错误事件）
+	Time      time.Time
+}
+
+// Notifier 是 Config.Notifier 接受的通知接口，MarketClient/UserClient 不关心
+// 具体实现是飞书、Slack 还是别的渠道，只负责把事件喂给它。Notify 不应该阻塞
+// 调用方太久；真正的批量发送/重试应该在实现内部异步完成。
+type Notifier interface {
+	Notify(event NotifyEvent)
+}
+
 // Config 是 WebSocket 客户端配置
 type Config struct {
 	// 代理设置
@@ -102,6 +124,10 @@ type Config struct {
 	ReadBufferSize   int           // 读缓冲区大小
 	WriteBufferSize  int           // 写缓冲区大小
 	HandshakeTimeout time.Duration // 握手超时时间
+
+	// Notifier 可选的外部通知渠道（例如飞书机器人）。为空时不发送任何通知，
+	// 行为与之前完全一致。
+	Notifier Notifier
 }
 
 // DefaultConfig 返回默认配置
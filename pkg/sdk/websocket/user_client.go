@@ -457,6 +457,9 @@ func (c *UserClient) readLoop() {
 			}
 			// 其他错误：记录并重连
 			log.Printf("[WSUser] 读取错误: %v, 重连中...", err)
+			if c.config.Notifier != nil {
+				c.config.Notifier.Notify(NotifyEvent{EventType: EventConnError, Message: err.Error(), Time: time.Now()})
+			}
 			if c.config.ReconnectEnabled {
 				c.reconnect()
 			} else {
@@ -541,6 +544,13 @@ func (c *UserClient) reconnect() {
 		case c.errChan <- fmt.Errorf("达到最大重连次数 (%d)", c.config.MaxReconnectAttempts):
 		default:
 		}
+		if c.config.Notifier != nil {
+			c.config.Notifier.Notify(NotifyEvent{
+				EventType: EventConnError,
+				Message:   fmt.Sprintf("达到最大重连次数 (%d)", c.config.MaxReconnectAttempts),
+				Time:      time.Now(),
+			})
+		}
 		return
 	}
 
@@ -551,6 +561,13 @@ func (c *UserClient) reconnect() {
 	}
 
 	log.Printf("[WSUser] %v 后重连 (尝试 %d/%d)...", delay, attempts, c.config.MaxReconnectAttempts)
+	if c.config.Notifier != nil {
+		c.config.Notifier.Notify(NotifyEvent{
+			EventType: EventReconnect,
+			Message:   fmt.Sprintf("%v 后重连 (尝试 %d/%d)", delay, attempts, c.config.MaxReconnectAttempts),
+			Time:      time.Now(),
+		})
+	}
 
 	select {
 	case <-c.ctx.Done():
@@ -620,8 +637,8 @@ func (c *UserClient) handleUserMessage(data []byte) {
 		}
 	}
 
-	// 处理交易事件
-	if c.tradeHandler != nil {
+	// 处理交易事件（tradeHandler 或 Notifier 任一配置了就需要解析）
+	if c.tradeHandler != nil || c.config.Notifier != nil {
 		eventType, ok := msg["event_type"].(string)
 		if !ok {
 			return
@@ -708,7 +725,19 @@ func (c *UserClient) processTradeMessage(msg map[string]interface{}) {
 	}
 
 	// 调用交易处理器
-	c.tradeHandler(trade)
+	if c.tradeHandler != nil {
+		c.tradeHandler(trade)
+	}
+
+	if c.config.Notifier != nil {
+		c.config.Notifier.Notify(NotifyEvent{
+			EventType: EventTrade,
+			AssetID:   trade.Asset,
+			Market:    trade.ConditionID,
+			Price:     fmt.Sprintf("%v", trade.Price),
+			Time:      time.Now(),
+		})
+	}
 }
 
 // parseNumeric 解析 Numeric 类型字段（支持字符串或数字）